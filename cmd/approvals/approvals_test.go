@@ -0,0 +1,170 @@
+package approvals
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/api"
+	"github.com/mozilla-ai/mcpd/internal/cmd"
+	cmdopts "github.com/mozilla-ai/mcpd/internal/cmd/options"
+)
+
+// newTestDaemon starts a fake daemon API which records the requests made to it, and serves the approval.
+func newTestDaemon(t *testing.T, approval api.Approval, requests *[]string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.Method+" "+r.URL.RequestURI())
+
+		if r.Method == http.MethodGet {
+			_ = json.NewEncoder(w).Encode(map[string]any{"approvals": []api.Approval{approval}})
+			return
+		}
+
+		var decision api.ApprovalDecision
+		_ = json.NewDecoder(r.Body).Decode(&decision)
+		approval.Reason = decision.Reason
+		_ = json.NewEncoder(w).Encode(approval)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestListCmd_run(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	approval := api.Approval{
+		ID:        "abc",
+		Server:    "github",
+		Tool:      "delete_repo",
+		Status:    api.ApprovalStatusPending,
+		CreatedAt: createdAt,
+		ExpiresAt: createdAt.Add(15 * time.Minute),
+	}
+
+	tests := []struct {
+		name            string
+		flags           map[string]string
+		expectedRequest string
+	}{
+		{name: "pending by default", expectedRequest: "GET /api/v1/approvals?status=pending"},
+		{name: "all", flags: map[string]string{"all": "true"}, expectedRequest: "GET /api/v1/approvals"},
+		{
+			name:            "status",
+			flags:           map[string]string{"status": " Denied "},
+			expectedRequest: "GET /api/v1/approvals?status=denied",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests []string
+			server := newTestDaemon(t, approval, &requests)
+
+			listCmd, err := NewListCmd(&cmd.BaseCmd{})
+			require.NoError(t, err)
+
+			require.NoError(t, listCmd.Flags().Set(flagAddr, server.URL))
+			for k, v := range tc.flags {
+				require.NoError(t, listCmd.Flags().Set(k, v))
+			}
+
+			var out bytes.Buffer
+			listCmd.SetOut(&out)
+			listCmd.SetArgs([]string{})
+
+			require.NoError(t, listCmd.Execute())
+			require.Equal(t, []string{tc.expectedRequest}, requests)
+			require.Equal(t, "abc (pending)\n"+
+				"  Server: github\n"+
+				"  Tool: delete_repo\n"+
+				"  Requested: 2025-01-02T03:04:05Z\n"+
+				"  Expires: 2025-01-02T03:19:05Z\n", out.String())
+		})
+	}
+}
+
+func TestDecideCmd_run(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		newCmd          func(*cmd.BaseCmd, ...cmdopts.CmdOption) (*cobra.Command, error)
+		status          api.ApprovalStatus
+		expectedRequest string
+	}{
+		{
+			name:            "approve",
+			newCmd:          NewApproveCmd,
+			status:          api.ApprovalStatusApproved,
+			expectedRequest: "POST /api/v1/approvals/abc/approve",
+		},
+		{
+			name:            "deny",
+			newCmd:          NewDenyCmd,
+			status:          api.ApprovalStatusDenied,
+			expectedRequest: "POST /api/v1/approvals/abc/deny",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests []string
+			server := newTestDaemon(t, api.Approval{ID: "abc", Status: tc.status}, &requests)
+
+			decideCmd, err := tc.newCmd(&cmd.BaseCmd{})
+			require.NoError(t, err)
+
+			require.NoError(t, decideCmd.Flags().Set(flagAddr, server.URL))
+			require.NoError(t, decideCmd.Flags().Set(flagReason, "checked"))
+			require.NoError(t, decideCmd.Flags().Set("format", "json"))
+
+			var out bytes.Buffer
+			decideCmd.SetOut(&out)
+			decideCmd.SetArgs([]string{"abc"})
+
+			require.NoError(t, decideCmd.Execute())
+			require.Equal(t, []string{tc.expectedRequest}, requests)
+
+			var payload struct {
+				Result api.Approval `json:"result"`
+			}
+			require.NoError(t, json.Unmarshal(out.Bytes(), &payload))
+			require.Equal(t, tc.status, payload.Result.Status)
+			require.Equal(t, "checked", payload.Result.Reason)
+		})
+	}
+}
+
+func TestDecideCmd_DaemonError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"title":"Not Found","status":404,"detail":"approval not found: abc"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	approveCmd, err := NewApproveCmd(&cmd.BaseCmd{})
+	require.NoError(t, err)
+	require.NoError(t, approveCmd.Flags().Set(flagAddr, server.URL))
+
+	approveCmd.SetOut(&bytes.Buffer{})
+	approveCmd.SetErr(&bytes.Buffer{})
+	approveCmd.SetArgs([]string{"abc"})
+
+	require.EqualError(t, approveCmd.Execute(), "daemon API error (404): Not Found: approval not found: abc")
+}
@@ -0,0 +1,95 @@
+package approvals
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mozilla-ai/mcpd/internal/apiclient"
+	"github.com/mozilla-ai/mcpd/internal/cmd"
+	"github.com/mozilla-ai/mcpd/internal/cmd/options"
+	"github.com/mozilla-ai/mcpd/internal/context"
+)
+
+const (
+	// flagAddr is the flag name for the address of the daemon API.
+	flagAddr = "addr"
+
+	// flagAPIKeyName is the flag name for the name of the API key used to authenticate with the daemon API.
+	flagAPIKeyName = "api-key-name"
+
+	// flagReason is the flag name for the reason given for a decision.
+	flagReason = "reason"
+)
+
+// NewCmd creates the command for reviewing tool calls which are held by the running daemon pending approval.
+func NewCmd(baseCmd *cmd.BaseCmd, opt ...options.CmdOption) (*cobra.Command, error) {
+	cobraCmd := &cobra.Command{
+		Use:   "approvals",
+		Short: "Reviews tool calls awaiting approval",
+		Long: "Reviews tool calls which the running mcpd daemon is holding pending approval, " +
+			"dealing with listing, approving, and denying them",
+	}
+
+	// Sub-commands for: mcpd approvals
+	fns := []func(baseCmd *cmd.BaseCmd, opt ...options.CmdOption) (*cobra.Command, error){
+		NewListCmd,    // list
+		NewApproveCmd, // approve
+		NewDenyCmd,    // deny
+	}
+
+	for _, fn := range fns {
+		tempCmd, err := fn(baseCmd, opt...)
+		if err != nil {
+			return nil, err
+		}
+		cobraCmd.AddCommand(tempCmd)
+	}
+
+	return cobraCmd, nil
+}
+
+// addAddrFlag adds the flag for the address of the daemon API to the command.
+func addAddrFlag(cobraCmd *cobra.Command, addr *string) {
+	cobraCmd.Flags().StringVar(
+		addr,
+		flagAddr,
+		apiclient.DefaultAddr,
+		"Address of the running mcpd daemon API (host:port, URL, or unix:///path/to/mcpd.sock)",
+	)
+}
+
+// addAPIKeyNameFlag adds the flag for the name of the API key used to authenticate with the daemon API.
+func addAPIKeyNameFlag(cobraCmd *cobra.Command, name *string) {
+	cobraCmd.Flags().StringVar(
+		name,
+		flagAPIKeyName,
+		"",
+		"Optional, name of the API key (see 'mcpd config daemon api-keys') to authenticate with the daemon API",
+	)
+}
+
+// newClient creates a client for the daemon API at the address.
+// When an API key name is given, the key is loaded from the runtime context configuration to authenticate requests.
+func newClient(
+	baseCmd *cmd.BaseCmd,
+	ctxLoader context.Loader,
+	addr string,
+	apiKeyName string,
+) (*apiclient.Client, error) {
+	if apiKeyName == "" {
+		return apiclient.NewClient(addr)
+	}
+
+	execCtx, err := baseCmd.LoadRuntimeContext(ctxLoader)
+	if err != nil {
+		return nil, err
+	}
+
+	key, ok := execCtx.APIKey(apiKeyName)
+	if !ok {
+		return nil, fmt.Errorf("API key '%s' not found in runtime context", apiKeyName)
+	}
+
+	return apiclient.NewClient(addr, apiclient.WithAPIKey(key))
+}
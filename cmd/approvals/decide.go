@@ -0,0 +1,126 @@
+package approvals
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mozilla-ai/mcpd/internal/api"
+	"github.com/mozilla-ai/mcpd/internal/apiclient"
+	internalcmd "github.com/mozilla-ai/mcpd/internal/cmd"
+	cmdopts "github.com/mozilla-ai/mcpd/internal/cmd/options"
+	"github.com/mozilla-ai/mcpd/internal/cmd/output"
+	configcontext "github.com/mozilla-ai/mcpd/internal/context"
+	"github.com/mozilla-ai/mcpd/internal/printer"
+)
+
+// decideFunc applies a decision to the approval with the given ID, using the client.
+type decideFunc func(ctx context.Context, client *apiclient.Client, id string, reason string) (api.Approval, error)
+
+// DecideCmd represents a command for deciding (approving or denying) a tool call awaiting approval.
+// Use NewApproveCmd or NewDenyCmd to create instances of DecideCmd.
+type DecideCmd struct {
+	*internalcmd.BaseCmd
+	approvalPrinter output.Printer[api.Approval]
+	format          internalcmd.OutputFormat
+	addr            string
+	apiKeyName      string
+	ctxLoader       configcontext.Loader
+	reason          string
+	decide          decideFunc
+}
+
+// NewApproveCmd creates a new command for approving a tool call.
+func NewApproveCmd(baseCmd *internalcmd.BaseCmd, opt ...cmdopts.CmdOption) (*cobra.Command, error) {
+	return newDecideCmd(
+		baseCmd,
+		&cobra.Command{
+			Use:   "approve <approval-id>",
+			Short: "Approves a tool call awaiting approval",
+			Long: "Approves a tool call the running mcpd daemon is holding pending approval. " +
+				"The tool call is started as a background job, whose ID is included in the output.",
+		},
+		func(ctx context.Context, client *apiclient.Client, id string, reason string) (api.Approval, error) {
+			return client.Approve(ctx, id, reason)
+		},
+		opt...,
+	)
+}
+
+// NewDenyCmd creates a new command for denying a tool call.
+func NewDenyCmd(baseCmd *internalcmd.BaseCmd, opt ...cmdopts.CmdOption) (*cobra.Command, error) {
+	return newDecideCmd(
+		baseCmd,
+		&cobra.Command{
+			Use:   "deny <approval-id>",
+			Short: "Denies a tool call awaiting approval",
+			Long:  "Denies a tool call the running mcpd daemon is holding pending approval, so it is never made.",
+		},
+		func(ctx context.Context, client *apiclient.Client, id string, reason string) (api.Approval, error) {
+			return client.Deny(ctx, id, reason)
+		},
+		opt...,
+	)
+}
+
+func newDecideCmd(
+	baseCmd *internalcmd.BaseCmd,
+	cobraCmd *cobra.Command,
+	decide decideFunc,
+	opt ...cmdopts.CmdOption,
+) (*cobra.Command, error) {
+	opts, err := cmdopts.NewOptions(opt...)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &DecideCmd{
+		BaseCmd:         baseCmd,
+		ctxLoader:       opts.ContextLoader,
+		approvalPrinter: &printer.ApprovalPrinter{},
+		format:          internalcmd.FormatText, // Default to plain text
+		decide:          decide,
+	}
+
+	cobraCmd.RunE = c.run
+	cobraCmd.Args = cobra.ExactArgs(1)
+
+	addAddrFlag(cobraCmd, &c.addr)
+	addAPIKeyNameFlag(cobraCmd, &c.apiKeyName)
+
+	allowed := internalcmd.AllowedOutputFormats()
+	cobraCmd.Flags().Var(
+		&c.format,
+		"format",
+		fmt.Sprintf("Specify the output format (one of: %s)", allowed.String()),
+	)
+
+	cobraCmd.Flags().StringVar(
+		&c.reason,
+		flagReason,
+		"",
+		"Reason for the decision, recorded in the daemon's audit log",
+	)
+
+	return cobraCmd, nil
+}
+
+func (c *DecideCmd) run(cmd *cobra.Command, args []string) error {
+	handler, err := internalcmd.FormatHandler(cmd.OutOrStdout(), c.format, c.approvalPrinter)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient(c.BaseCmd, c.ctxLoader, c.addr, c.apiKeyName)
+	if err != nil {
+		return handler.HandleError(err)
+	}
+
+	approval, err := c.decide(cmd.Context(), client, args[0], c.reason)
+	if err != nil {
+		return handler.HandleError(err)
+	}
+
+	return handler.HandleResult(approval)
+}
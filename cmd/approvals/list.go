@@ -0,0 +1,107 @@
+package approvals
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mozilla-ai/mcpd/internal/api"
+	internalcmd "github.com/mozilla-ai/mcpd/internal/cmd"
+	cmdopts "github.com/mozilla-ai/mcpd/internal/cmd/options"
+	"github.com/mozilla-ai/mcpd/internal/cmd/output"
+	"github.com/mozilla-ai/mcpd/internal/context"
+	"github.com/mozilla-ai/mcpd/internal/printer"
+)
+
+// ListCmd represents the command for listing tool call approvals.
+// Use NewListCmd to create instances of ListCmd.
+type ListCmd struct {
+	*internalcmd.BaseCmd
+	approvalPrinter output.Printer[api.Approval]
+	format          internalcmd.OutputFormat
+	addr            string
+	apiKeyName      string
+	ctxLoader       context.Loader
+	status          string
+	all             bool
+}
+
+// NewListCmd creates a new list command for displaying tool call approvals.
+func NewListCmd(baseCmd *internalcmd.BaseCmd, opt ...cmdopts.CmdOption) (*cobra.Command, error) {
+	opts, err := cmdopts.NewOptions(opt...)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &ListCmd{
+		BaseCmd:         baseCmd,
+		ctxLoader:       opts.ContextLoader,
+		approvalPrinter: &printer.ApprovalPrinter{},
+		format:          internalcmd.FormatText, // Default to plain text
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lists tool calls awaiting approval",
+		Long: "Lists the tool calls the running mcpd daemon is holding pending approval. " +
+			"Use --all to also list recently approved, denied, and expired tool calls.",
+		RunE: c.run,
+		Args: cobra.NoArgs,
+	}
+
+	addAddrFlag(cobraCmd, &c.addr)
+	addAPIKeyNameFlag(cobraCmd, &c.apiKeyName)
+
+	allowed := internalcmd.AllowedOutputFormats()
+	cobraCmd.Flags().Var(
+		&c.format,
+		"format",
+		fmt.Sprintf("Specify the output format (one of: %s)", allowed.String()),
+	)
+
+	cobraCmd.Flags().BoolVar(
+		&c.all,
+		"all",
+		false,
+		"List approvals regardless of status",
+	)
+
+	cobraCmd.Flags().StringVar(
+		&c.status,
+		"status",
+		"",
+		"Only list approvals with this status (one of: pending, approved, denied, expired)",
+	)
+
+	cobraCmd.MarkFlagsMutuallyExclusive("all", "status")
+
+	return cobraCmd, nil
+}
+
+func (c *ListCmd) run(cmd *cobra.Command, _ []string) error {
+	handler, err := internalcmd.FormatHandler(cmd.OutOrStdout(), c.format, c.approvalPrinter)
+	if err != nil {
+		return err
+	}
+
+	status := api.ApprovalStatusPending
+	switch {
+	case c.all:
+		status = ""
+	case c.status != "":
+		status = api.ApprovalStatus(strings.ToLower(strings.TrimSpace(c.status)))
+	}
+
+	client, err := newClient(c.BaseCmd, c.ctxLoader, c.addr, c.apiKeyName)
+	if err != nil {
+		return handler.HandleError(err)
+	}
+
+	approvals, err := client.ListApprovals(cmd.Context(), status)
+	if err != nil {
+		return handler.HandleError(err)
+	}
+
+	return handler.HandleResults(approvals...)
+}
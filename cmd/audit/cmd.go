@@ -0,0 +1,34 @@
+package audit
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/mozilla-ai/mcpd/internal/cmd"
+	"github.com/mozilla-ai/mcpd/internal/cmd/options"
+)
+
+// NewCmd creates the command for working with the audit log of tool calls, prompt gets and resource reads
+// written by the daemon.
+func NewCmd(baseCmd *cmd.BaseCmd, opt ...options.CmdOption) (*cobra.Command, error) {
+	cobraCmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Works with the daemon audit log",
+		Long: "Works with the audit log of tool calls, prompt gets and resource reads written by the mcpd daemon " +
+			"(see daemon.audit in .mcpd.toml)",
+	}
+
+	// Sub-commands for: mcpd audit
+	fns := []func(baseCmd *cmd.BaseCmd, opt ...options.CmdOption) (*cobra.Command, error){
+		NewVerifyCmd, // verify
+	}
+
+	for _, fn := range fns {
+		tempCmd, err := fn(baseCmd, opt...)
+		if err != nil {
+			return nil, err
+		}
+		cobraCmd.AddCommand(tempCmd)
+	}
+
+	return cobraCmd, nil
+}
@@ -0,0 +1,116 @@
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mozilla-ai/mcpd/internal/audit"
+	internalcmd "github.com/mozilla-ai/mcpd/internal/cmd"
+	cmdopts "github.com/mozilla-ai/mcpd/internal/cmd/options"
+	"github.com/mozilla-ai/mcpd/internal/config"
+)
+
+// VerifyCmd represents the command for verifying the hash chain of the audit log.
+// Use NewVerifyCmd to create instances of VerifyCmd.
+type VerifyCmd struct {
+	*internalcmd.BaseCmd
+	cfgLoader      config.Loader
+	allowTruncated bool
+}
+
+// NewVerifyCmd creates a new verify command for detecting tampering with the audit log.
+func NewVerifyCmd(baseCmd *internalcmd.BaseCmd, opt ...cmdopts.CmdOption) (*cobra.Command, error) {
+	opts, err := cmdopts.NewOptions(opt...)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &VerifyCmd{
+		BaseCmd:   baseCmd,
+		cfgLoader: opts.ConfigLoader,
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "verify [path]",
+		Short: "Verifies the audit log has not been tampered with",
+		Long: "Verifies the hash chain of the audit log, and its rotated files, detecting records which were " +
+			"modified, removed, inserted or reordered. " +
+			"The audit log at daemon.audit.path in .mcpd.toml is verified when no path is given. " +
+			"Verification fails when records were removed from the start of the log, unless --allow-truncated " +
+			"is given (e.g. as the oldest rotated files are removed beyond daemon.audit.max_files).",
+		RunE: c.run,
+		Args: cobra.MaximumNArgs(1),
+	}
+
+	cobraCmd.Flags().BoolVar(
+		&c.allowTruncated,
+		"allow-truncated",
+		false,
+		"Accept an audit log whose oldest records were removed, e.g. by rotation",
+	)
+
+	return cobraCmd, nil
+}
+
+func (c *VerifyCmd) run(cmd *cobra.Command, args []string) error {
+	path, err := c.auditLogPath(args)
+	if err != nil {
+		return err
+	}
+
+	files, err := audit.Files(path)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no audit log found at '%s'", path)
+	}
+
+	result, err := audit.Verify(files, audit.VerifyOptions{AllowTruncated: c.allowTruncated})
+	if errors.Is(err, audit.ErrTruncated) {
+		return fmt.Errorf(
+			"audit log verification failed: %w (use --allow-truncated if the oldest rotated files were removed)",
+			err,
+		)
+	}
+	if err != nil {
+		return fmt.Errorf("audit log verification failed: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(
+		cmd.OutOrStdout(),
+		"✓ Audit log is intact (records: %d, files: %d)\n",
+		result.Records,
+		result.Files,
+	)
+	if result.Truncated {
+		_, _ = fmt.Fprintln(
+			cmd.OutOrStdout(),
+			"  Earlier records are no longer present, the oldest rotated files were removed",
+		)
+	}
+
+	return nil
+}
+
+// auditLogPath returns the path given as an argument, or the configured audit log path.
+func (c *VerifyCmd) auditLogPath(args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+
+	cfg, err := c.LoadConfig(c.cfgLoader)
+	if err != nil {
+		return "", err
+	}
+
+	if cfg.Daemon == nil || cfg.Daemon.Audit == nil || cfg.Daemon.Audit.Path == nil ||
+		strings.TrimSpace(*cfg.Daemon.Audit.Path) == "" {
+		return "", fmt.Errorf("no audit log path given, and daemon.audit.path is not configured")
+	}
+
+	return *cfg.Daemon.Audit.Path, nil
+}
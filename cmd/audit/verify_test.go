@@ -0,0 +1,179 @@
+package audit
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/audit"
+	"github.com/mozilla-ai/mcpd/internal/cmd"
+	cmdopts "github.com/mozilla-ai/mcpd/internal/cmd/options"
+	"github.com/mozilla-ai/mcpd/internal/config"
+)
+
+// mockConfigLoader implements config.Loader, providing the daemon configuration.
+type mockConfigLoader struct {
+	daemonConfig *config.DaemonConfig
+}
+
+func (m *mockConfigLoader) Load(_ string) (config.Modifier, error) {
+	return &config.Config{Daemon: m.daemonConfig}, nil
+}
+
+// writeAuditLog writes an audit log with the given number of tool call records.
+func writeAuditLog(t *testing.T, path string, records int) {
+	t.Helper()
+
+	l, err := audit.Open(audit.Options{Path: path, MaxSize: 1 << 20})
+	require.NoError(t, err)
+	for range records {
+		require.NoError(t, l.Record(audit.Event{Operation: audit.OperationToolCall, Server: "time", Tool: "now"}))
+	}
+	require.NoError(t, l.Close())
+}
+
+func TestVerifyCmd_run(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	writeAuditLog(t, path, 3)
+
+	tests := []struct {
+		name    string
+		args    []string
+		loader  *mockConfigLoader
+		wantOut string
+		wantErr string
+	}{
+		{
+			name:    "path argument",
+			args:    []string{path},
+			loader:  &mockConfigLoader{},
+			wantOut: "✓ Audit log is intact (records: 3, files: 1)\n",
+		},
+		{
+			name: "configured path",
+			loader: &mockConfigLoader{
+				daemonConfig: &config.DaemonConfig{Audit: &config.AuditConfigSection{Path: &path}},
+			},
+			wantOut: "✓ Audit log is intact (records: 3, files: 1)\n",
+		},
+		{
+			name:    "not configured",
+			loader:  &mockConfigLoader{},
+			wantErr: "no audit log path given, and daemon.audit.path is not configured",
+		},
+		{
+			name:    "missing audit log",
+			args:    []string{filepath.Join(t.TempDir(), "missing.jsonl")},
+			loader:  &mockConfigLoader{},
+			wantErr: "no audit log found at",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			verifyCmd, err := NewVerifyCmd(&cmd.BaseCmd{}, cmdopts.WithConfigLoader(tc.loader))
+			require.NoError(t, err)
+
+			var stdout bytes.Buffer
+			verifyCmd.SetOut(&stdout)
+			verifyCmd.SetErr(&bytes.Buffer{})
+			verifyCmd.SetArgs(tc.args)
+
+			err = verifyCmd.Execute()
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantOut, stdout.String())
+		})
+	}
+}
+
+func TestVerifyCmd_run_Tampered(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	writeAuditLog(t, path, 2)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	tampered := strings.Replace(string(data), `"tool":"now"`, `"tool":"later"`, 1)
+	require.NoError(t, os.WriteFile(path, []byte(tampered), 0o600))
+
+	verifyCmd, err := NewVerifyCmd(&cmd.BaseCmd{}, cmdopts.WithConfigLoader(&mockConfigLoader{}))
+	require.NoError(t, err)
+
+	var stderr bytes.Buffer
+	verifyCmd.SetOut(&bytes.Buffer{})
+	verifyCmd.SetErr(&stderr)
+	verifyCmd.SetArgs([]string{path})
+
+	err = verifyCmd.Execute()
+	require.ErrorContains(t, err, "audit log verification failed: ")
+	require.ErrorContains(t, err, "audit.jsonl:1: record hash mismatch")
+	require.Contains(t, stderr.String(), "record hash mismatch")
+}
+
+func TestVerifyCmd_run_Truncated(t *testing.T) {
+	t.Parallel()
+
+	// Removing the first record leaves a log whose first record follows records which are no longer present.
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	writeAuditLog(t, path, 3)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	_, rest, _ := strings.Cut(string(data), "\n")
+	require.NoError(t, os.WriteFile(path, []byte(rest), 0o600))
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantOut string
+		wantErr string
+	}{
+		{
+			name:    "not allowed",
+			args:    []string{path},
+			wantErr: "audit.jsonl:1: the first record follows records which are no longer present",
+		},
+		{
+			name: "allowed",
+			args: []string{path, "--allow-truncated"},
+			wantOut: "✓ Audit log is intact (records: 2, files: 1)\n" +
+				"  Earlier records are no longer present, the oldest rotated files were removed\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			verifyCmd, err := NewVerifyCmd(&cmd.BaseCmd{}, cmdopts.WithConfigLoader(&mockConfigLoader{}))
+			require.NoError(t, err)
+
+			var stdout bytes.Buffer
+			verifyCmd.SetOut(&stdout)
+			verifyCmd.SetErr(&bytes.Buffer{})
+			verifyCmd.SetArgs(tc.args)
+
+			err = verifyCmd.Execute()
+			if tc.wantErr != "" {
+				require.ErrorIs(t, err, audit.ErrTruncated)
+				require.ErrorContains(t, err, tc.wantErr)
+				require.ErrorContains(t, err, "--allow-truncated")
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantOut, stdout.String())
+		})
+	}
+}
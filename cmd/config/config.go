@@ -8,6 +8,8 @@ import (
 	"github.com/mozilla-ai/mcpd/cmd/config/env"
 	"github.com/mozilla-ai/mcpd/cmd/config/export"
 	"github.com/mozilla-ai/mcpd/cmd/config/plugins"
+	"github.com/mozilla-ai/mcpd/cmd/config/prompts"
+	"github.com/mozilla-ai/mcpd/cmd/config/resources"
 	"github.com/mozilla-ai/mcpd/cmd/config/tools"
 	"github.com/mozilla-ai/mcpd/cmd/config/volumes"
 	"github.com/mozilla-ai/mcpd/internal/cmd"
@@ -23,13 +25,15 @@ func NewConfigCmd(baseCmd *cmd.BaseCmd, opt ...options.CmdOption) (*cobra.Comman
 
 	// Sub-commands for: mcpd config
 	fns := []func(baseCmd *cmd.BaseCmd, opt ...options.CmdOption) (*cobra.Command, error){
-		args.NewCmd,    // args
-		daemon.NewCmd,  // daemon
-		env.NewCmd,     // env
-		plugins.NewCmd, // plugins
-		tools.NewCmd,   // tools
-		volumes.NewCmd, // volumes
-		export.NewCmd,  // export
+		args.NewCmd,      // args
+		daemon.NewCmd,    // daemon
+		env.NewCmd,       // env
+		plugins.NewCmd,   // plugins
+		prompts.NewCmd,   // prompts
+		resources.NewCmd, // resources
+		tools.NewCmd,     // tools
+		volumes.NewCmd,   // volumes
+		export.NewCmd,    // export
 	}
 
 	for _, fn := range fns {
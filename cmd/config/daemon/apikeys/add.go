@@ -0,0 +1,145 @@
+package apikeys
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mozilla-ai/mcpd/internal/auth"
+	internalcmd "github.com/mozilla-ai/mcpd/internal/cmd"
+	cmdopts "github.com/mozilla-ai/mcpd/internal/cmd/options"
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/context"
+	"github.com/mozilla-ai/mcpd/internal/flags"
+)
+
+// AddCmd represents the command for generating a new API key.
+// NOTE: Use NewAddCmd to create instances of AddCmd.
+type AddCmd struct {
+	*internalcmd.BaseCmd
+
+	// cfgLoader is used to load the configuration.
+	cfgLoader config.Loader
+
+	// ctxLoader is used to load the runtime context configuration.
+	ctxLoader context.Loader
+
+	// servers are the servers (or glob patterns) the key may access.
+	servers []string
+
+	// tools are the '<server>/<tool>' tools (or glob patterns) the key may call.
+	tools []string
+
+	// readOnly limits the key to requests which don't make changes.
+	readOnly bool
+}
+
+// NewAddCmd creates a new add command for API keys.
+func NewAddCmd(baseCmd *internalcmd.BaseCmd, opt ...cmdopts.CmdOption) (*cobra.Command, error) {
+	opts, err := cmdopts.NewOptions(opt...)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &AddCmd{
+		BaseCmd:   baseCmd,
+		cfgLoader: opts.ConfigLoader,
+		ctxLoader: opts.ContextLoader,
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "add <name>",
+		Short: "Generate a new API key for the daemon API",
+		Long: "Generate a new API key for the daemon API, optionally limited to some servers or tools.\n\n" +
+			"The hash of the key is saved to .mcpd.toml, and the key is saved to the runtime context " +
+			"configuration file (e.g. " + flags.RuntimeFile + "). The key is printed once, " +
+			"and should be presented in the 'Authorization: Bearer <key>' header of requests.",
+		Example: `  # Add a key which may access all servers and tools
+  mcpd config daemon api-keys add ops
+
+  # Add a key limited to the tools of some servers
+  mcpd config daemon api-keys add ci --server=time --server='github-*'
+
+  # Add a key limited to some tools
+  mcpd config daemon api-keys add agent --tool=time/get_current_time --tool='github/list_*'
+
+  # Add a key which can list, but not call, tools
+  mcpd config daemon api-keys add dashboard --read-only`,
+		RunE: c.run,
+		Args: cobra.ExactArgs(1), // name
+	}
+
+	cobraCmd.Flags().StringArrayVar(
+		&c.servers,
+		flagServer,
+		nil,
+		"Optional, server (or glob pattern) the key may access (can be repeated)",
+	)
+
+	cobraCmd.Flags().StringArrayVar(
+		&c.tools,
+		flagTool,
+		nil,
+		"Optional, tool the key may call as '<server>/<tool>' (or glob pattern) (can be repeated)",
+	)
+
+	cobraCmd.Flags().BoolVar(
+		&c.readOnly,
+		flagReadOnly,
+		false,
+		"Optional, limit the key to requests which don't make changes (e.g. listing but not calling tools)",
+	)
+
+	return cobraCmd, nil
+}
+
+func (c *AddCmd) run(cmd *cobra.Command, args []string) error {
+	name := strings.TrimSpace(args[0])
+	if name == "" {
+		return fmt.Errorf("API key name cannot be empty")
+	}
+
+	cfg, err := c.LoadConfig(c.cfgLoader)
+	if err != nil {
+		return err
+	}
+
+	execCtx, err := c.LoadRuntimeContext(c.ctxLoader)
+	if err != nil {
+		return err
+	}
+
+	key, err := auth.GenerateAPIKey()
+	if err != nil {
+		return err
+	}
+
+	entry := config.APIKeyEntry{
+		Name:     name,
+		Hash:     auth.HashAPIKey(key),
+		Servers:  c.servers,
+		Tools:    c.tools,
+		ReadOnly: c.readOnly,
+	}
+
+	if err := cfg.AddAPIKey(entry); err != nil {
+		return err
+	}
+
+	if _, err := execCtx.SetAPIKey(name, key); err != nil {
+		// Don't leave a key configured which nobody has.
+		_ = cfg.RemoveAPIKey(name)
+		return fmt.Errorf("error saving API key '%s': %w", name, err)
+	}
+
+	_, _ = fmt.Fprintf(
+		cmd.OutOrStdout(),
+		"✓ API key '%s' added\n\n%s\n\nThe key has been saved to: %s\n",
+		name,
+		key,
+		flags.RuntimeFile,
+	)
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package apikeys
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/mozilla-ai/mcpd/internal/cmd"
+	"github.com/mozilla-ai/mcpd/internal/cmd/options"
+)
+
+const (
+	// flagServer is the flag name for the servers an API key may access.
+	flagServer = "server"
+
+	// flagTool is the flag name for the tools an API key may call.
+	flagTool = "tool"
+
+	// flagReadOnly is the flag name for limiting an API key to requests which don't make changes.
+	flagReadOnly = "read-only"
+)
+
+// NewCmd creates the parent api-keys command.
+func NewCmd(baseCmd *cmd.BaseCmd, opt ...options.CmdOption) (*cobra.Command, error) {
+	cobraCmd := &cobra.Command{
+		Use:   "api-keys",
+		Short: "Manage API keys for the daemon API",
+		Long: "Manage the API keys which authenticate requests to the daemon API. " +
+			"Hashes of the keys and their scopes are stored in .mcpd.toml, " +
+			"the keys themselves are stored in the runtime context configuration file",
+	}
+
+	// Sub-commands for: mcpd config daemon api-keys
+	fns := []func(baseCmd *cmd.BaseCmd, opt ...options.CmdOption) (*cobra.Command, error){
+		NewAddCmd,    // add
+		NewListCmd,   // list
+		NewRemoveCmd, // remove
+	}
+
+	for _, fn := range fns {
+		tempCmd, err := fn(baseCmd, opt...)
+		if err != nil {
+			return nil, err
+		}
+		cobraCmd.AddCommand(tempCmd)
+	}
+
+	return cobraCmd, nil
+}
@@ -0,0 +1,138 @@
+package apikeys
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/auth"
+	"github.com/mozilla-ai/mcpd/internal/cmd"
+	cmdopts "github.com/mozilla-ai/mcpd/internal/cmd/options"
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/context"
+)
+
+// fileLoaders loads the configuration and runtime context from temp files, so changes are saved during tests.
+type fileLoaders struct {
+	cfgPath string
+	ctxPath string
+}
+
+func newFileLoaders(t *testing.T) *fileLoaders {
+	t.Helper()
+
+	dir := filepath.Join(t.TempDir(), "mcpd")
+	require.NoError(t, os.Mkdir(dir, 0o700))
+
+	cfgPath := filepath.Join(dir, ".mcpd.toml")
+	require.NoError(t, os.WriteFile(cfgPath, []byte("servers = []\n"), 0o644))
+
+	return &fileLoaders{cfgPath: cfgPath, ctxPath: filepath.Join(dir, "secrets.dev.toml")}
+}
+
+func (l *fileLoaders) options() []cmdopts.CmdOption {
+	return []cmdopts.CmdOption{
+		cmdopts.WithConfigLoader(&configLoader{path: l.cfgPath}),
+		cmdopts.WithContextLoader(&contextLoader{path: l.ctxPath}),
+	}
+}
+
+type configLoader struct {
+	path string
+}
+
+func (l *configLoader) Load(_ string) (config.Modifier, error) {
+	return (&config.DefaultLoader{}).Load(l.path)
+}
+
+type contextLoader struct {
+	path string
+}
+
+func (l *contextLoader) Load(_ string) (context.Modifier, error) {
+	return (&context.DefaultLoader{}).Load(l.path)
+}
+
+// run executes the command created by newCmd with the arguments, returning its output.
+func run(
+	t *testing.T,
+	newCmd func(*cmd.BaseCmd, ...cmdopts.CmdOption) (*cobra.Command, error),
+	loaders *fileLoaders,
+	args ...string,
+) (string, error) {
+	t.Helper()
+
+	c, err := newCmd(&cmd.BaseCmd{}, loaders.options()...)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	c.SetOut(&out)
+	c.SetErr(&out)
+	c.SetArgs(args)
+
+	err = c.Execute()
+	return out.String(), err
+}
+
+func TestAPIKeys_AddListRemove(t *testing.T) {
+	t.Parallel()
+
+	loaders := newFileLoaders(t)
+
+	out, err := run(t, NewAddCmd, loaders, "ci", "--server=time", "--tool=time/get_*", "--read-only")
+	require.NoError(t, err)
+	require.Contains(t, out, "✓ API key 'ci' added")
+
+	cfg, err := (&configLoader{path: loaders.cfgPath}).Load("")
+	require.NoError(t, err)
+	entry, ok := cfg.(*config.Config).APIKey("ci")
+	require.True(t, ok)
+	require.Equal(t, []string{"time"}, entry.Servers)
+	require.Equal(t, []string{"time/get_*"}, entry.Tools)
+	require.True(t, entry.ReadOnly)
+
+	execCtx, err := (&contextLoader{path: loaders.ctxPath}).Load("")
+	require.NoError(t, err)
+	key, ok := execCtx.(*context.ExecutionContextConfig).APIKey("ci")
+	require.True(t, ok)
+	require.Contains(t, out, key)
+	require.Equal(t, auth.HashAPIKey(key), entry.Hash)
+
+	_, err = run(t, NewAddCmd, loaders, "ci")
+	require.ErrorContains(t, err, "already exists")
+
+	out, err = run(t, NewListCmd, loaders)
+	require.NoError(t, err)
+	require.Equal(t, "ci\n  servers: time; tools: time/get_*; read-only\n", out)
+	require.NotContains(t, out, key)
+
+	out, err = run(t, NewRemoveCmd, loaders, "ci")
+	require.NoError(t, err)
+	require.Contains(t, out, "✓ API key 'ci' removed")
+
+	execCtx, err = (&contextLoader{path: loaders.ctxPath}).Load("")
+	require.NoError(t, err)
+	_, ok = execCtx.(*context.ExecutionContextConfig).APIKey("ci")
+	require.False(t, ok)
+
+	out, err = run(t, NewListCmd, loaders)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(out, "No API keys configured"))
+}
+
+func TestAPIKeys_AddInvalidScope(t *testing.T) {
+	t.Parallel()
+
+	loaders := newFileLoaders(t)
+
+	_, err := run(t, NewAddCmd, loaders, "ci", "--tool=get_current_time")
+	require.ErrorContains(t, err, "must be in the form '<server>/<tool>'")
+
+	_, err = os.Stat(loaders.ctxPath)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,88 @@
+package apikeys
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	internalcmd "github.com/mozilla-ai/mcpd/internal/cmd"
+	cmdopts "github.com/mozilla-ai/mcpd/internal/cmd/options"
+	"github.com/mozilla-ai/mcpd/internal/config"
+)
+
+// ListCmd represents the command for listing the configured API keys.
+// Use NewListCmd to create instances of ListCmd.
+type ListCmd struct {
+	*internalcmd.BaseCmd
+
+	// cfgLoader is used to load the configuration.
+	cfgLoader config.Loader
+}
+
+// NewListCmd creates a new list command for API keys.
+func NewListCmd(baseCmd *internalcmd.BaseCmd, opt ...cmdopts.CmdOption) (*cobra.Command, error) {
+	opts, err := cmdopts.NewOptions(opt...)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &ListCmd{
+		BaseCmd:   baseCmd,
+		cfgLoader: opts.ConfigLoader,
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the configured API keys and their scopes",
+		Long:  "List the configured API keys and their scopes, the keys themselves are not shown",
+		RunE:  c.run,
+		Args:  cobra.NoArgs,
+	}
+
+	return cobraCmd, nil
+}
+
+func (c *ListCmd) run(cmd *cobra.Command, _ []string) error {
+	cfg, err := c.LoadConfig(c.cfgLoader)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+
+	keys := cfg.ListAPIKeys()
+	if len(keys) == 0 {
+		if _, err := fmt.Fprintln(out, "No API keys configured"); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+		return nil
+	}
+
+	for _, key := range keys {
+		if _, err := fmt.Fprintf(out, "%s\n  %s\n", key.Name, scope(key)); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// scope describes what the API key may access.
+func scope(key config.APIKeyEntry) string {
+	var parts []string
+	if len(key.Servers) > 0 {
+		parts = append(parts, "servers: "+strings.Join(key.Servers, ", "))
+	}
+	if len(key.Tools) > 0 {
+		parts = append(parts, "tools: "+strings.Join(key.Tools, ", "))
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "all servers and tools")
+	}
+	if key.ReadOnly {
+		parts = append(parts, "read-only")
+	}
+
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,81 @@
+package apikeys
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	internalcmd "github.com/mozilla-ai/mcpd/internal/cmd"
+	cmdopts "github.com/mozilla-ai/mcpd/internal/cmd/options"
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/context"
+)
+
+// RemoveCmd represents the command for removing an API key.
+// Use NewRemoveCmd to create instances of RemoveCmd.
+type RemoveCmd struct {
+	*internalcmd.BaseCmd
+
+	// cfgLoader is used to load the configuration.
+	cfgLoader config.Loader
+
+	// ctxLoader is used to load the runtime context configuration.
+	ctxLoader context.Loader
+}
+
+// NewRemoveCmd creates a new remove command for API keys.
+func NewRemoveCmd(baseCmd *internalcmd.BaseCmd, opt ...cmdopts.CmdOption) (*cobra.Command, error) {
+	opts, err := cmdopts.NewOptions(opt...)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &RemoveCmd{
+		BaseCmd:   baseCmd,
+		cfgLoader: opts.ConfigLoader,
+		ctxLoader: opts.ContextLoader,
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Remove an API key",
+		Long: "Remove an API key, so it can no longer be used to authenticate requests to the daemon API. " +
+			"The key is removed from both .mcpd.toml and the runtime context configuration file.",
+		Example: `  # Remove an API key
+  mcpd config daemon api-keys remove ci`,
+		RunE: c.run,
+		Args: cobra.ExactArgs(1), // name
+	}
+
+	return cobraCmd, nil
+}
+
+func (c *RemoveCmd) run(cmd *cobra.Command, args []string) error {
+	name := strings.TrimSpace(args[0])
+	if name == "" {
+		return fmt.Errorf("API key name cannot be empty")
+	}
+
+	cfg, err := c.LoadConfig(c.cfgLoader)
+	if err != nil {
+		return err
+	}
+
+	execCtx, err := c.LoadRuntimeContext(c.ctxLoader)
+	if err != nil {
+		return err
+	}
+
+	if err := cfg.RemoveAPIKey(name); err != nil {
+		return err
+	}
+
+	if _, err := execCtx.RemoveAPIKey(name); err != nil {
+		return fmt.Errorf("error removing API key '%s' from runtime context: %w", name, err)
+	}
+
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "✓ API key '%s' removed\n", name)
+
+	return nil
+}
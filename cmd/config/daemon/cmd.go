@@ -3,6 +3,7 @@ package daemon
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/mozilla-ai/mcpd/cmd/config/daemon/apikeys"
 	"github.com/mozilla-ai/mcpd/internal/cmd"
 	"github.com/mozilla-ai/mcpd/internal/cmd/options"
 )
@@ -21,6 +22,7 @@ func NewCmd(baseCmd *cmd.BaseCmd, opt ...options.CmdOption) (*cobra.Command, err
 		NewListCmd,     // list
 		NewRemoveCmd,   // remove
 		NewValidateCmd, // validate
+		apikeys.NewCmd, // api-keys
 	}
 
 	for _, fn := range fns {
@@ -140,12 +140,15 @@ func (c *ListCmd) showAvailableKeys(cmd *cobra.Command) error {
 	// Group keys by top-level section
 	var apiKeys []config.SchemaKey
 	var mcpKeys []config.SchemaKey
+	var auditKeys []config.SchemaKey
 
 	for _, key := range keys {
 		if strings.HasPrefix(key.Path, "api.") {
 			apiKeys = append(apiKeys, key)
 		} else if strings.HasPrefix(key.Path, "mcp.") {
 			mcpKeys = append(mcpKeys, key)
+		} else if strings.HasPrefix(key.Path, "audit.") {
+			auditKeys = append(auditKeys, key)
 		}
 	}
 
@@ -156,6 +159,9 @@ func (c *ListCmd) showAvailableKeys(cmd *cobra.Command) error {
 	sort.Slice(mcpKeys, func(i, j int) bool {
 		return mcpKeys[i].Path < mcpKeys[j].Path
 	})
+	sort.Slice(auditKeys, func(i, j int) bool {
+		return auditKeys[i].Path < auditKeys[j].Path
+	})
 
 	// Show API keys
 	if len(apiKeys) > 0 {
@@ -166,6 +172,12 @@ func (c *ListCmd) showAvailableKeys(cmd *cobra.Command) error {
 	// Show MCP keys
 	if len(mcpKeys) > 0 {
 		c.showKeySection(cmd, "MCP Configuration:", mcpKeys)
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "")
+	}
+
+	// Show audit keys
+	if len(auditKeys) > 0 {
+		c.showKeySection(cmd, "Audit Configuration:", auditKeys)
 	}
 
 	return nil
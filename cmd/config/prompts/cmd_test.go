@@ -0,0 +1,62 @@
+package prompts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/cmd"
+	"github.com/mozilla-ai/mcpd/internal/config"
+)
+
+// mockConfig is an in-memory config.Modifier for testing.
+type mockConfig struct {
+	servers []config.ServerEntry
+}
+
+func (m *mockConfig) AddServer(entry config.ServerEntry) error {
+	m.servers = append(m.servers, entry)
+	return nil
+}
+
+func (m *mockConfig) RemoveServer(name string) error {
+	for i, s := range m.servers {
+		if s.Name == name {
+			m.servers = append(m.servers[:i], m.servers[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (m *mockConfig) ListServers() []config.ServerEntry {
+	return m.servers
+}
+
+func (m *mockConfig) SaveConfig() error {
+	return nil
+}
+
+// mockLoader returns the mock config.
+type mockLoader struct {
+	cfg *mockConfig
+	err error
+}
+
+func (m *mockLoader) Load(_ string) (config.Modifier, error) {
+	return m.cfg, m.err
+}
+
+func TestNewCmd(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewCmd(&cmd.BaseCmd{})
+	require.NoError(t, err)
+	require.Equal(t, "prompts", c.Use)
+
+	var names []string
+	for _, sub := range c.Commands() {
+		names = append(names, sub.Name())
+	}
+	require.ElementsMatch(t, []string{"set", "remove", "list"}, names)
+}
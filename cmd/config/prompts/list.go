@@ -0,0 +1,83 @@
+package prompts
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mozilla-ai/mcpd/internal/cmd"
+	cmdopts "github.com/mozilla-ai/mcpd/internal/cmd/options"
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/flags"
+)
+
+// ListCmd represents the command for listing the allowed prompts of an MCP server.
+// Use NewListCmd to create instances of ListCmd.
+type ListCmd struct {
+	*cmd.BaseCmd
+	cfgLoader config.Loader
+}
+
+// NewListCmd creates a new list command for displaying the allowed prompts of an MCP server.
+func NewListCmd(baseCmd *cmd.BaseCmd, opt ...cmdopts.CmdOption) (*cobra.Command, error) {
+	opts, err := cmdopts.NewOptions(opt...)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &ListCmd{
+		BaseCmd:   baseCmd,
+		cfgLoader: opts.ConfigLoader,
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "list <server-name>",
+		Short: "Lists the configured (allowed) prompts for a specific MCP server",
+		Long: "Lists the configured (allowed) prompts for a specific MCP server " +
+			"from the .mcpd.toml configuration file",
+		RunE: c.run,
+		Args: cobra.ExactArgs(1),
+	}
+
+	return cobraCmd, nil
+}
+
+func (c *ListCmd) run(cmd *cobra.Command, args []string) error {
+	serverName := strings.TrimSpace(args[0])
+	if serverName == "" {
+		return fmt.Errorf("server-name is required")
+	}
+
+	cfg, err := c.cfgLoader.Load(flags.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	srv, err := findServer(cfg, serverName)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+
+	if _, err := fmt.Fprintf(out, "Prompts for '%s' (%d total):\n", serverName, len(srv.Prompts)); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	if len(srv.Prompts) == 0 {
+		if _, err := fmt.Fprintln(out, "  (No prompts configured, all prompts are allowed)"); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+		return nil
+	}
+
+	for _, prompt := range slices.Sorted(slices.Values(srv.Prompts)) {
+		if _, err := fmt.Fprintf(out, "  %s\n", prompt); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package prompts
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/cmd"
+	cmdopts "github.com/mozilla-ai/mcpd/internal/cmd/options"
+	"github.com/mozilla-ai/mcpd/internal/config"
+)
+
+func TestListCmd_run(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		prompts        []string
+		expectedOutput string
+	}{
+		{
+			name:           "prompts configured",
+			prompts:        []string{"triage", "code_review"},
+			expectedOutput: "Prompts for 'github' (2 total):\n  code_review\n  triage\n",
+		},
+		{
+			name:           "no prompts configured",
+			expectedOutput: "Prompts for 'github' (0 total):\n  (No prompts configured, all prompts are allowed)\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &mockConfig{servers: []config.ServerEntry{
+				{Name: "github", Package: "npx::github@1.0.0", Prompts: tc.prompts},
+			}}
+
+			c, err := NewListCmd(&cmd.BaseCmd{}, cmdopts.WithConfigLoader(&mockLoader{cfg: cfg}))
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+			c.SetOut(&out)
+			c.SetArgs([]string{"github"})
+
+			require.NoError(t, c.Execute())
+			require.Equal(t, tc.expectedOutput, out.String())
+		})
+	}
+}
+
+func TestListCmd_ServerNotFound(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewListCmd(&cmd.BaseCmd{}, cmdopts.WithConfigLoader(&mockLoader{cfg: &mockConfig{}}))
+	require.NoError(t, err)
+
+	c.SetOut(&bytes.Buffer{})
+	c.SetErr(&bytes.Buffer{})
+	c.SetArgs([]string{"github"})
+
+	require.EqualError(t, c.Execute(), "server 'github' not found in configuration")
+}
@@ -0,0 +1,92 @@
+package prompts
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mozilla-ai/mcpd/internal/cmd"
+	cmdopts "github.com/mozilla-ai/mcpd/internal/cmd/options"
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/filter"
+	"github.com/mozilla-ai/mcpd/internal/flags"
+)
+
+// RemoveCmd represents the command for removing prompts from the allowlist of an MCP server.
+// Use NewRemoveCmd to create instances of RemoveCmd.
+type RemoveCmd struct {
+	*cmd.BaseCmd
+	cfgLoader config.Loader
+}
+
+// NewRemoveCmd creates a new remove command for removing prompts from the allowlist of an MCP server.
+func NewRemoveCmd(baseCmd *cmd.BaseCmd, opt ...cmdopts.CmdOption) (*cobra.Command, error) {
+	opts, err := cmdopts.NewOptions(opt...)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &RemoveCmd{
+		BaseCmd:   baseCmd,
+		cfgLoader: opts.ConfigLoader,
+	}
+
+	// mcpd config prompts remove github PROMPT [PROMPT ...]
+	cobraCmd := &cobra.Command{
+		Use:   "remove <server-name> PROMPT [PROMPT ...]",
+		Short: "Remove allowed prompts for an MCP server from configuration",
+		Long: "Remove allowed prompts for an MCP server from configuration, " +
+			"if the specified prompts are present in config they will be removed. " +
+			"Removing every prompt allows all prompts offered by the server",
+		RunE: c.run,
+		Args: cobra.MinimumNArgs(2), // server-name + PROMPT ...
+	}
+
+	return cobraCmd, nil
+}
+
+func (c *RemoveCmd) run(cmd *cobra.Command, args []string) error {
+	serverName := strings.TrimSpace(args[0])
+	if serverName == "" {
+		return fmt.Errorf("server-name is required")
+	}
+
+	cfg, err := c.cfgLoader.Load(flags.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	srv, err := findServer(cfg, serverName)
+	if err != nil {
+		return err
+	}
+
+	var removed []string
+	for _, prompt := range args[1:] {
+		prompt = filter.NormalizeString(prompt)
+		if idx := slices.Index(srv.Prompts, prompt); idx >= 0 {
+			srv.Prompts = slices.Delete(srv.Prompts, idx, idx+1)
+			removed = append(removed, prompt)
+		}
+	}
+
+	if len(removed) == 0 {
+		_, err := fmt.Fprint(cmd.OutOrStdout(), "✓ Command completed successfully, no prompts required removal\n")
+		return err
+	}
+
+	if err := updateServer(cfg, srv); err != nil {
+		return err
+	}
+
+	msg := fmt.Sprintf("✓ Prompts removed for server '%s': %v\n", serverName, removed)
+	if len(srv.Prompts) == 0 {
+		msg += fmt.Sprintf("  No prompts remain configured, all prompts for server '%s' are now allowed\n", serverName)
+	}
+
+	_, err = fmt.Fprint(cmd.OutOrStdout(), msg)
+
+	return err
+}
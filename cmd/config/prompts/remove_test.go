@@ -0,0 +1,83 @@
+package prompts
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/cmd"
+	cmdopts "github.com/mozilla-ai/mcpd/internal/cmd/options"
+	"github.com/mozilla-ai/mcpd/internal/config"
+)
+
+func TestRemoveCmd_run(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		args            []string
+		expectedPrompts []string
+		expectedOutput  string
+		expectedError   string
+	}{
+		{
+			name:            "remove prompt",
+			args:            []string{"github", "Code_Review"},
+			expectedPrompts: []string{"triage"},
+			expectedOutput:  "✓ Prompts removed for server 'github': [code_review]\n",
+		},
+		{
+			name:            "remove all prompts",
+			args:            []string{"github", "code_review", "triage"},
+			expectedPrompts: []string{},
+			expectedOutput: "✓ Prompts removed for server 'github': [code_review triage]\n" +
+				"  No prompts remain configured, all prompts for server 'github' are now allowed\n",
+		},
+		{
+			name:            "nothing to remove",
+			args:            []string{"github", "unknown"},
+			expectedPrompts: []string{"code_review", "triage"},
+			expectedOutput:  "✓ Command completed successfully, no prompts required removal\n",
+		},
+		{
+			name:          "server not found",
+			args:          []string{"unknown", "code_review"},
+			expectedError: "server 'unknown' not found in configuration",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &mockConfig{servers: []config.ServerEntry{
+				{
+					Name:    "github",
+					Package: "npx::github@1.0.0",
+					Tools:   []string{"create_issue"},
+					Prompts: []string{"code_review", "triage"},
+				},
+			}}
+
+			c, err := NewRemoveCmd(&cmd.BaseCmd{}, cmdopts.WithConfigLoader(&mockLoader{cfg: cfg}))
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+			c.SetOut(&out)
+			c.SetErr(&out)
+			c.SetArgs(tc.args)
+
+			err = c.Execute()
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedOutput, out.String())
+			require.Len(t, cfg.servers, 1)
+			require.Equal(t, tc.expectedPrompts, cfg.servers[0].Prompts)
+		})
+	}
+}
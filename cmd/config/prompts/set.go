@@ -0,0 +1,113 @@
+package prompts
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mozilla-ai/mcpd/internal/cmd"
+	cmdopts "github.com/mozilla-ai/mcpd/internal/cmd/options"
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/filter"
+	"github.com/mozilla-ai/mcpd/internal/flags"
+)
+
+// SetCmd represents the command for adding prompts to the allowlist of an MCP server.
+// Use NewSetCmd to create instances of SetCmd.
+type SetCmd struct {
+	*cmd.BaseCmd
+	cfgLoader config.Loader
+	prompts   []string
+}
+
+// NewSetCmd creates a new set command for adding prompts to the allowlist of an MCP server.
+func NewSetCmd(baseCmd *cmd.BaseCmd, opt ...cmdopts.CmdOption) (*cobra.Command, error) {
+	opts, err := cmdopts.NewOptions(opt...)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &SetCmd{
+		BaseCmd:   baseCmd,
+		cfgLoader: opts.ConfigLoader,
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "set <server-name> --prompt <prompt1> [--prompt <prompt2> ...]",
+		Short: "Add allowed prompts to an MCP server configuration",
+		Long: "Add allowed prompts to an MCP server configuration. " +
+			"Prompts are added to the existing set of prompts (append behavior). " +
+			"Duplicate prompts are automatically deduplicated. " +
+			"Once any prompts are configured, only those prompts are allowed.",
+		RunE: c.run,
+		Args: cobra.ExactArgs(1), // server-name
+	}
+
+	cobraCmd.Flags().StringArrayVar(
+		&c.prompts,
+		"prompt",
+		nil,
+		"Prompt to add to the server's allowed list (can be repeated)",
+	)
+	_ = cobraCmd.MarkFlagRequired("prompt")
+
+	return cobraCmd, nil
+}
+
+func (c *SetCmd) run(cmd *cobra.Command, args []string) error {
+	serverName := strings.TrimSpace(args[0])
+	if serverName == "" {
+		return fmt.Errorf("server-name is required")
+	}
+
+	prompts := make([]string, 0, len(c.prompts))
+	for _, prompt := range c.prompts {
+		if normalized := filter.NormalizeString(prompt); normalized != "" {
+			prompts = append(prompts, normalized)
+		}
+	}
+
+	if len(prompts) == 0 {
+		return fmt.Errorf("at least one valid prompt name is required")
+	}
+
+	cfg, err := c.cfgLoader.Load(flags.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	srv, err := findServer(cfg, serverName)
+	if err != nil {
+		return err
+	}
+
+	// Track which prompts are actually new.
+	var added []string
+	for _, prompt := range prompts {
+		if !slices.Contains(srv.Prompts, prompt) {
+			srv.Prompts = append(srv.Prompts, prompt)
+			added = append(added, prompt)
+		}
+	}
+
+	if len(added) == 0 {
+		_, err := fmt.Fprintf(
+			cmd.OutOrStdout(),
+			"✓ No new prompts added for server '%s' (all specified prompts already exist)\n",
+			serverName,
+		)
+		return err
+	}
+
+	slices.Sort(srv.Prompts)
+
+	if err := updateServer(cfg, srv); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(cmd.OutOrStdout(), "✓ Prompts added for server '%s': %v\n", serverName, added)
+
+	return err
+}
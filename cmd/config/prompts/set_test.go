@@ -0,0 +1,100 @@
+package prompts
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/cmd"
+	cmdopts "github.com/mozilla-ai/mcpd/internal/cmd/options"
+	"github.com/mozilla-ai/mcpd/internal/config"
+)
+
+func TestSetCmd_run(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		args            []string
+		existing        []string
+		expectedPrompts []string
+		expectedOutput  string
+		expectedError   string
+	}{
+		{
+			name:            "add prompts",
+			args:            []string{"github", "--prompt", "Summarize_Issue", "--prompt", "code_review"},
+			expectedPrompts: []string{"code_review", "summarize_issue"},
+			expectedOutput:  "✓ Prompts added for server 'github': [summarize_issue code_review]\n",
+		},
+		{
+			name:            "append and deduplicate",
+			args:            []string{"github", "--prompt", "code_review", "--prompt", "triage"},
+			existing:        []string{"code_review"},
+			expectedPrompts: []string{"code_review", "triage"},
+			expectedOutput:  "✓ Prompts added for server 'github': [triage]\n",
+		},
+		{
+			name:            "no new prompts",
+			args:            []string{"github", "--prompt", "code_review"},
+			existing:        []string{"code_review"},
+			expectedPrompts: []string{"code_review"},
+			expectedOutput: "✓ No new prompts added for server 'github' " +
+				"(all specified prompts already exist)\n",
+		},
+		{
+			name:          "server not found",
+			args:          []string{"unknown", "--prompt", "code_review"},
+			expectedError: "server 'unknown' not found in configuration",
+		},
+		{
+			name:          "empty prompt",
+			args:          []string{"github", "--prompt", " "},
+			expectedError: "at least one valid prompt name is required",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &mockConfig{servers: []config.ServerEntry{
+				{Name: "github", Package: "npx::github@1.0.0", Tools: []string{"create_issue"}, Prompts: tc.existing},
+			}}
+
+			c, err := NewSetCmd(&cmd.BaseCmd{}, cmdopts.WithConfigLoader(&mockLoader{cfg: cfg}))
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+			c.SetOut(&out)
+			c.SetErr(&out)
+			c.SetArgs(tc.args)
+
+			err = c.Execute()
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedOutput, out.String())
+			require.Len(t, cfg.servers, 1)
+			require.Equal(t, tc.expectedPrompts, cfg.servers[0].Prompts)
+		})
+	}
+}
+
+func TestSetCmd_LoaderError(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewSetCmd(&cmd.BaseCmd{}, cmdopts.WithConfigLoader(&mockLoader{err: fmt.Errorf("load failed")}))
+	require.NoError(t, err)
+
+	c.SetOut(&bytes.Buffer{})
+	c.SetErr(&bytes.Buffer{})
+	c.SetArgs([]string{"github", "--prompt", "code_review"})
+
+	require.EqualError(t, c.Execute(), "load failed")
+}
@@ -0,0 +1,63 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mozilla-ai/mcpd/internal/cmd"
+	cmdopts "github.com/mozilla-ai/mcpd/internal/cmd/options"
+	"github.com/mozilla-ai/mcpd/internal/config"
+)
+
+// NewCmd creates a new resources command with its sub-commands.
+func NewCmd(baseCmd *cmd.BaseCmd, opt ...cmdopts.CmdOption) (*cobra.Command, error) {
+	cobraCmd := &cobra.Command{
+		Use:   "resources",
+		Short: "Manages the resources allowlist for a registered MCP server",
+		Long: "Manages the resources allowlist for a registered MCP server, " +
+			"dealing with setting, removing, and listing allowed resource URI patterns. " +
+			"When no patterns are configured all resources offered by the server are allowed",
+	}
+
+	// Sub-commands for: mcpd config resources
+	fns := []func(baseCmd *cmd.BaseCmd, opt ...cmdopts.CmdOption) (*cobra.Command, error){
+		NewSetCmd,    // set
+		NewRemoveCmd, // remove
+		NewListCmd,   // list
+	}
+
+	for _, fn := range fns {
+		tempCmd, err := fn(baseCmd, opt...)
+		if err != nil {
+			return nil, err
+		}
+		cobraCmd.AddCommand(tempCmd)
+	}
+
+	return cobraCmd, nil
+}
+
+// findServer returns the configuration of the named server.
+func findServer(cfg config.Modifier, name string) (config.ServerEntry, error) {
+	for _, srv := range cfg.ListServers() {
+		if srv.Name == name {
+			return srv, nil
+		}
+	}
+
+	return config.ServerEntry{}, fmt.Errorf("server '%s' not found in configuration", name)
+}
+
+// updateServer replaces the configuration of the server (following the existing remove and re-add pattern).
+func updateServer(cfg config.Modifier, entry config.ServerEntry) error {
+	if err := cfg.RemoveServer(entry.Name); err != nil {
+		return fmt.Errorf("error updating server configuration: %w", err)
+	}
+
+	if err := cfg.AddServer(entry); err != nil {
+		return fmt.Errorf("error updating server configuration: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package resources
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mozilla-ai/mcpd/internal/cmd"
+	cmdopts "github.com/mozilla-ai/mcpd/internal/cmd/options"
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/flags"
+)
+
+// ListCmd represents the command for listing the allowed resource URI patterns of an MCP server.
+// Use NewListCmd to create instances of ListCmd.
+type ListCmd struct {
+	*cmd.BaseCmd
+	cfgLoader config.Loader
+}
+
+// NewListCmd creates a new list command for displaying the allowed resource URI patterns of an MCP server.
+func NewListCmd(baseCmd *cmd.BaseCmd, opt ...cmdopts.CmdOption) (*cobra.Command, error) {
+	opts, err := cmdopts.NewOptions(opt...)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &ListCmd{
+		BaseCmd:   baseCmd,
+		cfgLoader: opts.ConfigLoader,
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "list <server-name>",
+		Short: "Lists the configured (allowed) resource URI patterns for a specific MCP server",
+		Long: "Lists the configured (allowed) resource URI patterns for a specific MCP server " +
+			"from the .mcpd.toml configuration file",
+		RunE: c.run,
+		Args: cobra.ExactArgs(1),
+	}
+
+	return cobraCmd, nil
+}
+
+func (c *ListCmd) run(cmd *cobra.Command, args []string) error {
+	serverName := strings.TrimSpace(args[0])
+	if serverName == "" {
+		return fmt.Errorf("server-name is required")
+	}
+
+	cfg, err := c.cfgLoader.Load(flags.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	srv, err := findServer(cfg, serverName)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+
+	_, err = fmt.Fprintf(out, "Resource patterns for '%s' (%d total):\n", serverName, len(srv.Resources))
+	if err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	if len(srv.Resources) == 0 {
+		if _, err := fmt.Fprintln(out, "  (No resource patterns configured, all resources are allowed)"); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+		return nil
+	}
+
+	for _, resource := range slices.Sorted(slices.Values(srv.Resources)) {
+		if _, err := fmt.Fprintf(out, "  %s\n", resource); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package resources
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/cmd"
+	cmdopts "github.com/mozilla-ai/mcpd/internal/cmd/options"
+	"github.com/mozilla-ai/mcpd/internal/config"
+)
+
+func TestListCmd_run(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		resources      []string
+		expectedOutput string
+	}{
+		{
+			name:      "patterns configured",
+			resources: []string{"file:///workspace/**", "config://app"},
+			expectedOutput: "Resource patterns for 'filesystem' (2 total):\n" +
+				"  config://app\n" +
+				"  file:///workspace/**\n",
+		},
+		{
+			name: "no patterns configured",
+			expectedOutput: "Resource patterns for 'filesystem' (0 total):\n" +
+				"  (No resource patterns configured, all resources are allowed)\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &mockConfig{servers: []config.ServerEntry{
+				{Name: "filesystem", Package: "npx::filesystem@1.0.0", Resources: tc.resources},
+			}}
+
+			c, err := NewListCmd(&cmd.BaseCmd{}, cmdopts.WithConfigLoader(&mockLoader{cfg: cfg}))
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+			c.SetOut(&out)
+			c.SetArgs([]string{"filesystem"})
+
+			require.NoError(t, c.Execute())
+			require.Equal(t, tc.expectedOutput, out.String())
+		})
+	}
+}
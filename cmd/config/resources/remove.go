@@ -0,0 +1,97 @@
+package resources
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mozilla-ai/mcpd/internal/cmd"
+	cmdopts "github.com/mozilla-ai/mcpd/internal/cmd/options"
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/flags"
+)
+
+// RemoveCmd represents the command for removing resources from the allowlist of an MCP server.
+// Use NewRemoveCmd to create instances of RemoveCmd.
+type RemoveCmd struct {
+	*cmd.BaseCmd
+	cfgLoader config.Loader
+}
+
+// NewRemoveCmd creates a new remove command for removing resources from the allowlist of an MCP server.
+func NewRemoveCmd(baseCmd *cmd.BaseCmd, opt ...cmdopts.CmdOption) (*cobra.Command, error) {
+	opts, err := cmdopts.NewOptions(opt...)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &RemoveCmd{
+		BaseCmd:   baseCmd,
+		cfgLoader: opts.ConfigLoader,
+	}
+
+	// mcpd config resources remove filesystem PATTERN [PATTERN ...]
+	cobraCmd := &cobra.Command{
+		Use:   "remove <server-name> PATTERN [PATTERN ...]",
+		Short: "Remove allowed resource URI patterns for an MCP server from configuration",
+		Long: "Remove allowed resource URI patterns for an MCP server from configuration, " +
+			"if the specified patterns are present in config they will be removed. " +
+			"Removing every pattern allows all resources offered by the server",
+		RunE: c.run,
+		Args: cobra.MinimumNArgs(2), // server-name + PATTERN ...
+	}
+
+	return cobraCmd, nil
+}
+
+func (c *RemoveCmd) run(cmd *cobra.Command, args []string) error {
+	serverName := strings.TrimSpace(args[0])
+	if serverName == "" {
+		return fmt.Errorf("server-name is required")
+	}
+
+	cfg, err := c.cfgLoader.Load(flags.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	srv, err := findServer(cfg, serverName)
+	if err != nil {
+		return err
+	}
+
+	var removed []string
+	for _, resource := range args[1:] {
+		resource = strings.TrimSpace(resource)
+		if idx := slices.Index(srv.Resources, resource); idx >= 0 {
+			srv.Resources = slices.Delete(srv.Resources, idx, idx+1)
+			removed = append(removed, resource)
+		}
+	}
+
+	if len(removed) == 0 {
+		_, err := fmt.Fprint(
+			cmd.OutOrStdout(),
+			"✓ Command completed successfully, no resource patterns required removal\n",
+		)
+		return err
+	}
+
+	if err := updateServer(cfg, srv); err != nil {
+		return err
+	}
+
+	msg := fmt.Sprintf("✓ Resource patterns removed for server '%s': %v\n", serverName, removed)
+	if len(srv.Resources) == 0 {
+		msg += fmt.Sprintf(
+			"  No resource patterns remain configured, all resources for server '%s' are now allowed\n",
+			serverName,
+		)
+	}
+
+	_, err = fmt.Fprint(cmd.OutOrStdout(), msg)
+
+	return err
+}
@@ -0,0 +1,84 @@
+package resources
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/cmd"
+	cmdopts "github.com/mozilla-ai/mcpd/internal/cmd/options"
+	"github.com/mozilla-ai/mcpd/internal/config"
+)
+
+func TestRemoveCmd_run(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		args              []string
+		expectedResources []string
+		expectedOutput    string
+		expectedError     string
+	}{
+		{
+			name:              "remove pattern",
+			args:              []string{"filesystem", "config://app"},
+			expectedResources: []string{"file:///workspace/**"},
+			expectedOutput:    "✓ Resource patterns removed for server 'filesystem': [config://app]\n",
+		},
+		{
+			name:              "remove all patterns",
+			args:              []string{"filesystem", "config://app", "file:///workspace/**"},
+			expectedResources: []string{},
+			expectedOutput: "✓ Resource patterns removed for server 'filesystem': " +
+				"[config://app file:///workspace/**]\n" +
+				"  No resource patterns remain configured, all resources for server 'filesystem' are now allowed\n",
+		},
+		{
+			name:              "nothing to remove",
+			args:              []string{"filesystem", "file:///etc/**"},
+			expectedResources: []string{"config://app", "file:///workspace/**"},
+			expectedOutput:    "✓ Command completed successfully, no resource patterns required removal\n",
+		},
+		{
+			name:          "server not found",
+			args:          []string{"unknown", "config://app"},
+			expectedError: "server 'unknown' not found in configuration",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &mockConfig{servers: []config.ServerEntry{
+				{
+					Name:      "filesystem",
+					Package:   "npx::filesystem@1.0.0",
+					Tools:     []string{"read_file"},
+					Resources: []string{"config://app", "file:///workspace/**"},
+				},
+			}}
+
+			c, err := NewRemoveCmd(&cmd.BaseCmd{}, cmdopts.WithConfigLoader(&mockLoader{cfg: cfg}))
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+			c.SetOut(&out)
+			c.SetErr(&out)
+			c.SetArgs(tc.args)
+
+			err = c.Execute()
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedOutput, out.String())
+			require.Len(t, cfg.servers, 1)
+			require.Equal(t, tc.expectedResources, cfg.servers[0].Resources)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package resources
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mozilla-ai/mcpd/internal/cmd"
+	cmdopts "github.com/mozilla-ai/mcpd/internal/cmd/options"
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/flags"
+)
+
+// SetCmd represents the command for adding resources to the allowlist of an MCP server.
+// Use NewSetCmd to create instances of SetCmd.
+type SetCmd struct {
+	*cmd.BaseCmd
+	cfgLoader config.Loader
+	resources []string
+}
+
+// NewSetCmd creates a new set command for adding resources to the allowlist of an MCP server.
+func NewSetCmd(baseCmd *cmd.BaseCmd, opt ...cmdopts.CmdOption) (*cobra.Command, error) {
+	opts, err := cmdopts.NewOptions(opt...)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &SetCmd{
+		BaseCmd:   baseCmd,
+		cfgLoader: opts.ConfigLoader,
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "set <server-name> --resource <pattern1> [--resource <pattern2> ...]",
+		Short: "Add allowed resource URI patterns to an MCP server configuration",
+		Long: "Add allowed resource URI patterns to an MCP server configuration. " +
+			"Patterns are matched using glob syntax (e.g. 'file:///workspace/*.md'), " +
+			"and a pattern ending in '**' allows any URI with that prefix (e.g. 'file:///workspace/**'). " +
+			"Patterns are added to the existing set of patterns (append behavior). " +
+			"Duplicate patterns are automatically deduplicated. " +
+			"Once any patterns are configured, only resources matching a pattern are allowed.",
+		RunE: c.run,
+		Args: cobra.ExactArgs(1), // server-name
+	}
+
+	cobraCmd.Flags().StringArrayVar(
+		&c.resources,
+		"resource",
+		nil,
+		"Prompt to add to the server's allowed list (can be repeated)",
+	)
+	_ = cobraCmd.MarkFlagRequired("resource")
+
+	return cobraCmd, nil
+}
+
+func (c *SetCmd) run(cmd *cobra.Command, args []string) error {
+	serverName := strings.TrimSpace(args[0])
+	if serverName == "" {
+		return fmt.Errorf("server-name is required")
+	}
+
+	resources := make([]string, 0, len(c.resources))
+	for _, resource := range c.resources {
+		resource = strings.TrimSpace(resource)
+		if err := config.ValidateResourcePattern(resource); err != nil {
+			return err
+		}
+		resources = append(resources, resource)
+	}
+
+	cfg, err := c.cfgLoader.Load(flags.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	srv, err := findServer(cfg, serverName)
+	if err != nil {
+		return err
+	}
+
+	// Track which resources are actually new.
+	var added []string
+	for _, resource := range resources {
+		if !slices.Contains(srv.Resources, resource) {
+			srv.Resources = append(srv.Resources, resource)
+			added = append(added, resource)
+		}
+	}
+
+	if len(added) == 0 {
+		_, err := fmt.Fprintf(
+			cmd.OutOrStdout(),
+			"✓ No new resource patterns added for server '%s' (all specified patterns already exist)\n",
+			serverName,
+		)
+		return err
+	}
+
+	slices.Sort(srv.Resources)
+
+	if err := updateServer(cfg, srv); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(cmd.OutOrStdout(), "✓ Resource patterns added for server '%s': %v\n", serverName, added)
+
+	return err
+}
@@ -0,0 +1,105 @@
+package resources
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/cmd"
+	cmdopts "github.com/mozilla-ai/mcpd/internal/cmd/options"
+	"github.com/mozilla-ai/mcpd/internal/config"
+)
+
+func TestSetCmd_run(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		args              []string
+		existing          []string
+		expectedResources []string
+		expectedOutput    string
+		expectedError     string
+	}{
+		{
+			name: "add patterns",
+			args: []string{
+				"filesystem",
+				"--resource", "file:///workspace/**",
+				"--resource", "config://app",
+			},
+			expectedResources: []string{"config://app", "file:///workspace/**"},
+			expectedOutput: "✓ Resource patterns added for server 'filesystem': " +
+				"[file:///workspace/** config://app]\n",
+		},
+		{
+			name: "append and deduplicate",
+			args: []string{
+				"filesystem",
+				"--resource", "file:///workspace/**",
+				"--resource", "file:///*.md",
+			},
+			existing:          []string{"file:///workspace/**"},
+			expectedResources: []string{"file:///*.md", "file:///workspace/**"},
+			expectedOutput:    "✓ Resource patterns added for server 'filesystem': [file:///*.md]\n",
+		},
+		{
+			name:              "no new patterns",
+			args:              []string{"filesystem", "--resource", "file:///workspace/**"},
+			existing:          []string{"file:///workspace/**"},
+			expectedResources: []string{"file:///workspace/**"},
+			expectedOutput: "✓ No new resource patterns added for server 'filesystem' " +
+				"(all specified patterns already exist)\n",
+		},
+		{
+			name:          "invalid pattern",
+			args:          []string{"filesystem", "--resource", "file:///[a-"},
+			expectedError: "resource pattern 'file:///[a-' is invalid: syntax error in pattern",
+		},
+		{
+			name:          "empty pattern",
+			args:          []string{"filesystem", "--resource", " "},
+			expectedError: "resource pattern is empty",
+		},
+		{
+			name:          "server not found",
+			args:          []string{"unknown", "--resource", "file:///workspace/**"},
+			expectedError: "server 'unknown' not found in configuration",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &mockConfig{servers: []config.ServerEntry{
+				{
+					Name:      "filesystem",
+					Package:   "npx::filesystem@1.0.0",
+					Tools:     []string{"read_file"},
+					Resources: tc.existing,
+				},
+			}}
+
+			c, err := NewSetCmd(&cmd.BaseCmd{}, cmdopts.WithConfigLoader(&mockLoader{cfg: cfg}))
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+			c.SetOut(&out)
+			c.SetErr(&out)
+			c.SetArgs(tc.args)
+
+			err = c.Execute()
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedOutput, out.String())
+			require.Len(t, cfg.servers, 1)
+			require.Equal(t, tc.expectedResources, cfg.servers[0].Resources)
+		})
+	}
+}
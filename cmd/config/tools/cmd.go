@@ -21,9 +21,10 @@ func NewCmd(baseCmd *cmd.BaseCmd, opt ...options.CmdOption) (*cobra.Command, err
 
 	// Sub-commands for: mcpd config env
 	fns := []func(baseCmd *cmd.BaseCmd, opt ...options.CmdOption) (*cobra.Command, error){
-		NewSetCmd,    // set
-		NewRemoveCmd, // remove
-		NewListCmd,   // list
+		NewSetCmd,     // set
+		NewRemoveCmd,  // remove
+		NewListCmd,    // list
+		NewTimeoutCmd, // timeout
 	}
 
 	for _, fn := range fns {
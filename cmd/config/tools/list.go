@@ -2,6 +2,8 @@ package tools
 
 import (
 	"fmt"
+	"io"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -29,6 +31,7 @@ type ListCmd struct {
 	registryBuilder registry.Builder
 	format          internalcmd.OutputFormat
 	allTools        bool
+	matches         bool
 	cacheDisabled   bool
 	cacheRefresh    bool
 	cacheDir        string
@@ -53,9 +56,12 @@ func NewListCmd(baseCmd *internalcmd.BaseCmd, opt ...cmdopts.CmdOption) (*cobra.
 	cobraCmd := &cobra.Command{
 		Use:   "list <server-name>",
 		Short: "Lists the configured (allowed) tools for a specific MCP server",
-		Long:  "Lists the configured (allowed) tools for a specific MCP server from the .mcpd.toml configuration file",
-		RunE:  c.run,
-		Args:  cobra.ExactArgs(1),
+		Long: "Lists the configured (allowed) tools for a specific MCP server " +
+			"from the .mcpd.toml configuration file. " +
+			"Denied tools are also listed. " +
+			"Use --matches to show the tools each pattern (e.g. 'list_*') matches in the registry.",
+		RunE: c.run,
+		Args: cobra.ExactArgs(1),
 	}
 
 	allowed := internalcmd.AllowedOutputFormats()
@@ -73,6 +79,13 @@ func NewListCmd(baseCmd *internalcmd.BaseCmd, opt ...cmdopts.CmdOption) (*cobra.
 			"(supports caching flags)",
 	)
 
+	cobraCmd.Flags().BoolVar(
+		&c.matches,
+		"matches",
+		false,
+		"Show the tools available in the registry which each tool pattern matches (supports caching flags)",
+	)
+
 	// Cache configuration flags (not used for standard configuration listing)
 	cobraCmd.Flags().BoolVar(
 		&c.cacheDisabled,
@@ -189,23 +202,69 @@ func (c *ListCmd) run(cmd *cobra.Command, args []string) error {
 		return handler.HandleError(fmt.Errorf("server '%s' not found in configuration", serverName))
 	}
 
-	tools := foundServer.Tools
 	if c.allTools {
-		tools, err = c.resolveServerTools(foundServer)
+		tools, err := c.resolveServerTools(foundServer)
 		if err != nil {
 			return handler.HandleError(err)
 		}
+
+		// Sort tools alphabetically for consistent output.
+		sort.Strings(tools)
+
+		return handler.HandleResult(printer.ToolsListResult{
+			Server: foundServer.Name,
+			Tools:  tools,
+			Count:  len(tools),
+		})
 	}
 
+	tools := slices.Clone(foundServer.Tools)
+	denied := slices.Clone(foundServer.ToolsDeny)
+
 	// Sort tools alphabetically for consistent output.
 	sort.Strings(tools)
+	sort.Strings(denied)
 
 	// Create and handle result.
 	result := printer.ToolsListResult{
 		Server: foundServer.Name,
 		Tools:  tools,
 		Count:  len(tools),
+		Denied: denied,
+	}
+	if c.matches {
+		result.Matches = c.resolvePatternMatches(cmd.ErrOrStderr(), foundServer)
 	}
 
 	return handler.HandleResult(result)
 }
+
+// resolvePatternMatches resolves each tool pattern in the server's allowlist and deny list against the
+// tools available in the registry, returning the concrete tools each pattern matches.
+// Nil is returned when the server has no patterns, or its available tools cannot be resolved,
+// in which case a warning is written and the patterns are listed without their matches.
+func (c *ListCmd) resolvePatternMatches(w io.Writer, s *config.ServerEntry) map[string][]string {
+	var patterns []string
+	for _, tool := range slices.Concat(s.Tools, s.ToolsDeny) {
+		if config.IsToolPattern(tool) {
+			patterns = append(patterns, tool)
+		}
+	}
+
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	available, err := c.resolveServerTools(s)
+	if err != nil {
+		_, _ = fmt.Fprintf(w, "Warning: unable to resolve the tools matched by patterns: %v\n", err)
+		return nil
+	}
+
+	matches := make(map[string][]string, len(patterns))
+	for _, pattern := range patterns {
+		matches[pattern] = config.MatchTools(pattern, available)
+	}
+
+	return matches
+}
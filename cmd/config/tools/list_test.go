@@ -479,3 +479,91 @@ func TestListCmd_AllFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestListCmd_Patterns(t *testing.T) {
+	t.Parallel()
+
+	configuredServer := config.ServerEntry{
+		Name:      "test-server",
+		Package:   "uvx::test-server@1.0.0",
+		Tools:     []string{"list_*", "create_issue"},
+		ToolsDeny: []string{"list_secrets", "delete_*"},
+	}
+
+	mockServer := packages.Server{
+		Name: "test-server",
+		Tools: packages.Tools{
+			{Name: "create_issue"},
+			{Name: "list_issues"},
+			{Name: "list_repos"},
+			{Name: "list_secrets"},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		args           []string
+		registryError  error
+		expectedOutput string
+	}{
+		{
+			name:          "patterns are listed without a registry lookup",
+			args:          []string{"test-server"},
+			registryError: errors.New("registry should not be used"),
+			expectedOutput: "Tools for 'test-server' (2 total):\n" +
+				"  create_issue\n" +
+				"  list_*\n" +
+				"Denied tools (2 total):\n" +
+				"  delete_*\n" +
+				"  list_secrets\n",
+		},
+		{
+			name: "shows pattern matches",
+			args: []string{"test-server", "--matches", "--no-cache"},
+			expectedOutput: "Tools for 'test-server' (2 total):\n" +
+				"  create_issue\n" +
+				"  list_* (registry matches: list_issues, list_repos, list_secrets)\n" +
+				"Denied tools (2 total):\n" +
+				"  delete_* (registry matches: none)\n" +
+				"  list_secrets\n",
+		},
+		{
+			name:          "registry unavailable warns and lists patterns without matches",
+			args:          []string{"test-server", "--matches", "--no-cache"},
+			registryError: errors.New("registry connection failed"),
+			expectedOutput: "Warning: unable to resolve the tools matched by patterns: " +
+				"failed to resolve server 'test-server': registry connection failed\n" +
+				"Tools for 'test-server' (2 total):\n" +
+				"  create_issue\n" +
+				"  list_*\n" +
+				"Denied tools (2 total):\n" +
+				"  delete_*\n" +
+				"  list_secrets\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+
+			loader := &mockConfigLoader{servers: []config.ServerEntry{configuredServer}}
+			provider := &mockPackageProvider{servers: []packages.Server{mockServer}, err: tc.registryError}
+
+			cmd, err := NewListCmd(
+				&internalcmd.BaseCmd{},
+				options.WithConfigLoader(loader),
+				options.WithRegistryBuilder(&mockRegistryBuilder{provider: provider}),
+			)
+			require.NoError(t, err)
+
+			cmd.SetOut(&buf)
+			cmd.SetErr(&buf)
+			cmd.SetArgs(tc.args)
+
+			require.NoError(t, cmd.Execute())
+			require.Equal(t, tc.expectedOutput, buf.String())
+		})
+	}
+}
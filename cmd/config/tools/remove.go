@@ -18,6 +18,7 @@ type RemoveCmd struct {
 	*cmd.BaseCmd
 	cfgLoader config.Loader
 	Tools     []string
+	deny      bool
 }
 
 func NewRemoveCmd(baseCmd *cmd.BaseCmd, opt ...cmdopts.CmdOption) (*cobra.Command, error) {
@@ -33,14 +34,22 @@ func NewRemoveCmd(baseCmd *cmd.BaseCmd, opt ...cmdopts.CmdOption) (*cobra.Comman
 
 	// mcpd config tools remove time TOOL [TOOL ...]
 	cobraCmd := &cobra.Command{
-		Use:   "remove <server-name> TOOL [TOOL ...]",
+		Use:   "remove <server-name> TOOL [TOOL ...] [--deny]",
 		Short: "Remove allowed-listed tools for an MCP server from configuration",
 		Long: "Remove allowed-listed tools for an MCP server from configuration, " +
-			"if the specified tools are present in config they will be removed",
+			"if the specified tools are present in config they will be removed. " +
+			"With --deny, tools are removed from the server's deny list (tools_deny) instead",
 		RunE: c.run,
 		Args: cobra.MinimumNArgs(2), // server-name + TOOL ...
 	}
 
+	cobraCmd.Flags().BoolVar(
+		&c.deny,
+		"deny",
+		false,
+		"Remove the tools from the server's deny list (tools_deny)",
+	)
+
 	return cobraCmd, nil
 }
 
@@ -69,14 +78,19 @@ func (c *RemoveCmd) run(cmd *cobra.Command, args []string) error {
 
 		removed := map[string]struct{}{}
 
+		targetTools := &srv.Tools
+		if c.deny {
+			targetTools = &srv.ToolsDeny
+		}
+
 		for k := range toolsMap {
-			if idx := slices.Index(srv.Tools, k); idx >= 0 {
-				srv.Tools = slices.Delete(srv.Tools, idx, idx+1)
+			if idx := slices.Index(*targetTools, k); idx >= 0 {
+				*targetTools = slices.Delete(*targetTools, idx, idx+1)
 				removed[k] = struct{}{}
 			}
 		}
 
-		// Validate that at least one tool remains.
+		// Validate that at least one tool remains, the deny list can be emptied.
 		if len(srv.Tools) == 0 {
 			return fmt.Errorf("cannot remove all tools from server '%s'\n"+
 				"To remove the server instead use: mcpd remove %s", serverName, serverName)
@@ -94,8 +108,12 @@ func (c *RemoveCmd) run(cmd *cobra.Command, args []string) error {
 		}
 
 		msg := "✓ Command completed successfully, no tools required removal\n"
-		if len(removed) > 0 {
-			msg = fmt.Sprintf("✓ Tools removed for server '%s': %v\n", serverName, slices.Collect(maps.Keys(removed)))
+		removedTools := slices.Collect(maps.Keys(removed))
+		switch {
+		case len(removed) > 0 && c.deny:
+			msg = fmt.Sprintf("✓ Denied tools removed for server '%s': %v\n", serverName, removedTools)
+		case len(removed) > 0:
+			msg = fmt.Sprintf("✓ Tools removed for server '%s': %v\n", serverName, removedTools)
 		}
 
 		_, err = fmt.Fprint(cmd.OutOrStdout(), msg)
@@ -126,3 +126,31 @@ func TestRemoveCmd_RemoveAllTools(t *testing.T) {
 		})
 	}
 }
+
+func TestRemoveCmd_Deny(t *testing.T) {
+	t.Parallel()
+
+	cfg := &mockConfigForRemove{
+		servers: []config.ServerEntry{
+			{
+				Name:      "test-server",
+				Tools:     []string{"*"},
+				ToolsDeny: []string{"delete_*"},
+			},
+		},
+	}
+
+	removeCmd, err := NewRemoveCmd(&cmd.BaseCmd{}, cmdopts.WithConfigLoader(&mockLoaderForRemove{cfg: cfg}))
+	require.NoError(t, err)
+	require.NoError(t, removeCmd.Flags().Set("deny", "true"))
+
+	var out bytes.Buffer
+	removeCmd.SetOut(&out)
+	removeCmd.SetErr(&out)
+
+	// The deny list can be emptied, and the allowed tools are unchanged.
+	require.NoError(t, removeCmd.RunE(removeCmd, []string{"test-server", "delete_*"}))
+	require.Equal(t, "✓ Denied tools removed for server 'test-server': [delete_*]\n", out.String())
+	require.Equal(t, []string{"*"}, cfg.servers[0].Tools)
+	require.Empty(t, cfg.servers[0].ToolsDeny)
+}
@@ -26,6 +26,7 @@ type SetCmd struct {
 	cfgLoader       config.Loader
 	registryBuilder registry.Builder
 	tools           []string
+	deny            bool
 	cacheDisabled   bool
 	cacheRefresh    bool
 	cacheDir        string
@@ -46,12 +47,15 @@ func NewSetCmd(baseCmd *cmd.BaseCmd, opt ...cmdopts.CmdOption) (*cobra.Command,
 	}
 
 	cobraCmd := &cobra.Command{
-		Use:   "set <server-name> --tool <tool1> [--tool <tool2> ...]",
+		Use:   "set <server-name> --tool <tool1> [--tool <tool2> ...] [--deny]",
 		Short: "Add allowed tools to an MCP server configuration",
 		Long: "Add allowed tools to an MCP server configuration. " +
 			"Tools are added to the existing set of tools (append behavior). " +
 			"Duplicate tools are automatically deduplicated. " +
-			"Only tools that are available for the server (as defined in the registry) can be added.",
+			"Only tools that are available for the server (as defined in the registry) can be added. " +
+			"Glob patterns (e.g. 'list_*') can be added when they match at least one available tool. " +
+			"With --deny, tools are added to the server's deny list (tools_deny) instead, " +
+			"and aren't checked against the registry, so tools the server may offer later can be denied.",
 		RunE: c.run,
 		Args: cobra.ExactArgs(1), // server-name
 	}
@@ -60,10 +64,17 @@ func NewSetCmd(baseCmd *cmd.BaseCmd, opt ...cmdopts.CmdOption) (*cobra.Command,
 		&c.tools,
 		"tool",
 		nil,
-		"Tool to add to the server's allowed list (can be repeated)",
+		"Tool name or pattern to add to the server's allowed list (can be repeated)",
 	)
 	_ = cobraCmd.MarkFlagRequired("tool")
 
+	cobraCmd.Flags().BoolVar(
+		&c.deny,
+		"deny",
+		false,
+		"Add the tools to the server's deny list (tools_deny), denied tools are never allowed",
+	)
+
 	// Cache configuration flags (for validating tools against registry)
 	cobraCmd.Flags().BoolVar(
 		&c.cacheDisabled,
@@ -151,6 +162,36 @@ func (c *SetCmd) resolveAvailableTools(s *config.ServerEntry) (map[string]struct
 	return available, nil
 }
 
+// validateTools checks that the tools are available for the server in the registry,
+// and that patterns match at least one available tool.
+func (c *SetCmd) validateTools(s *config.ServerEntry, tools []string) error {
+	// Get all available tools from the registry for this server (runtime, version).
+	availableTools, err := c.resolveAvailableTools(s)
+	if err != nil {
+		return fmt.Errorf("failed to get available tools for server '%s': %w", s.Name, err)
+	}
+
+	var invalidTools []string
+	for _, tool := range tools {
+		if config.IsToolPattern(tool) {
+			if len(config.MatchTools(tool, slices.Collect(maps.Keys(availableTools)))) == 0 {
+				invalidTools = append(invalidTools, tool)
+			}
+			continue
+		}
+
+		if _, exists := availableTools[tool]; !exists {
+			invalidTools = append(invalidTools, tool)
+		}
+	}
+
+	if len(invalidTools) > 0 {
+		return fmt.Errorf("the following tools are not available for server '%s': %v", s.Name, invalidTools)
+	}
+
+	return nil
+}
+
 func (c *SetCmd) run(cmd *cobra.Command, args []string) error {
 	serverName := strings.TrimSpace(args[0])
 	if serverName == "" {
@@ -192,27 +233,18 @@ func (c *SetCmd) run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("server '%s' not found in configuration", serverName)
 	}
 
-	// Get all available tools from the registry for this server (runtime, version).
-	availableTools, err := c.resolveAvailableTools(foundServer)
-	if err != nil {
-		return fmt.Errorf("failed to get available tools for server '%s': %w", serverName, err)
-	}
-
-	// Validate that all requested tools are available.
-	var invalidTools []string
-	for _, tool := range normalizedTools {
-		if _, exists := availableTools[tool]; !exists {
-			invalidTools = append(invalidTools, tool)
+	// Denied tools are added to the deny list, without checking them against the registry.
+	targetTools := &foundServer.ToolsDeny
+	if !c.deny {
+		targetTools = &foundServer.Tools
+		if err := c.validateTools(foundServer, normalizedTools); err != nil {
+			return err
 		}
 	}
 
-	if len(invalidTools) > 0 {
-		return fmt.Errorf("the following tools are not available for server '%s': %v", serverName, invalidTools)
-	}
-
 	// Create a map for efficient deduplication.
-	toolSet := make(map[string]struct{}, len(foundServer.Tools)+len(normalizedTools))
-	for _, tool := range foundServer.Tools {
+	toolSet := make(map[string]struct{}, len(*targetTools)+len(normalizedTools))
+	for _, tool := range *targetTools {
 		toolSet[tool] = struct{}{}
 	}
 
@@ -229,7 +261,7 @@ func (c *SetCmd) run(cmd *cobra.Command, args []string) error {
 	slices.Sort(allTools)
 
 	// Update the server's tools.
-	foundServer.Tools = allTools
+	*targetTools = allTools
 
 	// Update server in config by removing and re-adding (following existing pattern).
 	if err := cfg.RemoveServer(serverName); err != nil {
@@ -247,9 +279,17 @@ func (c *SetCmd) run(cmd *cobra.Command, args []string) error {
 
 	// Provide feedback to the user.
 	var msg string
-	if len(newTools) == 0 {
+	switch {
+	case len(newTools) == 0 && c.deny:
+		msg = fmt.Sprintf(
+			"✓ No new tools denied for server '%s' (all specified tools are already denied)\n",
+			serverName,
+		)
+	case len(newTools) == 0:
 		msg = fmt.Sprintf("✓ No new tools added for server '%s' (all specified tools already exist)\n", serverName)
-	} else {
+	case c.deny:
+		msg = fmt.Sprintf("✓ Tools denied for server '%s': %v\n", serverName, newTools)
+	default:
 		msg = fmt.Sprintf("✓ Tools added for server '%s': %v\n", serverName, newTools)
 	}
 
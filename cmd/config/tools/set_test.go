@@ -134,11 +134,13 @@ func TestSetCmd_run(t *testing.T) {
 		name            string
 		serverName      string
 		tools           []string
+		deny            bool
 		existingServers []config.ServerEntry
 		registryServers map[string]packages.Server
 		expectedOutput  string
 		expectedError   string
 		expectedTools   []string
+		expectedDeny    []string
 	}{
 		{
 			name:       "add new tools to server with existing tools",
@@ -298,6 +300,85 @@ func TestSetCmd_run(t *testing.T) {
 			},
 			expectedError: "the following tools are not available for server 'test-server': [invalid-tool]",
 		},
+		{
+			name:       "add tool pattern",
+			serverName: "test-server",
+			tools:      []string{"list_*"},
+			existingServers: []config.ServerEntry{
+				{
+					Name:    "test-server",
+					Package: "github.com/example/test-server@npx",
+					Tools:   []string{"create_issue"},
+				},
+			},
+			registryServers: map[string]packages.Server{
+				"test-server": {
+					Name: "test-server",
+					Tools: []packages.Tool{
+						{Name: "create_issue"},
+						{Name: "list_issues"},
+					},
+				},
+			},
+			expectedOutput: "✓ Tools added for server 'test-server': [list_*]",
+			expectedTools:  []string{"create_issue", "list_*"},
+		},
+		{
+			name:       "tool pattern matches no available tools",
+			serverName: "test-server",
+			tools:      []string{"delete_*"},
+			existingServers: []config.ServerEntry{
+				{
+					Name:    "test-server",
+					Package: "github.com/example/test-server@npx",
+				},
+			},
+			registryServers: map[string]packages.Server{
+				"test-server": {
+					Name: "test-server",
+					Tools: []packages.Tool{
+						{Name: "list_issues"},
+					},
+				},
+			},
+			expectedError: "the following tools are not available for server 'test-server': [delete_*]",
+		},
+		{
+			name:       "deny tools",
+			serverName: "test-server",
+			tools:      []string{"delete_*", "list_secrets"},
+			deny:       true,
+			existingServers: []config.ServerEntry{
+				{
+					Name:      "test-server",
+					Package:   "github.com/example/test-server@npx",
+					Tools:     []string{"*"},
+					ToolsDeny: []string{"list_secrets"},
+				},
+			},
+			// Denied tools aren't checked against the registry.
+			registryServers: map[string]packages.Server{},
+			expectedOutput:  "✓ Tools denied for server 'test-server': [delete_*]",
+			expectedTools:   []string{"*"},
+			expectedDeny:    []string{"delete_*", "list_secrets"},
+		},
+		{
+			name:       "all tools already denied",
+			serverName: "test-server",
+			tools:      []string{"delete_*"},
+			deny:       true,
+			existingServers: []config.ServerEntry{
+				{
+					Name:      "test-server",
+					Package:   "github.com/example/test-server@npx",
+					Tools:     []string{"*"},
+					ToolsDeny: []string{"delete_*"},
+				},
+			},
+			expectedOutput: "✓ No new tools denied for server 'test-server' (all specified tools are already denied)",
+			expectedTools:  []string{"*"},
+			expectedDeny:   []string{"delete_*"},
+		},
 	}
 
 	for _, tc := range tests {
@@ -329,6 +410,9 @@ func TestSetCmd_run(t *testing.T) {
 				err = setCmd.Flags().Set("tool", tool)
 				require.NoError(t, err)
 			}
+			if tc.deny {
+				require.NoError(t, setCmd.Flags().Set("deny", "true"))
+			}
 
 			// Capture output.
 			var output bytes.Buffer
@@ -360,6 +444,7 @@ func TestSetCmd_run(t *testing.T) {
 				slices.Sort(cfg.serverAdded.Tools)
 				slices.Sort(tc.expectedTools)
 				require.Equal(t, tc.expectedTools, cfg.serverAdded.Tools)
+				require.Equal(t, tc.expectedDeny, cfg.serverAdded.ToolsDeny)
 			}
 		})
 	}
@@ -0,0 +1,178 @@
+package tools
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mozilla-ai/mcpd/internal/cmd"
+	cmdopts "github.com/mozilla-ai/mcpd/internal/cmd/options"
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/filter"
+	"github.com/mozilla-ai/mcpd/internal/flags"
+)
+
+// TimeoutCmd represents the command for configuring tool call timeouts for an MCP server.
+// Use NewTimeoutCmd to create instances of TimeoutCmd.
+type TimeoutCmd struct {
+	*cmd.BaseCmd
+	cfgLoader config.Loader
+	tools     []string
+	remove    bool
+}
+
+// NewTimeoutCmd creates a new timeout command for configuring tool call timeouts for an MCP server.
+func NewTimeoutCmd(baseCmd *cmd.BaseCmd, opt ...cmdopts.CmdOption) (*cobra.Command, error) {
+	opts, err := cmdopts.NewOptions(opt...)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &TimeoutCmd{
+		BaseCmd:   baseCmd,
+		cfgLoader: opts.ConfigLoader,
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "timeout <server-name> [duration] [--tool <tool1> ...] [--remove]",
+		Short: "Configure tool call timeouts for an MCP server",
+		Long: "Configure tool call timeouts for an MCP server. " +
+			"Without --tool the duration (e.g. 30s, 5m) is the default timeout for all tools on the server, " +
+			"with --tool it applies only to the specified tools, which must be allowed for the server. " +
+			"Tool timeouts take precedence over the server timeout, which takes precedence over the daemon's " +
+			"tool call timeout. Use --remove (without a duration) to remove the configured timeouts.",
+		RunE: c.run,
+		Args: cobra.RangeArgs(1, 2), // server-name [duration]
+	}
+
+	cobraCmd.Flags().StringArrayVar(
+		&c.tools,
+		"tool",
+		nil,
+		"Tool to configure the timeout for (can be repeated)",
+	)
+
+	cobraCmd.Flags().BoolVar(
+		&c.remove,
+		"remove",
+		false,
+		"Remove the configured timeout instead of setting it",
+	)
+
+	return cobraCmd, nil
+}
+
+func (c *TimeoutCmd) run(cmd *cobra.Command, args []string) error {
+	serverName := strings.TrimSpace(args[0])
+	if serverName == "" {
+		return fmt.Errorf("server-name is required")
+	}
+
+	var timeout time.Duration
+	switch {
+	case c.remove && len(args) > 1:
+		return fmt.Errorf("duration cannot be specified with --remove")
+	case !c.remove && len(args) < 2:
+		return fmt.Errorf("duration is required (e.g. 30s), or use --remove")
+	case !c.remove:
+		d, err := time.ParseDuration(strings.TrimSpace(args[1]))
+		if err != nil {
+			return fmt.Errorf("invalid duration '%s': %w", args[1], err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("duration must be positive, got %s", d)
+		}
+		timeout = d
+	}
+
+	// Normalize all supplied tool names.
+	tools := make([]string, 0, len(c.tools))
+	for _, tool := range c.tools {
+		normalized := filter.NormalizeString(tool)
+		if normalized != "" && !slices.Contains(tools, normalized) {
+			tools = append(tools, normalized)
+		}
+	}
+
+	cfg, err := c.cfgLoader.Load(flags.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	// Find the server in the configuration.
+	var foundServer *config.ServerEntry
+	for _, srv := range cfg.ListServers() {
+		if srv.Name == serverName {
+			foundServer = &srv
+			break
+		}
+	}
+
+	if foundServer == nil {
+		return fmt.Errorf("server '%s' not found in configuration", serverName)
+	}
+
+	// Validate that all requested tools are allowed for the server.
+	var invalidTools []string
+	for _, tool := range tools {
+		if !slices.Contains(foundServer.Tools, tool) {
+			invalidTools = append(invalidTools, tool)
+		}
+	}
+
+	if len(invalidTools) > 0 {
+		return fmt.Errorf("the following tools are not allowed for server '%s': %v", serverName, invalidTools)
+	}
+
+	var msg string
+	switch {
+	case len(tools) == 0 && c.remove:
+		foundServer.Timeout = nil
+		msg = fmt.Sprintf("✓ Timeout removed for server '%s'\n", serverName)
+	case len(tools) == 0:
+		d := config.Duration(timeout)
+		foundServer.Timeout = &d
+		msg = fmt.Sprintf("✓ Timeout for server '%s' set to %s\n", serverName, timeout)
+	case c.remove:
+		for _, tool := range tools {
+			delete(foundServer.ToolTimeouts, tool)
+		}
+		if len(foundServer.ToolTimeouts) == 0 {
+			foundServer.ToolTimeouts = nil
+		}
+		msg = fmt.Sprintf("✓ Timeouts removed for server '%s' tools: %v\n", serverName, tools)
+	default:
+		toolTimeouts := make(map[string]*config.Duration, len(foundServer.ToolTimeouts)+len(tools))
+		maps.Copy(toolTimeouts, foundServer.ToolTimeouts)
+		for _, tool := range tools {
+			d := config.Duration(timeout)
+			toolTimeouts[tool] = &d
+		}
+		foundServer.ToolTimeouts = toolTimeouts
+		msg = fmt.Sprintf("✓ Timeout for server '%s' tools %v set to %s\n", serverName, tools, timeout)
+	}
+
+	// Update server in config by removing and re-adding (following existing pattern).
+	if err := cfg.RemoveServer(serverName); err != nil {
+		return fmt.Errorf("error updating server configuration: %w", err)
+	}
+
+	if err := cfg.AddServer(*foundServer); err != nil {
+		return fmt.Errorf("error updating server configuration: %w", err)
+	}
+
+	// Save the configuration.
+	if err := cfg.SaveConfig(); err != nil {
+		return fmt.Errorf("error saving configuration: %w", err)
+	}
+
+	if _, err := fmt.Fprint(cmd.OutOrStdout(), msg); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package tools
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/cmd"
+	cmdopts "github.com/mozilla-ai/mcpd/internal/cmd/options"
+	"github.com/mozilla-ai/mcpd/internal/config"
+)
+
+func durationPtr(d time.Duration) *config.Duration {
+	cd := config.Duration(d)
+	return &cd
+}
+
+func TestTimeoutCmd_run(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                 string
+		args                 []string
+		tools                []string
+		remove               bool
+		existing             config.ServerEntry
+		expectedError        string
+		expectedOutput       string
+		expectedTimeout      *config.Duration
+		expectedToolTimeouts map[string]*config.Duration
+	}{
+		{
+			name:            "set server timeout",
+			args:            []string{"test-server", "30s"},
+			existing:        config.ServerEntry{Name: "test-server", Tools: []string{"tool1"}},
+			expectedOutput:  "✓ Timeout for server 'test-server' set to 30s",
+			expectedTimeout: durationPtr(30 * time.Second),
+		},
+		{
+			name:  "set tool timeouts",
+			args:  []string{"test-server", "2m"},
+			tools: []string{"Tool1", "tool2"},
+			existing: config.ServerEntry{
+				Name:         "test-server",
+				Tools:        []string{"tool1", "tool2", "tool3"},
+				ToolTimeouts: map[string]*config.Duration{"tool3": durationPtr(time.Second)},
+			},
+			expectedOutput: "✓ Timeout for server 'test-server' tools [tool1 tool2] set to 2m0s",
+			expectedToolTimeouts: map[string]*config.Duration{
+				"tool1": durationPtr(2 * time.Minute),
+				"tool2": durationPtr(2 * time.Minute),
+				"tool3": durationPtr(time.Second),
+			},
+		},
+		{
+			name:   "remove server timeout",
+			args:   []string{"test-server"},
+			remove: true,
+			existing: config.ServerEntry{
+				Name:         "test-server",
+				Tools:        []string{"tool1"},
+				Timeout:      durationPtr(time.Minute),
+				ToolTimeouts: map[string]*config.Duration{"tool1": durationPtr(time.Second)},
+			},
+			expectedOutput:       "✓ Timeout removed for server 'test-server'",
+			expectedToolTimeouts: map[string]*config.Duration{"tool1": durationPtr(time.Second)},
+		},
+		{
+			name:   "remove tool timeout",
+			args:   []string{"test-server"},
+			tools:  []string{"tool1"},
+			remove: true,
+			existing: config.ServerEntry{
+				Name:         "test-server",
+				Tools:        []string{"tool1"},
+				Timeout:      durationPtr(time.Minute),
+				ToolTimeouts: map[string]*config.Duration{"tool1": durationPtr(time.Second)},
+			},
+			expectedOutput:  "✓ Timeouts removed for server 'test-server' tools: [tool1]",
+			expectedTimeout: durationPtr(time.Minute),
+		},
+		{
+			name:          "missing duration",
+			args:          []string{"test-server"},
+			existing:      config.ServerEntry{Name: "test-server", Tools: []string{"tool1"}},
+			expectedError: "duration is required (e.g. 30s), or use --remove",
+		},
+		{
+			name:          "duration with remove",
+			args:          []string{"test-server", "30s"},
+			remove:        true,
+			existing:      config.ServerEntry{Name: "test-server", Tools: []string{"tool1"}},
+			expectedError: "duration cannot be specified with --remove",
+		},
+		{
+			name:          "non-positive duration",
+			args:          []string{"test-server", "0s"},
+			existing:      config.ServerEntry{Name: "test-server", Tools: []string{"tool1"}},
+			expectedError: "duration must be positive, got 0s",
+		},
+		{
+			name:          "invalid duration",
+			args:          []string{"test-server", "soon"},
+			existing:      config.ServerEntry{Name: "test-server", Tools: []string{"tool1"}},
+			expectedError: "invalid duration 'soon': time: invalid duration \"soon\"",
+		},
+		{
+			name:          "server not found",
+			args:          []string{"other-server", "30s"},
+			existing:      config.ServerEntry{Name: "test-server", Tools: []string{"tool1"}},
+			expectedError: "server 'other-server' not found in configuration",
+		},
+		{
+			name:          "tool not allowed",
+			args:          []string{"test-server", "30s"},
+			tools:         []string{"tool9"},
+			existing:      config.ServerEntry{Name: "test-server", Tools: []string{"tool1"}},
+			expectedError: "the following tools are not allowed for server 'test-server': [tool9]",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &mockConfigForSet{servers: []config.ServerEntry{tc.existing}}
+			loader := &mockLoaderForSet{cfg: cfg}
+
+			timeoutCmd, err := NewTimeoutCmd(&cmd.BaseCmd{}, cmdopts.WithConfigLoader(loader))
+			require.NoError(t, err)
+
+			for _, tool := range tc.tools {
+				require.NoError(t, timeoutCmd.Flags().Set("tool", tool))
+			}
+			if tc.remove {
+				require.NoError(t, timeoutCmd.Flags().Set("remove", "true"))
+			}
+
+			var output bytes.Buffer
+			timeoutCmd.SetOut(&output)
+			timeoutCmd.SetErr(&output)
+
+			err = timeoutCmd.RunE(timeoutCmd, tc.args)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Contains(t, output.String(), tc.expectedOutput)
+			require.Equal(t, tc.args[0], cfg.serverRemoved)
+			require.Equal(t, tc.expectedTimeout, cfg.serverAdded.Timeout)
+			require.Equal(t, tc.expectedToolTimeouts, cfg.serverAdded.ToolTimeouts)
+		})
+	}
+}
@@ -30,6 +30,9 @@ const (
 	// flagAddr is the flag name for the API server address.
 	flagAddr = "addr"
 
+	// flagBatchConcurrency is the flag name for the maximum number of concurrent tool calls per batch.
+	flagBatchConcurrency = "batch-concurrency"
+
 	// flagCORSEnable is the flag name for enabling CORS support.
 	flagCORSEnable = "cors-enable"
 
@@ -107,6 +110,12 @@ type daemonFlagConfig struct {
 type apiFlagConfig struct {
 	// addr specifies the address to bind the daemon API server.
 	addr string
+
+	// batchConcurrency specifies the maximum number of tool calls from a single batch that are made concurrently.
+	batchConcurrency int
+
+	// tls indicates the daemon API server is served over TLS (only configurable via the config file).
+	tls bool
 }
 
 // corsFlagConfig groups CORS-related configuration flags.
@@ -234,7 +243,14 @@ func newDaemonCobraCmd(daemonCmd *DaemonCmd) *cobra.Command {
 		&daemonCmd.config.api.addr,
 		flagAddr,
 		"0.0.0.0:8090",
-		"Address for the daemon to bind (not applicable in --dev mode)",
+		"Address for the daemon to bind, host:port or unix:///path/to/mcpd.sock (not applicable in --dev mode)",
+	)
+
+	cobraCommand.Flags().IntVar(
+		&daemonCmd.config.api.batchConcurrency,
+		flagBatchConcurrency,
+		daemon.DefaultBatchConcurrency(),
+		"Maximum number of tool calls from a single batch request that are made concurrently",
 	)
 
 	// Add CORS flags.
@@ -428,6 +444,38 @@ func (c *DaemonCmd) run(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("error creating API options: %w", err)
 	}
 
+	// Add API jobs, authentication and TLS if configured.
+	if cfg.Daemon != nil && cfg.Daemon.API != nil {
+		if cfg.Daemon.API.Jobs != nil {
+			apiOptions = append(apiOptions, daemon.WithJobsConfig(cfg.Daemon.API.Jobs))
+		}
+		if cfg.Daemon.API.Auth != nil {
+			apiOptions = append(apiOptions, daemon.WithAuthConfig(cfg.Daemon.API.Auth))
+		}
+		if cfg.Daemon.API.TLS != nil {
+			apiOptions = append(apiOptions, daemon.WithTLSConfig(cfg.Daemon.API.TLS))
+		}
+		if cfg.Daemon.API.Socket != nil {
+			apiOptions = append(apiOptions, daemon.WithSocketConfig(cfg.Daemon.API.Socket))
+		}
+		if len(cfg.Daemon.API.Routes) > 0 {
+			apiOptions = append(apiOptions, daemon.WithRoutes(cfg.Daemon.API.Routes))
+		}
+		if len(cfg.Daemon.API.Listeners) > 0 {
+			apiOptions = append(apiOptions, daemon.WithListeners(cfg.Daemon.API.Listeners))
+		}
+	}
+
+	// Add the audit log if configured.
+	if cfg.Daemon != nil && cfg.Daemon.Audit != nil {
+		apiOptions = append(apiOptions, daemon.WithAuditConfig(cfg.Daemon.Audit))
+	}
+
+	// Add workflows if present.
+	if len(cfg.Workflows) > 0 {
+		apiOptions = append(apiOptions, daemon.WithWorkflows(cfg.Workflows))
+	}
+
 	opts, err := c.buildDaemonOptions(apiOptions)
 	if err != nil {
 		return fmt.Errorf("error creating daemon options: %w", err)
@@ -438,6 +486,11 @@ func (c *DaemonCmd) run(cmd *cobra.Command, _ []string) error {
 		opts = append(opts, daemon.WithPluginConfig(cfg.Plugins))
 	}
 
+	// Add sampling configuration if present.
+	if cfg.Sampling != nil {
+		opts = append(opts, daemon.WithSamplingConfig(cfg.Sampling))
+	}
+
 	d, err := daemon.NewDaemon(deps, opts...)
 	if err != nil {
 		return fmt.Errorf("failed to create mcpd daemon instance: %w", err)
@@ -475,6 +528,12 @@ func (c *DaemonCmd) run(cmd *cobra.Command, _ []string) error {
 	for {
 		select {
 		case <-state.reloadChan:
+			// A certificate which fails to load (e.g. while it is being renewed) doesn't stop the daemon,
+			// the current certificate continues to be presented.
+			if err := d.ReloadTLS(); err != nil {
+				logger.Error("Failed to reload API TLS certificate, keeping the current certificate", "error", err)
+			}
+
 			if err := c.reloadServers(shutdownCtx, d); err != nil {
 				logger.Error(
 					"Failed to reload servers, exiting to prevent inconsistent state",
@@ -578,6 +637,9 @@ func (c *DaemonCmd) loadConfigAPI(api *config.APIConfigSection, logger hclog.Log
 		}
 	}
 
+	// Handle TLS settings, these are only configurable via the config file.
+	c.config.api.tls = api.TLS != nil
+
 	// Handle API timeout settings.
 	if api.Timeout != nil {
 		warnings = append(warnings, c.loadConfigAPITimeout(api.Timeout, logger, cmd)...)
@@ -588,6 +650,22 @@ func (c *DaemonCmd) loadConfigAPI(api *config.APIConfigSection, logger hclog.Log
 		warnings = append(warnings, c.loadConfigCORS(api.CORS, logger, cmd)...)
 	}
 
+	// Handle batch settings.
+	if api.Batch != nil && api.Batch.Concurrency != nil {
+		concurrency := *api.Batch.Concurrency
+		if cmd.Flags().Changed(flagBatchConcurrency) {
+			warnings = append(
+				warnings,
+				flagOverrideWarning(flagBatchConcurrency, concurrency, c.config.api.batchConcurrency),
+			)
+			logger.Debug("Flag overriding config value", "flag", flagBatchConcurrency,
+				"config", concurrency, "using", c.config.api.batchConcurrency)
+		} else {
+			logger.Debug("Using config file value", "setting", "api.batch.concurrency", "value", concurrency)
+			c.config.api.batchConcurrency = concurrency
+		}
+	}
+
 	return warnings
 }
 
@@ -1019,6 +1097,10 @@ func (c *DaemonCmd) validateFlags(cmd *cobra.Command) error {
 		}
 	}
 
+	if cmd.Flags().Changed(flagBatchConcurrency) && c.config.api.batchConcurrency <= 0 {
+		return fmt.Errorf("invalid --%s: must be positive, got %d", flagBatchConcurrency, c.config.api.batchConcurrency)
+	}
+
 	return nil
 }
 
@@ -1079,6 +1161,11 @@ func (c *DaemonCmd) buildAPIOptions() ([]daemon.APIOption, error) {
 		apiOpts = append(apiOpts, daemon.WithToolCallTimeout(timeout))
 	}
 
+	// Add batch concurrency if specified.
+	if c.config.api.batchConcurrency > 0 {
+		apiOpts = append(apiOpts, daemon.WithBatchConcurrency(c.config.api.batchConcurrency))
+	}
+
 	return apiOpts, nil
 }
 
@@ -1131,12 +1218,17 @@ func (c *DaemonCmd) buildDaemonOptions(apiOptions []daemon.APIOption) ([]daemon.
 func (c *DaemonCmd) printDevBanner(w io.Writer, logger hclog.Logger, addr string) {
 	logger.Info("Launching daemon in dev mode", "addr", addr)
 
+	scheme := "http"
+	if c.config.api.tls {
+		scheme = "https"
+	}
+
 	banner := fmt.Sprintf("mcpd daemon running in 'dev' mode.\n\n"+
-		"  Local API:\thttp://%s/api/v1\n"+
-		"  OpenAPI UI:\thttp://%s/docs\n"+
+		"  Local API:\t%s://%s/api/v1\n"+
+		"  OpenAPI UI:\t%s://%s/docs\n"+
 		"  Config file:\t%s\n"+
 		"  Secrets file:\t%s\n",
-		addr, addr, flags.ConfigFile, flags.RuntimeFile)
+		scheme, addr, scheme, addr, flags.ConfigFile, flags.RuntimeFile)
 
 	if flags.LogPath != "" {
 		banner += fmt.Sprintf("  Log file:\t%s => (%s)\n", flags.LogPath, flags.LogLevel)
@@ -6,6 +6,7 @@ import (
 	"io"
 	"math/rand"
 	"os"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"syscall"
@@ -213,6 +214,7 @@ func TestDaemon_DaemonCmd_Flags(t *testing.T) {
 
 	require.NotNil(t, flags.Lookup("dev"))
 	require.NotNil(t, flags.Lookup(flagAddr))
+	require.NotNil(t, flags.Lookup(flagBatchConcurrency))
 
 	require.NotNil(t, flags.Lookup(flagCORSEnable))
 	require.NotNil(t, flags.Lookup(flagCORSOrigin))
@@ -706,6 +708,19 @@ func TestDaemon_DaemonCmd_BuildAPIOptions(t *testing.T) {
 				assert.Equal(t, 45*time.Second, apiOpts.ToolCallTimeout)
 			},
 		},
+		{
+			name: "batch concurrency",
+			config: daemonFlagConfig{
+				api: apiFlagConfig{
+					batchConcurrency: 3,
+				},
+			},
+			validateResult: func(t *testing.T, opts []daemon.APIOption) {
+				apiOpts, err := daemon.NewAPIOptions(opts...)
+				require.NoError(t, err)
+				assert.Equal(t, 3, apiOpts.BatchConcurrency)
+			},
+		},
 		{
 			name: "invalid CORS max age",
 			config: daemonFlagConfig{
@@ -1351,6 +1366,69 @@ func TestDaemon_ApplyConfigAPI(t *testing.T) {
 	}
 }
 
+func TestDaemon_ApplyConfigAPIBatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		apiConfig         *config.APIConfigSection
+		flagChanged       bool
+		initialFlagValue  int
+		expectWarnings    []string
+		expectConcurrency int
+	}{
+		{
+			name:              "no batch config",
+			apiConfig:         &config.APIConfigSection{},
+			initialFlagValue:  8,
+			expectConcurrency: 8,
+		},
+		{
+			name: "config sets value - flag not changed",
+			apiConfig: &config.APIConfigSection{
+				Batch: &config.APIBatchConfigSection{Concurrency: testIntPtr(t, 2)},
+			},
+			initialFlagValue:  8,
+			expectConcurrency: 2,
+		},
+		{
+			name: "flag overrides config value",
+			apiConfig: &config.APIConfigSection{
+				Batch: &config.APIBatchConfigSection{Concurrency: testIntPtr(t, 2)},
+			},
+			flagChanged:       true,
+			initialFlagValue:  16,
+			expectWarnings:    []string{"--batch-concurrency: config=2, flag=16 (using flag)"},
+			expectConcurrency: 16,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			daemonCmd := &DaemonCmd{
+				config: daemonFlagConfig{
+					api: apiFlagConfig{
+						batchConcurrency: tc.initialFlagValue,
+					},
+				},
+			}
+
+			command := &cobra.Command{}
+			command.Flags().Int(flagBatchConcurrency, 0, "test flag")
+			if tc.flagChanged {
+				require.NoError(t, command.Flags().Set(flagBatchConcurrency, strconv.Itoa(tc.initialFlagValue)))
+			}
+
+			warnings := daemonCmd.loadConfigAPI(tc.apiConfig, hclog.NewNullLogger(), command)
+
+			assert.Equal(t, tc.expectWarnings, warnings)
+			assert.Equal(t, tc.expectConcurrency, daemonCmd.config.api.batchConcurrency)
+		})
+	}
+}
+
 func TestDaemon_ApplyConfigCORS(t *testing.T) {
 	t.Parallel()
 
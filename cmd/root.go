@@ -5,6 +5,8 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/mozilla-ai/mcpd/cmd/approvals"
+	"github.com/mozilla-ai/mcpd/cmd/audit"
 	"github.com/mozilla-ai/mcpd/cmd/config"
 	"github.com/mozilla-ai/mcpd/internal/cmd"
 	"github.com/mozilla-ai/mcpd/internal/cmd/options"
@@ -70,6 +72,8 @@ func NewRootCmd(c *RootCmd) (*cobra.Command, error) {
 		NewRemoveCmd,
 		NewDaemonCmd,
 		config.NewConfigCmd,
+		approvals.NewCmd,
+		audit.NewCmd,
 		NewInspectorCmd,
 	}
 
@@ -0,0 +1,570 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/hashicorp/go-hclog"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/contracts"
+	"github.com/mozilla-ai/mcpd/internal/domain"
+	"github.com/mozilla-ai/mcpd/internal/errors"
+	"github.com/mozilla-ai/mcpd/internal/filter"
+)
+
+const (
+	// ApprovalStatusPending indicates the tool call is awaiting a decision.
+	ApprovalStatusPending ApprovalStatus = "pending"
+
+	// ApprovalStatusApproved indicates the tool call was approved, and has been started as a job.
+	ApprovalStatusApproved ApprovalStatus = "approved"
+
+	// ApprovalStatusDenied indicates the tool call was denied, and will not be made.
+	ApprovalStatusDenied ApprovalStatus = "denied"
+
+	// ApprovalStatusExpired indicates no decision was made before the approval expired, the tool call will not be made.
+	ApprovalStatusExpired ApprovalStatus = "expired"
+)
+
+// ApprovalStatus represents the lifecycle state of a tool call which requires approval.
+type ApprovalStatus string
+
+// Approval represents a tool call which is held pending approval.
+type Approval struct {
+	// ID uniquely identifies the approval.
+	ID string `doc:"Unique identifier of the approval" json:"id"`
+
+	// Server is the name of the MCP server the tool belongs to.
+	Server string `doc:"Name of the server" json:"server"`
+
+	// Tool is the name of the tool to call.
+	Tool string `doc:"Name of the tool" json:"tool"`
+
+	// Arguments are the arguments the tool will be called with, when approved.
+	Arguments map[string]any `doc:"Arguments of the tool call" json:"arguments,omitempty"`
+
+	// Status is the current state of the approval.
+	Status ApprovalStatus `doc:"Current approval status" enum:"pending,approved,denied,expired" json:"status"`
+
+	// Reason is the reason given for the decision, if any.
+	Reason string `doc:"Reason given for the decision" json:"reason,omitempty"`
+
+	// JobID identifies the job making the tool call, present when Status is approved and the call was started.
+	JobID string `doc:"Identifier of the job making the approved tool call" json:"jobId,omitempty"`
+
+	// Error describes why the approved tool call could not be started.
+	Error string `doc:"Error message" json:"error,omitempty"`
+
+	// CreatedAt is when the tool call was requested.
+	CreatedAt time.Time `doc:"Time the approval was requested" json:"createdAt"`
+
+	// ExpiresAt is when the approval expires, if no decision has been made.
+	ExpiresAt time.Time `doc:"Time the approval expires" json:"expiresAt"`
+
+	// DecidedAt is when the approval was approved, denied, or expired.
+	DecidedAt *time.Time `doc:"Time the approval was decided" json:"decidedAt,omitempty"`
+}
+
+// ApprovalsRequest represents the incoming API request for listing approvals.
+type ApprovalsRequest struct {
+	Status ApprovalStatus `doc:"Only include approvals with this status" enum:"pending,approved,denied,expired" query:"status"`
+}
+
+// ApprovalsResponse represents the wrapped API response for a list of approvals.
+type ApprovalsResponse struct {
+	Body struct {
+		Approvals []Approval `json:"approvals"`
+	}
+}
+
+// ApprovalRequest represents the incoming API request for an approval.
+type ApprovalRequest struct {
+	ID string `doc:"Identifier of the approval" path:"id"`
+}
+
+// ApprovalDecision represents the body of a request to approve or deny a tool call.
+type ApprovalDecision struct {
+	Reason string `doc:"Reason for the decision" json:"reason,omitempty"`
+}
+
+// ApprovalDecisionRequest represents the incoming API request to approve or deny a tool call.
+type ApprovalDecisionRequest struct {
+	ID   string            `doc:"Identifier of the approval" path:"id"`
+	Body *ApprovalDecision `doc:"Decision details"`
+}
+
+// ApprovalResponse represents the wrapped API response for an approval.
+type ApprovalResponse struct {
+	Body Approval
+}
+
+// IsPending reports whether the status is awaiting a decision.
+func (s ApprovalStatus) IsPending() bool {
+	return s == ApprovalStatusPending
+}
+
+// approvalStartFunc starts an approved tool call, returning the ID of the job making the call.
+type approvalStartFunc func() (string, error)
+
+// approvalEntry is the internal state tracked for an approval.
+type approvalEntry struct {
+	approval Approval
+	start    approvalStartFunc
+	timer    *time.Timer
+}
+
+// ApprovalStore tracks tool calls which are held pending approval, retaining a bounded number of decided approvals.
+// Every request and decision is audit logged.
+// NewApprovalStore should be used to create instances of ApprovalStore.
+type ApprovalStore struct {
+	mu sync.Mutex
+
+	// basePath is the API path under which approvals can be retrieved (e.g. /api/v1/approvals).
+	basePath string
+
+	// approvals holds all known approvals by ID.
+	approvals map[string]*approvalEntry
+
+	// decided holds the IDs of decided approvals, oldest first.
+	decided []string
+
+	// timeout is how long an approval remains pending before it expires.
+	timeout time.Duration
+
+	// maxDecided is the maximum number of decided approvals retained.
+	maxDecided int
+
+	// retention is how long decided approvals are retained.
+	retention time.Duration
+
+	// logger is used to audit log approvals.
+	logger hclog.Logger
+
+	// now returns the current time, replaceable for tests.
+	now func() time.Time
+}
+
+// NewApprovalStore creates an ApprovalStore configured from the supplied route options.
+// The base path is the API path under which the approval routes are registered.
+func NewApprovalStore(basePath string, options RouteOptions) *ApprovalStore {
+	logger := options.Logger
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+
+	return &ApprovalStore{
+		basePath:   basePath,
+		approvals:  make(map[string]*approvalEntry),
+		timeout:    options.ApprovalTimeout,
+		maxDecided: options.ApprovalMaxDecided,
+		retention:  options.ApprovalRetention,
+		logger:     logger.Named("approvals"),
+		now:        func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Request holds a tool call pending approval, start is called to make the call once it is approved.
+// The approval expires if no decision is made before the timeout.
+func (s *ApprovalStore) Request(server string, tool string, args map[string]any, start approvalStartFunc) Approval {
+	id := newToken()
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune()
+	entry := &approvalEntry{
+		approval: Approval{
+			ID:        id,
+			Server:    filter.NormalizeString(server),
+			Tool:      filter.NormalizeString(tool),
+			Arguments: args,
+			Status:    ApprovalStatusPending,
+			CreatedAt: now,
+			ExpiresAt: now.Add(s.timeout),
+		},
+		start: start,
+	}
+	entry.timer = time.AfterFunc(s.timeout, func() { s.expire(id) })
+	s.approvals[id] = entry
+
+	s.logger.Info(
+		"Tool call approval requested",
+		"id", id,
+		"server", entry.approval.Server,
+		"tool", entry.approval.Tool,
+		"expiresAt", entry.approval.ExpiresAt,
+	)
+
+	return entry.approval.clone()
+}
+
+// Location returns the API path of the approval with the given ID.
+func (s *ApprovalStore) Location(id string) string {
+	return path.Join(s.basePath, id)
+}
+
+// List returns snapshots of the retained approvals, oldest first.
+// When status is supplied, only approvals with that status are returned.
+func (s *ApprovalStore) List(status ApprovalStatus) []Approval {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune()
+
+	approvals := make([]Approval, 0, len(s.approvals))
+	for _, entry := range s.approvals {
+		if status == "" || entry.approval.Status == status {
+			approvals = append(approvals, entry.approval.clone())
+		}
+	}
+
+	slices.SortFunc(approvals, func(a, b Approval) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return approvals
+}
+
+// Get returns a snapshot of the approval with the given ID.
+func (s *ApprovalStore) Get(id string) (Approval, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune()
+
+	entry, ok := s.approvals[id]
+	if !ok {
+		return Approval{}, fmt.Errorf("%w: %s", errors.ErrApprovalNotFound, id)
+	}
+
+	return entry.approval.clone(), nil
+}
+
+// Approve approves a pending tool call, and starts it.
+// The caller identified in the context is audit logged as having approved the tool call.
+// Approving an approval which is not pending fails, the tool call is not made.
+func (s *ApprovalStore) Approve(ctx context.Context, id string, reason string) (Approval, error) {
+	entry, err := s.decide(ctx, id, ApprovalStatusApproved, reason)
+	if err != nil {
+		return Approval{}, err
+	}
+
+	jobID, startErr := entry.start()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry.approval.JobID = jobID
+	if startErr != nil {
+		entry.approval.Error = startErr.Error()
+		s.logger.Warn("Approved tool call failed to start", "id", id, "error", startErr)
+	}
+
+	return entry.approval.clone(), nil
+}
+
+// Deny denies a pending tool call, so it is never made.
+// The caller identified in the context is audit logged as having denied the tool call.
+// Denying an approval which is not pending fails.
+func (s *ApprovalStore) Deny(ctx context.Context, id string, reason string) (Approval, error) {
+	entry, err := s.decide(ctx, id, ApprovalStatusDenied, reason)
+	if err != nil {
+		return Approval{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return entry.approval.clone(), nil
+}
+
+// decide records the decision for a pending approval, made by the caller identified in the context.
+func (s *ApprovalStore) decide(
+	ctx context.Context,
+	id string,
+	status ApprovalStatus,
+	reason string,
+) (*approvalEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune()
+
+	entry, ok := s.approvals[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errors.ErrApprovalNotFound, id)
+	}
+
+	// Guard against deciding an approval which has expired, but whose timer has not yet fired.
+	if entry.approval.Status.IsPending() && !s.now().Before(entry.approval.ExpiresAt) {
+		s.markDecided(entry, ApprovalStatusExpired, "", "")
+	}
+
+	if !entry.approval.Status.IsPending() {
+		return nil, fmt.Errorf("%w: %s is %s", errors.ErrApprovalNotPending, id, entry.approval.Status)
+	}
+
+	s.markDecided(entry, status, strings.TrimSpace(reason), callerName(ctx))
+
+	return entry, nil
+}
+
+// expire marks the approval as expired, unless a decision has already been made.
+func (s *ApprovalStore) expire(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.approvals[id]
+	if !ok || !entry.approval.Status.IsPending() {
+		return
+	}
+
+	s.markDecided(entry, ApprovalStatusExpired, "", "")
+}
+
+// markDecided moves an approval to a decided status, registers it for retention, and audit logs the decision
+// along with who made it, when it wasn't made by expiring.
+// The caller must hold the lock.
+func (s *ApprovalStore) markDecided(entry *approvalEntry, status ApprovalStatus, reason string, decidedBy string) {
+	decidedAt := s.now()
+	entry.approval.Status = status
+	entry.approval.Reason = reason
+	entry.approval.DecidedAt = &decidedAt
+	entry.timer.Stop()
+
+	args := []any{
+		"id", entry.approval.ID,
+		"server", entry.approval.Server,
+		"tool", entry.approval.Tool,
+		"reason", reason,
+	}
+	if decidedBy != "" {
+		args = append(args, "decidedBy", decidedBy)
+	}
+	s.logger.Info("Tool call approval "+string(status), args...)
+
+	s.decided = append(s.decided, entry.approval.ID)
+	s.prune()
+}
+
+// prune removes decided approvals that exceed the retention limits.
+// The caller must hold the lock.
+func (s *ApprovalStore) prune() {
+	now := s.now()
+	for len(s.decided) > 0 {
+		oldest := s.approvals[s.decided[0]]
+		expired := oldest != nil && now.Sub(*oldest.approval.DecidedAt) > s.retention
+		if len(s.decided) <= s.maxDecided && !expired {
+			return
+		}
+		delete(s.approvals, s.decided[0])
+		s.decided = s.decided[1:]
+	}
+}
+
+// clone returns a copy of the approval that does not share pointers with the original.
+func (a Approval) clone() Approval {
+	a.Arguments = maps.Clone(a.Arguments)
+	if a.DecidedAt != nil {
+		d := *a.DecidedAt
+		a.DecidedAt = &d
+	}
+	return a
+}
+
+// approvalRequired returns true when calls to the tool require approval.
+// Calls to a virtual tool require approval when either the virtual tool, or its upstream tool, require approval.
+// The tool's hints are taken from the catalog when cached.
+func approvalRequired(options RouteOptions, server string, tool string) bool {
+	entry, ok := serverConfig(options.ServerConfigs, server)
+	if !ok || entry.Approval == nil {
+		return false
+	}
+
+	upstream := tool
+	if vt, ok := entry.VirtualTool(tool); ok {
+		upstream = vt.Tool
+	}
+
+	hints := cachedToolHints(options.Catalog, server, upstream)
+
+	return entry.ApprovalRequired(tool, hints) || entry.ApprovalRequired(upstream, hints)
+}
+
+// cachedToolHints returns the hints (annotations) for the tool from the catalog, or nil when they are unknown.
+func cachedToolHints(catalog contracts.MCPCatalogAccessor, server string, tool string) *config.ToolHints {
+	listing, _, cached := cachedListing(catalog, server, func(c domain.ServerCatalog) domain.CatalogListing[mcp.Tool] {
+		return c.Tools
+	})
+	if !cached {
+		return nil
+	}
+
+	tool = filter.NormalizeString(tool)
+	for _, t := range listing.Items {
+		if filter.NormalizeString(t.Name) == tool {
+			return toolHints(t)
+		}
+	}
+
+	return nil
+}
+
+// toolHints returns the hints (annotations) the server provides for the tool.
+func toolHints(tool mcp.Tool) *config.ToolHints {
+	return &config.ToolHints{
+		ReadOnly:    tool.Annotations.ReadOnlyHint,
+		Destructive: tool.Annotations.DestructiveHint,
+		OpenWorld:   tool.Annotations.OpenWorldHint,
+	}
+}
+
+// handleServerToolCallApproval validates a tool call and holds it pending approval.
+// Once approved, the call is started as a background job (with the allowlist applied again).
+// The response carries the approval ID in the body, and the approval location in the Location header.
+func handleServerToolCallApproval(
+	ctx context.Context,
+	accessor contracts.MCPClientAccessor,
+	catalog contracts.MCPCatalogAccessor,
+	configs contracts.MCPServerConfigAccessor,
+	approvals *ApprovalStore,
+	jobs *JobStore,
+	server string,
+	tool string,
+	data map[string]any,
+) (*ToolCallResponse, error) {
+	if _, _, _, err := allowedToolCall(accessor, catalog, configs, server, tool, data); err != nil {
+		return nil, err
+	}
+
+	approval := approvals.Request(server, tool, data, func() (string, error) {
+		resp, err := handleServerToolCallAsync(ctx, accessor, catalog, configs, jobs, server, tool, data)
+		if err != nil {
+			return "", err
+		}
+		return resp.Body, nil
+	})
+
+	resp := &ToolCallResponse{}
+	resp.Status = http.StatusAccepted
+	resp.Location = approvals.Location(approval.ID)
+	resp.Body = approval.ID
+
+	return resp, nil
+}
+
+// RegisterApprovalRoutes registers the tool call approval endpoints on the provided API group.
+func RegisterApprovalRoutes(routerAPI huma.API, approvals *ApprovalStore, apiPathPrefix string) {
+	approvalsAPI := huma.NewGroup(routerAPI, apiPathPrefix)
+	tags := []string{"Approvals"}
+
+	huma.Register(
+		approvalsAPI,
+		huma.Operation{
+			OperationID: "listApprovals",
+			Method:      http.MethodGet,
+			Summary:     "List tool call approvals",
+			Description: "Returns the tool calls which are pending approval, along with recently decided approvals",
+			Tags:        tags,
+		},
+		func(ctx context.Context, input *ApprovalsRequest) (*ApprovalsResponse, error) {
+			return handleApprovals(approvals, input.Status)
+		},
+	)
+
+	huma.Register(
+		approvalsAPI,
+		huma.Operation{
+			OperationID: "getApproval",
+			Method:      http.MethodGet,
+			Path:        "/{id}",
+			Summary:     "Get tool call approval",
+			Description: "Returns the status of a tool call approval, including the job ID once approved",
+			Tags:        tags,
+		},
+		func(ctx context.Context, input *ApprovalRequest) (*ApprovalResponse, error) {
+			return handleApproval(approvals, input.ID)
+		},
+	)
+
+	huma.Register(
+		approvalsAPI,
+		huma.Operation{
+			OperationID: "approveApproval",
+			Method:      http.MethodPost,
+			Path:        "/{id}/approve",
+			Summary:     "Approve tool call",
+			Description: "Approves a pending tool call, which is started as an asynchronous job",
+			Tags:        tags,
+		},
+		func(ctx context.Context, input *ApprovalDecisionRequest) (*ApprovalResponse, error) {
+			return handleApprovalDecision(ctx, approvals.Approve, input)
+		},
+	)
+
+	huma.Register(
+		approvalsAPI,
+		huma.Operation{
+			OperationID: "denyApproval",
+			Method:      http.MethodPost,
+			Path:        "/{id}/deny",
+			Summary:     "Deny tool call",
+			Description: "Denies a pending tool call, so it is never made",
+			Tags:        tags,
+		},
+		func(ctx context.Context, input *ApprovalDecisionRequest) (*ApprovalResponse, error) {
+			return handleApprovalDecision(ctx, approvals.Deny, input)
+		},
+	)
+}
+
+// handleApprovals returns the retained approvals, optionally filtered by status.
+func handleApprovals(approvals *ApprovalStore, status ApprovalStatus) (*ApprovalsResponse, error) {
+	resp := &ApprovalsResponse{}
+	resp.Body.Approvals = approvals.List(status)
+
+	return resp, nil
+}
+
+// handleApproval returns the current state of an approval.
+func handleApproval(approvals *ApprovalStore, id string) (*ApprovalResponse, error) {
+	approval, err := approvals.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ApprovalResponse{Body: approval}, nil
+}
+
+// handleApprovalDecision applies the decision (approve or deny) of the caller to an approval and returns its state.
+func handleApprovalDecision(
+	ctx context.Context,
+	decide func(ctx context.Context, id string, reason string) (Approval, error),
+	input *ApprovalDecisionRequest,
+) (*ApprovalResponse, error) {
+	var reason string
+	if input.Body != nil {
+		reason = input.Body.Reason
+	}
+
+	approval, err := decide(ctx, input.ID, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ApprovalResponse{Body: approval}, nil
+}
@@ -0,0 +1,420 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/auth"
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/domain"
+	"github.com/mozilla-ai/mcpd/internal/errors"
+)
+
+func waitForApprovalStatus(t *testing.T, approvals *ApprovalStore, id string, status ApprovalStatus) Approval {
+	t.Helper()
+
+	var approval Approval
+	require.Eventually(t, func() bool {
+		var err error
+		approval, err = approvals.Get(id)
+		require.NoError(t, err)
+		return approval.Status == status
+	}, 2*time.Second, 10*time.Millisecond)
+
+	return approval
+}
+
+func TestHandleServerToolCallApproval_Approve(t *testing.T) {
+	t.Parallel()
+
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("testserver", &mockMCPClient{
+		callToolResult: &mcp.CallToolResult{Content: []mcp.Content{mcp.TextContent{Text: "deleted"}}},
+	}, []string{"delete_file"})
+
+	options := newRouteOptions()
+	jobs := NewJobStore("/api/v1/jobs", options)
+	approvals := NewApprovalStore("/api/v1/approvals", options)
+
+	args := map[string]any{"path": "/tmp/file"}
+	resp, err := handleServerToolCallApproval(
+		context.Background(),
+		accessor,
+		nil,
+		nil,
+		approvals,
+		jobs,
+		"testserver",
+		"delete_file",
+		args,
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusAccepted, resp.Status)
+	assert.NotEmpty(t, resp.Body)
+	assert.Equal(t, "/api/v1/approvals/"+resp.Body, resp.Location)
+
+	approval, err := approvals.Get(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, ApprovalStatusPending, approval.Status)
+	assert.Equal(t, "testserver", approval.Server)
+	assert.Equal(t, "delete_file", approval.Tool)
+	assert.Equal(t, args, approval.Arguments)
+	assert.Equal(t, approval.CreatedAt.Add(DefaultApprovalTimeout()), approval.ExpiresAt)
+	assert.Nil(t, approval.DecidedAt)
+
+	// Nothing is called until the approval is decided.
+	assert.Empty(t, jobs.jobs)
+
+	approval, err = approvals.Approve(context.Background(), resp.Body, " looks fine ")
+	require.NoError(t, err)
+	assert.Equal(t, ApprovalStatusApproved, approval.Status)
+	assert.Equal(t, "looks fine", approval.Reason)
+	assert.Empty(t, approval.Error)
+	require.NotNil(t, approval.DecidedAt)
+	require.NotEmpty(t, approval.JobID)
+
+	job := waitForJobStatus(t, jobs, approval.JobID, JobStatusSucceeded)
+	assert.Equal(t, "deleted", job.Result)
+
+	// Decisions are final.
+	_, err = approvals.Approve(context.Background(), resp.Body, "")
+	require.ErrorIs(t, err, errors.ErrApprovalNotPending)
+	_, err = approvals.Deny(context.Background(), resp.Body, "")
+	require.ErrorIs(t, err, errors.ErrApprovalNotPending)
+}
+
+func TestHandleServerToolCallApproval_ValidatesBeforeHolding(t *testing.T) {
+	t.Parallel()
+
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("testserver", &mockMCPClient{}, []string{"delete_file"})
+
+	options := newRouteOptions()
+	jobs := NewJobStore("/api/v1/jobs", options)
+	approvals := NewApprovalStore("/api/v1/approvals", options)
+
+	_, err := handleServerToolCallApproval(
+		context.Background(),
+		accessor,
+		nil,
+		nil,
+		approvals,
+		jobs,
+		"nonexistent",
+		"delete_file",
+		nil,
+	)
+	require.ErrorIs(t, err, errors.ErrServerNotFound)
+
+	_, err = handleServerToolCallApproval(
+		context.Background(),
+		accessor,
+		nil,
+		nil,
+		approvals,
+		jobs,
+		"testserver",
+		"forbidden",
+		nil,
+	)
+	require.ErrorIs(t, err, errors.ErrToolForbidden)
+
+	assert.Empty(t, approvals.List(""))
+}
+
+func TestApprovalStore_Deny(t *testing.T) {
+	t.Parallel()
+
+	approvals := NewApprovalStore("/api/v1/approvals", newRouteOptions())
+
+	started := false
+	approval := approvals.Request("testserver", "delete_file", nil, func() (string, error) {
+		started = true
+		return "job", nil
+	})
+
+	approval, err := approvals.Deny(context.Background(), approval.ID, "not today")
+	require.NoError(t, err)
+	assert.Equal(t, ApprovalStatusDenied, approval.Status)
+	assert.Equal(t, "not today", approval.Reason)
+	assert.Empty(t, approval.JobID)
+	require.NotNil(t, approval.DecidedAt)
+	assert.False(t, started)
+
+	_, err = approvals.Approve(context.Background(), approval.ID, "")
+	require.ErrorIs(t, err, errors.ErrApprovalNotPending)
+	assert.False(t, started)
+}
+
+func TestApprovalStore_StartFailure(t *testing.T) {
+	t.Parallel()
+
+	approvals := NewApprovalStore("/api/v1/approvals", newRouteOptions())
+
+	approval := approvals.Request("testserver", "delete_file", nil, func() (string, error) {
+		return "", errors.ErrToolForbidden
+	})
+
+	approval, err := approvals.Approve(context.Background(), approval.ID, "")
+	require.NoError(t, err)
+	assert.Equal(t, ApprovalStatusApproved, approval.Status)
+	assert.Empty(t, approval.JobID)
+	assert.Equal(t, errors.ErrToolForbidden.Error(), approval.Error)
+}
+
+func TestApprovalStore_Expire(t *testing.T) {
+	t.Parallel()
+
+	approvals := NewApprovalStore("/api/v1/approvals", newRouteOptions(WithApprovalTimeout(20*time.Millisecond)))
+
+	started := false
+	approval := approvals.Request("testserver", "delete_file", nil, func() (string, error) {
+		started = true
+		return "job", nil
+	})
+
+	approval = waitForApprovalStatus(t, approvals, approval.ID, ApprovalStatusExpired)
+	require.NotNil(t, approval.DecidedAt)
+
+	_, err := approvals.Approve(context.Background(), approval.ID, "")
+	require.ErrorIs(t, err, errors.ErrApprovalNotPending)
+	assert.False(t, started)
+}
+
+func TestApprovalStore_ExpiredBeforeTimerFires(t *testing.T) {
+	t.Parallel()
+
+	approvals := NewApprovalStore("/api/v1/approvals", newRouteOptions())
+
+	approval := approvals.Request("testserver", "delete_file", nil, func() (string, error) {
+		return "job", nil
+	})
+
+	now := time.Now().UTC().Add(time.Hour)
+	approvals.now = func() time.Time { return now }
+
+	_, err := approvals.Approve(context.Background(), approval.ID, "")
+	require.ErrorIs(t, err, errors.ErrApprovalNotPending)
+
+	approval, err = approvals.Get(approval.ID)
+	require.NoError(t, err)
+	assert.Equal(t, ApprovalStatusExpired, approval.Status)
+}
+
+func TestApprovalStore_NotFound(t *testing.T) {
+	t.Parallel()
+
+	approvals := NewApprovalStore("/api/v1/approvals", newRouteOptions())
+
+	_, err := approvals.Get("unknown")
+	require.ErrorIs(t, err, errors.ErrApprovalNotFound)
+
+	_, err = approvals.Approve(context.Background(), "unknown", "")
+	require.ErrorIs(t, err, errors.ErrApprovalNotFound)
+
+	_, err = approvals.Deny(context.Background(), "unknown", "")
+	require.ErrorIs(t, err, errors.ErrApprovalNotFound)
+}
+
+func TestApprovalStore_List(t *testing.T) {
+	t.Parallel()
+
+	approvals := NewApprovalStore("/api/v1/approvals", newRouteOptions())
+	start := func() (string, error) { return "job", nil }
+
+	first := approvals.Request("testserver", "delete_file", nil, start)
+	second := approvals.Request("testserver", "write_file", nil, start)
+
+	_, err := approvals.Deny(context.Background(), first.ID, "")
+	require.NoError(t, err)
+
+	all := approvals.List("")
+	require.Len(t, all, 2)
+	assert.Equal(t, first.ID, all[0].ID)
+	assert.Equal(t, second.ID, all[1].ID)
+
+	pending := approvals.List(ApprovalStatusPending)
+	require.Len(t, pending, 1)
+	assert.Equal(t, second.ID, pending[0].ID)
+
+	denied := approvals.List(ApprovalStatusDenied)
+	require.Len(t, denied, 1)
+	assert.Equal(t, first.ID, denied[0].ID)
+
+	assert.Empty(t, approvals.List(ApprovalStatusExpired))
+}
+
+func TestApprovalStore_Retention(t *testing.T) {
+	t.Parallel()
+
+	approvals := NewApprovalStore("/api/v1/approvals", newRouteOptions(WithApprovalRetention(1, time.Hour)))
+	start := func() (string, error) { return "job", nil }
+
+	first := approvals.Request("testserver", "delete_file", nil, start)
+	second := approvals.Request("testserver", "delete_file", nil, start)
+	pending := approvals.Request("testserver", "delete_file", nil, start)
+
+	_, err := approvals.Deny(context.Background(), first.ID, "")
+	require.NoError(t, err)
+	_, err = approvals.Deny(context.Background(), second.ID, "")
+	require.NoError(t, err)
+
+	// Only the most recently decided approval is retained, pending approvals are never pruned.
+	_, err = approvals.Get(first.ID)
+	require.ErrorIs(t, err, errors.ErrApprovalNotFound)
+
+	_, err = approvals.Get(second.ID)
+	require.NoError(t, err)
+
+	_, err = approvals.Get(pending.ID)
+	require.NoError(t, err)
+
+	// Decided approvals are removed once the retention period has passed.
+	now := time.Now().UTC().Add(2 * time.Hour)
+	approvals.now = func() time.Time { return now }
+
+	_, err = approvals.Get(second.ID)
+	require.ErrorIs(t, err, errors.ErrApprovalNotFound)
+}
+
+func TestHandleApprovalDecision(t *testing.T) {
+	t.Parallel()
+
+	approvals := NewApprovalStore("/api/v1/approvals", newRouteOptions())
+	approval := approvals.Request("testserver", "delete_file", nil, func() (string, error) { return "job", nil })
+
+	// The decision body is optional.
+	ctx := context.Background()
+	resp, err := handleApprovalDecision(ctx, approvals.Deny, &ApprovalDecisionRequest{ID: approval.ID})
+	require.NoError(t, err)
+	assert.Equal(t, ApprovalStatusDenied, resp.Body.Status)
+	assert.Empty(t, resp.Body.Reason)
+
+	_, err = handleApprovalDecision(ctx, approvals.Approve, &ApprovalDecisionRequest{
+		ID:   approval.ID,
+		Body: &ApprovalDecision{Reason: "too late"},
+	})
+	require.ErrorIs(t, err, errors.ErrApprovalNotPending)
+}
+
+func TestApprovalStore_LogsDecidedBy(t *testing.T) {
+	t.Parallel()
+
+	var logs bytes.Buffer
+	options := newRouteOptions()
+	options.Logger = hclog.New(&hclog.LoggerOptions{Output: &logs, JSONFormat: true})
+	approvals := NewApprovalStore("/api/v1/approvals", options)
+	start := func() (string, error) { return "job", nil }
+
+	// Decisions made by an identified caller record their name.
+	ctx := auth.NewContext(context.Background(), auth.Identity{Name: "ops"})
+	denied := approvals.Request("testserver", "delete_file", nil, start)
+	_, err := approvals.Deny(ctx, denied.ID, "")
+	require.NoError(t, err)
+
+	// Decisions made without authentication are recorded as anonymous.
+	approved := approvals.Request("testserver", "delete_file", nil, start)
+	_, err = approvals.Approve(context.Background(), approved.ID, "")
+	require.NoError(t, err)
+
+	decidedBy := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		if by, ok := entry["decidedBy"].(string); ok {
+			decidedBy[entry["id"].(string)] = by
+		}
+	}
+	require.Equal(t, map[string]string{denied.ID: "ops", approved.ID: "anonymous"}, decidedBy)
+}
+
+func TestApprovalRequired(t *testing.T) {
+	t.Parallel()
+
+	destructive := true
+	notDestructive := false
+
+	configs := &mockServerConfigAccessor{
+		configs: map[string]config.ServerEntry{
+			"github": {
+				Name: "github",
+				Approval: &config.ApprovalConfig{
+					Tools:       []string{"delete_*"},
+					Destructive: &destructive,
+				},
+				VirtualTools: []config.VirtualToolEntry{
+					{Name: "remove_org_repo", Tool: "delete_repo"},
+				},
+			},
+			"time": {Name: "time"},
+		},
+	}
+
+	refreshedAt := time.Now()
+	catalog := &mockCatalogAccessor{catalogs: map[string]domain.ServerCatalog{
+		"github": {
+			Tools: domain.CatalogListing[mcp.Tool]{
+				Items: []mcp.Tool{
+					{Name: "list_issues", Annotations: mcp.ToolAnnotation{DestructiveHint: &notDestructive}},
+					{Name: "merge_pr", Annotations: mcp.ToolAnnotation{DestructiveHint: &destructive}},
+				},
+				RefreshedAt: &refreshedAt,
+			},
+		},
+	}}
+
+	options := newRouteOptions(WithServerConfigAccessor(configs), WithCatalogAccessor(catalog))
+
+	testCases := []struct {
+		name     string
+		server   string
+		tool     string
+		expected bool
+	}{
+		{name: "named by pattern", server: "github", tool: "delete_repo", expected: true},
+		{name: "virtual tool of a named tool", server: "github", tool: "remove_org_repo", expected: true},
+		{name: "annotated as destructive", server: "github", tool: "merge_pr", expected: true},
+		{name: "annotated as not destructive", server: "github", tool: "list_issues", expected: false},
+		{name: "unknown hints default to destructive", server: "github", tool: "create_repo", expected: true},
+		{name: "server without approval", server: "time", tool: "get_current_time", expected: false},
+		{name: "unknown server", server: "unknown", tool: "delete_repo", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.expected, approvalRequired(options, tc.server, tc.tool))
+		})
+	}
+}
+
+func TestApprovalRequired_NoConfigs(t *testing.T) {
+	t.Parallel()
+
+	require.False(t, approvalRequired(newRouteOptions(), "github", "delete_repo"))
+}
+
+func TestApprovalStore_RequestNormalizesNames(t *testing.T) {
+	t.Parallel()
+
+	approvals := NewApprovalStore("/api/v1/approvals", newRouteOptions())
+	approval := approvals.Request(" GitHub ", "Delete_Repo", nil, func() (string, error) {
+		return "", stdErrors.New("unused")
+	})
+
+	assert.Equal(t, "github", approval.Server)
+	assert.Equal(t, "delete_repo", approval.Tool)
+}
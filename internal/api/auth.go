@@ -0,0 +1,197 @@
+package api
+
+import (
+	"context"
+	"crypto/x509"
+	stdErrors "errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/mozilla-ai/mcpd/internal/auth"
+	"github.com/mozilla-ai/mcpd/internal/errors"
+	"github.com/mozilla-ai/mcpd/internal/workflow"
+)
+
+const (
+	// securitySchemeName is the name of the security scheme declared in the OpenAPI spec when requests are
+	// authenticated.
+	securitySchemeName = "bearerAuth"
+
+	// bearerPrefix prefixes the token in the Authorization header of authenticated requests.
+	bearerPrefix = "Bearer "
+)
+
+// HeaderClientCertSubject carries the subject of the verified client certificate of requests made over mutual TLS,
+// e.g. 'CN=agent,O=Example', so that plugins can make decisions based on the caller.
+// The header is always set by the daemon, never by the client.
+const HeaderClientCertSubject = "Mcpd-Client-Cert-Subject"
+
+// registerAuth authenticates all requests to the routes of the group which are registered afterward,
+// and declares the security scheme in the OpenAPI spec.
+// Callers are authorized per server and tool by the routes themselves (see authorizeServer and authorizeTool).
+func registerAuth(router huma.API, group *huma.Group, authenticator auth.Authenticator) {
+	components := router.OpenAPI().Components
+	if components.SecuritySchemes == nil {
+		components.SecuritySchemes = map[string]*huma.SecurityScheme{}
+	}
+	components.SecuritySchemes[securitySchemeName] = &huma.SecurityScheme{
+		Type:   "http",
+		Scheme: "bearer",
+		Description: "API key configured for the daemon API (see 'mcpd config daemon api-keys'), " +
+			"or a JWT issued by the configured issuer. " +
+			"Callers may instead present a client certificate, when the API is served over mutual TLS",
+	}
+
+	// Modifiers run once the operation's responses are defined, so the error responses are added directly.
+	errSchema := router.OpenAPI().Components.Schemas.Schema(reflect.TypeFor[huma.ErrorModel](), true, "Error")
+	group.UseSimpleModifier(func(o *huma.Operation) {
+		o.Security = []map[string][]string{{securitySchemeName: {}}}
+		for _, status := range []int{http.StatusUnauthorized, http.StatusForbidden} {
+			o.Responses[strconv.Itoa(status)] = &huma.Response{
+				Description: http.StatusText(status),
+				Content:     map[string]*huma.MediaType{"application/problem+json": {Schema: errSchema}},
+			}
+		}
+	})
+	group.UseMiddleware(authMiddleware(group, authenticator))
+}
+
+// authMiddleware identifies the caller of each request from its bearer token, or from its client certificate when no
+// token is presented, rejecting requests without valid credentials, and requests which would make changes when the
+// caller is read-only.
+// The identity of the caller is added to the request context for the routes to authorize against.
+func authMiddleware(api huma.API, authenticator auth.Authenticator) func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		token, _ := strings.CutPrefix(ctx.Header("Authorization"), bearerPrefix)
+		token = strings.TrimSpace(token)
+
+		var (
+			identity auth.Identity
+			err      error
+		)
+		certAuthenticator, ok := authenticator.(auth.CertificateAuthenticator)
+		if cert := clientCertificate(ctx); token == "" && ok && cert != nil {
+			identity, err = certAuthenticator.AuthenticateCertificate(ctx.Context(), cert)
+		} else {
+			identity, err = authenticator.Authenticate(ctx.Context(), token)
+		}
+		if stdErrors.Is(err, errors.ErrForbidden) {
+			// The caller was identified, but isn't granted access to anything (e.g. by the claims of a JWT).
+			_ = huma.WriteErr(api, ctx, http.StatusForbidden, "", err)
+			return
+		}
+		if err != nil {
+			ctx.SetHeader("WWW-Authenticate", `Bearer realm="mcpd"`)
+			_ = huma.WriteErr(api, ctx, http.StatusUnauthorized, "", err)
+			return
+		}
+
+		if identity.ReadOnly && !readOnlyMethod(ctx.Method()) {
+			err := fmt.Errorf("%w: '%s' is read-only", errors.ErrForbidden, identity.Name)
+			_ = huma.WriteErr(api, ctx, http.StatusForbidden, "", err)
+			return
+		}
+
+		next(huma.WithContext(ctx, auth.NewContext(ctx.Context(), identity)))
+	}
+}
+
+// serverScopeMiddleware rejects requests for servers (and their tools) outside the caller's scope.
+// The server and tool are taken from the path parameters of the route ('name' or 'server', and 'tool').
+func serverScopeMiddleware(api huma.API) func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		server := ctx.Param("name")
+		if server == "" {
+			server = ctx.Param("server")
+		}
+
+		var err error
+		if tool := ctx.Param("tool"); tool != "" {
+			err = authorizeTool(ctx.Context(), server, tool)
+		} else if server != "" {
+			err = authorizeServer(ctx.Context(), server)
+		}
+		if err != nil {
+			_ = huma.WriteErr(api, ctx, http.StatusForbidden, "", err)
+			return
+		}
+
+		next(ctx)
+	}
+}
+
+// unrestrictedMiddleware rejects requests from callers which are limited to some servers or tools.
+// Used for routes which aren't specific to a server, such as deciding on tool calls pending approval.
+func unrestrictedMiddleware(api huma.API) func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		if identity, ok := auth.FromContext(ctx.Context()); ok && !identity.Unrestricted() {
+			err := fmt.Errorf("%w: '%s' is limited to some servers or tools", errors.ErrForbidden, identity.Name)
+			_ = huma.WriteErr(api, ctx, http.StatusForbidden, "", err)
+			return
+		}
+
+		next(ctx)
+	}
+}
+
+// authorizeServer returns an error wrapping errors.ErrForbidden when the caller may not access the server.
+// Requests without an identity (i.e. when authentication isn't configured) are always authorized.
+func authorizeServer(ctx context.Context, server string) error {
+	if identity, ok := auth.FromContext(ctx); ok && !identity.AllowsServer(server) {
+		return fmt.Errorf("%w: '%s' may not access server: %s", errors.ErrForbidden, identity.Name, server)
+	}
+
+	return nil
+}
+
+// authorizeTool returns an error wrapping errors.ErrForbidden when the caller may not call the tool of the server.
+// Requests without an identity (i.e. when authentication isn't configured) are always authorized.
+func authorizeTool(ctx context.Context, server string, tool string) error {
+	if identity, ok := auth.FromContext(ctx); ok && !identity.AllowsTool(server, tool) {
+		return fmt.Errorf("%w: '%s' may not call tool: %s/%s", errors.ErrForbidden, identity.Name, server, tool)
+	}
+
+	return nil
+}
+
+// authorizeWorkflow returns an error wrapping errors.ErrForbidden when the caller may not call every tool called by
+// the workflow's steps.
+func authorizeWorkflow(ctx context.Context, w *workflow.Workflow) error {
+	for _, step := range w.Steps() {
+		if err := authorizeTool(ctx, step.Server, step.Tool); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// callerName returns the name of the caller identified in the context,
+// or 'anonymous' for requests without an identity (i.e. when authentication isn't configured).
+func callerName(ctx context.Context) string {
+	if identity, ok := auth.FromContext(ctx); ok {
+		return identity.Name
+	}
+
+	return "anonymous"
+}
+
+// clientCertificate returns the verified client certificate of requests made over mutual TLS, if any.
+func clientCertificate(ctx huma.Context) *x509.Certificate {
+	state := ctx.TLS()
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return nil
+	}
+
+	return state.VerifiedChains[0][0]
+}
+
+// readOnlyMethod returns true for HTTP methods which don't make changes.
+func readOnlyMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
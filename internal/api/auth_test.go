@@ -0,0 +1,319 @@
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humachi"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/go-chi/chi/v5"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/auth"
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/domain"
+	internalerrors "github.com/mozilla-ai/mcpd/internal/errors"
+)
+
+// newAuthTestAPI registers the API routes for the time and github servers, authenticating requests with API keys:
+// 'ops-key' (unrestricted), 'time-key' (time server), 'list-key' (github/list_* tools) and 'ro-key' (read-only).
+func newAuthTestAPI(t *testing.T) humatest.TestAPI {
+	t.Helper()
+
+	keys, err := auth.NewAPIKeys([]config.APIKeyEntry{
+		{Name: "ops", Hash: auth.HashAPIKey("ops-key")},
+		{Name: "time", Hash: auth.HashAPIKey("time-key"), Servers: []string{"time"}},
+		{Name: "list", Hash: auth.HashAPIKey("list-key"), Tools: []string{"github/list_*"}},
+		{Name: "ro", Hash: auth.HashAPIKey("ro-key"), ReadOnly: true},
+	})
+	require.NoError(t, err)
+
+	tools := &mcp.ListToolsResult{Tools: []mcp.Tool{{Name: "list_issues"}, {Name: "create_issue"}}}
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("time", &mockMCPClient{
+		listToolsResult: &mcp.ListToolsResult{Tools: []mcp.Tool{{Name: "get_current_time"}}},
+		callToolResult:  &mcp.CallToolResult{Content: []mcp.Content{mcp.TextContent{Type: "text", Text: "12:00"}}},
+	}, []string{"get_current_time"})
+	accessor.Add("github", &mockMCPClient{listToolsResult: tools}, []string{"list_issues", "create_issue"})
+
+	monitor := &mockHealthMonitor{servers: map[string]domain.ServerHealth{
+		"time":   {Name: "time", Status: domain.HealthStatusOK},
+		"github": {Name: "github", Status: domain.HealthStatusOK},
+	}}
+
+	_, testAPI := humatest.New(t, huma.DefaultConfig("mcpd docs", APIVersion))
+	_, err = RegisterRoutes(testAPI, monitor, accessor, WithAuthenticator(keys))
+	require.NoError(t, err)
+
+	return testAPI
+}
+
+func TestAuth_Requests(t *testing.T) {
+	t.Parallel()
+
+	testAPI := newAuthTestAPI(t)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		key    string
+		status int
+	}{
+		{name: "missing key", method: http.MethodGet, path: "/api/v1/servers", status: http.StatusUnauthorized},
+		{
+			name:   "invalid key",
+			method: http.MethodGet,
+			path:   "/api/v1/servers",
+			key:    "wrong-key",
+			status: http.StatusUnauthorized,
+		},
+		{name: "valid key", method: http.MethodGet, path: "/api/v1/servers", key: "ops-key", status: http.StatusOK},
+		{
+			name:   "server in scope",
+			method: http.MethodGet,
+			path:   "/api/v1/servers/time/tools",
+			key:    "time-key",
+			status: http.StatusOK,
+		},
+		{
+			name:   "server out of scope",
+			method: http.MethodGet,
+			path:   "/api/v1/servers/github/tools",
+			key:    "time-key",
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "server health out of scope",
+			method: http.MethodGet,
+			path:   "/api/v1/health/servers/github",
+			key:    "time-key",
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "tool call out of scope",
+			method: http.MethodPost,
+			path:   "/api/v1/servers/github/tools/create_issue",
+			key:    "list-key",
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "tool call in scope",
+			method: http.MethodPost,
+			path:   "/api/v1/servers/time/tools/get_current_time",
+			key:    "time-key",
+			status: http.StatusOK,
+		},
+		{
+			name:   "read-only tool call",
+			method: http.MethodPost,
+			path:   "/api/v1/servers/time/tools/get_current_time",
+			key:    "ro-key",
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "read-only listing",
+			method: http.MethodGet,
+			path:   "/api/v1/servers/time/tools",
+			key:    "ro-key",
+			status: http.StatusOK,
+		},
+		{
+			name:   "approvals restricted",
+			method: http.MethodGet,
+			path:   "/api/v1/approvals",
+			key:    "time-key",
+			status: http.StatusForbidden,
+		},
+		{name: "approvals", method: http.MethodGet, path: "/api/v1/approvals", key: "ops-key", status: http.StatusOK},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			args := []any{map[string]any{}}
+			if tc.key != "" {
+				args = append([]any{"Authorization: Bearer " + tc.key}, args...)
+			}
+
+			resp := testAPI.Do(tc.method, tc.path, args...)
+			require.Equal(t, tc.status, resp.Code, resp.Body.String())
+
+			if tc.status == http.StatusUnauthorized {
+				require.Equal(t, `Bearer realm="mcpd"`, resp.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestAuth_ListingsFilteredByScope(t *testing.T) {
+	t.Parallel()
+
+	testAPI := newAuthTestAPI(t)
+
+	resp := testAPI.Get("/api/v1/servers?detail=full", "Authorization: Bearer time-key")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+	var servers []ServerDetails
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &servers))
+	require.Len(t, servers, 1)
+	require.Equal(t, "time", servers[0].Name)
+
+	resp = testAPI.Get("/api/v1/servers/github/tools", "Authorization: Bearer list-key")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+	var tools struct {
+		Tools []Tool `json:"tools"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &tools))
+	require.Len(t, tools.Tools, 1)
+	require.Equal(t, "list_issues", tools.Tools[0].Name)
+
+	resp = testAPI.Get("/api/v1/health/servers", "Authorization: Bearer time-key")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+	var health struct {
+		Servers []ServerHealth `json:"servers"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &health))
+	require.Len(t, health.Servers, 1)
+	require.Equal(t, "time", health.Servers[0].Name)
+}
+
+func TestAuth_OpenAPISecurityScheme(t *testing.T) {
+	t.Parallel()
+
+	testAPI := newAuthTestAPI(t)
+	spec := testAPI.OpenAPI()
+
+	scheme, ok := spec.Components.SecuritySchemes[securitySchemeName]
+	require.True(t, ok)
+	require.Equal(t, "http", scheme.Type)
+	require.Equal(t, "bearer", scheme.Scheme)
+
+	op := spec.Paths["/api/v1/servers"].Get
+	require.NotNil(t, op)
+	require.Equal(t, []map[string][]string{{securitySchemeName: {}}}, op.Security)
+	require.Contains(t, op.Responses, "401")
+	require.Contains(t, op.Responses, "403")
+}
+
+func TestAuth_NoAuthenticator(t *testing.T) {
+	t.Parallel()
+
+	accessor := newMockMCPClientAccessor()
+	monitor := &mockHealthMonitor{servers: map[string]domain.ServerHealth{}}
+
+	_, testAPI := humatest.New(t, huma.DefaultConfig("mcpd docs", APIVersion))
+	_, err := RegisterRoutes(testAPI, monitor, accessor)
+	require.NoError(t, err)
+
+	require.Empty(t, testAPI.OpenAPI().Components.SecuritySchemes)
+
+	resp := testAPI.Get("/api/v1/servers")
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+}
+
+// forbiddenAuthenticator identifies every caller, but grants none of them access.
+type forbiddenAuthenticator struct{}
+
+func (forbiddenAuthenticator) Authenticate(_ context.Context, _ string) (auth.Identity, error) {
+	return auth.Identity{}, fmt.Errorf("%w: no access granted", internalerrors.ErrForbidden)
+}
+
+func TestAuth_AuthenticatorForbidden(t *testing.T) {
+	t.Parallel()
+
+	accessor := newMockMCPClientAccessor()
+	monitor := &mockHealthMonitor{servers: map[string]domain.ServerHealth{}}
+
+	_, testAPI := humatest.New(t, huma.DefaultConfig("mcpd docs", APIVersion))
+	_, err := RegisterRoutes(testAPI, monitor, accessor, WithAuthenticator(forbiddenAuthenticator{}))
+	require.NoError(t, err)
+
+	resp := testAPI.Get("/api/v1/servers", "Authorization: Bearer a.b.c")
+	require.Equal(t, http.StatusForbidden, resp.Code, resp.Body.String())
+	require.Empty(t, resp.Header().Get("WWW-Authenticate"))
+}
+
+func TestAuth_ClientCertificate(t *testing.T) {
+	t.Parallel()
+
+	authenticator, err := auth.NewAuthenticator(&config.APIAuthConfigSection{
+		ClientCerts: []config.ClientCertEntry{{CommonName: "agent", Servers: []string{"time"}}},
+	})
+	require.NoError(t, err)
+
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("time", &mockMCPClient{
+		listToolsResult: &mcp.ListToolsResult{Tools: []mcp.Tool{{Name: "get_current_time"}}},
+	}, []string{"get_current_time"})
+	accessor.Add("github", &mockMCPClient{listToolsResult: &mcp.ListToolsResult{}}, nil)
+	monitor := &mockHealthMonitor{servers: map[string]domain.ServerHealth{}}
+
+	mux := chi.NewMux()
+	router := humachi.New(mux, huma.DefaultConfig("mcpd docs", APIVersion))
+	_, err = RegisterRoutes(router, monitor, accessor, WithAuthenticator(authenticator))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		path       string
+		commonName string
+		status     int
+	}{
+		{name: "server in scope", path: "/api/v1/servers/time/tools", commonName: "agent", status: http.StatusOK},
+		{
+			name:       "server out of scope",
+			path:       "/api/v1/servers/github/tools",
+			commonName: "agent",
+			status:     http.StatusForbidden,
+		},
+		{name: "unknown subject", path: "/api/v1/servers", commonName: "other", status: http.StatusForbidden},
+		{name: "missing certificate", path: "/api/v1/servers", status: http.StatusUnauthorized},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			if tc.commonName != "" {
+				cert := &x509.Certificate{Subject: pkix.Name{CommonName: tc.commonName}}
+				req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+			}
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+			require.Equal(t, tc.status, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func TestAuthorizeTool(t *testing.T) {
+	t.Parallel()
+
+	identity := auth.Identity{Name: "agent", Tools: []string{"time/*"}}
+	ctx := auth.NewContext(context.Background(), identity)
+
+	require.NoError(t, authorizeTool(ctx, "time", "get_current_time"))
+	require.ErrorIs(t, authorizeTool(ctx, "github", "list_issues"), internalerrors.ErrForbidden)
+	require.NoError(t, authorizeTool(context.Background(), "github", "list_issues"))
+
+	_, err := handleWorkflowCall(ctx, newTestWorkflows(t), "report_time", nil, nil)
+	require.ErrorIs(t, err, internalerrors.ErrForbidden)
+
+	resp, err := handleWorkflows(ctx, newTestWorkflows(t))
+	require.NoError(t, err)
+	require.Empty(t, resp.Body.Tools)
+}
@@ -0,0 +1,350 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/mozilla-ai/mcpd/internal/contracts"
+	"github.com/mozilla-ai/mcpd/internal/errors"
+)
+
+// headerTimeout is the header used by clients to request a shorter tool call timeout.
+const headerTimeout = "X-Mcpd-Timeout"
+
+// BatchRequest represents the incoming API request for calling several tools at once.
+type BatchRequest struct {
+	Body BatchRequestBody
+
+	// header contains the headers of the batch request, which are applied to each tool call.
+	header http.Header
+
+	// tlsState is the TLS connection state of the batch request, which is applied to each tool call,
+	// so callers identified by their client certificate are identified for each tool call too.
+	tlsState *tls.ConnectionState
+}
+
+// BatchRequestBody is the body of a batch request.
+type BatchRequestBody struct {
+	// Calls are the tool calls to make.
+	Calls []BatchToolCall `doc:"Tool calls to make" json:"calls" maxItems:"100" minItems:"1"`
+}
+
+// BatchToolCall describes a single tool call within a batch.
+type BatchToolCall struct {
+	// Server is the name of the server offering the tool.
+	Server string `doc:"Name of the server" example:"time" json:"server" minLength:"1"`
+
+	// Tool is the name of the tool to call.
+	Tool string `doc:"Name of the tool to call" example:"get_current_time" json:"tool" minLength:"1"`
+
+	// Arguments are passed to the tool.
+	Arguments map[string]any `doc:"Arguments for the tool" json:"arguments,omitempty"`
+
+	// Timeout is an optional tool call timeout, which is capped by the configured timeout.
+	Timeout string `doc:"Tool call timeout, capped by the configured timeout" example:"30s" json:"timeout,omitempty"`
+}
+
+// BatchResponse represents the wrapped API response for a batch of tool calls.
+type BatchResponse struct {
+	Body struct {
+		// Results contains the outcome of each tool call, in the order they were requested.
+		Results []BatchToolCallResult `doc:"Results of the tool calls, in request order" json:"results"`
+	}
+}
+
+// BatchToolCallResult is the outcome of a single tool call within a batch.
+type BatchToolCallResult struct {
+	// Server is the name of the server offering the tool.
+	Server string `doc:"Name of the server" json:"server"`
+
+	// Tool is the name of the tool that was called.
+	Tool string `doc:"Name of the tool" json:"tool"`
+
+	// Status is the HTTP status code the tool call would have returned if it was made individually.
+	Status int `doc:"HTTP status code of the tool call" json:"status"`
+
+	// Result is the tool call result, present when the call succeeded.
+	Result string `doc:"Result of the tool call" json:"result,omitempty"`
+
+	// Error describes why the tool call failed.
+	Error string `doc:"Error message" json:"error,omitempty"`
+}
+
+// Resolve captures the headers and TLS connection state of the batch request, so they can be applied to each
+// tool call.
+func (r *BatchRequest) Resolve(ctx huma.Context) []error {
+	r.header = http.Header{}
+	ctx.EachHeader(func(name, value string) {
+		r.header.Add(name, value)
+	})
+	r.tlsState = ctx.TLS()
+
+	return nil
+}
+
+// RegisterBatchRoutes registers the batch tool call endpoint.
+// The toolCallPathPrefix is the path under which individual tool calls are routed (e.g. /api/v1/servers).
+func RegisterBatchRoutes(
+	routerAPI huma.API,
+	accessor contracts.MCPClientAccessor,
+	apiPathPrefix string,
+	toolCallPathPrefix string,
+	options RouteOptions,
+) {
+	huma.Register(
+		routerAPI,
+		huma.Operation{
+			OperationID: "callTools",
+			Method:      http.MethodPost,
+			Path:        apiPathPrefix,
+			Summary:     "Call several tools",
+			Description: "Calls the tools concurrently and returns the result of each call in request order. " +
+				"Each call is processed in the same way as an individual tool call, a failed call is reported " +
+				"in its result and doesn't fail the batch",
+			Tags: []string{"Tools"},
+		},
+		func(ctx context.Context, input *BatchRequest) (*BatchResponse, error) {
+			call := func(ctx context.Context, c BatchToolCall) BatchToolCallResult {
+				return directToolCall(ctx, accessor, options, c)
+			}
+			if options.BatchHandler != nil {
+				call = func(ctx context.Context, c BatchToolCall) BatchToolCallResult {
+					return dispatchToolCall(
+						ctx,
+						options.BatchHandler,
+						toolCallPathPrefix,
+						input.header,
+						input.tlsState,
+						c,
+					)
+				}
+			}
+
+			return handleBatch(ctx, options.BatchConcurrency, input.Body.Calls, call)
+		},
+	)
+}
+
+// handleBatch makes the tool calls with at most concurrency calls in flight at once.
+// Results are returned in the same order as the calls.
+func handleBatch(
+	ctx context.Context,
+	concurrency int,
+	calls []BatchToolCall,
+	call func(context.Context, BatchToolCall) BatchToolCallResult,
+) (*BatchResponse, error) {
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency()
+	}
+
+	results := make([]BatchToolCallResult, len(calls))
+
+	g := errgroup.Group{}
+	g.SetLimit(concurrency)
+	for i, c := range calls {
+		g.Go(func() error {
+			results[i] = call(ctx, c)
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	resp := &BatchResponse{}
+	resp.Body.Results = results
+
+	return resp, nil
+}
+
+// directToolCall makes the tool call using the client accessor, applying the same allowlist and timeouts
+// as an individual tool call.
+func directToolCall(
+	ctx context.Context,
+	accessor contracts.MCPClientAccessor,
+	options RouteOptions,
+	c BatchToolCall,
+) BatchToolCallResult {
+	result := BatchToolCallResult{Server: c.Server, Tool: c.Tool}
+
+	resp, err := func() (*ToolCallResponse, error) {
+		if err := authorizeTool(ctx, c.Server, c.Tool); err != nil {
+			return nil, err
+		}
+		// Tool calls made directly cannot be held pending approval.
+		if approvalRequired(options, c.Server, c.Tool) {
+			return nil, fmt.Errorf("%w: %s/%s", errors.ErrToolApprovalRequired, c.Server, c.Tool)
+		}
+		timeout, err := resolveToolCallTimeout(options, c.Server, c.Tool, c.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		return handleServerToolCall(
+			ctx,
+			accessor,
+			options.Catalog,
+			options.ServerConfigs,
+			c.Server,
+			c.Tool,
+			c.Arguments,
+			timeout,
+		)
+	}()
+	if err != nil {
+		statusErr := huma.NewErrorWithContext(nil, http.StatusInternalServerError, err.Error(), err)
+		result.Status = statusErr.GetStatus()
+		result.Error = statusErrorMessage(statusErr)
+		return result
+	}
+
+	result.Status = resp.Status
+	result.Result = resp.Body
+
+	return result
+}
+
+// dispatchToolCall makes the tool call by routing it to the handler as an individual tool call request.
+// This ensures the call is processed by the same middleware (e.g. plugins) as any other request.
+// The headers and TLS connection state of the batch request are applied to the tool call request,
+// so the caller is authenticated in the same way as for the batch request.
+func dispatchToolCall(
+	ctx context.Context,
+	handler http.Handler,
+	toolCallPathPrefix string,
+	header http.Header,
+	tlsState *tls.ConnectionState,
+	c BatchToolCall,
+) BatchToolCallResult {
+	result := BatchToolCallResult{Server: c.Server, Tool: c.Tool}
+
+	req, err := newToolCallRequest(ctx, toolCallPathPrefix, header, tlsState, c)
+	if err != nil {
+		result.Status = http.StatusBadRequest
+		result.Error = err.Error()
+		return result
+	}
+
+	rec := newBatchResponseRecorder()
+	handler.ServeHTTP(rec, req)
+
+	result.Status = rec.status
+	if rec.status >= http.StatusOK && rec.status < http.StatusMultipleChoices {
+		// Tool call results are encoded as a JSON string.
+		if err := json.Unmarshal(rec.body.Bytes(), &result.Result); err != nil {
+			result.Result = rec.body.String()
+		}
+		return result
+	}
+
+	result.Error = responseErrorMessage(rec.status, rec.body.Bytes())
+
+	return result
+}
+
+// newToolCallRequest creates the HTTP request for an individual tool call within a batch.
+func newToolCallRequest(
+	ctx context.Context,
+	toolCallPathPrefix string,
+	header http.Header,
+	tlsState *tls.ConnectionState,
+	c BatchToolCall,
+) (*http.Request, error) {
+	target, err := url.JoinPath(toolCallPathPrefix, c.Server, "tools", c.Tool)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tool call path: %w", err)
+	}
+
+	arguments := c.Arguments
+	if arguments == nil {
+		arguments = map[string]any{}
+	}
+	body, err := json.Marshal(arguments)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tool call arguments: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tool call request: %w", err)
+	}
+	req.TLS = tlsState
+
+	req.Header = header.Clone()
+	if req.Header == nil {
+		req.Header = http.Header{}
+	}
+	req.Header.Del("Content-Length")
+	req.Header.Set("Content-Type", "application/json")
+	if c.Timeout != "" {
+		req.Header.Set(headerTimeout, c.Timeout)
+	}
+
+	return req, nil
+}
+
+// responseErrorMessage returns the error message from an error response body.
+// Problem details (as returned by the API) use the detail, falling back to the title,
+// other bodies (e.g. from plugins) are returned as-is.
+func responseErrorMessage(status int, body []byte) string {
+	var model huma.ErrorModel
+	if err := json.Unmarshal(body, &model); err == nil {
+		if model.Detail != "" {
+			return model.Detail
+		}
+		if model.Title != "" {
+			return model.Title
+		}
+	}
+
+	if msg := strings.TrimSpace(string(body)); msg != "" {
+		return msg
+	}
+
+	return http.StatusText(status)
+}
+
+// statusErrorMessage returns the error message for a status error, preferring the detail of problem details.
+func statusErrorMessage(err huma.StatusError) string {
+	if model, ok := err.(*huma.ErrorModel); ok && model.Detail != "" {
+		return model.Detail
+	}
+
+	return err.Error()
+}
+
+// batchResponseRecorder captures the response to an individual tool call within a batch.
+type batchResponseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+// newBatchResponseRecorder creates a new batchResponseRecorder.
+func newBatchResponseRecorder() *batchResponseRecorder {
+	return &batchResponseRecorder{
+		header: http.Header{},
+		status: http.StatusOK, // Default status.
+	}
+}
+
+// Header returns the response headers.
+func (r *batchResponseRecorder) Header() http.Header {
+	return r.header
+}
+
+// Write captures the response body.
+func (r *batchResponseRecorder) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}
+
+// WriteHeader captures the status code.
+func (r *batchResponseRecorder) WriteHeader(code int) {
+	r.status = code
+}
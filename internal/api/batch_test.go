@@ -0,0 +1,283 @@
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humachi"
+	"github.com/go-chi/chi/v5"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/auth"
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/domain"
+)
+
+func TestHandleBatch_DirectToolCalls(t *testing.T) {
+	t.Parallel()
+
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("time", &mockMCPClient{
+		callToolResult: &mcp.CallToolResult{
+			Content: []mcp.Content{mcp.TextContent{Type: "text", Text: "12:00"}},
+		},
+	}, []string{"get_current_time"})
+
+	calls := []BatchToolCall{
+		{Server: "time", Tool: "get_current_time", Arguments: map[string]any{"timezone": "UTC"}},
+		{Server: "time", Tool: "convert_time"},
+		{Server: "unknown", Tool: "tool"},
+		{Server: "time", Tool: "get_current_time", Timeout: "invalid"},
+	}
+
+	call := func(ctx context.Context, c BatchToolCall) BatchToolCallResult {
+		return directToolCall(ctx, accessor, newRouteOptions(), c)
+	}
+
+	resp, err := handleBatch(context.Background(), 2, calls, call)
+	require.NoError(t, err)
+	require.Len(t, resp.Body.Results, len(calls))
+
+	for i, result := range resp.Body.Results {
+		assert.Equal(t, calls[i].Server, result.Server)
+		assert.Equal(t, calls[i].Tool, result.Tool)
+	}
+
+	assert.Equal(t, http.StatusOK, resp.Body.Results[0].Status)
+	assert.Contains(t, resp.Body.Results[0].Result, "12:00")
+	assert.Empty(t, resp.Body.Results[0].Error)
+
+	assert.Contains(t, resp.Body.Results[1].Error, "not allowed")
+	assert.Contains(t, resp.Body.Results[2].Error, "unknown")
+	assert.Contains(t, resp.Body.Results[3].Error, "invalid timeout")
+	for _, result := range resp.Body.Results[1:] {
+		assert.NotEqual(t, http.StatusOK, result.Status)
+		assert.Empty(t, result.Result)
+	}
+}
+
+func TestHandleBatch_ConcurrencyLimit(t *testing.T) {
+	t.Parallel()
+
+	const limit = 3
+
+	var inFlight, maxInFlight atomic.Int32
+	call := func(_ context.Context, c BatchToolCall) BatchToolCallResult {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			current := maxInFlight.Load()
+			if n <= current || maxInFlight.CompareAndSwap(current, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		return BatchToolCallResult{Server: c.Server, Tool: c.Tool, Status: http.StatusOK}
+	}
+
+	calls := make([]BatchToolCall, 12)
+	for i := range calls {
+		calls[i] = BatchToolCall{Server: "server", Tool: fmt.Sprintf("tool%d", i)}
+	}
+
+	resp, err := handleBatch(context.Background(), limit, calls, call)
+	require.NoError(t, err)
+	require.Len(t, resp.Body.Results, len(calls))
+
+	for i, result := range resp.Body.Results {
+		assert.Equal(t, fmt.Sprintf("tool%d", i), result.Tool)
+	}
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(limit))
+	assert.Greater(t, maxInFlight.Load(), int32(1))
+}
+
+func TestDispatchToolCall(t *testing.T) {
+	t.Parallel()
+
+	type received struct {
+		path      string
+		header    http.Header
+		arguments map[string]any
+	}
+
+	var mu sync.Mutex
+	requests := make(map[string]received)
+
+	// The handler stands in for the API router, wrapped by middleware (e.g. plugins).
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		var arguments map[string]any
+		require.NoError(t, json.Unmarshal(body, &arguments))
+
+		mu.Lock()
+		requests[r.URL.Path] = received{path: r.URL.Path, header: r.Header.Clone(), arguments: arguments}
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/api/v1/servers/time/tools/get_current_time":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`"12:00"`))
+		case "/api/v1/servers/time/tools/convert_time":
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"title":"Forbidden","status":403,"detail":"tool not allowed"}`))
+		default:
+			// Plugins can respond directly, with any body.
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte("rate limit exceeded\n"))
+		}
+	})
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer token")
+	header.Set("Content-Length", "1024")
+
+	tests := []struct {
+		name     string
+		call     BatchToolCall
+		expected BatchToolCallResult
+	}{
+		{
+			name: "success",
+			call: BatchToolCall{
+				Server:    "time",
+				Tool:      "get_current_time",
+				Arguments: map[string]any{"timezone": "UTC"},
+				Timeout:   "5s",
+			},
+			expected: BatchToolCallResult{
+				Server: "time",
+				Tool:   "get_current_time",
+				Status: http.StatusOK,
+				Result: "12:00",
+			},
+		},
+		{
+			name: "problem details error",
+			call: BatchToolCall{Server: "time", Tool: "convert_time"},
+			expected: BatchToolCallResult{
+				Server: "time",
+				Tool:   "convert_time",
+				Status: http.StatusForbidden,
+				Error:  "tool not allowed",
+			},
+		},
+		{
+			name: "plain text error",
+			call: BatchToolCall{Server: "fetch", Tool: "fetch"},
+			expected: BatchToolCallResult{
+				Server: "fetch",
+				Tool:   "fetch",
+				Status: http.StatusTooManyRequests,
+				Error:  "rate limit exceeded",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := dispatchToolCall(context.Background(), handler, "/api/v1/servers", header, nil, tc.call)
+			require.Equal(t, tc.expected, result)
+		})
+	}
+
+	t.Run("request", func(t *testing.T) {
+		t.Parallel()
+
+		call := BatchToolCall{Server: "time", Tool: "get_current_time", Arguments: map[string]any{"timezone": "UTC"}}
+		dispatchToolCall(context.Background(), handler, "/api/v1/servers", header, nil, call)
+
+		mu.Lock()
+		req, ok := requests["/api/v1/servers/time/tools/get_current_time"]
+		mu.Unlock()
+		require.True(t, ok)
+
+		assert.Equal(t, map[string]any{"timezone": "UTC"}, req.arguments)
+		assert.Equal(t, "Bearer token", req.header.Get("Authorization"))
+		assert.Equal(t, "application/json", req.header.Get("Content-Type"))
+		assert.Empty(t, req.header.Get("Content-Length"))
+	})
+}
+
+func TestDispatchToolCall_ClientCertificate(t *testing.T) {
+	t.Parallel()
+
+	authenticator, err := auth.NewAuthenticator(&config.APIAuthConfigSection{
+		ClientCerts: []config.ClientCertEntry{{CommonName: "agent", Servers: []string{"time"}}},
+	})
+	require.NoError(t, err)
+
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("time", &mockMCPClient{
+		callToolResult: &mcp.CallToolResult{Content: []mcp.Content{mcp.TextContent{Type: "text", Text: "12:00"}}},
+	}, []string{"get_current_time"})
+	monitor := &mockHealthMonitor{servers: map[string]domain.ServerHealth{}}
+
+	mux := chi.NewMux()
+	router := humachi.New(mux, huma.DefaultConfig("mcpd docs", APIVersion))
+	_, err = RegisterRoutes(router, monitor, accessor, WithAuthenticator(authenticator), WithBatchHandler(mux))
+	require.NoError(t, err)
+
+	// The caller presents a client certificate, and no bearer token.
+	body := `{"calls":[{"server":"time","tool":"get_current_time"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/batch", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "agent"}}
+	req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp struct {
+		Results []BatchToolCallResult `json:"results"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, []BatchToolCallResult{{
+		Server: "time",
+		Tool:   "get_current_time",
+		Status: http.StatusOK,
+		Result: "12:00",
+	}}, resp.Results)
+}
+
+func TestNewToolCallRequest_Timeout(t *testing.T) {
+	t.Parallel()
+
+	header := http.Header{}
+	header.Set(headerTimeout, "30s")
+
+	req, err := newToolCallRequest(context.Background(), "/api/v1/servers", header, nil, BatchToolCall{
+		Server:  "time",
+		Tool:    "get_current_time",
+		Timeout: "5s",
+	})
+	require.NoError(t, err)
+
+	// The timeout of the call takes precedence over the timeout of the batch.
+	assert.Equal(t, "5s", req.Header.Get(headerTimeout))
+	assert.Equal(t, "30s", header.Get(headerTimeout), "batch headers should not be modified")
+
+	body, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(body))
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"time"
+
+	"github.com/mozilla-ai/mcpd/internal/contracts"
+	"github.com/mozilla-ai/mcpd/internal/domain"
+)
+
+// ListingCache describes the state of the daemon's cached copy of a listing (e.g. tools) for a server.
+type ListingCache struct {
+	// RefreshedAt is when the listing was last refreshed successfully.
+	RefreshedAt *time.Time `doc:"Time the cached listing was last refreshed" json:"refreshedAt,omitempty"`
+
+	// AgeSeconds is how long ago the listing was last refreshed successfully, in seconds.
+	AgeSeconds *int64 `doc:"Age of the cached listing in seconds" json:"ageSeconds,omitempty"`
+
+	// Error is the error from the most recent refresh, if it failed.
+	// The listing from the last successful refresh continues to be served.
+	Error string `doc:"Error from the most recent refresh of the listing" json:"error,omitempty"`
+}
+
+// cachedListing returns the cached listing for the named server, selected from its catalog by the supplied function.
+// The returned ListingCache is nil when the server's listings are not tracked by the catalog.
+// The returned boolean indicates whether the listing holds the result of a successful refresh, and can be served.
+func cachedListing[T any](
+	catalog contracts.MCPCatalogAccessor,
+	name string,
+	selectListing func(domain.ServerCatalog) domain.CatalogListing[T],
+) (domain.CatalogListing[T], *ListingCache, bool) {
+	if catalog == nil {
+		return domain.CatalogListing[T]{}, nil, false
+	}
+
+	serverCatalog, ok := catalog.Catalog(name)
+	if !ok {
+		return domain.CatalogListing[T]{}, nil, false
+	}
+
+	listing := selectListing(serverCatalog)
+	cache := &ListingCache{}
+	if listing.Err != nil {
+		cache.Error = listing.Err.Error()
+	}
+	if listing.RefreshedAt != nil {
+		refreshedAt := *listing.RefreshedAt
+		age := int64(time.Since(refreshedAt).Seconds())
+		cache.RefreshedAt = &refreshedAt
+		cache.AgeSeconds = &age
+	}
+
+	return listing, cache, listing.Cached()
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+	stdErrors "errors"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/domain"
+	"github.com/mozilla-ai/mcpd/internal/errors"
+)
+
+// mockCatalogAccessor provides cached server listings for tests.
+type mockCatalogAccessor struct {
+	catalogs map[string]domain.ServerCatalog
+}
+
+func (m *mockCatalogAccessor) Catalog(name string) (domain.ServerCatalog, bool) {
+	catalog, ok := m.catalogs[name]
+	return catalog, ok
+}
+
+func TestHandleServerTools_ServedFromCatalog(t *testing.T) {
+	t.Parallel()
+
+	accessor := newMockMCPClientAccessor()
+	mockClient := &mockMCPClient{listToolsError: stdErrors.New("should not be called")}
+	accessor.Add("testserver", mockClient, []string{"tool1"})
+
+	refreshedAt := time.Now().Add(-time.Minute)
+	catalog := &mockCatalogAccessor{catalogs: map[string]domain.ServerCatalog{
+		"testserver": {
+			Tools: domain.CatalogListing[mcp.Tool]{
+				Items:       []mcp.Tool{{Name: "tool1"}, {Name: "tool2"}},
+				RefreshedAt: &refreshedAt,
+				Err:         stdErrors.New("refresh failed"),
+			},
+		},
+	}}
+
+	result, err := handleServerTools(context.Background(), accessor, catalog, nil, "testserver")
+	require.NoError(t, err)
+
+	require.Len(t, result.Body.Tools, 1)
+	assert.Equal(t, "tool1", result.Body.Tools[0].Name)
+
+	require.NotNil(t, result.Body.Cache)
+	require.NotNil(t, result.Body.Cache.RefreshedAt)
+	assert.True(t, refreshedAt.Equal(*result.Body.Cache.RefreshedAt))
+	require.NotNil(t, result.Body.Cache.AgeSeconds)
+	assert.GreaterOrEqual(t, *result.Body.Cache.AgeSeconds, int64(59))
+	assert.Equal(t, "refresh failed", result.Body.Cache.Error)
+}
+
+func TestHandleServerTools_FallsBackWhenNotCached(t *testing.T) {
+	t.Parallel()
+
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("testserver", &mockMCPClient{
+		listToolsResult: &mcp.ListToolsResult{Tools: []mcp.Tool{{Name: "tool1"}}},
+	}, []string{"tool1"})
+
+	// The initial refresh failed, so nothing is cached yet.
+	catalog := &mockCatalogAccessor{catalogs: map[string]domain.ServerCatalog{
+		"testserver": {
+			Tools: domain.CatalogListing[mcp.Tool]{Err: stdErrors.New("timed out")},
+		},
+	}}
+
+	result, err := handleServerTools(context.Background(), accessor, catalog, nil, "testserver")
+	require.NoError(t, err)
+
+	require.Len(t, result.Body.Tools, 1)
+	require.NotNil(t, result.Body.Cache)
+	assert.Nil(t, result.Body.Cache.RefreshedAt)
+	assert.Equal(t, "timed out", result.Body.Cache.Error)
+}
+
+func TestHandleServerPrompts_ServedFromCatalog(t *testing.T) {
+	t.Parallel()
+
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("testserver", &mockMCPClient{
+		listPromptsResult: &mcp.ListPromptsResult{Prompts: []mcp.Prompt{{Name: "live"}}},
+	}, []string{"tool1"})
+	accessor.Add("unsupported", &mockMCPClient{}, []string{"tool1"})
+
+	refreshedAt := time.Now()
+	catalog := &mockCatalogAccessor{catalogs: map[string]domain.ServerCatalog{
+		"testserver": {
+			Prompts: domain.CatalogListing[mcp.Prompt]{
+				Items:       []mcp.Prompt{{Name: "cached"}},
+				RefreshedAt: &refreshedAt,
+			},
+		},
+		"unsupported": {
+			Prompts: domain.CatalogListing[mcp.Prompt]{Unsupported: true, RefreshedAt: &refreshedAt},
+		},
+	}}
+
+	result, err := handleServerPrompts(context.Background(), accessor, catalog, nil, "testserver", "")
+	require.NoError(t, err)
+	require.Len(t, result.Body.Prompts, 1)
+	assert.Equal(t, "cached", result.Body.Prompts[0].Name)
+	assert.Empty(t, result.Body.NextCursor)
+	require.NotNil(t, result.Body.Cache)
+
+	// Requests for subsequent pages are passed through to the server.
+	result, err = handleServerPrompts(context.Background(), accessor, catalog, nil, "testserver", "page2")
+	require.NoError(t, err)
+	require.Len(t, result.Body.Prompts, 1)
+	assert.Equal(t, "live", result.Body.Prompts[0].Name)
+	assert.Nil(t, result.Body.Cache)
+
+	_, err = handleServerPrompts(context.Background(), accessor, catalog, nil, "unsupported", "")
+	require.ErrorIs(t, err, errors.ErrPromptsNotImplemented)
+}
+
+func TestToolFieldSelectTransformer_PreservesCache(t *testing.T) {
+	t.Parallel()
+
+	cache := &ListingCache{Error: "refresh failed"}
+	tool := Tool{ToolSummary: ToolSummary{ToolMinimal: ToolMinimal{Name: "tool1"}}}
+	body := ToolsResponseBody[Tool]{Tools: []Tool{tool}, Cache: cache}
+
+	mockCtx := &mockHumaContext{queryParams: map[string]string{queryParamDetail: "minimal"}}
+	result, err := toolFieldSelectTransformer(mockCtx, "", body)
+	require.NoError(t, err)
+
+	minimal, ok := result.(ToolsResponseBody[ToolMinimal])
+	require.True(t, ok)
+	assert.Equal(t, cache, minimal.Cache)
+}
@@ -0,0 +1,182 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/mozilla-ai/mcpd/internal/contracts"
+	errorsint "github.com/mozilla-ai/mcpd/internal/errors"
+)
+
+const (
+	// completionRefPrompt is the reference type for completing the arguments of a prompt.
+	completionRefPrompt = "ref/prompt"
+
+	// completionRefResource is the reference type for completing the variables of a resource template.
+	completionRefResource = "ref/resource"
+
+	// completionTimeout bounds how long an MCP server may take to suggest completions.
+	completionTimeout = 15 * time.Second
+)
+
+// serverCapabilitiesProvider is implemented by MCP clients which expose the capabilities declared by the server.
+type serverCapabilitiesProvider interface {
+	GetServerCapabilities() mcp.ServerCapabilities
+}
+
+// CompletionRef identifies the prompt or resource template whose argument is being completed.
+type CompletionRef struct {
+	// Type of the reference.
+	Type string `doc:"Type of the reference" enum:"ref/prompt,ref/resource" json:"type"`
+
+	// Name of the prompt, required for prompt references.
+	Name string `doc:"Name of the prompt (ref/prompt)" json:"name,omitempty"`
+
+	// URI of the resource template, required for resource references.
+	URI string `doc:"URI template of the resource (ref/resource)" json:"uri,omitempty"`
+}
+
+// CompletionArgument is the argument being completed, and its partial value.
+type CompletionArgument struct {
+	// Name of the prompt argument or resource template variable.
+	Name string `doc:"Name of the argument" json:"name" minLength:"1"`
+
+	// Value entered so far.
+	Value string `doc:"Partial value of the argument" json:"value"`
+}
+
+// CompletionRequestBody describes the argument to complete.
+type CompletionRequestBody struct {
+	// Ref identifies the prompt or resource template.
+	Ref CompletionRef `doc:"Prompt or resource template the argument belongs to" json:"ref"`
+
+	// Argument is the argument being completed.
+	Argument CompletionArgument `doc:"Argument to complete" json:"argument"`
+
+	// Arguments are the values of arguments which have already been resolved, used as context by the server.
+	Arguments map[string]string `doc:"Values of previously resolved arguments" json:"arguments,omitempty"`
+}
+
+// ServerCompletionRequest represents the incoming API request for completing an argument.
+type ServerCompletionRequest struct {
+	Name string                `doc:"Name of the server" path:"name"`
+	Body CompletionRequestBody `doc:"Argument to complete"`
+}
+
+// Completion contains the values suggested by the server.
+type Completion struct {
+	// Values suggested for the argument, at most 100.
+	Values []string `json:"values"`
+
+	// Total is the number of available values, which may exceed the number of values returned.
+	Total int `json:"total,omitempty"`
+
+	// HasMore indicates that there are more values than were returned, even when the total is unknown.
+	HasMore bool `json:"hasMore,omitempty"`
+}
+
+// CompletionResponse represents the API response for completing an argument.
+type CompletionResponse struct {
+	Body Completion
+}
+
+// handleServerCompletion asks a server to suggest values for an argument of a prompt or resource template,
+// provided the prompt or resource template is allowed by the server's allowlists.
+func handleServerCompletion(
+	ctx context.Context,
+	accessor contracts.MCPClientAccessor,
+	configs contracts.MCPServerConfigAccessor,
+	name string,
+	body CompletionRequestBody,
+) (*CompletionResponse, error) {
+	mcpClient, clientOk := accessor.Client(name)
+	if !clientOk {
+		return nil, fmt.Errorf("%w: %s", errorsint.ErrServerNotFound, name)
+	}
+
+	var ref any
+	switch body.Ref.Type {
+	case completionRefPrompt:
+		if body.Ref.Name == "" {
+			return nil, fmt.Errorf("%w: prompt reference requires a name", errorsint.ErrBadRequest)
+		}
+		if entry, ok := serverConfig(configs, name); ok && !entry.PromptAllowed(body.Ref.Name) {
+			return nil, fmt.Errorf("%w: %s/%s", errorsint.ErrPromptForbidden, name, body.Ref.Name)
+		}
+		ref = mcp.PromptReference{Type: completionRefPrompt, Name: body.Ref.Name}
+	case completionRefResource:
+		if body.Ref.URI == "" {
+			return nil, fmt.Errorf("%w: resource reference requires a uri", errorsint.ErrBadRequest)
+		}
+		if entry, ok := serverConfig(configs, name); ok && !entry.ResourceAllowed(body.Ref.URI) {
+			return nil, fmt.Errorf("%w: %s: %s", errorsint.ErrResourceForbidden, name, body.Ref.URI)
+		}
+		ref = mcp.ResourceReference{Type: completionRefResource, URI: body.Ref.URI}
+	default:
+		return nil, fmt.Errorf("%w: unknown reference type '%s'", errorsint.ErrBadRequest, body.Ref.Type)
+	}
+
+	// Servers declare completion support when they initialize, avoid the round trip when they don't.
+	if p, ok := mcpClient.(serverCapabilitiesProvider); ok && p.GetServerCapabilities().Completions == nil {
+		return nil, fmt.Errorf("%w: %s", errorsint.ErrCompletionsNotImplemented, name)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, completionTimeout)
+	defer cancel()
+
+	result, err := mcpClient.Complete(ctx, mcp.CompleteRequest{
+		Params: mcp.CompleteParams{
+			Ref:      ref,
+			Argument: mcp.CompleteArgument{Name: body.Argument.Name, Value: body.Argument.Value},
+			Context:  mcp.CompleteContext{Arguments: body.Arguments},
+		},
+	})
+	if err != nil {
+		if errors.Is(err, mcp.ErrMethodNotFound) {
+			return nil, fmt.Errorf("%w: %s", errorsint.ErrCompletionsNotImplemented, name)
+		}
+		return nil, fmt.Errorf("%w: %s: %s: %w", errorsint.ErrCompletionFailed, name, body.Argument.Name, err)
+	}
+	if result == nil {
+		return nil, fmt.Errorf("%w: %s: %s: no result", errorsint.ErrCompletionFailed, name, body.Argument.Name)
+	}
+
+	values := result.Completion.Values
+	if values == nil {
+		values = []string{}
+	}
+
+	resp := &CompletionResponse{}
+	resp.Body = Completion{
+		Values:  values,
+		Total:   result.Completion.Total,
+		HasMore: result.Completion.HasMore,
+	}
+
+	return resp, nil
+}
+
+// RegisterCompletionRoutes registers argument completion routes under the servers API.
+func RegisterCompletionRoutes(parentAPI huma.API, accessor contracts.MCPClientAccessor, options RouteOptions) {
+	tags := []string{"Completions"}
+
+	huma.Register(
+		parentAPI,
+		huma.Operation{
+			OperationID: "completeArgument",
+			Method:      "POST",
+			Path:        "/{name}/completions",
+			Summary:     "Complete a prompt or resource template argument",
+			Description: "Suggests values for an argument of a prompt or resource template, given its partial value",
+			Tags:        tags,
+		},
+		func(ctx context.Context, input *ServerCompletionRequest) (*CompletionResponse, error) {
+			return handleServerCompletion(ctx, accessor, options.ServerConfigs, input.Name, input.Body)
+		},
+	)
+}
@@ -0,0 +1,186 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/config"
+	internalerrors "github.com/mozilla-ai/mcpd/internal/errors"
+)
+
+// mockMCPClientWithCapabilities is a mock MCP client which exposes the capabilities declared by the server.
+type mockMCPClientWithCapabilities struct {
+	mockMCPClient
+	capabilities mcp.ServerCapabilities
+}
+
+func (m *mockMCPClientWithCapabilities) GetServerCapabilities() mcp.ServerCapabilities {
+	return m.capabilities
+}
+
+func TestAPI_HandleServerCompletion_Prompt(t *testing.T) {
+	t.Parallel()
+
+	mockClient := &mockMCPClientWithCapabilities{
+		mockMCPClient: mockMCPClient{
+			completeResult: &mcp.CompleteResult{
+				Completion: mcp.Completion{Values: []string{"python", "pytorch"}, Total: 10, HasMore: true},
+			},
+		},
+		capabilities: mcp.ServerCapabilities{Completions: &struct{}{}},
+	}
+
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("test-server", mockClient, []string{})
+
+	result, err := handleServerCompletion(context.Background(), accessor, nil, "test-server", CompletionRequestBody{
+		Ref:       CompletionRef{Type: "ref/prompt", Name: "code_review"},
+		Argument:  CompletionArgument{Name: "language", Value: "py"},
+		Arguments: map[string]string{"framework": "flask"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, Completion{Values: []string{"python", "pytorch"}, Total: 10, HasMore: true}, result.Body)
+
+	params := mockClient.completeRequest.Params
+	require.Equal(t, mcp.PromptReference{Type: "ref/prompt", Name: "code_review"}, params.Ref)
+	require.Equal(t, mcp.CompleteArgument{Name: "language", Value: "py"}, params.Argument)
+	require.Equal(t, map[string]string{"framework": "flask"}, params.Context.Arguments)
+}
+
+func TestAPI_HandleServerCompletion_ResourceTemplate(t *testing.T) {
+	t.Parallel()
+
+	mockClient := &mockMCPClient{
+		completeResult: &mcp.CompleteResult{},
+	}
+
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("test-server", mockClient, []string{})
+
+	result, err := handleServerCompletion(context.Background(), accessor, nil, "test-server", CompletionRequestBody{
+		Ref:      CompletionRef{Type: "ref/resource", URI: "file:///{path}"},
+		Argument: CompletionArgument{Name: "path", Value: "src/"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{}, result.Body.Values)
+	require.Equal(
+		t,
+		mcp.ResourceReference{Type: "ref/resource", URI: "file:///{path}"},
+		mockClient.completeRequest.Params.Ref,
+	)
+}
+
+func TestAPI_HandleServerCompletion_Errors(t *testing.T) {
+	t.Parallel()
+
+	configs := &mockServerConfigAccessor{configs: map[string]config.ServerEntry{
+		"test-server": {
+			Name:      "test-server",
+			Prompts:   []string{"code_review"},
+			Resources: []string{"file:///workspace/**"},
+		},
+	}}
+
+	testCases := []struct {
+		name     string
+		server   string
+		client   *mockMCPClientWithCapabilities
+		body     CompletionRequestBody
+		expected error
+	}{
+		{
+			name:     "server not found",
+			server:   "missing-server",
+			body:     CompletionRequestBody{Ref: CompletionRef{Type: "ref/prompt", Name: "code_review"}},
+			expected: internalerrors.ErrServerNotFound,
+		},
+		{
+			name:     "unknown reference type",
+			server:   "test-server",
+			body:     CompletionRequestBody{Ref: CompletionRef{Type: "ref/tool", Name: "code_review"}},
+			expected: internalerrors.ErrBadRequest,
+		},
+		{
+			name:     "prompt reference without name",
+			server:   "test-server",
+			body:     CompletionRequestBody{Ref: CompletionRef{Type: "ref/prompt"}},
+			expected: internalerrors.ErrBadRequest,
+		},
+		{
+			name:     "resource reference without uri",
+			server:   "test-server",
+			body:     CompletionRequestBody{Ref: CompletionRef{Type: "ref/resource"}},
+			expected: internalerrors.ErrBadRequest,
+		},
+		{
+			name:     "prompt not allowed",
+			server:   "test-server",
+			body:     CompletionRequestBody{Ref: CompletionRef{Type: "ref/prompt", Name: "delete_everything"}},
+			expected: internalerrors.ErrPromptForbidden,
+		},
+		{
+			name:     "resource template not allowed",
+			server:   "test-server",
+			body:     CompletionRequestBody{Ref: CompletionRef{Type: "ref/resource", URI: "file:///etc/{path}"}},
+			expected: internalerrors.ErrResourceForbidden,
+		},
+		{
+			name:     "capability not declared",
+			server:   "test-server",
+			client:   &mockMCPClientWithCapabilities{},
+			body:     CompletionRequestBody{Ref: CompletionRef{Type: "ref/prompt", Name: "code_review"}},
+			expected: internalerrors.ErrCompletionsNotImplemented,
+		},
+		{
+			name:   "method not found",
+			server: "test-server",
+			client: &mockMCPClientWithCapabilities{
+				mockMCPClient: mockMCPClient{completeError: mcp.ErrMethodNotFound},
+				capabilities:  mcp.ServerCapabilities{Completions: &struct{}{}},
+			},
+			body:     CompletionRequestBody{Ref: CompletionRef{Type: "ref/prompt", Name: "code_review"}},
+			expected: internalerrors.ErrCompletionsNotImplemented,
+		},
+		{
+			name:   "server error",
+			server: "test-server",
+			client: &mockMCPClientWithCapabilities{
+				mockMCPClient: mockMCPClient{completeError: errors.New("boom")},
+				capabilities:  mcp.ServerCapabilities{Completions: &struct{}{}},
+			},
+			body:     CompletionRequestBody{Ref: CompletionRef{Type: "ref/resource", URI: "file:///workspace/{path}"}},
+			expected: internalerrors.ErrCompletionFailed,
+		},
+		{
+			name:   "no result",
+			server: "test-server",
+			client: &mockMCPClientWithCapabilities{
+				capabilities: mcp.ServerCapabilities{Completions: &struct{}{}},
+			},
+			body:     CompletionRequestBody{Ref: CompletionRef{Type: "ref/prompt", Name: "code_review"}},
+			expected: internalerrors.ErrCompletionFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockClient := tc.client
+			if mockClient == nil {
+				mockClient = &mockMCPClientWithCapabilities{}
+			}
+
+			accessor := newMockMCPClientAccessor()
+			accessor.Add("test-server", mockClient, []string{})
+
+			result, err := handleServerCompletion(context.Background(), accessor, configs, tc.server, tc.body)
+			require.Nil(t, result)
+			require.ErrorIs(t, err, tc.expected)
+		})
+	}
+}
@@ -0,0 +1,707 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/hashicorp/go-hclog"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/mozilla-ai/mcpd/internal/errors"
+	"github.com/mozilla-ai/mcpd/internal/filter"
+)
+
+const (
+	// streamEventElicitationRequested is the SSE event name for an elicitation requested by an MCP server.
+	streamEventElicitationRequested = "requested"
+
+	// streamEventElicitationResolved is the SSE event name for an elicitation which is no longer pending.
+	streamEventElicitationResolved = "resolved"
+
+	// elicitationStreamKeepAlive is how often an idle elicitation stream is kept alive.
+	elicitationStreamKeepAlive = 30 * time.Second
+)
+
+// Elicitation represents a request from an MCP server for input from the user, which is pending a response.
+type Elicitation struct {
+	// ID uniquely identifies the elicitation.
+	ID string `doc:"Unique identifier of the elicitation" json:"id"`
+
+	// Server is the name of the MCP server requesting input.
+	Server string `doc:"Name of the server" json:"server"`
+
+	// Mode is the type of elicitation, 'form' for structured input, or 'url' for a URL the user should open.
+	Mode string `doc:"Type of elicitation" enum:"form,url" json:"mode"`
+
+	// Message explains what input is requested, and why.
+	Message string `doc:"Message explaining the requested input" json:"message"`
+
+	// RequestedSchema is the JSON Schema of the requested input, present for form elicitations.
+	RequestedSchema map[string]any `doc:"JSON Schema of the requested input" json:"requestedSchema,omitempty"`
+
+	// URL is the URL the user should open, present for url elicitations.
+	URL string `doc:"URL the user should open" json:"url,omitempty"`
+
+	// CreatedAt is when the input was requested.
+	CreatedAt time.Time `doc:"Time the input was requested" json:"createdAt"`
+
+	// ExpiresAt is when the elicitation is declined, if no response is given.
+	ExpiresAt time.Time `doc:"Time the elicitation is declined if there is no response" json:"expiresAt"`
+}
+
+// ElicitationResolution represents how an elicitation was resolved.
+type ElicitationResolution struct {
+	// ID identifies the elicitation.
+	ID string `doc:"Identifier of the elicitation" json:"id"`
+
+	// Server is the name of the MCP server which requested input.
+	Server string `doc:"Name of the server" json:"server"`
+
+	// Action is the response sent to the server.
+	Action string `doc:"Response sent to the server" enum:"accept,decline,cancel" json:"action"`
+
+	// Reason describes why the elicitation was resolved, when it was not resolved by a user.
+	Reason string `doc:"Reason the elicitation was resolved" json:"reason,omitempty"`
+
+	// ResolvedAt is when the elicitation was resolved.
+	ResolvedAt time.Time `doc:"Time the elicitation was resolved" json:"resolvedAt"`
+}
+
+// ElicitationsRequest represents the incoming API request for listing pending elicitations.
+type ElicitationsRequest struct {
+	Server string `doc:"Only include elicitations requested by this server" query:"server"`
+}
+
+// ElicitationsResponse represents the wrapped API response for a list of pending elicitations.
+type ElicitationsResponse struct {
+	Body struct {
+		Elicitations []Elicitation `json:"elicitations"`
+	}
+}
+
+// ElicitationRequest represents the incoming API request for a pending elicitation.
+type ElicitationRequest struct {
+	ID string `doc:"Identifier of the elicitation" path:"id"`
+}
+
+// ElicitationResponse represents the wrapped API response for a pending elicitation.
+type ElicitationResponse struct {
+	Body Elicitation
+}
+
+// ElicitationAnswer represents the body of a request to respond to an elicitation.
+type ElicitationAnswer struct {
+	Content map[string]any `doc:"Input matching the requested schema" json:"content,omitempty"`
+}
+
+// ElicitationAnswerRequest represents the incoming API request to respond to an elicitation.
+type ElicitationAnswerRequest struct {
+	ID   string             `doc:"Identifier of the elicitation" path:"id"`
+	Body *ElicitationAnswer `doc:"Response to the elicitation"`
+}
+
+// ElicitationResolutionResponse represents the wrapped API response for a resolved elicitation.
+type ElicitationResolutionResponse struct {
+	Body ElicitationResolution
+}
+
+// elicitationEntry is the internal state tracked for a pending elicitation.
+type elicitationEntry struct {
+	elicitation Elicitation
+	response    chan mcp.ElicitationResponse
+}
+
+// elicitationHandler is the elicitation handler for a single MCP server.
+type elicitationHandler struct {
+	store  *ElicitationStore
+	server string
+}
+
+// ElicitationStore holds elicitations (requests for user input) made by MCP servers, until they are responded to.
+// Elicitations which receive no response before the timeout are declined.
+// Every request and response is audit logged, the content of responses is not.
+// NewElicitationStore should be used to create instances of ElicitationStore.
+type ElicitationStore struct {
+	mu sync.Mutex
+
+	// pending holds the elicitations awaiting a response, by ID.
+	pending map[string]*elicitationEntry
+
+	// subscribers receive events when elicitations are requested and resolved, by subscription ID.
+	subscribers map[uint64]func(streamEvent)
+
+	// nextSubscriberID is the ID of the next subscription.
+	nextSubscriberID uint64
+
+	// timeout is how long an elicitation remains pending before it is declined.
+	timeout time.Duration
+
+	// logger is used to audit log elicitations.
+	logger hclog.Logger
+
+	// now returns the current time, replaceable for tests.
+	now func() time.Time
+}
+
+// NewElicitationStore creates an ElicitationStore which declines elicitations after the timeout.
+func NewElicitationStore(logger hclog.Logger, timeout time.Duration) *ElicitationStore {
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+
+	return &ElicitationStore{
+		pending:     make(map[string]*elicitationEntry),
+		subscribers: make(map[uint64]func(streamEvent)),
+		timeout:     timeout,
+		logger:      logger.Named("elicitations"),
+		now:         func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Handler returns the elicitation handler for the named server.
+func (s *ElicitationStore) Handler(server string) client.ElicitationHandler {
+	return &elicitationHandler{store: s, server: server}
+}
+
+// Elicit implements client.ElicitationHandler.
+func (h *elicitationHandler) Elicit(ctx context.Context, req mcp.ElicitationRequest) (*mcp.ElicitationResult, error) {
+	resp, err := h.store.Request(ctx, h.server, req.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcp.ElicitationResult{ElicitationResponse: resp}, nil
+}
+
+// Request holds an elicitation pending a response, and blocks until it is responded to.
+// The elicitation is declined if there is no response before the timeout, and cancelled if the context is done.
+func (s *ElicitationStore) Request(
+	ctx context.Context,
+	server string,
+	params mcp.ElicitationParams,
+) (mcp.ElicitationResponse, error) {
+	if err := params.Validate(); err != nil {
+		return mcp.ElicitationResponse{}, fmt.Errorf("invalid elicitation request: %w", err)
+	}
+
+	schema, err := elicitationSchema(params.RequestedSchema)
+	if err != nil {
+		return mcp.ElicitationResponse{}, fmt.Errorf("invalid elicitation request: %w", err)
+	}
+
+	mode := params.Mode
+	if mode == "" {
+		mode = mcp.ElicitationModeForm
+	}
+
+	now := s.now()
+	entry := &elicitationEntry{
+		elicitation: Elicitation{
+			ID:              newToken(),
+			Server:          filter.NormalizeString(server),
+			Mode:            mode,
+			Message:         params.Message,
+			RequestedSchema: schema,
+			URL:             params.URL,
+			CreatedAt:       now,
+			ExpiresAt:       now.Add(s.timeout),
+		},
+		// Buffered so that resolving an elicitation never blocks on the requesting server.
+		response: make(chan mcp.ElicitationResponse, 1),
+	}
+
+	s.mu.Lock()
+	s.pending[entry.elicitation.ID] = entry
+	s.logger.Info(
+		"Elicitation requested",
+		"id", entry.elicitation.ID,
+		"server", entry.elicitation.Server,
+		"mode", mode,
+		"expiresAt", entry.elicitation.ExpiresAt,
+	)
+	s.publish(streamEvent{name: streamEventElicitationRequested, data: entry.elicitation.clone()})
+	s.mu.Unlock()
+
+	timer := time.NewTimer(s.timeout)
+	defer timer.Stop()
+
+	select {
+	case resp := <-entry.response:
+		return resp, nil
+	case <-timer.C:
+		// The elicitation may have been resolved concurrently, in which case that response is sent instead.
+		s.resolve(entry.elicitation.ID, mcp.ElicitationResponseActionDecline, nil, "timed out")
+		return <-entry.response, nil
+	case <-ctx.Done():
+		// The request was abandoned by the server (or the server stopped), so there is nobody to respond to.
+		s.resolve(entry.elicitation.ID, mcp.ElicitationResponseActionCancel, nil, "request cancelled")
+		return mcp.ElicitationResponse{}, ctx.Err()
+	}
+}
+
+// List returns snapshots of the pending elicitations, oldest first.
+// When server is supplied, only elicitations requested by that server are returned.
+func (s *ElicitationStore) List(server string) []Elicitation {
+	server = filter.NormalizeString(server)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.list(server)
+}
+
+// Get returns a snapshot of the pending elicitation with the given ID.
+func (s *ElicitationStore) Get(id string) (Elicitation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.pending[id]
+	if !ok {
+		return Elicitation{}, fmt.Errorf("%w: %s", errors.ErrElicitationNotFound, id)
+	}
+
+	return entry.elicitation.clone(), nil
+}
+
+// Respond accepts a pending elicitation with the supplied content, which must match the requested schema.
+func (s *ElicitationStore) Respond(id string, content map[string]any) (ElicitationResolution, error) {
+	s.mu.Lock()
+	entry, ok := s.pending[id]
+	s.mu.Unlock()
+	if !ok {
+		return ElicitationResolution{}, fmt.Errorf("%w: %s", errors.ErrElicitationNotFound, id)
+	}
+
+	if entry.elicitation.Mode == mcp.ElicitationModeForm {
+		if content == nil {
+			content = map[string]any{}
+		}
+		if err := validateElicitationContent(entry.elicitation.RequestedSchema, content); err != nil {
+			return ElicitationResolution{}, fmt.Errorf("%w: %w", errors.ErrBadRequest, err)
+		}
+	}
+
+	resolution := s.resolve(id, mcp.ElicitationResponseActionAccept, content, "")
+	if resolution == nil {
+		return ElicitationResolution{}, fmt.Errorf("%w: %s", errors.ErrElicitationNotFound, id)
+	}
+
+	return *resolution, nil
+}
+
+// Decline declines a pending elicitation, the user explicitly chose not to provide the input.
+func (s *ElicitationStore) Decline(id string) (ElicitationResolution, error) {
+	return s.dismiss(id, mcp.ElicitationResponseActionDecline)
+}
+
+// Cancel cancels a pending elicitation, the user dismissed it without making a choice.
+func (s *ElicitationStore) Cancel(id string) (ElicitationResolution, error) {
+	return s.dismiss(id, mcp.ElicitationResponseActionCancel)
+}
+
+// Subscribe registers a handler for events published when elicitations are requested and resolved.
+// It returns the elicitations which are pending at the time of subscribing, and a function that removes the handler.
+// Handlers are called while the store is locked, so they must not block or call the store.
+func (s *ElicitationStore) Subscribe(handler func(streamEvent)) ([]Elicitation, func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextSubscriberID++
+	id := s.nextSubscriberID
+	s.subscribers[id] = handler
+
+	return s.list(""), func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		delete(s.subscribers, id)
+	}
+}
+
+// dismiss resolves a pending elicitation without content.
+func (s *ElicitationStore) dismiss(id string, action mcp.ElicitationResponseAction) (ElicitationResolution, error) {
+	resolution := s.resolve(id, action, nil, "")
+	if resolution == nil {
+		return ElicitationResolution{}, fmt.Errorf("%w: %s", errors.ErrElicitationNotFound, id)
+	}
+
+	return *resolution, nil
+}
+
+// resolve removes a pending elicitation and sends the response to the server which requested it.
+// Returns nil when the elicitation is no longer pending.
+func (s *ElicitationStore) resolve(
+	id string,
+	action mcp.ElicitationResponseAction,
+	content map[string]any,
+	reason string,
+) *ElicitationResolution {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.pending[id]
+	if !ok {
+		return nil
+	}
+	delete(s.pending, id)
+
+	resp := mcp.ElicitationResponse{Action: action}
+	if content != nil {
+		resp.Content = content
+	}
+	entry.response <- resp
+
+	resolution := ElicitationResolution{
+		ID:         id,
+		Server:     entry.elicitation.Server,
+		Action:     string(action),
+		Reason:     reason,
+		ResolvedAt: s.now(),
+	}
+
+	s.logger.Info(
+		"Elicitation resolved",
+		"id", id,
+		"server", resolution.Server,
+		"action", resolution.Action,
+		"reason", reason,
+	)
+	s.publish(streamEvent{name: streamEventElicitationResolved, data: resolution})
+
+	return &resolution
+}
+
+// list returns snapshots of the pending elicitations, oldest first, optionally filtered by server.
+// The caller must hold the lock.
+func (s *ElicitationStore) list(server string) []Elicitation {
+	elicitations := make([]Elicitation, 0, len(s.pending))
+	for _, entry := range s.pending {
+		if server == "" || entry.elicitation.Server == server {
+			elicitations = append(elicitations, entry.elicitation.clone())
+		}
+	}
+
+	slices.SortFunc(elicitations, func(a, b Elicitation) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return elicitations
+}
+
+// publish delivers the event to all subscribers.
+// The caller must hold the lock.
+func (s *ElicitationStore) publish(event streamEvent) {
+	for _, handler := range s.subscribers {
+		handler(event)
+	}
+}
+
+// clone returns a copy of the elicitation that does not share the top level of its schema with the original.
+func (e Elicitation) clone() Elicitation {
+	e.RequestedSchema = maps.Clone(e.RequestedSchema)
+	return e
+}
+
+// elicitationSchema returns the requested schema of an elicitation as a JSON object.
+func elicitationSchema(schema any) (map[string]any, error) {
+	if schema == nil {
+		return nil, nil
+	}
+
+	if m, ok := schema.(map[string]any); ok {
+		return m, nil
+	}
+
+	// The schema may be a typed value, so convert it through its JSON representation.
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return nil, fmt.Errorf("requested schema cannot be encoded: %w", err)
+	}
+
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("requested schema must be an object: %w", err)
+	}
+
+	return m, nil
+}
+
+// validateElicitationContent checks the content of a response against the requested schema.
+// Requested schemas are flat objects whose properties are primitives (string, number, integer, or boolean),
+// optionally restricted to an enum. Properties without a known type are not checked.
+func validateElicitationContent(schema map[string]any, content map[string]any) error {
+	properties, _ := schema["properties"].(map[string]any)
+
+	if required, ok := schema["required"].([]any); ok {
+		for _, r := range required {
+			name, _ := r.(string)
+			if _, ok := content[name]; !ok {
+				return fmt.Errorf("content is missing required property '%s'", name)
+			}
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(content)) {
+		property, ok := properties[name].(map[string]any)
+		if !ok {
+			return fmt.Errorf("content has unknown property '%s'", name)
+		}
+
+		if err := validateElicitationValue(property, content[name]); err != nil {
+			return fmt.Errorf("content property '%s' %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// validateElicitationValue checks a value against the schema of a primitive property.
+func validateElicitationValue(property map[string]any, value any) error {
+	typ, _ := property["type"].(string)
+	switch typ {
+	case "string":
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("must be a string")
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			return fmt.Errorf("must be a number")
+		}
+	case "integer":
+		if n, ok := value.(float64); !ok || n != math.Trunc(n) {
+			return fmt.Errorf("must be an integer")
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("must be a boolean")
+		}
+	}
+
+	if enum, ok := property["enum"].([]any); ok && !slices.Contains(enum, value) {
+		return fmt.Errorf("must be one of %v", enum)
+	}
+
+	return nil
+}
+
+// RegisterElicitationRoutes registers the elicitation endpoints on the provided API group.
+func RegisterElicitationRoutes(routerAPI huma.API, elicitations *ElicitationStore, apiPathPrefix string) {
+	elicitationsAPI := huma.NewGroup(routerAPI, apiPathPrefix)
+	tags := []string{"Elicitations"}
+
+	huma.Register(
+		elicitationsAPI,
+		huma.Operation{
+			OperationID: "listElicitations",
+			Method:      http.MethodGet,
+			Summary:     "List pending elicitations",
+			Description: "Returns the requests for user input made by MCP servers, which are awaiting a response",
+			Tags:        tags,
+		},
+		func(ctx context.Context, input *ElicitationsRequest) (*ElicitationsResponse, error) {
+			return handleElicitations(elicitations, input.Server)
+		},
+	)
+
+	huma.Register(
+		elicitationsAPI,
+		huma.Operation{
+			OperationID: "streamElicitations",
+			Method:      http.MethodGet,
+			Path:        "/events",
+			Summary:     "Stream elicitation events",
+			Description: "Streams Server-Sent Events: a 'requested' event for each pending elicitation, " +
+				"and for each elicitation subsequently requested by an MCP server, " +
+				"and a 'resolved' event when an elicitation is responded to, declined, cancelled, or times out.",
+			Tags: tags,
+			Responses: map[string]*huma.Response{
+				"200": {
+					Description: "Server-Sent Events stream",
+					Content: map[string]*huma.MediaType{
+						"text/event-stream": {
+							Schema: &huma.Schema{
+								Type:        huma.TypeString,
+								Description: "Events named requested and resolved, each with JSON encoded data",
+							},
+						},
+					},
+				},
+			},
+		},
+		func(ctx context.Context, input *struct{}) (*huma.StreamResponse, error) {
+			return handleElicitationEvents(elicitations), nil
+		},
+	)
+
+	huma.Register(
+		elicitationsAPI,
+		huma.Operation{
+			OperationID: "getElicitation",
+			Method:      http.MethodGet,
+			Path:        "/{id}",
+			Summary:     "Get pending elicitation",
+			Description: "Returns a pending elicitation, including the schema of the requested input",
+			Tags:        tags,
+		},
+		func(ctx context.Context, input *ElicitationRequest) (*ElicitationResponse, error) {
+			return handleElicitation(elicitations, input.ID)
+		},
+	)
+
+	huma.Register(
+		elicitationsAPI,
+		huma.Operation{
+			OperationID: "respondElicitation",
+			Method:      http.MethodPost,
+			Path:        "/{id}/respond",
+			Summary:     "Respond to elicitation",
+			Description: "Accepts a pending elicitation, sending the content (which must match the requested schema) " +
+				"to the MCP server",
+			Tags: tags,
+		},
+		func(ctx context.Context, input *ElicitationAnswerRequest) (*ElicitationResolutionResponse, error) {
+			var content map[string]any
+			if input.Body != nil {
+				content = input.Body.Content
+			}
+			return handleElicitationResolution(func(id string) (ElicitationResolution, error) {
+				return elicitations.Respond(id, content)
+			}, input.ID)
+		},
+	)
+
+	huma.Register(
+		elicitationsAPI,
+		huma.Operation{
+			OperationID: "declineElicitation",
+			Method:      http.MethodPost,
+			Path:        "/{id}/decline",
+			Summary:     "Decline elicitation",
+			Description: "Declines a pending elicitation, the requested input is not provided",
+			Tags:        tags,
+		},
+		func(ctx context.Context, input *ElicitationRequest) (*ElicitationResolutionResponse, error) {
+			return handleElicitationResolution(elicitations.Decline, input.ID)
+		},
+	)
+
+	huma.Register(
+		elicitationsAPI,
+		huma.Operation{
+			OperationID: "cancelElicitation",
+			Method:      http.MethodPost,
+			Path:        "/{id}/cancel",
+			Summary:     "Cancel elicitation",
+			Description: "Cancels a pending elicitation, dismissing it without a decision",
+			Tags:        tags,
+		},
+		func(ctx context.Context, input *ElicitationRequest) (*ElicitationResolutionResponse, error) {
+			return handleElicitationResolution(elicitations.Cancel, input.ID)
+		},
+	)
+}
+
+// handleElicitations returns the pending elicitations, optionally filtered by server.
+func handleElicitations(elicitations *ElicitationStore, server string) (*ElicitationsResponse, error) {
+	resp := &ElicitationsResponse{}
+	resp.Body.Elicitations = elicitations.List(server)
+
+	return resp, nil
+}
+
+// handleElicitation returns a pending elicitation.
+func handleElicitation(elicitations *ElicitationStore, id string) (*ElicitationResponse, error) {
+	elicitation, err := elicitations.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ElicitationResponse{Body: elicitation}, nil
+}
+
+// handleElicitationResolution resolves an elicitation (respond, decline, or cancel) and returns the resolution.
+func handleElicitationResolution(
+	resolve func(id string) (ElicitationResolution, error),
+	id string,
+) (*ElicitationResolutionResponse, error) {
+	resolution, err := resolve(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ElicitationResolutionResponse{Body: resolution}, nil
+}
+
+// handleElicitationEvents returns a response that streams elicitation events as Server-Sent Events.
+func handleElicitationEvents(elicitations *ElicitationStore) *huma.StreamResponse {
+	return &huma.StreamResponse{
+		Body: func(hctx huma.Context) {
+			hctx.SetHeader("Content-Type", "text/event-stream")
+			hctx.SetHeader("Cache-Control", "no-cache")
+			hctx.SetStatus(http.StatusOK)
+
+			streamElicitations(
+				hctx.Context(),
+				&sseWriter{w: hctx.BodyWriter()},
+				elicitations,
+				elicitationStreamKeepAlive,
+			)
+		},
+	}
+}
+
+// streamElicitations writes the pending elicitations as events, followed by events for elicitations as they are
+// requested and resolved, until writing fails (e.g. the client disconnected) or the context is done.
+func streamElicitations(ctx context.Context, w *sseWriter, elicitations *ElicitationStore, keepAlive time.Duration) {
+	events := make(chan streamEvent, streamEventBuffer)
+
+	pending, unsubscribe := elicitations.Subscribe(func(event streamEvent) {
+		select {
+		case events <- event:
+		default:
+		}
+	})
+	defer unsubscribe()
+
+	for _, e := range pending {
+		if err := w.write(streamEvent{name: streamEventElicitationRequested, data: e}); err != nil {
+			return
+		}
+	}
+
+	// Commit the headers so the client knows the stream has started.
+	if err := w.flush(); err != nil {
+		return
+	}
+
+	ticker := time.NewTicker(keepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-events:
+			if err := w.write(event); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := w.comment("keep-alive"); err != nil {
+				return
+			}
+		}
+	}
+}
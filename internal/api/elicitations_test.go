@@ -0,0 +1,329 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/errors"
+)
+
+// elicitationOutcome is the result of a pending elicitation request.
+type elicitationOutcome struct {
+	result *mcp.ElicitationResult
+	err    error
+}
+
+func formElicitation() mcp.ElicitationRequest {
+	return mcp.ElicitationRequest{
+		Params: mcp.ElicitationParams{
+			Message: "Which repository should be used?",
+			RequestedSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"repo":    map[string]any{"type": "string"},
+					"private": map[string]any{"type": "boolean"},
+					"count":   map[string]any{"type": "integer"},
+					"color":   map[string]any{"type": "string", "enum": []any{"red", "green"}},
+				},
+				"required": []any{"repo"},
+			},
+		},
+	}
+}
+
+// elicit makes the elicitation request in the background, returning the channel which receives its outcome.
+func elicit(
+	ctx context.Context,
+	store *ElicitationStore,
+	server string,
+	req mcp.ElicitationRequest,
+) chan elicitationOutcome {
+	outcome := make(chan elicitationOutcome, 1)
+	go func() {
+		result, err := store.Handler(server).Elicit(ctx, req)
+		outcome <- elicitationOutcome{result: result, err: err}
+	}()
+	return outcome
+}
+
+// waitForElicitation waits until the server has a pending elicitation, and returns it.
+func waitForElicitation(t *testing.T, store *ElicitationStore, server string) Elicitation {
+	t.Helper()
+
+	var pending []Elicitation
+	require.Eventually(t, func() bool {
+		pending = store.List(server)
+		return len(pending) == 1
+	}, 2*time.Second, 5*time.Millisecond)
+
+	return pending[0]
+}
+
+func receive(t *testing.T, outcome chan elicitationOutcome) elicitationOutcome {
+	t.Helper()
+
+	select {
+	case o := <-outcome:
+		return o
+	case <-time.After(2 * time.Second):
+		t.Fatal("elicitation request did not return")
+		return elicitationOutcome{}
+	}
+}
+
+func TestElicitationStore_Respond(t *testing.T) {
+	t.Parallel()
+
+	store := NewElicitationStore(nil, time.Minute)
+	outcome := elicit(context.Background(), store, "GitHub", formElicitation())
+
+	pending := waitForElicitation(t, store, "github")
+	assert.Equal(t, "github", pending.Server)
+	assert.Equal(t, mcp.ElicitationModeForm, pending.Mode)
+	assert.Equal(t, "Which repository should be used?", pending.Message)
+	assert.Contains(t, pending.RequestedSchema, "properties")
+	assert.Equal(t, pending.CreatedAt.Add(time.Minute), pending.ExpiresAt)
+
+	got, err := store.Get(pending.ID)
+	require.NoError(t, err)
+	assert.Equal(t, pending, got)
+
+	content := map[string]any{"repo": "mcpd", "private": true, "count": float64(2), "color": "red"}
+	resolution, err := store.Respond(pending.ID, content)
+	require.NoError(t, err)
+	assert.Equal(t, pending.ID, resolution.ID)
+	assert.Equal(t, "accept", resolution.Action)
+
+	o := receive(t, outcome)
+	require.NoError(t, o.err)
+	assert.Equal(t, mcp.ElicitationResponseActionAccept, o.result.Action)
+	assert.Equal(t, content, o.result.Content)
+
+	// The elicitation is no longer pending.
+	assert.Empty(t, store.List(""))
+	_, err = store.Get(pending.ID)
+	require.ErrorIs(t, err, errors.ErrElicitationNotFound)
+	_, err = store.Decline(pending.ID)
+	require.ErrorIs(t, err, errors.ErrElicitationNotFound)
+}
+
+func TestElicitationStore_RespondValidatesContent(t *testing.T) {
+	t.Parallel()
+
+	store := NewElicitationStore(nil, time.Minute)
+	outcome := elicit(context.Background(), store, "github", formElicitation())
+	pending := waitForElicitation(t, store, "github")
+
+	tests := []struct {
+		name     string
+		content  map[string]any
+		expected string
+	}{
+		{
+			name:     "missing required",
+			content:  map[string]any{"private": true},
+			expected: "content is missing required property 'repo'",
+		},
+		{
+			name:     "unknown property",
+			content:  map[string]any{"repo": "mcpd", "owner": "mozilla-ai"},
+			expected: "content has unknown property 'owner'",
+		},
+		{
+			name:     "wrong type",
+			content:  map[string]any{"repo": "mcpd", "private": "yes"},
+			expected: "content property 'private' must be a boolean",
+		},
+		{
+			name:     "not an integer",
+			content:  map[string]any{"repo": "mcpd", "count": 1.5},
+			expected: "content property 'count' must be an integer",
+		},
+		{
+			name:     "not in enum",
+			content:  map[string]any{"repo": "mcpd", "color": "blue"},
+			expected: "content property 'color' must be one of [red green]",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := store.Respond(pending.ID, tc.content)
+			require.ErrorIs(t, err, errors.ErrBadRequest)
+			require.ErrorContains(t, err, tc.expected)
+		})
+	}
+
+	// Invalid responses leave the elicitation pending.
+	_, err := store.Get(pending.ID)
+	require.NoError(t, err)
+
+	_, err = store.Cancel(pending.ID)
+	require.NoError(t, err)
+	o := receive(t, outcome)
+	require.NoError(t, o.err)
+	assert.Equal(t, mcp.ElicitationResponseActionCancel, o.result.Action)
+	assert.Nil(t, o.result.Content)
+}
+
+func TestElicitationStore_Decline(t *testing.T) {
+	t.Parallel()
+
+	store := NewElicitationStore(nil, time.Minute)
+	outcome := elicit(context.Background(), store, "github", formElicitation())
+	pending := waitForElicitation(t, store, "github")
+
+	resolution, err := store.Decline(pending.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "decline", resolution.Action)
+	assert.Empty(t, resolution.Reason)
+
+	o := receive(t, outcome)
+	require.NoError(t, o.err)
+	assert.Equal(t, mcp.ElicitationResponseActionDecline, o.result.Action)
+}
+
+func TestElicitationStore_TimeoutDeclines(t *testing.T) {
+	t.Parallel()
+
+	store := NewElicitationStore(nil, 20*time.Millisecond)
+	outcome := elicit(context.Background(), store, "github", formElicitation())
+
+	o := receive(t, outcome)
+	require.NoError(t, o.err)
+	assert.Equal(t, mcp.ElicitationResponseActionDecline, o.result.Action)
+	assert.Empty(t, store.List(""))
+}
+
+func TestElicitationStore_ContextCancelled(t *testing.T) {
+	t.Parallel()
+
+	store := NewElicitationStore(nil, time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	outcome := elicit(ctx, store, "github", formElicitation())
+	waitForElicitation(t, store, "github")
+
+	cancel()
+
+	o := receive(t, outcome)
+	require.ErrorIs(t, o.err, context.Canceled)
+	assert.Empty(t, store.List(""))
+}
+
+func TestElicitationStore_InvalidRequest(t *testing.T) {
+	t.Parallel()
+
+	store := NewElicitationStore(nil, time.Minute)
+
+	_, err := store.Handler("github").Elicit(context.Background(), mcp.ElicitationRequest{
+		Params: mcp.ElicitationParams{Message: "missing schema"},
+	})
+	require.ErrorContains(t, err, "invalid elicitation request: requestedSchema is required for form elicitation")
+	assert.Empty(t, store.List(""))
+}
+
+func TestElicitationStore_URLMode(t *testing.T) {
+	t.Parallel()
+
+	store := NewElicitationStore(nil, time.Minute)
+	outcome := elicit(context.Background(), store, "github", mcp.ElicitationRequest{
+		Params: mcp.ElicitationParams{
+			Mode:          mcp.ElicitationModeURL,
+			Message:       "Authorize access to your repositories",
+			ElicitationID: "auth-1",
+			URL:           "https://github.com/login/oauth/authorize",
+		},
+	})
+	pending := waitForElicitation(t, store, "github")
+	assert.Equal(t, mcp.ElicitationModeURL, pending.Mode)
+	assert.Equal(t, "https://github.com/login/oauth/authorize", pending.URL)
+	assert.Nil(t, pending.RequestedSchema)
+
+	// URL elicitations are accepted without content.
+	_, err := store.Respond(pending.ID, nil)
+	require.NoError(t, err)
+
+	o := receive(t, outcome)
+	require.NoError(t, o.err)
+	assert.Equal(t, mcp.ElicitationResponseActionAccept, o.result.Action)
+	assert.Nil(t, o.result.Content)
+}
+
+func TestElicitationStore_ListByServer(t *testing.T) {
+	t.Parallel()
+
+	store := NewElicitationStore(nil, time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	elicit(ctx, store, "github", formElicitation())
+	waitForElicitation(t, store, "github")
+	elicit(ctx, store, "jira", formElicitation())
+	waitForElicitation(t, store, "jira")
+
+	assert.Len(t, store.List(""), 2)
+	assert.Len(t, store.List("GitHub"), 1)
+	assert.Empty(t, store.List("slack"))
+}
+
+func TestStreamElicitations_Events(t *testing.T) {
+	t.Parallel()
+
+	store := NewElicitationStore(nil, time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// An elicitation pending before the stream starts is sent first.
+	first := elicit(context.Background(), store, "github", formElicitation())
+	existing := waitForElicitation(t, store, "github")
+
+	w := &syncBuffer{}
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		streamElicitations(ctx, &sseWriter{w: w}, store, time.Hour)
+	}()
+
+	require.Eventually(t, func() bool {
+		return len(parseSSE(t, w.String())) == 1
+	}, 2*time.Second, 5*time.Millisecond)
+
+	_, err := store.Decline(existing.ID)
+	require.NoError(t, err)
+	receive(t, first)
+
+	second := elicit(context.Background(), store, "jira", formElicitation())
+	requested := waitForElicitation(t, store, "jira")
+	_, err = store.Respond(requested.ID, map[string]any{"repo": "mcpd"})
+	require.NoError(t, err)
+	receive(t, second)
+
+	require.Eventually(t, func() bool {
+		return len(parseSSE(t, w.String())) == 4
+	}, 2*time.Second, 5*time.Millisecond)
+
+	cancel()
+	<-finished
+
+	events := parseSSE(t, w.String())
+	require.Len(t, events, 4)
+
+	assert.Equal(t, streamEventElicitationRequested, events[0].name)
+	assert.Contains(t, events[0].data, `"id":"`+existing.ID+`"`)
+	assert.Contains(t, events[0].data, `"requestedSchema"`)
+
+	assert.Equal(t, streamEventElicitationResolved, events[1].name)
+	assert.Contains(t, events[1].data, `"action":"decline"`)
+
+	assert.Equal(t, streamEventElicitationRequested, events[2].name)
+	assert.Contains(t, events[2].data, `"server":"jira"`)
+
+	assert.Equal(t, streamEventElicitationResolved, events[3].name)
+	assert.Contains(t, events[3].data, `"action":"accept"`)
+	assert.NotContains(t, events[3].data, "mcpd", "the content of responses is not published")
+}
@@ -83,6 +83,7 @@ func (d DomainServerHealth) ToAPIType() (ServerHealth, error) {
 // RegisterHealthRoutes sets up health-related API endpoint routes.
 func RegisterHealthRoutes(routerAPI huma.API, monitor contracts.MCPHealthMonitor, apiPathPrefix string) {
 	healthAPI := huma.NewGroup(routerAPI, apiPathPrefix)
+	healthAPI.UseMiddleware(serverScopeMiddleware(healthAPI))
 	tags := []string{"Health"}
 
 	huma.Register(
@@ -95,7 +96,7 @@ func RegisterHealthRoutes(routerAPI huma.API, monitor contracts.MCPHealthMonitor
 			Tags:        tags,
 		},
 		func(ctx context.Context, _ *struct{}) (*ServersHealthResponse, error) {
-			return handleHealthServers(monitor)
+			return handleHealthServers(ctx, monitor)
 		},
 	)
 
@@ -114,9 +115,12 @@ func RegisterHealthRoutes(routerAPI huma.API, monitor contracts.MCPHealthMonitor
 	)
 }
 
-// handleHealthServers is the handler for retrieving the current health for all registered MCP servers.
-func handleHealthServers(monitor contracts.MCPHealthMonitor) (*ServersHealthResponse, error) {
-	servers := monitor.List()
+// handleHealthServers is the handler for retrieving the current health for all registered MCP servers
+// within the caller's scope.
+func handleHealthServers(ctx context.Context, monitor contracts.MCPHealthMonitor) (*ServersHealthResponse, error) {
+	servers := slices.DeleteFunc(monitor.List(), func(s domain.ServerHealth) bool {
+		return authorizeServer(ctx, s.Name) != nil
+	})
 
 	slices.SortFunc(servers, func(a, b domain.ServerHealth) int {
 		return strings.Compare(a.Name, b.Name)
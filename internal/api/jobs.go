@@ -0,0 +1,411 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	stdErrors "errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/mozilla-ai/mcpd/internal/contracts"
+	"github.com/mozilla-ai/mcpd/internal/errors"
+	"github.com/mozilla-ai/mcpd/internal/filter"
+)
+
+const (
+	// JobStatusRunning indicates the tool call is in progress.
+	JobStatusRunning JobStatus = "running"
+
+	// JobStatusSucceeded indicates the tool call completed and a result is available.
+	JobStatusSucceeded JobStatus = "succeeded"
+
+	// JobStatusFailed indicates the tool call returned an error or timed out.
+	JobStatusFailed JobStatus = "failed"
+
+	// JobStatusCancelled indicates the tool call was cancelled by a client.
+	JobStatusCancelled JobStatus = "cancelled"
+)
+
+// JobStatus represents the lifecycle state of an asynchronous tool call.
+type JobStatus string
+
+// Job represents an asynchronous tool call.
+type Job struct {
+	// ID uniquely identifies the job.
+	ID string `doc:"Unique identifier of the job" json:"id"`
+
+	// Server is the name of the MCP server the tool belongs to.
+	Server string `doc:"Name of the server" json:"server"`
+
+	// Tool is the name of the tool being called.
+	Tool string `doc:"Name of the tool" json:"tool"`
+
+	// Status is the current state of the job.
+	Status JobStatus `doc:"Current job status" enum:"running,succeeded,failed,cancelled" json:"status"`
+
+	// Progress is the most recent progress reported by the MCP server, if any.
+	Progress *ToolCallProgress `doc:"Most recent progress notification" json:"progress,omitempty"`
+
+	// Result is the tool call result, present when Status is succeeded.
+	Result string `doc:"Result of the tool call" json:"result,omitempty"`
+
+	// Error describes why the job did not succeed, present when Status is failed or cancelled.
+	Error string `doc:"Error message" json:"error,omitempty"`
+
+	// CreatedAt is when the job was accepted.
+	CreatedAt time.Time `doc:"Time the job was created" json:"createdAt"`
+
+	// FinishedAt is when the job reached a terminal status.
+	FinishedAt *time.Time `doc:"Time the job finished" json:"finishedAt,omitempty"`
+}
+
+// ToolCallProgress represents a progress notification received for a tool call.
+type ToolCallProgress struct {
+	// Progress thus far, this should increase every time progress is made.
+	Progress float64 `doc:"Progress thus far" json:"progress"`
+
+	// Total is the total progress required, if known.
+	Total *float64 `doc:"Total progress required, if known" json:"total,omitempty"`
+
+	// Message is human-readable progress information.
+	Message string `doc:"Progress message" json:"message,omitempty"`
+
+	// UpdatedAt is when the notification was received.
+	UpdatedAt time.Time `doc:"Time the progress was received" json:"updatedAt"`
+}
+
+// JobRequest represents the incoming API request for a job.
+type JobRequest struct {
+	ID string `doc:"Identifier of the job" path:"id"`
+}
+
+// JobResponse represents the wrapped API response for a job.
+type JobResponse struct {
+	Body Job
+}
+
+// IsFinished reports whether the status is terminal.
+func (s JobStatus) IsFinished() bool {
+	return s != JobStatusRunning
+}
+
+// jobFunc performs the work of a job, the progress token should be sent to the MCP server with the request.
+type jobFunc func(ctx context.Context, progressToken mcp.ProgressToken) (string, error)
+
+// jobEntry is the internal state tracked for a job.
+type jobEntry struct {
+	job    Job
+	cancel context.CancelFunc
+}
+
+// JobStore tracks asynchronous tool calls, retaining a bounded number of finished jobs.
+// NewJobStore should be used to create instances of JobStore.
+type JobStore struct {
+	mu sync.Mutex
+
+	// basePath is the API path under which jobs can be retrieved (e.g. /api/v1/jobs).
+	basePath string
+
+	// jobs holds all known jobs by ID.
+	jobs map[string]*jobEntry
+
+	// finished holds the IDs of finished jobs, oldest first.
+	finished []string
+
+	// timeout bounds how long a single job may run.
+	timeout time.Duration
+
+	// maxFinished is the maximum number of finished jobs retained.
+	maxFinished int
+
+	// retention is how long finished jobs are retained.
+	retention time.Duration
+
+	// notifications provides progress notifications from MCP servers, it may be nil.
+	notifications contracts.MCPNotificationSubscriber
+
+	// now returns the current time, replaceable for tests.
+	now func() time.Time
+}
+
+// NewJobStore creates a JobStore configured from the supplied route options.
+// The base path is the API path under which the job routes are registered.
+func NewJobStore(basePath string, options RouteOptions) *JobStore {
+	return &JobStore{
+		basePath:      basePath,
+		jobs:          make(map[string]*jobEntry),
+		timeout:       options.JobTimeout,
+		maxFinished:   options.JobMaxFinished,
+		retention:     options.JobRetention,
+		notifications: options.NotificationSubscriber,
+		now:           func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Start creates a job for the given server and tool and runs fn in the background.
+// The job ID doubles as the progress token so notifications can be associated with the job.
+// The job's context keeps the values of ctx (e.g. the caller's identity), but isn't canceled with it.
+func (s *JobStore) Start(ctx context.Context, server string, tool string, fn jobFunc) Job {
+	id := newToken()
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.timeout)
+
+	s.mu.Lock()
+	s.prune()
+	entry := &jobEntry{
+		job: Job{
+			ID:        id,
+			Server:    filter.NormalizeString(server),
+			Tool:      filter.NormalizeString(tool),
+			Status:    JobStatusRunning,
+			CreatedAt: s.now(),
+		},
+		cancel: cancel,
+	}
+	s.jobs[id] = entry
+	job := entry.job
+	s.mu.Unlock()
+
+	unsubscribe := func() {}
+	if s.notifications != nil {
+		unsubscribe = s.notifications.Subscribe(server, func(n mcp.JSONRPCNotification) {
+			s.handleProgress(id, n)
+		})
+	}
+
+	go func() {
+		defer cancel()
+		defer unsubscribe()
+
+		result, err := fn(ctx, id)
+		s.finish(id, result, err, ctx.Err())
+	}()
+
+	return job
+}
+
+// Location returns the API path of the job with the given ID.
+func (s *JobStore) Location(id string) string {
+	return path.Join(s.basePath, id)
+}
+
+// Get returns a snapshot of the job with the given ID.
+func (s *JobStore) Get(id string) (Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune()
+
+	entry, ok := s.jobs[id]
+	if !ok {
+		return Job{}, fmt.Errorf("%w: %s", errors.ErrJobNotFound, id)
+	}
+
+	return entry.job.clone(), nil
+}
+
+// Cancel cancels a running job, causing the MCP request to be cancelled.
+// Cancelling a finished job has no effect, the current state of the job is returned.
+func (s *JobStore) Cancel(id string) (Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune()
+
+	entry, ok := s.jobs[id]
+	if !ok {
+		return Job{}, fmt.Errorf("%w: %s", errors.ErrJobNotFound, id)
+	}
+
+	if !entry.job.Status.IsFinished() {
+		entry.cancel()
+		s.markFinished(entry, JobStatusCancelled, "", "job cancelled")
+	}
+
+	return entry.job.clone(), nil
+}
+
+// finish records the outcome of a job, unless it has already finished (e.g. was cancelled).
+func (s *JobStore) finish(id string, result string, err error, ctxErr error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.jobs[id]
+	if !ok || entry.job.Status.IsFinished() {
+		return
+	}
+
+	switch {
+	case err == nil:
+		s.markFinished(entry, JobStatusSucceeded, result, "")
+	case stdErrors.Is(ctxErr, context.DeadlineExceeded):
+		s.markFinished(entry, JobStatusFailed, "", fmt.Sprintf("job timed out after %s", s.timeout))
+	default:
+		s.markFinished(entry, JobStatusFailed, "", err.Error())
+	}
+}
+
+// markFinished moves a job to a terminal status and registers it for retention.
+// The caller must hold the lock.
+func (s *JobStore) markFinished(entry *jobEntry, status JobStatus, result string, errMsg string) {
+	finishedAt := s.now()
+	entry.job.Status = status
+	entry.job.Result = result
+	entry.job.Error = errMsg
+	entry.job.FinishedAt = &finishedAt
+
+	s.finished = append(s.finished, entry.job.ID)
+	s.prune()
+}
+
+// prune removes finished jobs that exceed the retention limits.
+// The caller must hold the lock.
+func (s *JobStore) prune() {
+	now := s.now()
+	for len(s.finished) > 0 {
+		oldest := s.jobs[s.finished[0]]
+		expired := oldest != nil && now.Sub(*oldest.job.FinishedAt) > s.retention
+		if len(s.finished) <= s.maxFinished && !expired {
+			return
+		}
+		delete(s.jobs, s.finished[0])
+		s.finished = s.finished[1:]
+	}
+}
+
+// handleProgress records a progress notification if it is intended for the job.
+func (s *JobStore) handleProgress(id string, n mcp.JSONRPCNotification) {
+	if n.Method != string(mcp.MethodNotificationProgress) {
+		return
+	}
+
+	progress, ok := parseProgress(n, id)
+	if !ok {
+		return
+	}
+	progress.UpdatedAt = s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.jobs[id]
+	if !ok || entry.job.Status.IsFinished() {
+		return
+	}
+	entry.job.Progress = &progress
+}
+
+// parseProgress extracts progress from a notification when its progress token matches the supplied token.
+func parseProgress(n mcp.JSONRPCNotification, token string) (ToolCallProgress, bool) {
+	fields := n.Params.AdditionalFields
+	if fmt.Sprint(fields["progressToken"]) != token {
+		return ToolCallProgress{}, false
+	}
+
+	progress := ToolCallProgress{}
+	if v, ok := fields["progress"].(float64); ok {
+		progress.Progress = v
+	}
+	if v, ok := fields["total"].(float64); ok {
+		progress.Total = &v
+	}
+	if v, ok := fields["message"].(string); ok {
+		progress.Message = v
+	}
+
+	return progress, true
+}
+
+// newToken returns a random identifier suitable for job IDs and progress tokens.
+func newToken() string {
+	return strings.ToLower(rand.Text())
+}
+
+// clone returns a copy of the job that does not share pointers with the original.
+func (j Job) clone() Job {
+	if j.Progress != nil {
+		p := *j.Progress
+		j.Progress = &p
+	}
+	if j.FinishedAt != nil {
+		f := *j.FinishedAt
+		j.FinishedAt = &f
+	}
+	return j
+}
+
+// RegisterJobRoutes registers the asynchronous job endpoints on the provided API group.
+func RegisterJobRoutes(routerAPI huma.API, jobs *JobStore, apiPathPrefix string) {
+	jobsAPI := huma.NewGroup(routerAPI, apiPathPrefix)
+	tags := []string{"Jobs"}
+
+	huma.Register(
+		jobsAPI,
+		huma.Operation{
+			OperationID: "getJob",
+			Method:      http.MethodGet,
+			Path:        "/{id}",
+			Summary:     "Get asynchronous tool call status",
+			Description: "Returns the status of an asynchronous tool call, including progress and the result once finished",
+			Tags:        tags,
+		},
+		func(ctx context.Context, input *JobRequest) (*JobResponse, error) {
+			return handleJob(ctx, jobs, input.ID)
+		},
+	)
+
+	huma.Register(
+		jobsAPI,
+		huma.Operation{
+			OperationID: "cancelJob",
+			Method:      http.MethodDelete,
+			Path:        "/{id}",
+			Summary:     "Cancel asynchronous tool call",
+			Description: "Cancels a running asynchronous tool call, the MCP server is notified of the cancellation",
+			Tags:        tags,
+		},
+		func(ctx context.Context, input *JobRequest) (*JobResponse, error) {
+			return handleJobCancel(ctx, jobs, input.ID)
+		},
+	)
+}
+
+// handleJob returns the current state of a job.
+func handleJob(ctx context.Context, jobs *JobStore, id string) (*JobResponse, error) {
+	job, err := jobs.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeTool(ctx, job.Server, job.Tool); err != nil {
+		return nil, err
+	}
+
+	return &JobResponse{Body: job}, nil
+}
+
+// handleJobCancel cancels a job and returns its state.
+func handleJobCancel(ctx context.Context, jobs *JobStore, id string) (*JobResponse, error) {
+	job, err := jobs.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only callers which may call the tool can cancel the call.
+	if err := authorizeTool(ctx, job.Server, job.Tool); err != nil {
+		return nil, err
+	}
+
+	job, err = jobs.Cancel(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JobResponse{Body: job}, nil
+}
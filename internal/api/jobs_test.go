@@ -0,0 +1,389 @@
+package api
+
+import (
+	"context"
+	stdErrors "errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/auth"
+	"github.com/mozilla-ai/mcpd/internal/errors"
+)
+
+// blockingMCPClient is a mock client whose tool calls block until released or the context is done.
+type blockingMCPClient struct {
+	mockMCPClient
+
+	release chan struct{}
+
+	mu        sync.Mutex
+	lastMeta  *mcp.Meta
+	cancelled bool
+}
+
+func newBlockingMCPClient() *blockingMCPClient {
+	return &blockingMCPClient{release: make(chan struct{})}
+}
+
+func (m *blockingMCPClient) CallTool(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	m.mu.Lock()
+	m.lastMeta = req.Params.Meta
+	m.mu.Unlock()
+
+	select {
+	case <-m.release:
+		return &mcp.CallToolResult{Content: []mcp.Content{mcp.TextContent{Text: "done"}}}, nil
+	case <-ctx.Done():
+		m.mu.Lock()
+		m.cancelled = true
+		m.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+func (m *blockingMCPClient) meta() *mcp.Meta {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lastMeta
+}
+
+func (m *blockingMCPClient) wasCancelled() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.cancelled
+}
+
+// mockNotificationSubscriber records subscriptions and allows notifications to be published in tests.
+type mockNotificationSubscriber struct {
+	mu       sync.Mutex
+	handlers map[string][]func(mcp.JSONRPCNotification)
+}
+
+func newMockNotificationSubscriber() *mockNotificationSubscriber {
+	return &mockNotificationSubscriber{handlers: make(map[string][]func(mcp.JSONRPCNotification))}
+}
+
+func (m *mockNotificationSubscriber) Subscribe(name string, handler func(mcp.JSONRPCNotification)) func() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handlers[name] = append(m.handlers[name], handler)
+	return func() {}
+}
+
+func (m *mockNotificationSubscriber) publish(name string, n mcp.JSONRPCNotification) {
+	m.mu.Lock()
+	handlers := append([]func(mcp.JSONRPCNotification){}, m.handlers[name]...)
+	m.mu.Unlock()
+
+	for _, h := range handlers {
+		h(n)
+	}
+}
+
+func progressNotification(token any, progress float64, total float64, message string) mcp.JSONRPCNotification {
+	return mcp.JSONRPCNotification{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		Notification: mcp.Notification{
+			Method: string(mcp.MethodNotificationProgress),
+			Params: mcp.NotificationParams{
+				AdditionalFields: map[string]any{
+					"progressToken": token,
+					"progress":      progress,
+					"total":         total,
+					"message":       message,
+				},
+			},
+		},
+	}
+}
+
+func waitForJobStatus(t *testing.T, jobs *JobStore, id string, status JobStatus) Job {
+	t.Helper()
+
+	var job Job
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = jobs.Get(id)
+		require.NoError(t, err)
+		return job.Status == status
+	}, 2*time.Second, 10*time.Millisecond)
+
+	return job
+}
+
+func TestHandleServerToolCallAsync_Success(t *testing.T) {
+	t.Parallel()
+
+	accessor := newMockMCPClientAccessor()
+	mockClient := newBlockingMCPClient()
+	accessor.Add("testserver", mockClient, []string{"report"})
+
+	jobs := NewJobStore("/api/v1/jobs", newRouteOptions())
+
+	resp, err := handleServerToolCallAsync(
+		context.Background(),
+		accessor,
+		nil,
+		nil,
+		jobs,
+		"testserver",
+		"report",
+		map[string]any{},
+	)
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+
+	assert.Equal(t, http.StatusAccepted, resp.Status)
+	assert.NotEmpty(t, resp.Body)
+	assert.Equal(t, "/api/v1/jobs/"+resp.Body, resp.Location)
+
+	job, err := jobs.Get(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, JobStatusRunning, job.Status)
+	assert.Equal(t, "testserver", job.Server)
+	assert.Equal(t, "report", job.Tool)
+
+	// The job ID is used as the progress token.
+	require.Eventually(t, func() bool { return mockClient.meta() != nil }, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, resp.Body, mockClient.meta().ProgressToken)
+
+	close(mockClient.release)
+
+	job = waitForJobStatus(t, jobs, resp.Body, JobStatusSucceeded)
+	assert.Equal(t, "done", job.Result)
+	assert.Empty(t, job.Error)
+	require.NotNil(t, job.FinishedAt)
+}
+
+func TestHandleServerToolCallAsync_ValidatesBeforeStarting(t *testing.T) {
+	t.Parallel()
+
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("testserver", &mockMCPClient{}, []string{"report"})
+
+	jobs := NewJobStore("/api/v1/jobs", newRouteOptions())
+
+	_, err := handleServerToolCallAsync(context.Background(), accessor, nil, nil, jobs, "nonexistent", "report", nil)
+	require.ErrorIs(t, err, errors.ErrServerNotFound)
+
+	_, err = handleServerToolCallAsync(context.Background(), accessor, nil, nil, jobs, "testserver", "forbidden", nil)
+	require.ErrorIs(t, err, errors.ErrToolForbidden)
+
+	assert.Empty(t, jobs.jobs)
+}
+
+func TestJobStore_ToolCallFailure(t *testing.T) {
+	t.Parallel()
+
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("testserver", &mockMCPClient{
+		callToolResult: &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{mcp.TextContent{Text: "crawl failed"}},
+		},
+	}, []string{"crawl"})
+
+	jobs := NewJobStore("/api/v1/jobs", newRouteOptions())
+
+	resp, err := handleServerToolCallAsync(context.Background(), accessor, nil, nil, jobs, "testserver", "crawl", nil)
+	require.NoError(t, err)
+
+	job := waitForJobStatus(t, jobs, resp.Body, JobStatusFailed)
+	assert.Contains(t, job.Error, "crawl failed")
+	assert.Empty(t, job.Result)
+}
+
+func TestJobStore_Cancel(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newBlockingMCPClient()
+	jobs := NewJobStore("/api/v1/jobs", newRouteOptions())
+
+	ctx := context.Background()
+	job := jobs.Start(ctx, "testserver", "report", func(ctx context.Context, token mcp.ProgressToken) (string, error) {
+		return callTool(ctx, mockClient, "testserver", "report", nil, &mcp.Meta{ProgressToken: token})
+	})
+
+	cancelled, err := jobs.Cancel(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, JobStatusCancelled, cancelled.Status)
+	require.NotNil(t, cancelled.FinishedAt)
+
+	// The in-flight request context is cancelled.
+	require.Eventually(t, mockClient.wasCancelled, 2*time.Second, 10*time.Millisecond)
+
+	// The outcome of the cancelled call does not overwrite the cancellation.
+	got, err := jobs.Get(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, JobStatusCancelled, got.Status)
+
+	// Cancelling again is a no-op.
+	again, err := jobs.Cancel(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, cancelled, again)
+}
+
+func TestJobStore_Start_KeepsContextValues(t *testing.T) {
+	t.Parallel()
+
+	jobs := NewJobStore("/api/v1/jobs", newRouteOptions())
+
+	// The job outlives the request which started it, but keeps the caller's identity.
+	ctx, cancel := context.WithCancel(auth.NewContext(context.Background(), auth.Identity{Name: "agent"}))
+	cancel()
+
+	job := jobs.Start(ctx, "testserver", "report", func(ctx context.Context, _ mcp.ProgressToken) (string, error) {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		identity, _ := auth.FromContext(ctx)
+		return identity.Name, nil
+	})
+
+	got := waitForJobStatus(t, jobs, job.ID, JobStatusSucceeded)
+	assert.Equal(t, "agent", got.Result)
+}
+
+func TestJobStore_Timeout(t *testing.T) {
+	t.Parallel()
+
+	jobs := NewJobStore("/api/v1/jobs", newRouteOptions(WithJobTimeout(20*time.Millisecond)))
+
+	ctx := context.Background()
+	job := jobs.Start(ctx, "testserver", "report", func(ctx context.Context, _ mcp.ProgressToken) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+
+	got := waitForJobStatus(t, jobs, job.ID, JobStatusFailed)
+	assert.Contains(t, got.Error, "timed out")
+}
+
+func TestJobStore_Progress(t *testing.T) {
+	t.Parallel()
+
+	notifications := newMockNotificationSubscriber()
+	jobs := NewJobStore("/api/v1/jobs", newRouteOptions(WithNotificationSubscriber(notifications)))
+
+	release := make(chan struct{})
+	ctx := context.Background()
+	job := jobs.Start(ctx, "testserver", "report", func(ctx context.Context, _ mcp.ProgressToken) (string, error) {
+		<-release
+		return "done", nil
+	})
+
+	// Notifications for other tokens or methods are ignored.
+	notifications.publish("testserver", progressNotification("other-token", 1, 10, "ignored"))
+	notifications.publish("testserver", mcp.JSONRPCNotification{
+		Notification: mcp.Notification{Method: "notifications/message"},
+	})
+
+	got, err := jobs.Get(job.ID)
+	require.NoError(t, err)
+	require.Nil(t, got.Progress)
+
+	notifications.publish("testserver", progressNotification(job.ID, 3, 10, "crawling"))
+
+	got, err = jobs.Get(job.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.Progress)
+	assert.InDelta(t, 3, got.Progress.Progress, 0)
+	require.NotNil(t, got.Progress.Total)
+	assert.InDelta(t, 10, *got.Progress.Total, 0)
+	assert.Equal(t, "crawling", got.Progress.Message)
+
+	close(release)
+	waitForJobStatus(t, jobs, job.ID, JobStatusSucceeded)
+}
+
+func TestJobStore_Retention(t *testing.T) {
+	t.Parallel()
+
+	t.Run("max finished jobs", func(t *testing.T) {
+		t.Parallel()
+
+		jobs := NewJobStore("/api/v1/jobs", newRouteOptions(WithJobRetention(2, time.Hour)))
+
+		var ids []string
+		for range 3 {
+			ctx := context.Background()
+			job := jobs.Start(ctx, "testserver", "tool", func(context.Context, mcp.ProgressToken) (string, error) {
+				return "ok", nil
+			})
+			waitForJobStatus(t, jobs, job.ID, JobStatusSucceeded)
+			ids = append(ids, job.ID)
+		}
+
+		_, err := jobs.Get(ids[0])
+		require.ErrorIs(t, err, errors.ErrJobNotFound)
+
+		for _, id := range ids[1:] {
+			_, err := jobs.Get(id)
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("expired finished jobs", func(t *testing.T) {
+		t.Parallel()
+
+		jobs := NewJobStore("/api/v1/jobs", newRouteOptions(WithJobRetention(10, time.Minute)))
+
+		now := time.Now()
+		var nowMu sync.Mutex
+		jobs.now = func() time.Time {
+			nowMu.Lock()
+			defer nowMu.Unlock()
+			return now
+		}
+
+		ctx := context.Background()
+		job := jobs.Start(ctx, "testserver", "tool", func(context.Context, mcp.ProgressToken) (string, error) {
+			return "ok", nil
+		})
+		waitForJobStatus(t, jobs, job.ID, JobStatusSucceeded)
+
+		nowMu.Lock()
+		now = now.Add(2 * time.Minute)
+		nowMu.Unlock()
+
+		_, err := jobs.Get(job.ID)
+		require.ErrorIs(t, err, errors.ErrJobNotFound)
+	})
+
+	t.Run("running jobs are retained", func(t *testing.T) {
+		t.Parallel()
+
+		jobs := NewJobStore("/api/v1/jobs", newRouteOptions(WithJobRetention(0, 0)))
+
+		release := make(chan struct{})
+		defer close(release)
+
+		ctx := context.Background()
+		job := jobs.Start(ctx, "testserver", "tool", func(context.Context, mcp.ProgressToken) (string, error) {
+			<-release
+			return "ok", nil
+		})
+
+		got, err := jobs.Get(job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, JobStatusRunning, got.Status)
+	})
+}
+
+func TestHandleJob_NotFound(t *testing.T) {
+	t.Parallel()
+
+	jobs := NewJobStore("/api/v1/jobs", newRouteOptions())
+
+	_, err := handleJob(context.Background(), jobs, "missing")
+	require.True(t, stdErrors.Is(err, errors.ErrJobNotFound))
+
+	_, err = handleJobCancel(context.Background(), jobs, "missing")
+	require.True(t, stdErrors.Is(err, errors.ErrJobNotFound))
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/mozilla-ai/mcpd/internal/contracts"
+	"github.com/mozilla-ai/mcpd/internal/errors"
+)
+
+// logLevelTimeout bounds how long an MCP server may take to change its log level.
+const logLevelTimeout = 15 * time.Second
+
+// ServerLogLevelRequest represents the incoming API request for changing the log level of a server.
+type ServerLogLevelRequest struct {
+	Name string                  `doc:"Name of the server" example:"time" path:"name"`
+	Body ServerLogLevelArguments `doc:"Log level to set"`
+}
+
+// ServerLogLevelArguments contains the log level to set for a server.
+type ServerLogLevelArguments struct {
+	// Level is the minimum level of log messages.
+	Level string `doc:"Minimum level of log messages" enum:"debug,info,notice,warning,error,critical,alert,emergency" json:"level"`
+}
+
+// ServerLogLevel describes the log level of a server.
+type ServerLogLevel struct {
+	// Level is the minimum level of log messages.
+	Level string `doc:"Minimum level of log messages" json:"level"`
+
+	// Forwarded indicates the server supports logging, and was asked to change the level of the messages it sends.
+	// When false, only the level of the messages logged by mcpd for the server (e.g. from stderr) was changed.
+	Forwarded bool `doc:"Whether the server was asked to change its log level" json:"forwarded"`
+}
+
+// ServerLogLevelResponse represents the wrapped API response for changing the log level of a server.
+type ServerLogLevelResponse struct {
+	Body ServerLogLevel
+}
+
+// handleServerLogLevel changes the log level of a running MCP server.
+func handleServerLogLevel(
+	ctx context.Context,
+	accessor contracts.MCPClientAccessor,
+	controller contracts.MCPLogLevelController,
+	name string,
+	level string,
+) (*ServerLogLevelResponse, error) {
+	if _, ok := accessor.Client(name); !ok {
+		return nil, fmt.Errorf("%w: %s", errors.ErrServerNotFound, name)
+	}
+
+	if controller == nil {
+		return nil, fmt.Errorf("%w: %s: log levels are not controlled by the daemon", errors.ErrLogLevelFailed, name)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, logLevelTimeout)
+	defer cancel()
+
+	forwarded, err := controller.SetLogLevel(ctx, name, mcp.LoggingLevel(level))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ServerLogLevelResponse{}
+	resp.Body = ServerLogLevel{Level: level, Forwarded: forwarded}
+
+	return resp, nil
+}
+
+// RegisterLogLevelRoutes registers the log level route under the servers API.
+func RegisterLogLevelRoutes(parentAPI huma.API, accessor contracts.MCPClientAccessor, options RouteOptions) {
+	huma.Register(
+		parentAPI,
+		huma.Operation{
+			OperationID: "setServerLogLevel",
+			Method:      "PUT",
+			Path:        "/{name}/log-level",
+			Summary:     "Set server log level",
+			Description: "Sets the minimum level of log messages logged for the server, and sent by the server " +
+				"when it supports logging",
+			Tags: []string{"Servers"},
+		},
+		func(ctx context.Context, input *ServerLogLevelRequest) (*ServerLogLevelResponse, error) {
+			return handleServerLogLevel(ctx, accessor, options.LogLevels, input.Name, input.Body.Level)
+		},
+	)
+}
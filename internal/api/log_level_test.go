@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/contracts"
+	internalerrors "github.com/mozilla-ai/mcpd/internal/errors"
+)
+
+// mockLogLevelController records the log levels set for servers.
+type mockLogLevelController struct {
+	levels    map[string]mcp.LoggingLevel
+	forwarded bool
+	err       error
+}
+
+func (m *mockLogLevelController) SetLogLevel(_ context.Context, name string, level mcp.LoggingLevel) (bool, error) {
+	if m.err != nil {
+		return false, m.err
+	}
+	m.levels[name] = level
+	return m.forwarded, nil
+}
+
+func TestHandleServerLogLevel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		server     string
+		controller *mockLogLevelController
+		expected   ServerLogLevel
+		wantErr    error
+	}{
+		{
+			name:       "forwarded to server",
+			server:     "time",
+			controller: &mockLogLevelController{levels: map[string]mcp.LoggingLevel{}, forwarded: true},
+			expected:   ServerLogLevel{Level: "warning", Forwarded: true},
+		},
+		{
+			name:       "server without logging capability",
+			server:     "time",
+			controller: &mockLogLevelController{levels: map[string]mcp.LoggingLevel{}},
+			expected:   ServerLogLevel{Level: "warning"},
+		},
+		{
+			name:       "server not found",
+			server:     "missing",
+			controller: &mockLogLevelController{levels: map[string]mcp.LoggingLevel{}},
+			wantErr:    internalerrors.ErrServerNotFound,
+		},
+		{
+			name:    "no controller",
+			server:  "time",
+			wantErr: internalerrors.ErrLogLevelFailed,
+		},
+		{
+			name:   "server error",
+			server: "time",
+			controller: &mockLogLevelController{
+				err: errors.Join(internalerrors.ErrLogLevelFailed, errors.New("boom")),
+			},
+			wantErr: internalerrors.ErrLogLevelFailed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			accessor := newMockMCPClientAccessor()
+			accessor.Add("time", &mockMCPClient{}, nil)
+
+			var controller contracts.MCPLogLevelController
+			if tc.controller != nil {
+				controller = tc.controller
+			}
+
+			resp, err := handleServerLogLevel(context.Background(), accessor, controller, tc.server, "warning")
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, resp.Body)
+			require.Equal(t, mcp.LoggingLevelWarning, tc.controller.levels[tc.server])
+		})
+	}
+}
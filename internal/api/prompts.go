@@ -10,6 +10,7 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 
 	"github.com/mozilla-ai/mcpd/internal/contracts"
+	"github.com/mozilla-ai/mcpd/internal/domain"
 	errorsint "github.com/mozilla-ai/mcpd/internal/errors"
 )
 
@@ -24,8 +25,9 @@ type DomainPromptMessage mcp.PromptMessage
 
 // Prompts represents a collection of Prompt types.
 type Prompts struct {
-	Prompts    []Prompt `json:"prompts"`
-	NextCursor string   `json:"nextCursor,omitempty"`
+	Prompts    []Prompt      `json:"prompts"`
+	NextCursor string        `json:"nextCursor,omitempty"`
+	Cache      *ListingCache `json:"cache,omitempty"`
 }
 
 // Prompt represents a prompt or prompt template that the server offers.
@@ -144,8 +146,14 @@ func (d DomainPromptMessage) ToAPIType() (PromptMessage, error) {
 }
 
 // handleServerPrompts returns the list of prompts for a given server.
+// The first page is served from the catalog when cached (containing all prompts),
+// requests for subsequent pages are passed through to the server.
+// Prompts which are not allowed by the server's prompt allowlist are omitted.
 func handleServerPrompts(
+	ctx context.Context,
 	accessor contracts.MCPClientAccessor,
+	catalog contracts.MCPCatalogAccessor,
+	configs contracts.MCPServerConfigAccessor,
 	name string,
 	cursor string,
 ) (*PromptsListResponse, error) {
@@ -154,7 +162,22 @@ func handleServerPrompts(
 		return nil, fmt.Errorf("%w: %s", errorsint.ErrServerNotFound, name)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	var cache *ListingCache
+	if cursor == "" {
+		var listing domain.CatalogListing[mcp.Prompt]
+		var cached bool
+		listing, cache, cached = cachedListing(catalog, name, func(c domain.ServerCatalog) domain.CatalogListing[mcp.Prompt] {
+			return c.Prompts
+		})
+		switch {
+		case cached && listing.Unsupported:
+			return nil, fmt.Errorf("%w: %s", errorsint.ErrPromptsNotImplemented, name)
+		case cached:
+			return newPromptsListResponse(allowedPrompts(configs, name, listing.Items), "", cache)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
 
 	req := mcp.ListPromptsRequest{}
@@ -175,8 +198,34 @@ func handleServerPrompts(
 		return nil, fmt.Errorf("%w: %s: no result", errorsint.ErrPromptListFailed, name)
 	}
 
-	prompts := make([]Prompt, 0, len(result.Prompts))
-	for _, prompt := range result.Prompts {
+	return newPromptsListResponse(allowedPrompts(configs, name, result.Prompts), result.NextCursor, cache)
+}
+
+// allowedPrompts returns the prompts which are allowed by the server's prompt allowlist.
+func allowedPrompts(configs contracts.MCPServerConfigAccessor, server string, prompts []mcp.Prompt) []mcp.Prompt {
+	entry, ok := serverConfig(configs, server)
+	if !ok || len(entry.Prompts) == 0 {
+		return prompts
+	}
+
+	allowed := make([]mcp.Prompt, 0, len(prompts))
+	for _, prompt := range prompts {
+		if entry.PromptAllowed(prompt.Name) {
+			allowed = append(allowed, prompt)
+		}
+	}
+
+	return allowed
+}
+
+// newPromptsListResponse converts prompts into the API response for listing prompts.
+func newPromptsListResponse(
+	mcpPrompts []mcp.Prompt,
+	nextCursor mcp.Cursor,
+	cache *ListingCache,
+) (*PromptsListResponse, error) {
+	prompts := make([]Prompt, 0, len(mcpPrompts))
+	for _, prompt := range mcpPrompts {
 		apiPrompt, err := DomainPrompt(prompt).ToAPIType()
 		if err != nil {
 			return nil, err
@@ -187,15 +236,19 @@ func handleServerPrompts(
 	resp := &PromptsListResponse{}
 	resp.Body = Prompts{
 		Prompts:    prompts,
-		NextCursor: string(result.NextCursor),
+		NextCursor: string(nextCursor),
+		Cache:      cache,
 	}
 
 	return resp, nil
 }
 
-// handleServerPromptGenerate generates a prompt from a template on a server.
+// handleServerPromptGenerate generates a prompt from a template on a server,
+// provided the prompt is allowed by the server's prompt allowlist.
 func handleServerPromptGenerate(
+	ctx context.Context,
 	accessor contracts.MCPClientAccessor,
+	configs contracts.MCPServerConfigAccessor,
 	serverName string,
 	promptName string,
 	arguments map[string]string,
@@ -205,7 +258,11 @@ func handleServerPromptGenerate(
 		return nil, fmt.Errorf("%w: %s", errorsint.ErrServerNotFound, serverName)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	if entry, ok := serverConfig(configs, serverName); ok && !entry.PromptAllowed(promptName) {
+		return nil, fmt.Errorf("%w: %s/%s", errorsint.ErrPromptForbidden, serverName, promptName)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
 
 	result, err := mcpClient.GetPrompt(ctx, mcp.GetPromptRequest{
@@ -241,7 +298,7 @@ func handleServerPromptGenerate(
 }
 
 // RegisterPromptRoutes registers prompt-related routes under the servers API.
-func RegisterPromptRoutes(parentAPI huma.API, accessor contracts.MCPClientAccessor) {
+func RegisterPromptRoutes(parentAPI huma.API, accessor contracts.MCPClientAccessor, options RouteOptions) {
 	tags := []string{"Prompts"}
 
 	huma.Register(
@@ -254,7 +311,7 @@ func RegisterPromptRoutes(parentAPI huma.API, accessor contracts.MCPClientAccess
 			Tags:        tags,
 		},
 		func(ctx context.Context, input *ServerPromptsListRequest) (*PromptsListResponse, error) {
-			return handleServerPrompts(accessor, input.Name, input.Cursor)
+			return handleServerPrompts(ctx, accessor, options.Catalog, options.ServerConfigs, input.Name, input.Cursor)
 		},
 	)
 
@@ -269,7 +326,14 @@ func RegisterPromptRoutes(parentAPI huma.API, accessor contracts.MCPClientAccess
 			Tags:        tags,
 		},
 		func(ctx context.Context, input *ServerPromptGenerateRequest) (*GeneratePromptResponse, error) {
-			return handleServerPromptGenerate(accessor, input.ServerName, input.PromptName, input.Body.Arguments)
+			return handleServerPromptGenerate(
+				ctx,
+				accessor,
+				options.ServerConfigs,
+				input.ServerName,
+				input.PromptName,
+				input.Body.Arguments,
+			)
 		},
 	)
 }
@@ -1,12 +1,14 @@
 package api
 
 import (
+	"context"
 	"errors"
 	"testing"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/require"
 
+	"github.com/mozilla-ai/mcpd/internal/config"
 	internalerrors "github.com/mozilla-ai/mcpd/internal/errors"
 )
 
@@ -86,7 +88,7 @@ func TestAPI_HandleServerPrompts_Success(t *testing.T) {
 			accessor := newMockMCPClientAccessor()
 			accessor.Add(tc.serverName, mockClient, []string{})
 
-			result, err := handleServerPrompts(accessor, tc.serverName, "")
+			result, err := handleServerPrompts(context.Background(), accessor, nil, nil, tc.serverName, "")
 
 			require.NoError(t, err)
 			require.NotNil(t, result)
@@ -114,7 +116,7 @@ func TestAPI_HandleServerPrompts_WithCursor(t *testing.T) {
 	accessor := newMockMCPClientAccessor()
 	accessor.Add("test-server", mockClient, []string{})
 
-	result, err := handleServerPrompts(accessor, "test-server", cursor)
+	result, err := handleServerPrompts(context.Background(), accessor, nil, nil, "test-server", cursor)
 
 	require.NoError(t, err)
 	require.NotNil(t, result)
@@ -126,7 +128,7 @@ func TestAPI_HandleServerPrompts_ServerNotFound(t *testing.T) {
 
 	accessor := newMockMCPClientAccessor()
 
-	result, err := handleServerPrompts(accessor, "nonexistent-server", "")
+	result, err := handleServerPrompts(context.Background(), accessor, nil, nil, "nonexistent-server", "")
 
 	require.Error(t, err)
 	require.Nil(t, result)
@@ -143,7 +145,7 @@ func TestAPI_HandleServerPrompts_ListError(t *testing.T) {
 	accessor := newMockMCPClientAccessor()
 	accessor.Add("test-server", mockClient, []string{})
 
-	result, err := handleServerPrompts(accessor, "test-server", "")
+	result, err := handleServerPrompts(context.Background(), accessor, nil, nil, "test-server", "")
 
 	require.Error(t, err)
 	require.Nil(t, result)
@@ -160,7 +162,7 @@ func TestAPI_HandleServerPrompts_NilResult(t *testing.T) {
 	accessor := newMockMCPClientAccessor()
 	accessor.Add("test-server", mockClient, []string{})
 
-	result, err := handleServerPrompts(accessor, "test-server", "")
+	result, err := handleServerPrompts(context.Background(), accessor, nil, nil, "test-server", "")
 
 	require.Error(t, err)
 	require.Nil(t, result)
@@ -177,13 +179,78 @@ func TestAPI_HandleServerPrompts_MethodNotFound(t *testing.T) {
 	accessor := newMockMCPClientAccessor()
 	accessor.Add("test-server", mockClient, []string{})
 
-	result, err := handleServerPrompts(accessor, "test-server", "")
+	result, err := handleServerPrompts(context.Background(), accessor, nil, nil, "test-server", "")
 
 	require.Error(t, err)
 	require.Nil(t, result)
 	require.True(t, errors.Is(err, internalerrors.ErrPromptsNotImplemented))
 }
 
+func TestAPI_HandleServerPrompts_Allowlist(t *testing.T) {
+	t.Parallel()
+
+	mockClient := &mockMCPClient{
+		listPromptsResult: mcp.NewListPromptsResult([]mcp.Prompt{
+			{Name: "code_review"},
+			{Name: "delete_everything"},
+		}, ""),
+	}
+
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("test-server", mockClient, []string{})
+
+	configs := &mockServerConfigAccessor{configs: map[string]config.ServerEntry{
+		"test-server": {Name: "test-server", Prompts: []string{"Code_Review"}},
+	}}
+
+	result, err := handleServerPrompts(context.Background(), accessor, nil, configs, "test-server", "")
+
+	require.NoError(t, err)
+	require.Len(t, result.Body.Prompts, 1)
+	require.Equal(t, "code_review", result.Body.Prompts[0].Name)
+}
+
+func TestAPI_HandleServerPromptGenerate_Allowlist(t *testing.T) {
+	t.Parallel()
+
+	mockClient := &mockMCPClient{
+		getPromptResult: &mcp.GetPromptResult{
+			Messages: []mcp.PromptMessage{
+				{Role: mcp.RoleUser, Content: mcp.TextContent{Type: "text", Text: "Review this"}},
+			},
+		},
+	}
+
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("test-server", mockClient, []string{})
+
+	configs := &mockServerConfigAccessor{configs: map[string]config.ServerEntry{
+		"test-server": {Name: "test-server", Prompts: []string{"code_review"}},
+	}}
+
+	result, err := handleServerPromptGenerate(
+		context.Background(),
+		accessor,
+		configs,
+		"test-server",
+		"code_review",
+		nil,
+	)
+	require.NoError(t, err)
+	require.Len(t, result.Body.Messages, 1)
+
+	result, err = handleServerPromptGenerate(
+		context.Background(),
+		accessor,
+		configs,
+		"test-server",
+		"delete_everything",
+		nil,
+	)
+	require.Nil(t, result)
+	require.ErrorIs(t, err, internalerrors.ErrPromptForbidden)
+}
+
 func TestAPI_HandleServerPromptGenerate_Success(t *testing.T) {
 	t.Parallel()
 
@@ -205,7 +272,7 @@ func TestAPI_HandleServerPromptGenerate_Success(t *testing.T) {
 	promptName := "test-prompt"
 	arguments := map[string]string{}
 
-	result, err := handleServerPromptGenerate(accessor, "test-server", promptName, arguments)
+	result, err := handleServerPromptGenerate(context.Background(), accessor, nil, "test-server", promptName, arguments)
 
 	require.NoError(t, err)
 	require.NotNil(t, result)
@@ -238,7 +305,7 @@ func TestAPI_HandleServerPromptGenerate_WithArguments(t *testing.T) {
 		"param2": "value2",
 	}
 
-	result, err := handleServerPromptGenerate(accessor, "test-server", promptName, arguments)
+	result, err := handleServerPromptGenerate(context.Background(), accessor, nil, "test-server", promptName, arguments)
 
 	require.NoError(t, err)
 	require.NotNil(t, result)
@@ -272,7 +339,7 @@ func TestAPI_HandleServerPromptGenerate_MultipleMessages(t *testing.T) {
 	promptName := "multi-prompt"
 	arguments := map[string]string{}
 
-	result, err := handleServerPromptGenerate(accessor, "test-server", promptName, arguments)
+	result, err := handleServerPromptGenerate(context.Background(), accessor, nil, "test-server", promptName, arguments)
 
 	require.NoError(t, err)
 	require.NotNil(t, result)
@@ -289,7 +356,14 @@ func TestAPI_HandleServerPromptGenerate_ServerNotFound(t *testing.T) {
 	promptName := "test-prompt"
 	arguments := map[string]string{}
 
-	result, err := handleServerPromptGenerate(accessor, "nonexistent-server", promptName, arguments)
+	result, err := handleServerPromptGenerate(
+		context.Background(),
+		accessor,
+		nil,
+		"nonexistent-server",
+		promptName,
+		arguments,
+	)
 
 	require.Error(t, err)
 	require.Nil(t, result)
@@ -309,7 +383,7 @@ func TestAPI_HandleServerPromptGenerate_GenerateError(t *testing.T) {
 	promptName := "nonexistent-prompt"
 	arguments := map[string]string{}
 
-	result, err := handleServerPromptGenerate(accessor, "test-server", promptName, arguments)
+	result, err := handleServerPromptGenerate(context.Background(), accessor, nil, "test-server", promptName, arguments)
 
 	require.Error(t, err)
 	require.Nil(t, result)
@@ -329,7 +403,7 @@ func TestAPI_HandleServerPromptGenerate_NilResult(t *testing.T) {
 	promptName := "test-prompt"
 	arguments := map[string]string{}
 
-	result, err := handleServerPromptGenerate(accessor, "test-server", promptName, arguments)
+	result, err := handleServerPromptGenerate(context.Background(), accessor, nil, "test-server", promptName, arguments)
 
 	require.Error(t, err)
 	require.Nil(t, result)
@@ -349,7 +423,7 @@ func TestAPI_HandleServerPromptGenerate_MethodNotFound(t *testing.T) {
 	promptName := "test-prompt"
 	arguments := map[string]string{}
 
-	result, err := handleServerPromptGenerate(accessor, "test-server", promptName, arguments)
+	result, err := handleServerPromptGenerate(context.Background(), accessor, nil, "test-server", promptName, arguments)
 
 	require.Error(t, err)
 	require.Nil(t, result)
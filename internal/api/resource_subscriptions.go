@@ -0,0 +1,471 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/hashicorp/go-hclog"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/mozilla-ai/mcpd/internal/contracts"
+	errorsint "github.com/mozilla-ai/mcpd/internal/errors"
+	"github.com/mozilla-ai/mcpd/internal/filter"
+)
+
+const (
+	// streamEventResourceUpdated is the SSE event name for resource updated notifications.
+	streamEventResourceUpdated = "updated"
+
+	// streamEventResourcesListChanged is the SSE event name for resource list changed notifications.
+	streamEventResourcesListChanged = "list_changed"
+
+	// resourceSubscriptionTimeout bounds the upstream subscribe and unsubscribe requests.
+	resourceSubscriptionTimeout = 15 * time.Second
+
+	// resourceSubscriptionKeepAlive is how often an idle subscription stream is kept alive,
+	// and checked for the server having been restarted or removed.
+	resourceSubscriptionKeepAlive = 30 * time.Second
+)
+
+// ServerResourceSubscribeRequest represents the incoming API request to subscribe to resource updates.
+type ServerResourceSubscribeRequest struct {
+	Name string   `doc:"Name of the server"                    path:"name"`
+	URIs []string `doc:"URIs of the resources to subscribe to"             query:"uri" required:"true"`
+}
+
+// ResourceUpdatedEvent represents a notification that a subscribed resource has been updated.
+type ResourceUpdatedEvent struct {
+	// URI of the updated resource.
+	URI string `json:"uri"`
+
+	// UpdatedAt is when mcpd received the notification.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ResourcesListChangedEvent represents a notification that the list of resources offered by the server has changed.
+type ResourcesListChangedEvent struct {
+	// UpdatedAt is when mcpd received the notification.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ResourceSubscriptionErrorEvent represents the end of a subscription stream, because it can't be continued.
+type ResourceSubscriptionErrorEvent struct {
+	// Error describes why the subscription ended.
+	Error string `json:"error"`
+}
+
+// resourceSubscriptionKey identifies an upstream resource subscription.
+type resourceSubscriptionKey struct {
+	server string
+	uri    string
+}
+
+// resourceSubscription is an upstream resource subscription, and the number of streams which hold it.
+type resourceSubscription struct {
+	client client.MCPClient
+	refs   int
+
+	// pending is closed once an upstream subscribe or unsubscribe request in progress has finished,
+	// nil when there is no request in progress.
+	pending chan struct{}
+}
+
+// ResourceSubscriptions manages the upstream resource subscriptions made on behalf of API clients.
+// Each resource is subscribed to once, and unsubscribed from once no clients hold the subscription.
+// NewResourceSubscriptions should be used to create instances of ResourceSubscriptions.
+type ResourceSubscriptions struct {
+	mu sync.Mutex
+
+	// subscriptions holds the active upstream subscriptions.
+	subscriptions map[resourceSubscriptionKey]*resourceSubscription
+
+	// keepAlive is how often idle streams are kept alive.
+	keepAlive time.Duration
+
+	logger hclog.Logger
+}
+
+// NewResourceSubscriptions creates a ResourceSubscriptions configured from the supplied route options.
+func NewResourceSubscriptions(options RouteOptions) *ResourceSubscriptions {
+	logger := options.Logger
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+
+	return &ResourceSubscriptions{
+		subscriptions: make(map[resourceSubscriptionKey]*resourceSubscription),
+		keepAlive:     resourceSubscriptionKeepAlive,
+		logger:        logger.Named("subscriptions"),
+	}
+}
+
+// Acquire holds a subscription to the resource, subscribing upstream using the client when it's the first holder.
+// When the server has been restarted (i.e. its unwrapped client has changed) the resource is subscribed to again.
+// Upstream requests are made without holding the lock, holders of the same resource wait for them to finish.
+func (s *ResourceSubscriptions) Acquire(
+	ctx context.Context,
+	server string,
+	mcpClient client.MCPClient,
+	uri string,
+) error {
+	key := resourceSubscriptionKey{server: filter.NormalizeString(server), uri: uri}
+
+	for {
+		s.mu.Lock()
+
+		sub, ok := s.subscriptions[key]
+		if ok && sub.pending != nil {
+			pending := sub.pending
+			s.mu.Unlock()
+
+			select {
+			case <-pending:
+				continue
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		if ok && sameClient(sub.client, mcpClient) {
+			sub.refs++
+			s.mu.Unlock()
+			return nil
+		}
+
+		// Record the pending subscription, keeping the previous client in case subscribing fails.
+		var prevClient client.MCPClient
+		if ok {
+			prevClient = sub.client
+		} else {
+			sub = &resourceSubscription{}
+			s.subscriptions[key] = sub
+		}
+		sub.client = mcpClient
+		sub.pending = make(chan struct{})
+		s.mu.Unlock()
+
+		err := subscribeResource(ctx, mcpClient, uri)
+
+		// Commit the subscription, or roll it back when subscribing failed.
+		s.mu.Lock()
+		close(sub.pending)
+		sub.pending = nil
+		if err == nil {
+			sub.refs++
+		} else {
+			sub.client = prevClient
+			if sub.refs == 0 {
+				delete(s.subscriptions, key)
+			}
+		}
+		s.mu.Unlock()
+
+		if err != nil {
+			if errors.Is(err, mcp.ErrMethodNotFound) {
+				return fmt.Errorf("%w: %s", errorsint.ErrResourceSubscriptionsNotImplemented, key.server)
+			}
+			return fmt.Errorf("%w: %s: %s: %w", errorsint.ErrResourceSubscribeFailed, key.server, uri, err)
+		}
+
+		s.logger.Debug("Subscribed to resource", "server", key.server, "uri", uri)
+
+		return nil
+	}
+}
+
+// Release gives up a subscription to the resource, unsubscribing upstream when there are no remaining holders.
+// The upstream request is made without holding the lock.
+func (s *ResourceSubscriptions) Release(server string, uri string) {
+	key := resourceSubscriptionKey{server: filter.NormalizeString(server), uri: uri}
+
+	s.mu.Lock()
+
+	sub, ok := s.subscriptions[key]
+	if !ok || sub.refs == 0 {
+		s.mu.Unlock()
+		return
+	}
+
+	sub.refs--
+	// A subscribe in progress (i.e. after a restart) becomes the only holder, or removes the subscription.
+	if sub.refs > 0 || sub.pending != nil {
+		s.mu.Unlock()
+		return
+	}
+
+	// Holders of the resource wait for it to be unsubscribed from, before subscribing to it again.
+	sub.pending = make(chan struct{})
+	s.mu.Unlock()
+
+	err := unsubscribeResource(sub.client, uri)
+
+	s.mu.Lock()
+	delete(s.subscriptions, key)
+	close(sub.pending)
+	sub.pending = nil
+	s.mu.Unlock()
+
+	if err != nil {
+		// The server may have been stopped, in which case the subscription has already gone.
+		s.logger.Debug("Failed to unsubscribe from resource", "server", key.server, "uri", uri, "error", err)
+		return
+	}
+
+	s.logger.Debug("Unsubscribed from resource", "server", key.server, "uri", uri)
+}
+
+// subscribeResource subscribes to updates of the resource from the server.
+func subscribeResource(ctx context.Context, mcpClient client.MCPClient, uri string) error {
+	ctx, cancel := context.WithTimeout(ctx, resourceSubscriptionTimeout)
+	defer cancel()
+
+	req := mcp.SubscribeRequest{}
+	req.Params.URI = uri
+
+	return mcpClient.Subscribe(ctx, req)
+}
+
+// unsubscribeResource unsubscribes from updates of the resource from the server.
+func unsubscribeResource(mcpClient client.MCPClient, uri string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), resourceSubscriptionTimeout)
+	defer cancel()
+
+	req := mcp.UnsubscribeRequest{}
+	req.Params.URI = uri
+
+	return mcpClient.Unsubscribe(ctx, req)
+}
+
+// RegisterResourceSubscribeRoute registers the resource subscription streaming endpoint on the provided API group.
+func RegisterResourceSubscribeRoute(
+	parentAPI huma.API,
+	accessor contracts.MCPClientAccessor,
+	subscriptions *ResourceSubscriptions,
+	options RouteOptions,
+) {
+	huma.Register(
+		parentAPI,
+		huma.Operation{
+			OperationID: "subscribeResources",
+			Method:      http.MethodGet,
+			Path:        "/{name}/resources/subscribe",
+			Summary:     "Subscribe to resource updates from a server",
+			Description: "Subscribes to one or more resources (?uri=) and streams Server-Sent Events: " +
+				"'updated' events when a subscribed resource changes, " +
+				"and 'list_changed' events when the server's resources change. " +
+				"Subscriptions are shared across clients, disconnecting releases them. " +
+				"The stream ends with an 'error' event if the server is restarted or removed.",
+			Tags: []string{"Resources"},
+			Responses: map[string]*huma.Response{
+				"200": {
+					Description: "Server-Sent Events stream",
+					Content: map[string]*huma.MediaType{
+						"text/event-stream": {
+							Schema: &huma.Schema{
+								Type: huma.TypeString,
+								Description: "Events named updated, list_changed, and error, " +
+									"each with JSON encoded data",
+							},
+						},
+					},
+				},
+			},
+		},
+		func(ctx context.Context, input *ServerResourceSubscribeRequest) (*huma.StreamResponse, error) {
+			return handleServerResourceSubscribe(
+				ctx,
+				accessor,
+				options.ServerConfigs,
+				options.NotificationSubscriber,
+				subscriptions,
+				input.Name,
+				input.URIs,
+			)
+		},
+	)
+}
+
+// handleServerResourceSubscribe subscribes to the resources and returns a response that streams their updates
+// as Server-Sent Events. Validation and subscription errors are returned before the stream starts,
+// so they can be mapped to status codes as usual.
+func handleServerResourceSubscribe(
+	ctx context.Context,
+	accessor contracts.MCPClientAccessor,
+	configs contracts.MCPServerConfigAccessor,
+	notifications contracts.MCPNotificationSubscriber,
+	subscriptions *ResourceSubscriptions,
+	server string,
+	uris []string,
+) (*huma.StreamResponse, error) {
+	mcpClient, ok := accessor.Client(server)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errorsint.ErrServerNotFound, server)
+	}
+
+	uris = normalizeURIs(uris)
+	if len(uris) == 0 {
+		return nil, fmt.Errorf("%w: at least one resource URI is required", errorsint.ErrBadRequest)
+	}
+
+	if entry, ok := serverConfig(configs, server); ok {
+		for _, uri := range uris {
+			if !entry.ResourceAllowed(uri) {
+				return nil, fmt.Errorf("%w: %s: %s", errorsint.ErrResourceForbidden, server, uri)
+			}
+		}
+	}
+
+	acquired := make([]string, 0, len(uris))
+	release := func() {
+		for _, uri := range acquired {
+			subscriptions.Release(server, uri)
+		}
+	}
+
+	for _, uri := range uris {
+		if err := subscriptions.Acquire(ctx, server, mcpClient, uri); err != nil {
+			release()
+			return nil, err
+		}
+		acquired = append(acquired, uri)
+	}
+
+	return &huma.StreamResponse{
+		Body: func(hctx huma.Context) {
+			defer release()
+
+			hctx.SetHeader("Content-Type", "text/event-stream")
+			hctx.SetHeader("Cache-Control", "no-cache")
+			hctx.SetStatus(http.StatusOK)
+
+			streamResourceUpdates(
+				hctx.Context(),
+				&sseWriter{w: hctx.BodyWriter()},
+				accessor,
+				notifications,
+				mcpClient,
+				subscriptions.keepAlive,
+				server,
+				uris,
+			)
+		},
+	}, nil
+}
+
+// streamResourceUpdates writes notifications for the subscribed resources as events,
+// until writing fails (e.g. the client disconnected), the context is done,
+// or the server is restarted or removed (i.e. its client has changed).
+func streamResourceUpdates(
+	ctx context.Context,
+	w *sseWriter,
+	accessor contracts.MCPClientAccessor,
+	notifications contracts.MCPNotificationSubscriber,
+	mcpClient client.MCPClient,
+	keepAlive time.Duration,
+	server string,
+	uris []string,
+) {
+	events := make(chan streamEvent, streamEventBuffer)
+
+	if notifications != nil {
+		unsubscribe := notifications.Subscribe(server, func(n mcp.JSONRPCNotification) {
+			event, ok := notificationToResourceEvent(n, uris)
+			if !ok {
+				return
+			}
+			select {
+			case events <- event:
+			default:
+			}
+		})
+		defer unsubscribe()
+	}
+
+	// Commit the headers so the client knows the subscription has started.
+	if err := w.flush(); err != nil {
+		return
+	}
+
+	ticker := time.NewTicker(keepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-events:
+			if err := w.write(event); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if current, ok := accessor.Client(server); !ok || !sameClient(current, mcpClient) {
+				err := fmt.Sprintf("server '%s' was restarted or removed", server)
+				_ = w.write(streamEvent{name: streamEventError, data: ResourceSubscriptionErrorEvent{Error: err}})
+				return
+			}
+			if err := w.comment("keep-alive"); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// notificationToResourceEvent converts a notification into an event, when it is relevant to the subscription.
+// Updates are only relevant for the subscribed resources, list changes are relevant to all subscriptions.
+func notificationToResourceEvent(n mcp.JSONRPCNotification, uris []string) (streamEvent, bool) {
+	switch n.Method {
+	case mcp.MethodNotificationResourceUpdated:
+		uri, _ := n.Params.AdditionalFields["uri"].(string)
+		if !slices.Contains(uris, uri) {
+			return streamEvent{}, false
+		}
+		return streamEvent{
+			name: streamEventResourceUpdated,
+			data: ResourceUpdatedEvent{URI: uri, UpdatedAt: time.Now().UTC()},
+		}, true
+	case mcp.MethodNotificationResourcesListChanged:
+		return streamEvent{
+			name: streamEventResourcesListChanged,
+			data: ResourcesListChangedEvent{UpdatedAt: time.Now().UTC()},
+		}, true
+	default:
+		return streamEvent{}, false
+	}
+}
+
+// sameClient returns true when the clients are the same once unwrapped (see contracts.MCPClientUnwrapper),
+// since accessors may wrap the client of a server (e.g. to audit its requests) each time it's looked up.
+func sameClient(a client.MCPClient, b client.MCPClient) bool {
+	return unwrapClient(a) == unwrapClient(b)
+}
+
+// unwrapClient returns the innermost client, removing the wrappers implementing contracts.MCPClientUnwrapper.
+func unwrapClient(c client.MCPClient) client.MCPClient {
+	for {
+		w, ok := c.(contracts.MCPClientUnwrapper)
+		if !ok {
+			return c
+		}
+		c = w.Unwrap()
+	}
+}
+
+// normalizeURIs trims the URIs, removing empty and duplicate URIs.
+func normalizeURIs(uris []string) []string {
+	normalized := make([]string, 0, len(uris))
+	for _, uri := range uris {
+		uri = strings.TrimSpace(uri)
+		if uri != "" && !slices.Contains(normalized, uri) {
+			normalized = append(normalized, uri)
+		}
+	}
+	return normalized
+}
@@ -0,0 +1,468 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/errors"
+)
+
+// subscribingMCPClient is a mock client which records the resources subscribed to, and unsubscribed from.
+type subscribingMCPClient struct {
+	mockMCPClient
+
+	mu           sync.Mutex
+	subscribed   []string
+	unsubscribed []string
+
+	// subscribeErrs maps a URI to the error returned when subscribing to it.
+	subscribeErrs map[string]error
+}
+
+func (m *subscribingMCPClient) Subscribe(_ context.Context, req mcp.SubscribeRequest) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.subscribeErrs[req.Params.URI]; err != nil {
+		return err
+	}
+	m.subscribed = append(m.subscribed, req.Params.URI)
+	return nil
+}
+
+func (m *subscribingMCPClient) Unsubscribe(_ context.Context, req mcp.UnsubscribeRequest) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.unsubscribed = append(m.unsubscribed, req.Params.URI)
+	return nil
+}
+
+func (m *subscribingMCPClient) calls() ([]string, []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string{}, m.subscribed...), append([]string{}, m.unsubscribed...)
+}
+
+// slowSubscribingMCPClient is a subscribing mock client whose subscribe requests wait until released.
+type slowSubscribingMCPClient struct {
+	subscribingMCPClient
+
+	started chan struct{}
+	release chan struct{}
+}
+
+func (m *slowSubscribingMCPClient) Subscribe(ctx context.Context, req mcp.SubscribeRequest) error {
+	m.started <- struct{}{}
+	<-m.release
+	return m.subscribingMCPClient.Subscribe(ctx, req)
+}
+
+// wrappingMCPClientAccessor returns a new wrapper around the client of a server for each lookup,
+// as accessors which decorate clients (e.g. to audit their requests) may do.
+type wrappingMCPClientAccessor struct {
+	*mockMCPClientAccessor
+}
+
+func (a *wrappingMCPClientAccessor) Client(name string) (client.MCPClient, bool) {
+	c, ok := a.mockMCPClientAccessor.Client(name)
+	if !ok {
+		return nil, false
+	}
+	return &wrappedMCPClient{MCPClient: c}, true
+}
+
+// wrappedMCPClient wraps a client, implementing contracts.MCPClientUnwrapper.
+type wrappedMCPClient struct {
+	client.MCPClient
+}
+
+func (w *wrappedMCPClient) Unwrap() client.MCPClient {
+	return w.MCPClient
+}
+
+// syncBuffer is a flushable writer which is safe to read while events are written.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) Flush() {}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func resourceNotification(method string, fields map[string]any) mcp.JSONRPCNotification {
+	return mcp.JSONRPCNotification{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		Notification: mcp.Notification{
+			Method: method,
+			Params: mcp.NotificationParams{AdditionalFields: fields},
+		},
+	}
+}
+
+func TestResourceSubscriptions_ReferenceCounted(t *testing.T) {
+	t.Parallel()
+
+	subscriptions := NewResourceSubscriptions(newRouteOptions())
+	mockClient := &subscribingMCPClient{}
+
+	require.NoError(t, subscriptions.Acquire(context.Background(), "TestServer", mockClient, "file:///a"))
+	require.NoError(t, subscriptions.Acquire(context.Background(), "testserver", mockClient, "file:///a"))
+	require.NoError(t, subscriptions.Acquire(context.Background(), "testserver", mockClient, "file:///b"))
+
+	subscribed, unsubscribed := mockClient.calls()
+	assert.Equal(t, []string{"file:///a", "file:///b"}, subscribed)
+	assert.Empty(t, unsubscribed)
+
+	// The subscription is held until the last holder releases it.
+	subscriptions.Release("testserver", "file:///a")
+	_, unsubscribed = mockClient.calls()
+	assert.Empty(t, unsubscribed)
+
+	subscriptions.Release("testserver", "file:///a")
+	subscriptions.Release("testserver", "file:///b")
+	_, unsubscribed = mockClient.calls()
+	assert.Equal(t, []string{"file:///a", "file:///b"}, unsubscribed)
+	assert.Empty(t, subscriptions.subscriptions)
+
+	// Releasing a resource which isn't subscribed to is a no-op.
+	subscriptions.Release("testserver", "file:///a")
+	_, unsubscribed = mockClient.calls()
+	assert.Len(t, unsubscribed, 2)
+}
+
+func TestResourceSubscriptions_ResubscribesAfterRestart(t *testing.T) {
+	t.Parallel()
+
+	subscriptions := NewResourceSubscriptions(newRouteOptions())
+	before := &subscribingMCPClient{}
+	after := &subscribingMCPClient{}
+
+	require.NoError(t, subscriptions.Acquire(context.Background(), "testserver", before, "file:///a"))
+	require.NoError(t, subscriptions.Acquire(context.Background(), "testserver", after, "file:///a"))
+
+	subscribed, _ := after.calls()
+	assert.Equal(t, []string{"file:///a"}, subscribed)
+
+	subscriptions.Release("testserver", "file:///a")
+	subscriptions.Release("testserver", "file:///a")
+
+	// The subscription is removed using the current client.
+	_, unsubscribed := before.calls()
+	assert.Empty(t, unsubscribed)
+	_, unsubscribed = after.calls()
+	assert.Equal(t, []string{"file:///a"}, unsubscribed)
+}
+
+func TestResourceSubscriptions_SlowServer(t *testing.T) {
+	t.Parallel()
+
+	subscriptions := NewResourceSubscriptions(newRouteOptions())
+	slowClient := &slowSubscribingMCPClient{started: make(chan struct{}, 1), release: make(chan struct{})}
+	fastClient := &subscribingMCPClient{}
+
+	errs := make(chan error, 2)
+	go func() {
+		errs <- subscriptions.Acquire(context.Background(), "slow", slowClient, "file:///a")
+	}()
+	<-slowClient.started
+
+	// Subscriptions to other servers aren't blocked by the slow server.
+	done := make(chan error, 1)
+	go func() {
+		err := subscriptions.Acquire(context.Background(), "fast", fastClient, "file:///a")
+		subscriptions.Release("fast", "file:///a")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscribing to a server was blocked by another server")
+	}
+	_, unsubscribed := fastClient.calls()
+	assert.Equal(t, []string{"file:///a"}, unsubscribed)
+
+	// Holders of the same resource wait for the subscription in progress, and share it.
+	go func() {
+		errs <- subscriptions.Acquire(context.Background(), "slow", slowClient, "file:///a")
+	}()
+	close(slowClient.release)
+	require.NoError(t, <-errs)
+	require.NoError(t, <-errs)
+
+	subscribed, _ := slowClient.calls()
+	assert.Equal(t, []string{"file:///a"}, subscribed)
+	key := resourceSubscriptionKey{server: "slow", uri: "file:///a"}
+	subscriptions.mu.Lock()
+	assert.Equal(t, 2, subscriptions.subscriptions[key].refs)
+	subscriptions.mu.Unlock()
+}
+
+func TestResourceSubscriptions_WrappedClients(t *testing.T) {
+	t.Parallel()
+
+	accessor := &wrappingMCPClientAccessor{newMockMCPClientAccessor()}
+	mockClient := &subscribingMCPClient{}
+	accessor.Add("testserver", mockClient, nil)
+
+	// Each lookup returns a different wrapper around the same client, which shares the subscription.
+	subscriptions := NewResourceSubscriptions(newRouteOptions())
+	for range 2 {
+		c, ok := accessor.Client("testserver")
+		require.True(t, ok)
+		require.NoError(t, subscriptions.Acquire(context.Background(), "testserver", c, "file:///a"))
+	}
+
+	subscribed, _ := mockClient.calls()
+	assert.Equal(t, []string{"file:///a"}, subscribed)
+	key := resourceSubscriptionKey{server: "testserver", uri: "file:///a"}
+	assert.Equal(t, 2, subscriptions.subscriptions[key].refs)
+}
+
+func TestResourceSubscriptions_SubscribeErrors(t *testing.T) {
+	t.Parallel()
+
+	subscriptions := NewResourceSubscriptions(newRouteOptions())
+	mockClient := &subscribingMCPClient{
+		subscribeErrs: map[string]error{
+			"file:///unsupported": fmt.Errorf("%w: resources/subscribe", mcp.ErrMethodNotFound),
+			"file:///failing":     stdErrors.New("connection reset"),
+		},
+	}
+
+	err := subscriptions.Acquire(context.Background(), "testserver", mockClient, "file:///unsupported")
+	require.ErrorIs(t, err, errors.ErrResourceSubscriptionsNotImplemented)
+
+	err = subscriptions.Acquire(context.Background(), "testserver", mockClient, "file:///failing")
+	require.ErrorIs(t, err, errors.ErrResourceSubscribeFailed)
+	require.ErrorContains(t, err, "connection reset")
+
+	assert.Empty(t, subscriptions.subscriptions)
+}
+
+func TestHandleServerResourceSubscribe_ValidatesBeforeStreaming(t *testing.T) {
+	t.Parallel()
+
+	accessor := newMockMCPClientAccessor()
+	mockClient := &subscribingMCPClient{
+		subscribeErrs: map[string]error{"file:///workspace/failing": stdErrors.New("connection reset")},
+	}
+	accessor.Add("testserver", mockClient, nil)
+
+	configs := &mockServerConfigAccessor{configs: map[string]config.ServerEntry{
+		"testserver": {Name: "testserver", Resources: []string{"file:///workspace/**"}},
+	}}
+	subscriptions := NewResourceSubscriptions(newRouteOptions())
+
+	subscribe := func(server string, uris ...string) error {
+		_, err := handleServerResourceSubscribe(
+			context.Background(),
+			accessor,
+			configs,
+			nil,
+			subscriptions,
+			server,
+			uris,
+		)
+		return err
+	}
+
+	require.ErrorIs(t, subscribe("nonexistent", "file:///workspace/a"), errors.ErrServerNotFound)
+	require.ErrorIs(t, subscribe("testserver", " "), errors.ErrBadRequest)
+	err := subscribe("testserver", "file:///workspace/a", "file:///etc/passwd")
+	require.ErrorIs(t, err, errors.ErrResourceForbidden)
+
+	// Subscriptions acquired before a failure are released.
+	err = subscribe("testserver", "file:///workspace/a", "file:///workspace/failing")
+	require.ErrorIs(t, err, errors.ErrResourceSubscribeFailed)
+
+	subscribed, unsubscribed := mockClient.calls()
+	assert.Equal(t, []string{"file:///workspace/a"}, subscribed)
+	assert.Equal(t, []string{"file:///workspace/a"}, unsubscribed)
+	assert.Empty(t, subscriptions.subscriptions)
+}
+
+func TestHandleServerResourceSubscribe_DeduplicatesURIs(t *testing.T) {
+	t.Parallel()
+
+	accessor := newMockMCPClientAccessor()
+	mockClient := &subscribingMCPClient{}
+	accessor.Add("testserver", mockClient, nil)
+	subscriptions := NewResourceSubscriptions(newRouteOptions())
+
+	resp, err := handleServerResourceSubscribe(
+		context.Background(),
+		accessor,
+		nil,
+		nil,
+		subscriptions,
+		"testserver",
+		[]string{"file:///a", " file:///a ", "file:///b"},
+	)
+	require.NoError(t, err)
+	require.NotNil(t, resp.Body)
+
+	subscribed, _ := mockClient.calls()
+	assert.Equal(t, []string{"file:///a", "file:///b"}, subscribed)
+}
+
+func TestStreamResourceUpdates_Events(t *testing.T) {
+	t.Parallel()
+
+	accessor := newMockMCPClientAccessor()
+	mockClient := &subscribingMCPClient{}
+	accessor.Add("testserver", mockClient, nil)
+	notifications := newMockNotificationSubscriber()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := &syncBuffer{}
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		uris := []string{"file:///a"}
+		streamResourceUpdates(ctx, &sseWriter{w: w}, accessor, notifications, mockClient, time.Hour, "testserver", uris)
+	}()
+
+	require.Eventually(t, func() bool {
+		notifications.mu.Lock()
+		defer notifications.mu.Unlock()
+		return len(notifications.handlers["testserver"]) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+
+	notifications.publish("testserver", resourceNotification(
+		mcp.MethodNotificationResourceUpdated,
+		map[string]any{"uri": "file:///other"},
+	))
+	notifications.publish("testserver", resourceNotification(
+		mcp.MethodNotificationResourceUpdated,
+		map[string]any{"uri": "file:///a"},
+	))
+	notifications.publish("testserver", resourceNotification(mcp.MethodNotificationResourcesListChanged, nil))
+	notifications.publish("testserver", progressNotification("token", 1, 2, "ignored"))
+
+	require.Eventually(t, func() bool {
+		return len(parseSSE(t, w.String())) == 2
+	}, 2*time.Second, 10*time.Millisecond)
+
+	cancel()
+	select {
+	case <-finished:
+	case <-time.After(2 * time.Second):
+		t.Fatal("stream did not finish after the context was cancelled")
+	}
+
+	events := parseSSE(t, w.String())
+	require.Len(t, events, 2)
+
+	assert.Equal(t, streamEventResourceUpdated, events[0].name)
+	assert.Contains(t, events[0].data, `"uri":"file:///a"`)
+
+	assert.Equal(t, streamEventResourcesListChanged, events[1].name)
+	assert.Contains(t, events[1].data, `"updatedAt"`)
+}
+
+func TestStreamResourceUpdates_KeepAlive(t *testing.T) {
+	t.Parallel()
+
+	accessor := newMockMCPClientAccessor()
+	mockClient := &subscribingMCPClient{}
+	accessor.Add("testserver", mockClient, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := &syncBuffer{}
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		uris := []string{"file:///a"}
+		streamResourceUpdates(ctx, &sseWriter{w: w}, accessor, nil, mockClient, 10*time.Millisecond, "testserver", uris)
+	}()
+
+	require.Eventually(t, func() bool {
+		return bytes.Contains([]byte(w.String()), []byte(": keep-alive\n\n"))
+	}, 2*time.Second, 10*time.Millisecond)
+
+	cancel()
+	<-finished
+}
+
+func TestStreamResourceUpdates_KeepAliveWrappedClient(t *testing.T) {
+	t.Parallel()
+
+	accessor := &wrappingMCPClientAccessor{newMockMCPClientAccessor()}
+	accessor.Add("testserver", &subscribingMCPClient{}, nil)
+	mockClient, ok := accessor.Client("testserver")
+	require.True(t, ok)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := &syncBuffer{}
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		uris := []string{"file:///a"}
+		streamResourceUpdates(ctx, &sseWriter{w: w}, accessor, nil, mockClient, 10*time.Millisecond, "testserver", uris)
+	}()
+
+	// The stream isn't ended when the server's client is wrapped differently for each lookup.
+	require.Eventually(t, func() bool {
+		return bytes.Count([]byte(w.String()), []byte(": keep-alive\n\n")) >= 2
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.NotContains(t, w.String(), "was restarted or removed")
+
+	cancel()
+	<-finished
+}
+
+func TestStreamResourceUpdates_ServerRestarted(t *testing.T) {
+	t.Parallel()
+
+	// The server's current client isn't the one the stream subscribed with.
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("testserver", &subscribingMCPClient{}, nil)
+
+	w := &syncBuffer{}
+	uris := []string{"file:///a"}
+	streamResourceUpdates(
+		context.Background(),
+		&sseWriter{w: w},
+		accessor,
+		nil,
+		&subscribingMCPClient{},
+		10*time.Millisecond,
+		"testserver",
+		uris,
+	)
+
+	events := parseSSE(t, w.String())
+	require.Len(t, events, 1)
+	assert.Equal(t, streamEventError, events[0].name)
+	assert.JSONEq(t, `{"error":"server 'testserver' was restarted or removed"}`, events[0].data)
+}
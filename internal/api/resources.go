@@ -10,6 +10,7 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 
 	"github.com/mozilla-ai/mcpd/internal/contracts"
+	"github.com/mozilla-ai/mcpd/internal/domain"
 	errorsint "github.com/mozilla-ai/mcpd/internal/errors"
 )
 
@@ -21,8 +22,9 @@ type DomainResourceTemplate mcp.ResourceTemplate
 
 // Resources represents a collection of Resource types.
 type Resources struct {
-	Resources  []Resource `json:"resources"`
-	NextCursor string     `json:"nextCursor,omitempty"`
+	Resources  []Resource    `json:"resources"`
+	NextCursor string        `json:"nextCursor,omitempty"`
+	Cache      *ListingCache `json:"cache,omitempty"`
 }
 
 // Resource represents a known resource.
@@ -47,6 +49,7 @@ type Resource struct {
 type ResourceTemplates struct {
 	Templates  []ResourceTemplate `json:"templates"`
 	NextCursor string             `json:"nextCursor,omitempty"`
+	Cache      *ListingCache      `json:"cache,omitempty"`
 }
 
 // ResourceTemplate represents a resource template.
@@ -164,8 +167,14 @@ func (d DomainResourceTemplate) ToAPIType() (ResourceTemplate, error) {
 }
 
 // handleServerResources returns the list of resources for a given server.
+// The first page is served from the catalog when cached (containing all resources),
+// requests for subsequent pages are passed through to the server.
+// Resources which are not allowed by the server's resource allowlist are omitted.
 func handleServerResources(
+	ctx context.Context,
 	accessor contracts.MCPClientAccessor,
+	catalog contracts.MCPCatalogAccessor,
+	configs contracts.MCPServerConfigAccessor,
 	name string,
 	cursor string,
 ) (*ResourcesResponse, error) {
@@ -174,7 +183,26 @@ func handleServerResources(
 		return nil, fmt.Errorf("%w: %s", errorsint.ErrServerNotFound, name)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	var cache *ListingCache
+	if cursor == "" {
+		var listing domain.CatalogListing[mcp.Resource]
+		var cached bool
+		listing, cache, cached = cachedListing(
+			catalog,
+			name,
+			func(c domain.ServerCatalog) domain.CatalogListing[mcp.Resource] {
+				return c.Resources
+			},
+		)
+		switch {
+		case cached && listing.Unsupported:
+			return nil, fmt.Errorf("%w: %s", errorsint.ErrResourcesNotImplemented, name)
+		case cached:
+			return newResourcesResponse(allowedResources(configs, name, listing.Items), "", cache)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
 
 	req := mcp.ListResourcesRequest{}
@@ -195,8 +223,38 @@ func handleServerResources(
 		return nil, fmt.Errorf("%w: %s: no result", errorsint.ErrResourceListFailed, name)
 	}
 
-	resources := make([]Resource, 0, len(result.Resources))
-	for _, res := range result.Resources {
+	return newResourcesResponse(allowedResources(configs, name, result.Resources), result.NextCursor, cache)
+}
+
+// allowedResources returns the resources which are allowed by the server's resource allowlist.
+func allowedResources(
+	configs contracts.MCPServerConfigAccessor,
+	server string,
+	resources []mcp.Resource,
+) []mcp.Resource {
+	entry, ok := serverConfig(configs, server)
+	if !ok || len(entry.Resources) == 0 {
+		return resources
+	}
+
+	allowed := make([]mcp.Resource, 0, len(resources))
+	for _, res := range resources {
+		if entry.ResourceAllowed(res.URI) {
+			allowed = append(allowed, res)
+		}
+	}
+
+	return allowed
+}
+
+// newResourcesResponse converts resources into the API response for listing resources.
+func newResourcesResponse(
+	mcpResources []mcp.Resource,
+	nextCursor mcp.Cursor,
+	cache *ListingCache,
+) (*ResourcesResponse, error) {
+	resources := make([]Resource, 0, len(mcpResources))
+	for _, res := range mcpResources {
 		apiRes, err := DomainResource(res).ToAPIType()
 		if err != nil {
 			return nil, err
@@ -207,15 +265,22 @@ func handleServerResources(
 	resp := &ResourcesResponse{}
 	resp.Body = Resources{
 		Resources:  resources,
-		NextCursor: string(result.NextCursor),
+		NextCursor: string(nextCursor),
+		Cache:      cache,
 	}
 
 	return resp, nil
 }
 
 // handleServerResourceTemplates returns the list of resource templates for a given server.
+// The first page is served from the catalog when cached (containing all resource templates),
+// requests for subsequent pages are passed through to the server.
+// Templates which are not allowed by the server's resource allowlist are omitted.
 func handleServerResourceTemplates(
+	ctx context.Context,
 	accessor contracts.MCPClientAccessor,
+	catalog contracts.MCPCatalogAccessor,
+	configs contracts.MCPServerConfigAccessor,
 	name string,
 	cursor string,
 ) (*ResourceTemplatesResponse, error) {
@@ -224,7 +289,26 @@ func handleServerResourceTemplates(
 		return nil, fmt.Errorf("%w: %s", errorsint.ErrServerNotFound, name)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	var cache *ListingCache
+	if cursor == "" {
+		var listing domain.CatalogListing[mcp.ResourceTemplate]
+		var cached bool
+		listing, cache, cached = cachedListing(
+			catalog,
+			name,
+			func(c domain.ServerCatalog) domain.CatalogListing[mcp.ResourceTemplate] {
+				return c.ResourceTemplates
+			},
+		)
+		switch {
+		case cached && listing.Unsupported:
+			return nil, fmt.Errorf("%w: %s", errorsint.ErrResourcesNotImplemented, name)
+		case cached:
+			return newResourceTemplatesResponse(allowedResourceTemplates(configs, name, listing.Items), "", cache)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
 
 	req := mcp.ListResourceTemplatesRequest{}
@@ -245,8 +329,44 @@ func handleServerResourceTemplates(
 		return nil, fmt.Errorf("%w: %s: no result", errorsint.ErrResourceTemplateListFailed, name)
 	}
 
-	templates := make([]ResourceTemplate, 0, len(result.ResourceTemplates))
-	for _, tmpl := range result.ResourceTemplates {
+	return newResourceTemplatesResponse(
+		allowedResourceTemplates(configs, name, result.ResourceTemplates),
+		result.NextCursor,
+		cache,
+	)
+}
+
+// allowedResourceTemplates returns the resource templates which are allowed by the server's resource allowlist.
+// The raw URI template is matched against the allowlist, e.g. 'file:///workspace/{path}' is allowed by
+// 'file:///workspace/**'. Reading a resource constructed from a template is still subject to the allowlist.
+func allowedResourceTemplates(
+	configs contracts.MCPServerConfigAccessor,
+	server string,
+	templates []mcp.ResourceTemplate,
+) []mcp.ResourceTemplate {
+	entry, ok := serverConfig(configs, server)
+	if !ok || len(entry.Resources) == 0 {
+		return templates
+	}
+
+	allowed := make([]mcp.ResourceTemplate, 0, len(templates))
+	for _, tmpl := range templates {
+		if tmpl.URITemplate != nil && entry.ResourceAllowed(tmpl.URITemplate.Raw()) {
+			allowed = append(allowed, tmpl)
+		}
+	}
+
+	return allowed
+}
+
+// newResourceTemplatesResponse converts resource templates into the API response for listing resource templates.
+func newResourceTemplatesResponse(
+	mcpTemplates []mcp.ResourceTemplate,
+	nextCursor mcp.Cursor,
+	cache *ListingCache,
+) (*ResourceTemplatesResponse, error) {
+	templates := make([]ResourceTemplate, 0, len(mcpTemplates))
+	for _, tmpl := range mcpTemplates {
 		apiTmpl, err := DomainResourceTemplate(tmpl).ToAPIType()
 		if err != nil {
 			return nil, err
@@ -257,15 +377,19 @@ func handleServerResourceTemplates(
 	resp := &ResourceTemplatesResponse{}
 	resp.Body = ResourceTemplates{
 		Templates:  templates,
-		NextCursor: string(result.NextCursor),
+		NextCursor: string(nextCursor),
+		Cache:      cache,
 	}
 
 	return resp, nil
 }
 
-// handleServerResourceContent gets the content of a specific resource from a server.
+// handleServerResourceContent gets the content of a specific resource from a server,
+// provided the resource is allowed by the server's resource allowlist.
 func handleServerResourceContent(
+	ctx context.Context,
 	accessor contracts.MCPClientAccessor,
+	configs contracts.MCPServerConfigAccessor,
 	name string,
 	uri string,
 ) (*ResourceContentResponse, error) {
@@ -274,7 +398,11 @@ func handleServerResourceContent(
 		return nil, fmt.Errorf("%w: %s", errorsint.ErrServerNotFound, name)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	if entry, ok := serverConfig(configs, name); ok && !entry.ResourceAllowed(uri) {
+		return nil, fmt.Errorf("%w: %s: %s", errorsint.ErrResourceForbidden, name, uri)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
 
 	result, err := mcpClient.ReadResource(ctx, mcp.ReadResourceRequest{
@@ -319,7 +447,7 @@ func handleServerResourceContent(
 }
 
 // RegisterResourceRoutes registers resource-related routes under the servers API.
-func RegisterResourceRoutes(parentAPI huma.API, accessor contracts.MCPClientAccessor) {
+func RegisterResourceRoutes(parentAPI huma.API, accessor contracts.MCPClientAccessor, options RouteOptions) {
 	tags := []string{"Resources"}
 
 	huma.Register(
@@ -332,7 +460,14 @@ func RegisterResourceRoutes(parentAPI huma.API, accessor contracts.MCPClientAcce
 			Tags:        tags,
 		},
 		func(ctx context.Context, input *ServerResourcesRequest) (*ResourcesResponse, error) {
-			return handleServerResources(accessor, input.Name, input.Cursor)
+			return handleServerResources(
+				ctx,
+				accessor,
+				options.Catalog,
+				options.ServerConfigs,
+				input.Name,
+				input.Cursor,
+			)
 		},
 	)
 
@@ -346,7 +481,14 @@ func RegisterResourceRoutes(parentAPI huma.API, accessor contracts.MCPClientAcce
 			Tags:        tags,
 		},
 		func(ctx context.Context, input *ServerResourceTemplatesRequest) (*ResourceTemplatesResponse, error) {
-			return handleServerResourceTemplates(accessor, input.Name, input.Cursor)
+			return handleServerResourceTemplates(
+				ctx,
+				accessor,
+				options.Catalog,
+				options.ServerConfigs,
+				input.Name,
+				input.Cursor,
+			)
 		},
 	)
 
@@ -361,7 +503,8 @@ func RegisterResourceRoutes(parentAPI huma.API, accessor contracts.MCPClientAcce
 			Tags:        tags,
 		},
 		func(ctx context.Context, input *ServerResourceContentRequest) (*ResourceContentResponse, error) {
-			return handleServerResourceContent(accessor, input.Name, input.URI)
+			return handleServerResourceContent(ctx, accessor, options.ServerConfigs, input.Name, input.URI)
 		},
 	)
+	RegisterResourceSubscribeRoute(parentAPI, accessor, NewResourceSubscriptions(options), options)
 }
@@ -1,12 +1,14 @@
 package api
 
 import (
+	"context"
 	"errors"
 	"testing"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/require"
 
+	"github.com/mozilla-ai/mcpd/internal/config"
 	internalerrors "github.com/mozilla-ai/mcpd/internal/errors"
 )
 
@@ -71,7 +73,7 @@ func TestAPI_HandleServerResources_Success(t *testing.T) {
 			accessor := newMockMCPClientAccessor()
 			accessor.Add(tc.serverName, mockClient, []string{})
 
-			result, err := handleServerResources(accessor, tc.serverName, "")
+			result, err := handleServerResources(context.Background(), accessor, nil, nil, tc.serverName, "")
 
 			require.NoError(t, err)
 			require.NotNil(t, result)
@@ -99,7 +101,7 @@ func TestAPI_HandleServerResources_WithCursor(t *testing.T) {
 	accessor := newMockMCPClientAccessor()
 	accessor.Add("test-server", mockClient, []string{})
 
-	result, err := handleServerResources(accessor, "test-server", cursor)
+	result, err := handleServerResources(context.Background(), accessor, nil, nil, "test-server", cursor)
 
 	require.NoError(t, err)
 	require.NotNil(t, result)
@@ -111,7 +113,7 @@ func TestAPI_HandleServerResources_ServerNotFound(t *testing.T) {
 
 	accessor := newMockMCPClientAccessor()
 
-	result, err := handleServerResources(accessor, "nonexistent-server", "")
+	result, err := handleServerResources(context.Background(), accessor, nil, nil, "nonexistent-server", "")
 
 	require.Error(t, err)
 	require.Nil(t, result)
@@ -128,7 +130,7 @@ func TestAPI_HandleServerResources_MCPError(t *testing.T) {
 	accessor := newMockMCPClientAccessor()
 	accessor.Add("test-server", mockClient, []string{})
 
-	result, err := handleServerResources(accessor, "test-server", "")
+	result, err := handleServerResources(context.Background(), accessor, nil, nil, "test-server", "")
 
 	require.Error(t, err)
 	require.Nil(t, result)
@@ -145,13 +147,96 @@ func TestAPI_HandleServerResources_NilResult(t *testing.T) {
 	accessor := newMockMCPClientAccessor()
 	accessor.Add("test-server", mockClient, []string{})
 
-	result, err := handleServerResources(accessor, "test-server", "")
+	result, err := handleServerResources(context.Background(), accessor, nil, nil, "test-server", "")
 
 	require.Error(t, err)
 	require.Nil(t, result)
 	require.True(t, errors.Is(err, internalerrors.ErrResourceListFailed))
 }
 
+func TestAPI_HandleServerResources_Allowlist(t *testing.T) {
+	t.Parallel()
+
+	mockClient := &mockMCPClient{
+		listResourcesResult: mcp.NewListResourcesResult([]mcp.Resource{
+			{URI: "file:///workspace/README.md", Name: "readme"},
+			{URI: "file:///etc/passwd", Name: "passwd"},
+		}, ""),
+	}
+
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("test-server", mockClient, []string{})
+
+	configs := &mockServerConfigAccessor{configs: map[string]config.ServerEntry{
+		"test-server": {Name: "test-server", Resources: []string{"file:///workspace/**"}},
+	}}
+
+	result, err := handleServerResources(context.Background(), accessor, nil, configs, "test-server", "")
+
+	require.NoError(t, err)
+	require.Len(t, result.Body.Resources, 1)
+	require.Equal(t, "file:///workspace/README.md", result.Body.Resources[0].URI)
+}
+
+func TestAPI_HandleServerResourceTemplates_Allowlist(t *testing.T) {
+	t.Parallel()
+
+	mockClient := &mockMCPClient{
+		listTemplatesResult: mcp.NewListResourceTemplatesResult([]mcp.ResourceTemplate{
+			mcp.NewResourceTemplate("file:///workspace/{path}", "workspace"),
+			mcp.NewResourceTemplate("file:///{path}", "root"),
+		}, ""),
+	}
+
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("test-server", mockClient, []string{})
+
+	configs := &mockServerConfigAccessor{configs: map[string]config.ServerEntry{
+		"test-server": {Name: "test-server", Resources: []string{"file:///workspace/**"}},
+	}}
+
+	result, err := handleServerResourceTemplates(context.Background(), accessor, nil, configs, "test-server", "")
+
+	require.NoError(t, err)
+	require.Len(t, result.Body.Templates, 1)
+	require.Equal(t, "workspace", result.Body.Templates[0].Name)
+}
+
+func TestAPI_HandleServerResourceContent_Allowlist(t *testing.T) {
+	t.Parallel()
+
+	mockClient := &mockMCPClient{
+		readResourceResult: &mcp.ReadResourceResult{
+			Contents: []mcp.ResourceContents{
+				mcp.TextResourceContents{URI: "file:///workspace/README.md", Text: "Hello, world!"},
+			},
+		},
+	}
+
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("test-server", mockClient, []string{})
+
+	configs := &mockServerConfigAccessor{configs: map[string]config.ServerEntry{
+		"test-server": {Name: "test-server", Resources: []string{"file:///workspace/**"}},
+	}}
+
+	result, err := handleServerResourceContent(
+		context.Background(),
+		accessor,
+		configs,
+		"test-server",
+		"file:///workspace/README.md",
+	)
+	require.NoError(t, err)
+	require.Len(t, result.Body, 1)
+
+	for _, uri := range []string{"file:///etc/passwd", "file:///workspace/../etc/passwd"} {
+		result, err = handleServerResourceContent(context.Background(), accessor, configs, "test-server", uri)
+		require.Nil(t, result)
+		require.ErrorIs(t, err, internalerrors.ErrResourceForbidden)
+	}
+}
+
 func TestAPI_HandleServerResourceTemplates_Success(t *testing.T) {
 	t.Parallel()
 
@@ -174,7 +259,7 @@ func TestAPI_HandleServerResourceTemplates_Success(t *testing.T) {
 	accessor := newMockMCPClientAccessor()
 	accessor.Add("test-server", mockClient, []string{})
 
-	result, err := handleServerResourceTemplates(accessor, "test-server", "")
+	result, err := handleServerResourceTemplates(context.Background(), accessor, nil, nil, "test-server", "")
 
 	require.NoError(t, err)
 	require.NotNil(t, result)
@@ -189,7 +274,7 @@ func TestAPI_HandleServerResourceTemplates_ServerNotFound(t *testing.T) {
 
 	accessor := newMockMCPClientAccessor()
 
-	result, err := handleServerResourceTemplates(accessor, "nonexistent-server", "")
+	result, err := handleServerResourceTemplates(context.Background(), accessor, nil, nil, "nonexistent-server", "")
 
 	require.Error(t, err)
 	require.Nil(t, result)
@@ -206,7 +291,7 @@ func TestAPI_HandleServerResourceTemplates_ListError(t *testing.T) {
 	accessor := newMockMCPClientAccessor()
 	accessor.Add("test-server", mockClient, []string{})
 
-	result, err := handleServerResourceTemplates(accessor, "test-server", "")
+	result, err := handleServerResourceTemplates(context.Background(), accessor, nil, nil, "test-server", "")
 
 	require.Error(t, err)
 	require.Nil(t, result)
@@ -233,7 +318,7 @@ func TestAPI_HandleServerResourceContent_TextContent(t *testing.T) {
 
 	uri := "file:///test.txt"
 
-	result, err := handleServerResourceContent(accessor, "test-server", uri)
+	result, err := handleServerResourceContent(context.Background(), accessor, nil, "test-server", uri)
 
 	require.NoError(t, err)
 	require.NotNil(t, result)
@@ -263,7 +348,7 @@ func TestAPI_HandleServerResourceContent_BlobContent(t *testing.T) {
 
 	uri := "file:///image.png"
 
-	result, err := handleServerResourceContent(accessor, "test-server", uri)
+	result, err := handleServerResourceContent(context.Background(), accessor, nil, "test-server", uri)
 
 	require.NoError(t, err)
 	require.NotNil(t, result)
@@ -298,7 +383,7 @@ func TestAPI_HandleServerResourceContent_MultipleContents(t *testing.T) {
 
 	uri := "file:///multi"
 
-	result, err := handleServerResourceContent(accessor, "test-server", uri)
+	result, err := handleServerResourceContent(context.Background(), accessor, nil, "test-server", uri)
 
 	require.NoError(t, err)
 	require.NotNil(t, result)
@@ -314,7 +399,7 @@ func TestAPI_HandleServerResourceContent_ServerNotFound(t *testing.T) {
 
 	uri := "file:///test.txt"
 
-	result, err := handleServerResourceContent(accessor, "nonexistent-server", uri)
+	result, err := handleServerResourceContent(context.Background(), accessor, nil, "nonexistent-server", uri)
 
 	require.Error(t, err)
 	require.Nil(t, result)
@@ -333,7 +418,7 @@ func TestAPI_HandleServerResourceContent_ReadError(t *testing.T) {
 
 	uri := "file:///nonexistent.txt"
 
-	result, err := handleServerResourceContent(accessor, "test-server", uri)
+	result, err := handleServerResourceContent(context.Background(), accessor, nil, "test-server", uri)
 
 	require.Error(t, err)
 	require.Nil(t, result)
@@ -352,7 +437,7 @@ func TestAPI_HandleServerResourceContent_NilResult(t *testing.T) {
 
 	uri := "file:///test.txt"
 
-	result, err := handleServerResourceContent(accessor, "test-server", uri)
+	result, err := handleServerResourceContent(context.Background(), accessor, nil, "test-server", uri)
 
 	require.Error(t, err)
 	require.Nil(t, result)
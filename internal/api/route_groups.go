@@ -0,0 +1,38 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// routeGroupsContextKey is the context key for the groups of routes served to a request.
+type routeGroupsContextKey struct{}
+
+// ContextWithRouteGroups returns a copy of the context which limits the requests made with it to the routes in the
+// groups (see config.RouteGroups), e.g. for the requests received on a listener which only serves admin routes.
+func ContextWithRouteGroups(ctx context.Context, groups []string) context.Context {
+	return context.WithValue(ctx, routeGroupsContextKey{}, slices.Clone(groups))
+}
+
+// routeGroupsFromContext returns the groups of routes served to the request, when limited.
+func routeGroupsFromContext(ctx context.Context) ([]string, bool) {
+	groups, ok := ctx.Value(routeGroupsContextKey{}).([]string)
+	return groups, ok
+}
+
+// routeGroupMiddleware responds with 404 Not Found to requests for the routes in the group, when they were received on
+// a listener which doesn't serve the group, so the routes appear not to exist.
+// Requests whose context isn't limited to some groups are always served.
+func routeGroupMiddleware(api huma.API, group string) func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		if groups, ok := routeGroupsFromContext(ctx.Context()); ok && !slices.Contains(groups, group) {
+			_ = huma.WriteErr(api, ctx, http.StatusNotFound, http.StatusText(http.StatusNotFound))
+			return
+		}
+
+		next(ctx)
+	}
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/domain"
+)
+
+func TestRouteGroups(t *testing.T) {
+	t.Parallel()
+
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("time", &mockMCPClient{
+		listToolsResult: &mcp.ListToolsResult{Tools: []mcp.Tool{{Name: "get_current_time"}}},
+	}, []string{"get_current_time"})
+	monitor := &mockHealthMonitor{servers: map[string]domain.ServerHealth{
+		"time": {Name: "time", Status: domain.HealthStatusOK},
+	}}
+	controller := &mockLogLevelController{levels: map[string]mcp.LoggingLevel{}}
+
+	_, testAPI := humatest.New(t, huma.DefaultConfig("mcpd docs", APIVersion))
+	_, err := RegisterRoutes(testAPI, monitor, accessor, WithLogLevelController(controller))
+	require.NoError(t, err)
+
+	admin := ContextWithRouteGroups(context.Background(), []string{config.RouteGroupAdmin})
+	data := ContextWithRouteGroups(context.Background(), []string{config.RouteGroupData})
+	all := context.Background()
+	ok, notFound := http.StatusOK, http.StatusNotFound
+	logLevel := "/servers/time/log-level"
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		method string
+		path   string
+		status int
+	}{
+		{name: "tools on data", ctx: data, method: http.MethodGet, path: "/servers/time/tools", status: ok},
+		{name: "tools on admin", ctx: admin, method: http.MethodGet, path: "/servers/time/tools", status: notFound},
+		{name: "health on admin", ctx: admin, method: http.MethodGet, path: "/health/servers", status: ok},
+		{name: "health on data", ctx: data, method: http.MethodGet, path: "/health/servers", status: notFound},
+		{name: "approvals on admin", ctx: admin, method: http.MethodGet, path: "/approvals", status: ok},
+		{name: "approvals on data", ctx: data, method: http.MethodGet, path: "/approvals", status: notFound},
+		{name: "log level on admin", ctx: admin, method: http.MethodPut, path: logLevel, status: ok},
+		{name: "log level on data", ctx: data, method: http.MethodPut, path: logLevel, status: notFound},
+		{name: "tools unlimited", ctx: all, method: http.MethodGet, path: "/servers/time/tools", status: ok},
+		{name: "approvals unlimited", ctx: all, method: http.MethodGet, path: "/approvals", status: ok},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var body []any
+			if tc.method == http.MethodPut {
+				body = append(body, map[string]any{"level": "debug"})
+			}
+
+			resp := testAPI.DoCtx(tc.ctx, tc.method, "/api/v1"+tc.path, body...)
+			require.Equal(t, tc.status, resp.Code, resp.Body.String())
+		})
+	}
+}
@@ -2,12 +2,16 @@ package api
 
 import (
 	"fmt"
+	"net/http"
 	"net/url"
 	"reflect"
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/hashicorp/go-hclog"
 
+	"github.com/mozilla-ai/mcpd/internal/auth"
+	"github.com/mozilla-ai/mcpd/internal/config"
 	"github.com/mozilla-ai/mcpd/internal/contracts"
 )
 
@@ -21,11 +25,79 @@ type RouteOption func(*RouteOptions)
 type RouteOptions struct {
 	// ToolCallTimeout bounds how long a single MCP tool call may run.
 	ToolCallTimeout time.Duration
+
+	// JobTimeout bounds how long a single asynchronous MCP tool call may run.
+	JobTimeout time.Duration
+
+	// JobMaxFinished is the maximum number of finished asynchronous tool calls that are retained.
+	JobMaxFinished int
+
+	// JobRetention is how long finished asynchronous tool calls are retained.
+	JobRetention time.Duration
+
+	// NotificationSubscriber provides notifications sent by MCP servers (e.g. progress).
+	// Optional, when nil progress is not reported.
+	NotificationSubscriber contracts.MCPNotificationSubscriber
+
+	// ServerConfigs provides the configuration of MCP servers (e.g. per-server and per-tool timeouts).
+	// Optional, when nil only ToolCallTimeout is applied.
+	ServerConfigs contracts.MCPServerConfigAccessor
+
+	// Catalog provides cached listings of the tools, prompts, and resources offered by MCP servers.
+	// Optional, when nil (or a listing is not cached) listings are requested from the MCP server.
+	Catalog contracts.MCPCatalogAccessor
+
+	// Processes provides details of the running MCP server processes (e.g. capabilities, PID).
+	// Optional, when nil server details are limited to their configuration and tools.
+	Processes contracts.MCPServerProcessAccessor
+
+	// LogLevels changes the log levels of MCP servers.
+	// Optional, when nil log levels can't be changed via the API.
+	LogLevels contracts.MCPLogLevelController
+
+	// BatchConcurrency is the maximum number of tool calls from a single batch that are made concurrently.
+	BatchConcurrency int
+
+	// BatchHandler handles the individual tool call requests that make up a batch,
+	// so they are processed by the same middleware (e.g. plugins) as requests made directly by clients.
+	// Optional, when nil tool calls within a batch are made directly and bypass any middleware.
+	BatchHandler http.Handler
+
+	// Workflows are the configured workflows, exposed as tools composed of calls to the tools of MCP servers.
+	Workflows []config.WorkflowEntry
+
+	// ApprovalTimeout is how long a tool call is held pending approval before it expires.
+	ApprovalTimeout time.Duration
+
+	// ApprovalMaxDecided is the maximum number of decided tool call approvals that are retained.
+	ApprovalMaxDecided int
+
+	// ApprovalRetention is how long decided tool call approvals are retained.
+	ApprovalRetention time.Duration
+
+	// Elicitations holds the requests for user input made by MCP servers.
+	// Optional, when nil a store is created which no MCP server makes requests to.
+	Elicitations *ElicitationStore
+
+	// Authenticator identifies the callers of the API, whose requests are authorized against their scope.
+	// Optional, when nil requests are not authenticated.
+	Authenticator auth.Authenticator
+
+	// Logger is used to audit log decisions made by the API (e.g. tool call approvals).
+	// Optional, when nil nothing is logged.
+	Logger hclog.Logger
 }
 
 func newRouteOptions(opts ...RouteOption) RouteOptions {
 	options := RouteOptions{
-		ToolCallTimeout: DefaultToolCallTimeout(),
+		ToolCallTimeout:    DefaultToolCallTimeout(),
+		JobTimeout:         DefaultJobTimeout(),
+		JobMaxFinished:     DefaultJobMaxFinished(),
+		JobRetention:       DefaultJobRetention(),
+		BatchConcurrency:   DefaultBatchConcurrency(),
+		ApprovalTimeout:    DefaultApprovalTimeout(),
+		ApprovalMaxDecided: DefaultApprovalMaxDecided(),
+		ApprovalRetention:  DefaultApprovalRetention(),
 	}
 
 	for _, opt := range opts {
@@ -42,6 +114,47 @@ func DefaultToolCallTimeout() time.Duration {
 	return 15 * time.Second
 }
 
+// DefaultJobTimeout returns the default timeout for asynchronous MCP tool calls.
+func DefaultJobTimeout() time.Duration {
+	return time.Hour
+}
+
+// DefaultJobMaxFinished returns the default number of finished asynchronous tool calls that are retained.
+func DefaultJobMaxFinished() int {
+	return 100
+}
+
+// DefaultJobRetention returns the default duration finished asynchronous tool calls are retained.
+func DefaultJobRetention() time.Duration {
+	return time.Hour
+}
+
+// DefaultBatchConcurrency returns the default number of tool calls from a single batch that are made concurrently.
+func DefaultBatchConcurrency() int {
+	return 8
+}
+
+// DefaultApprovalTimeout returns the default duration a tool call is held pending approval before it expires.
+func DefaultApprovalTimeout() time.Duration {
+	return 15 * time.Minute
+}
+
+// DefaultApprovalMaxDecided returns the default number of decided tool call approvals that are retained.
+func DefaultApprovalMaxDecided() int {
+	return 100
+}
+
+// DefaultApprovalRetention returns the default duration decided tool call approvals are retained.
+func DefaultApprovalRetention() time.Duration {
+	return time.Hour
+}
+
+// DefaultElicitationTimeout returns the default duration an elicitation is held pending a response before it is
+// declined.
+func DefaultElicitationTimeout() time.Duration {
+	return 10 * time.Minute
+}
+
 // RegisterRoutes registers all API routes on the provided Huma router.
 // This is the single source of truth for the API route structure.
 // Returns the API path prefix (e.g., "/api/v1") under which the routes are created.
@@ -74,8 +187,61 @@ func RegisterRoutes(
 
 	// Group all routes under the /api/{version} prefix.
 	versionedGroup := huma.NewGroup(router, apiPathPrefix)
-	RegisterHealthRoutes(versionedGroup, healthTracker, "/health")
-	RegisterServerRoutes(versionedGroup, clientManager, "/servers", routeOptions)
+	if routeOptions.Authenticator != nil {
+		registerAuth(router, versionedGroup, routeOptions.Authenticator)
+	}
+
+	// Routes are grouped so the daemon can serve the groups on separate listeners,
+	// e.g. keeping operational routes on a loopback address while tool calls are served to agents.
+	dataGroup := huma.NewGroup(versionedGroup)
+	dataGroup.UseMiddleware(routeGroupMiddleware(dataGroup, config.RouteGroupData))
+	adminGroup := huma.NewGroup(versionedGroup)
+	adminGroup.UseMiddleware(routeGroupMiddleware(adminGroup, config.RouteGroupAdmin))
+
+	RegisterHealthRoutes(adminGroup, healthTracker, "/health")
+	jobsPath, err := url.JoinPath(apiPathPrefix, "jobs")
+	if err != nil {
+		return "", fmt.Errorf("failed to construct jobs path: %w", err)
+	}
+
+	approvalsPath, err := url.JoinPath(apiPathPrefix, "approvals")
+	if err != nil {
+		return "", fmt.Errorf("failed to construct approvals path: %w", err)
+	}
+
+	jobs := NewJobStore(jobsPath, routeOptions)
+	approvals := NewApprovalStore(approvalsPath, routeOptions)
+	RegisterServerRoutes(dataGroup, clientManager, jobs, approvals, "/servers", routeOptions)
+	RegisterToolSearchRoutes(dataGroup, clientManager, "/tools", routeOptions)
+
+	toolCallPathPrefix, err := url.JoinPath(apiPathPrefix, "servers")
+	if err != nil {
+		return "", fmt.Errorf("failed to construct servers path: %w", err)
+	}
+	RegisterBatchRoutes(dataGroup, clientManager, "/batch", toolCallPathPrefix, routeOptions)
+
+	workflows, err := newWorkflows(routeOptions.Workflows)
+	if err != nil {
+		return "", fmt.Errorf("failed to create workflows: %w", err)
+	}
+	RegisterWorkflowRoutes(dataGroup, clientManager, workflows, "/workflows", routeOptions)
+	RegisterJobRoutes(dataGroup, jobs, "/jobs")
+
+	// Changing the log level of servers is operational, though remains limited to the servers in the caller's scope.
+	logLevelGroup := huma.NewGroup(adminGroup, "/servers")
+	logLevelGroup.UseMiddleware(serverScopeMiddleware(logLevelGroup))
+	RegisterLogLevelRoutes(logLevelGroup, clientManager, routeOptions)
+
+	// Approvals and elicitations are decided by operators, rather than callers limited to some servers or tools.
+	operatorGroup := huma.NewGroup(adminGroup)
+	operatorGroup.UseMiddleware(unrestrictedMiddleware(operatorGroup))
+	RegisterApprovalRoutes(operatorGroup, approvals, "/approvals")
+
+	elicitations := routeOptions.Elicitations
+	if elicitations == nil {
+		elicitations = NewElicitationStore(routeOptions.Logger, DefaultElicitationTimeout())
+	}
+	RegisterElicitationRoutes(operatorGroup, elicitations, "/elicitations")
 
 	return apiPathPrefix, nil
 }
@@ -86,3 +252,110 @@ func WithToolCallTimeout(timeout time.Duration) RouteOption {
 		o.ToolCallTimeout = timeout
 	}
 }
+
+// WithJobTimeout sets the timeout applied to asynchronous MCP tool calls.
+func WithJobTimeout(timeout time.Duration) RouteOption {
+	return func(o *RouteOptions) {
+		o.JobTimeout = timeout
+	}
+}
+
+// WithJobRetention sets how many finished asynchronous tool calls are retained, and for how long.
+func WithJobRetention(maxFinished int, retention time.Duration) RouteOption {
+	return func(o *RouteOptions) {
+		o.JobMaxFinished = maxFinished
+		o.JobRetention = retention
+	}
+}
+
+// WithNotificationSubscriber sets the source of notifications sent by MCP servers.
+func WithNotificationSubscriber(subscriber contracts.MCPNotificationSubscriber) RouteOption {
+	return func(o *RouteOptions) {
+		o.NotificationSubscriber = subscriber
+	}
+}
+
+// WithServerConfigAccessor sets the source of MCP server configuration.
+func WithServerConfigAccessor(accessor contracts.MCPServerConfigAccessor) RouteOption {
+	return func(o *RouteOptions) {
+		o.ServerConfigs = accessor
+	}
+}
+
+// WithCatalogAccessor sets the source of cached MCP server listings.
+func WithCatalogAccessor(accessor contracts.MCPCatalogAccessor) RouteOption {
+	return func(o *RouteOptions) {
+		o.Catalog = accessor
+	}
+}
+
+// WithServerProcessAccessor sets the source of details of the running MCP server processes.
+func WithServerProcessAccessor(accessor contracts.MCPServerProcessAccessor) RouteOption {
+	return func(o *RouteOptions) {
+		o.Processes = accessor
+	}
+}
+
+// WithLogLevelController sets the controller used to change the log levels of MCP servers.
+func WithLogLevelController(controller contracts.MCPLogLevelController) RouteOption {
+	return func(o *RouteOptions) {
+		o.LogLevels = controller
+	}
+}
+
+// WithAuthenticator sets the authenticator used to identify the callers of the API.
+func WithAuthenticator(authenticator auth.Authenticator) RouteOption {
+	return func(o *RouteOptions) {
+		o.Authenticator = authenticator
+	}
+}
+
+// WithBatchConcurrency sets the maximum number of tool calls from a single batch that are made concurrently.
+func WithBatchConcurrency(concurrency int) RouteOption {
+	return func(o *RouteOptions) {
+		o.BatchConcurrency = concurrency
+	}
+}
+
+// WithBatchHandler sets the handler used to route the individual tool calls that make up a batch.
+func WithBatchHandler(handler http.Handler) RouteOption {
+	return func(o *RouteOptions) {
+		o.BatchHandler = handler
+	}
+}
+
+// WithWorkflows sets the workflows exposed by the API.
+func WithWorkflows(workflows []config.WorkflowEntry) RouteOption {
+	return func(o *RouteOptions) {
+		o.Workflows = workflows
+	}
+}
+
+// WithApprovalTimeout sets how long a tool call is held pending approval before it expires.
+func WithApprovalTimeout(timeout time.Duration) RouteOption {
+	return func(o *RouteOptions) {
+		o.ApprovalTimeout = timeout
+	}
+}
+
+// WithApprovalRetention sets how many decided tool call approvals are retained, and for how long.
+func WithApprovalRetention(maxDecided int, retention time.Duration) RouteOption {
+	return func(o *RouteOptions) {
+		o.ApprovalMaxDecided = maxDecided
+		o.ApprovalRetention = retention
+	}
+}
+
+// WithElicitationStore sets the store of requests for user input made by MCP servers.
+func WithElicitationStore(store *ElicitationStore) RouteOption {
+	return func(o *RouteOptions) {
+		o.Elicitations = store
+	}
+}
+
+// WithLogger sets the logger used to audit log decisions made by the API.
+func WithLogger(logger hclog.Logger) RouteOption {
+	return func(o *RouteOptions) {
+		o.Logger = logger
+	}
+}
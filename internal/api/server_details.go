@@ -0,0 +1,346 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/mozilla-ai/mcpd/internal/contracts"
+	"github.com/mozilla-ai/mcpd/internal/domain"
+	"github.com/mozilla-ai/mcpd/internal/errors"
+)
+
+const (
+	// serverDetailNames returns only the names of servers.
+	serverDetailNames serverDetailLevel = "names"
+
+	// serverDetailSummary returns the configured package, server info, and process of servers.
+	serverDetailSummary serverDetailLevel = "summary"
+
+	// serverDetailFull returns all details, including capabilities, instructions, and tools.
+	serverDetailFull serverDetailLevel = "full"
+)
+
+// serverDetailLevel defines the amount of information to return about servers.
+type serverDetailLevel string
+
+// Normalize handles case-insensitivity and trimming, providing a safe default.
+func (s serverDetailLevel) Normalize() serverDetailLevel {
+	normalized := serverDetailLevel(strings.ToLower(strings.TrimSpace(string(s))))
+	switch normalized {
+	case serverDetailNames, serverDetailSummary, serverDetailFull:
+		return normalized
+	default:
+		return serverDetailNames // Safe default, matching the original response.
+	}
+}
+
+// ServersRequest represents the incoming API request for listing servers.
+type ServersRequest struct {
+	// Detail specifies the level of detail to return (names, summary, or full).
+	// NOTE: This field is not used by the handler itself; it exists solely for OpenAPI documentation.
+	// The actual filtering is performed by serverFieldSelectTransformer, which reads the query parameter directly.
+	Detail serverDetailLevel `default:"names" doc:"Level of detail to return" enum:"names,summary,full" query:"detail"`
+}
+
+// ServerDetailsRequest represents the incoming API request for the details of a server.
+type ServerDetailsRequest struct {
+	Name string `doc:"Name of the server" example:"time" path:"name"`
+}
+
+// ServersDetailsResponse represents the wrapped API response for listing servers with their details.
+type ServersDetailsResponse struct {
+	Body []ServerDetails
+}
+
+// ServerDetailsResponse represents the wrapped API response for the details of a server.
+type ServerDetailsResponse struct {
+	Body ServerDetails
+}
+
+// ServerDetails describes a running MCP server.
+type ServerDetails struct {
+	// Name of the server.
+	Name string `json:"name"`
+
+	// Package is the configured package of the server, without its runtime and version.
+	Package string `doc:"Configured package" example:"mcp-server-time" json:"package,omitempty"`
+
+	// Runtime is the configured runtime of the server.
+	Runtime string `doc:"Configured runtime" example:"uvx" json:"runtime,omitempty"`
+
+	// Version is the configured version of the package.
+	Version string `doc:"Configured package version" example:"2025.8.4" json:"version,omitempty"`
+
+	// ServerInfo is the name and version the server reported when it was initialized.
+	ServerInfo *ServerInfo `doc:"Name and version reported by the server" json:"serverInfo,omitempty"`
+
+	// ProtocolVersion is the MCP protocol version negotiated with the server.
+	ProtocolVersion string `doc:"Negotiated MCP protocol version" example:"2025-06-18" json:"protocolVersion,omitempty"`
+
+	// Capabilities are the capabilities the server declared when it was initialized.
+	Capabilities *ServerCapabilities `doc:"Capabilities declared by the server" json:"capabilities,omitempty"`
+
+	// Instructions describe how to use the server and its features, as provided by the server.
+	Instructions string `doc:"Instructions provided by the server" json:"instructions,omitempty"`
+
+	// Tools are the allowed tools, and the tools the server offers.
+	Tools *ServerTools `doc:"Allowed and available tools" json:"tools,omitempty"`
+
+	// Process describes the server's process.
+	Process *ServerProcess `doc:"Process of the server" json:"process,omitempty"`
+}
+
+// ServerInfo is the name and version of an MCP server implementation.
+type ServerInfo struct {
+	Name    string `json:"name"`
+	Title   string `json:"title,omitempty"`
+	Version string `json:"version"`
+}
+
+// ServerCapabilities are the capabilities an MCP server declared when it was initialized.
+type ServerCapabilities struct {
+	// Tools is set when the server offers tools.
+	Tools *ListChangedCapability `json:"tools,omitempty"`
+
+	// Prompts is set when the server offers prompts.
+	Prompts *ListChangedCapability `json:"prompts,omitempty"`
+
+	// Resources is set when the server offers resources.
+	Resources *ResourcesCapability `json:"resources,omitempty"`
+
+	// Logging indicates the server can send log messages.
+	Logging bool `json:"logging"`
+
+	// Completions indicates the server can suggest values for arguments.
+	Completions bool `json:"completions"`
+
+	// Experimental are non-standard capabilities declared by the server.
+	Experimental map[string]any `json:"experimental,omitempty"`
+}
+
+// ListChangedCapability describes whether a server notifies of changes to a listing.
+type ListChangedCapability struct {
+	ListChanged bool `json:"listChanged"`
+}
+
+// ResourcesCapability describes the resource features offered by a server.
+type ResourcesCapability struct {
+	Subscribe   bool `json:"subscribe"`
+	ListChanged bool `json:"listChanged"`
+}
+
+// ServerTools are the tools allowed on a server, and the tools it offers.
+type ServerTools struct {
+	// Allowed are the tools which are allowed to be called.
+	Allowed []string `doc:"Tools which are allowed to be called" json:"allowed"`
+
+	// Available are all the tools offered by the server, omitted when they aren't cached.
+	Available []string `doc:"Tools offered by the server" json:"available,omitempty"`
+}
+
+// ServerProcess describes the process of a running MCP server.
+type ServerProcess struct {
+	// PID is the process ID of the server.
+	PID int `doc:"Process ID" json:"pid,omitempty"`
+
+	// StartedAt is when the server was started.
+	StartedAt time.Time `doc:"Time the server was started" json:"startedAt"`
+
+	// Restarts is the number of times the server has been restarted by the daemon (e.g. on reload).
+	Restarts int `doc:"Number of times the server has been restarted" json:"restarts"`
+}
+
+// DomainServerProcess wraps domain.ServerProcess for API conversion.
+type DomainServerProcess domain.ServerProcess
+
+// DomainServerCapabilities wraps mcp.ServerCapabilities for API conversion.
+type DomainServerCapabilities mcp.ServerCapabilities
+
+// ToAPIType converts domain server capabilities to API server capabilities.
+func (d DomainServerCapabilities) ToAPIType() (ServerCapabilities, error) {
+	capabilities := ServerCapabilities{
+		Logging:      d.Logging != nil,
+		Completions:  d.Completions != nil,
+		Experimental: d.Experimental,
+	}
+	if d.Tools != nil {
+		capabilities.Tools = &ListChangedCapability{ListChanged: d.Tools.ListChanged}
+	}
+	if d.Prompts != nil {
+		capabilities.Prompts = &ListChangedCapability{ListChanged: d.Prompts.ListChanged}
+	}
+	if d.Resources != nil {
+		capabilities.Resources = &ResourcesCapability{
+			Subscribe:   d.Resources.Subscribe,
+			ListChanged: d.Resources.ListChanged,
+		}
+	}
+
+	return capabilities, nil
+}
+
+// ToAPIType converts a domain server process to an API server process.
+func (d DomainServerProcess) ToAPIType() (ServerProcess, error) {
+	return ServerProcess{
+		PID:       d.PID,
+		StartedAt: d.StartedAt,
+		Restarts:  d.Restarts,
+	}, nil
+}
+
+// handleServersDetails returns the details of all running MCP servers within the caller's scope.
+// This always returns full details, which can be filtered by the transformer.
+func handleServersDetails(
+	ctx context.Context,
+	accessor contracts.MCPClientAccessor,
+	options RouteOptions,
+) (*ServersDetailsResponse, error) {
+	names := accessor.List()
+	slices.Sort(names)
+
+	servers := make([]ServerDetails, 0, len(names))
+	for _, name := range names {
+		if authorizeServer(ctx, name) != nil {
+			continue
+		}
+
+		details, err := serverDetails(accessor, options, name)
+		if err != nil {
+			return nil, err
+		}
+		servers = append(servers, details)
+	}
+
+	return &ServersDetailsResponse{Body: servers}, nil
+}
+
+// handleServerDetails returns the details of a running MCP server.
+func handleServerDetails(
+	accessor contracts.MCPClientAccessor,
+	options RouteOptions,
+	name string,
+) (*ServerDetailsResponse, error) {
+	if _, ok := accessor.Client(name); !ok {
+		return nil, fmt.Errorf("%w: %s", errors.ErrServerNotFound, name)
+	}
+
+	details, err := serverDetails(accessor, options, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ServerDetailsResponse{Body: details}, nil
+}
+
+// serverDetails collects the details of the named server from its configuration, tools, and process.
+func serverDetails(accessor contracts.MCPClientAccessor, options RouteOptions, name string) (ServerDetails, error) {
+	details := ServerDetails{Name: name}
+
+	if entry, ok := serverConfig(options.ServerConfigs, name); ok {
+		details.Package = entry.PackageName()
+		details.Runtime = entry.Runtime()
+		details.Version = entry.PackageVersion()
+	}
+
+	allowed, _ := accessor.Tools(name)
+	details.Tools = &ServerTools{Allowed: slices.Sorted(slices.Values(allowed))}
+	selectTools := func(c domain.ServerCatalog) domain.CatalogListing[mcp.Tool] { return c.Tools }
+	listing, _, cached := cachedListing(options.Catalog, name, selectTools)
+	if cached {
+		details.Tools.Available = make([]string, 0, len(listing.Items))
+		for _, tool := range listing.Items {
+			details.Tools.Available = append(details.Tools.Available, tool.Name)
+		}
+		slices.Sort(details.Tools.Available)
+	}
+
+	if options.Processes == nil {
+		return details, nil
+	}
+
+	process, ok := options.Processes.ServerProcess(name)
+	if !ok {
+		return details, nil
+	}
+
+	capabilities, err := DomainServerCapabilities(process.Capabilities).ToAPIType()
+	if err != nil {
+		return ServerDetails{}, err
+	}
+
+	apiProcess, err := DomainServerProcess(process).ToAPIType()
+	if err != nil {
+		return ServerDetails{}, err
+	}
+
+	details.ServerInfo = &ServerInfo{
+		Name:    process.Info.Name,
+		Title:   process.Info.Title,
+		Version: process.Info.Version,
+	}
+	details.ProtocolVersion = process.ProtocolVersion
+	details.Capabilities = &capabilities
+	details.Instructions = process.Instructions
+	details.Process = &apiProcess
+
+	return details, nil
+}
+
+// serverFieldSelectTransformer transforms server listings based on the detail query parameter.
+// It filters the response to return only the requested level of detail: names, summary, or full.
+func serverFieldSelectTransformer(ctx huma.Context, _ string, v any) (any, error) {
+	// Huma passes the Body field to transformers, not the full response.
+	servers, ok := v.([]ServerDetails)
+	if !ok {
+		return v, nil // Not our type, pass through.
+	}
+
+	switch serverDetailLevel(ctx.Query(queryParamDetail)).Normalize() {
+	case serverDetailFull:
+		return v, nil
+	case serverDetailSummary:
+		summary := make([]ServerDetails, len(servers))
+		for i, srv := range servers {
+			summary[i] = ServerDetails{
+				Name:            srv.Name,
+				Package:         srv.Package,
+				Runtime:         srv.Runtime,
+				Version:         srv.Version,
+				ServerInfo:      srv.ServerInfo,
+				ProtocolVersion: srv.ProtocolVersion,
+				Process:         srv.Process,
+			}
+		}
+		return summary, nil
+	default:
+		names := make([]string, len(servers))
+		for i, srv := range servers {
+			names[i] = srv.Name
+		}
+		return names, nil
+	}
+}
+
+// RegisterServerDetailsRoutes registers the server details route under the servers API.
+func RegisterServerDetailsRoutes(parentAPI huma.API, accessor contracts.MCPClientAccessor, options RouteOptions) {
+	huma.Register(
+		parentAPI,
+		huma.Operation{
+			OperationID: "getServer",
+			Method:      "GET",
+			Path:        "/{name}",
+			Summary:     "Get server details",
+			Description: "Returns the server's configuration, capabilities, instructions, tools, and process",
+			Tags:        []string{"Servers"},
+		},
+		func(ctx context.Context, input *ServerDetailsRequest) (*ServerDetailsResponse, error) {
+			return handleServerDetails(accessor, options, input.Name)
+		},
+	)
+}
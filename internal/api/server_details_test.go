@@ -0,0 +1,182 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/domain"
+	internalerrors "github.com/mozilla-ai/mcpd/internal/errors"
+)
+
+// mockServerProcessAccessor provides server process details for tests.
+type mockServerProcessAccessor struct {
+	processes map[string]domain.ServerProcess
+}
+
+func (m *mockServerProcessAccessor) ServerProcess(name string) (domain.ServerProcess, bool) {
+	process, ok := m.processes[name]
+	return process, ok
+}
+
+func TestHandleServerDetails(t *testing.T) {
+	t.Parallel()
+
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("time", &mockMCPClient{}, []string{"get_current_time"})
+
+	startedAt := time.Now().Add(-time.Hour)
+	refreshedAt := time.Now()
+	options := newRouteOptions(
+		WithServerConfigAccessor(&mockServerConfigAccessor{configs: map[string]config.ServerEntry{
+			"time": {Name: "time", Package: "uvx::mcp-server-time@2025.8.4"},
+		}}),
+		WithCatalogAccessor(&mockCatalogAccessor{catalogs: map[string]domain.ServerCatalog{
+			"time": {
+				Tools: domain.CatalogListing[mcp.Tool]{
+					Items:       []mcp.Tool{{Name: "get_current_time"}, {Name: "convert_time"}},
+					RefreshedAt: &refreshedAt,
+				},
+			},
+		}}),
+		WithServerProcessAccessor(&mockServerProcessAccessor{processes: map[string]domain.ServerProcess{
+			"time": {
+				Info:            mcp.Implementation{Name: "mcp-time", Version: "1.9.4"},
+				ProtocolVersion: "2025-06-18",
+				Capabilities: mcp.ServerCapabilities{
+					Tools: &struct {
+						ListChanged bool `json:"listChanged,omitempty"`
+					}{ListChanged: true},
+					Completions: &struct{}{},
+				},
+				Instructions: "Use get_current_time to find out the time.",
+				PID:          4242,
+				StartedAt:    startedAt,
+				Restarts:     2,
+			},
+		}}),
+	)
+
+	resp, err := handleServerDetails(accessor, options, "time")
+	require.NoError(t, err)
+	require.Equal(t, ServerDetails{
+		Name:            "time",
+		Package:         "mcp-server-time",
+		Runtime:         "uvx",
+		Version:         "2025.8.4",
+		ServerInfo:      &ServerInfo{Name: "mcp-time", Version: "1.9.4"},
+		ProtocolVersion: "2025-06-18",
+		Capabilities: &ServerCapabilities{
+			Tools:       &ListChangedCapability{ListChanged: true},
+			Completions: true,
+		},
+		Instructions: "Use get_current_time to find out the time.",
+		Tools: &ServerTools{
+			Allowed:   []string{"get_current_time"},
+			Available: []string{"convert_time", "get_current_time"},
+		},
+		Process: &ServerProcess{PID: 4242, StartedAt: startedAt, Restarts: 2},
+	}, resp.Body)
+}
+
+func TestHandleServerDetails_WithoutProcess(t *testing.T) {
+	t.Parallel()
+
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("time", &mockMCPClient{}, []string{"get_current_time"})
+
+	resp, err := handleServerDetails(accessor, newRouteOptions(), "time")
+	require.NoError(t, err)
+	require.Equal(t, ServerDetails{
+		Name:  "time",
+		Tools: &ServerTools{Allowed: []string{"get_current_time"}},
+	}, resp.Body)
+
+	_, err = handleServerDetails(accessor, newRouteOptions(), "github")
+	require.ErrorIs(t, err, internalerrors.ErrServerNotFound)
+}
+
+func TestHandleServersDetails_Sorted(t *testing.T) {
+	t.Parallel()
+
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("time", &mockMCPClient{}, []string{})
+	accessor.Add("github", &mockMCPClient{}, []string{})
+
+	resp, err := handleServersDetails(context.Background(), accessor, newRouteOptions())
+	require.NoError(t, err)
+	require.Len(t, resp.Body, 2)
+	require.Equal(t, "github", resp.Body[0].Name)
+	require.Equal(t, "time", resp.Body[1].Name)
+}
+
+func TestServerFieldSelectTransformer(t *testing.T) {
+	t.Parallel()
+
+	servers := []ServerDetails{
+		{
+			Name:         "time",
+			Package:      "mcp-server-time",
+			Runtime:      "uvx",
+			Version:      "2025.8.4",
+			Instructions: "Use get_current_time to find out the time.",
+			Capabilities: &ServerCapabilities{Logging: true},
+			Tools:        &ServerTools{Allowed: []string{"get_current_time"}},
+			Process:      &ServerProcess{PID: 4242},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		detail   string
+		expected any
+	}{
+		{
+			name:     "default returns names",
+			expected: []string{"time"},
+		},
+		{
+			name:     "invalid returns names",
+			detail:   "everything",
+			expected: []string{"time"},
+		},
+		{
+			name:   "summary",
+			detail: "Summary",
+			expected: []ServerDetails{
+				{
+					Name:    "time",
+					Package: "mcp-server-time",
+					Runtime: "uvx",
+					Version: "2025.8.4",
+					Process: &ServerProcess{PID: 4242},
+				},
+			},
+		},
+		{
+			name:     "full",
+			detail:   "full",
+			expected: servers,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockCtx := &mockHumaContext{queryParams: map[string]string{queryParamDetail: tc.detail}}
+			result, err := serverFieldSelectTransformer(mockCtx, "200", servers)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, result)
+		})
+	}
+
+	// Other responses pass through.
+	result, err := serverFieldSelectTransformer(&mockHumaContext{}, "200", []string{"time"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"time"}, result)
+}
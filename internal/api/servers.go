@@ -8,18 +8,16 @@ import (
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/mark3labs/mcp-go/client"
 	"github.com/mark3labs/mcp-go/mcp"
 
+	"github.com/mozilla-ai/mcpd/internal/config"
 	"github.com/mozilla-ai/mcpd/internal/contracts"
+	"github.com/mozilla-ai/mcpd/internal/domain"
 	"github.com/mozilla-ai/mcpd/internal/errors"
 	"github.com/mozilla-ai/mcpd/internal/filter"
 )
 
-// ServersResponse represents the wrapped API response for a list of servers.
-type ServersResponse struct {
-	Body []string
-}
-
 // ServerToolsRequest represents the incoming API request for giving the configured tools schemas for a server.
 type ServerToolsRequest struct {
 	Name string `doc:"Name of the server to lookup tools for" example:"time" path:"name"`
@@ -32,20 +30,26 @@ type ServerToolsRequest struct {
 
 // ServerToolCallRequest represents the incoming API request to call a tool on a particular server.
 type ServerToolCallRequest struct {
-	Server string         `doc:"Name of the server"       example:"time"             path:"server"`
-	Tool   string         `doc:"Name of the tool to call" example:"get_current_time" path:"tool"`
-	Body   map[string]any `doc:"Body of the tool to call"                            path:"body"`
+	Server  string         `doc:"Name of the server"       example:"time"             path:"server"`
+	Tool    string         `doc:"Name of the tool to call" example:"get_current_time" path:"tool"`
+	Async   bool           `doc:"Run the tool call as a background job and return the job ID" query:"async"`
+	Timeout string         `doc:"Tool call timeout (e.g. 30s), capped by the configured timeout" example:"30s" header:"X-Mcpd-Timeout"`
+	Body    map[string]any `doc:"Body of the tool to call"                            path:"body"`
 }
 
 // RegisterServerRoutes registers the server listing endpoint along with the
-// tool, prompt, and resource routes on the provided API group.
+// tool, prompt, resource, and completion routes on the provided API group.
+// The log level route is registered separately, as it is operational (see RegisterLogLevelRoutes).
 func RegisterServerRoutes(
 	routerAPI huma.API,
 	accessor contracts.MCPClientAccessor,
+	jobs *JobStore,
+	approvals *ApprovalStore,
 	apiPathPrefix string,
 	options RouteOptions,
 ) {
 	serversAPI := huma.NewGroup(routerAPI, apiPathPrefix)
+	serversAPI.UseMiddleware(serverScopeMiddleware(serversAPI))
 	tags := []string{"Servers"}
 
 	// Add route at the root of the group (no path specified).
@@ -55,127 +59,273 @@ func RegisterServerRoutes(
 			OperationID: "listServers",
 			Method:      http.MethodGet,
 			Summary:     "List all servers",
+			Description: "Lists the names of servers, or their details when requested with detail (summary or full)",
 			Tags:        tags,
 		},
-		func(ctx context.Context, _ *struct{}) (*ServersResponse, error) {
-			return handleServers(accessor)
+		func(ctx context.Context, _ *ServersRequest) (*ServersDetailsResponse, error) {
+			return handleServersDetails(ctx, accessor, options)
 		},
 	)
 
+	// Register the server details route.
+	RegisterServerDetailsRoutes(serversAPI, accessor, options)
+
 	// Register tool routes.
-	RegisterToolRoutes(serversAPI, accessor, options)
+	RegisterToolRoutes(serversAPI, accessor, jobs, approvals, options)
 
 	// Register prompt routes.
-	RegisterPromptRoutes(serversAPI, accessor)
+	RegisterPromptRoutes(serversAPI, accessor, options)
 
 	// Register resource routes.
-	RegisterResourceRoutes(serversAPI, accessor)
-}
-
-// handleServers returns the list of configured MCP servers.
-func handleServers(accessor contracts.MCPClientAccessor) (*ServersResponse, error) {
-	servers := accessor.List()
-	slices.Sort(servers)
-
-	resp := &ServersResponse{}
-	resp.Body = servers
+	RegisterResourceRoutes(serversAPI, accessor, options)
 
-	return resp, nil
+	// Register completion routes.
+	RegisterCompletionRoutes(serversAPI, accessor, options)
 }
 
-// handleServerTools returns the schemas for the allowed tools that exist for a given server.
+// handleServerTools returns the schemas for the allowed tools that exist for a given server,
+// followed by the server's virtual tools.
+// Tools are served from the catalog when cached, otherwise they are requested from the server.
 // This always returns full tool details (Tool), which can be filtered by the transformer.
-func handleServerTools(accessor contracts.MCPClientAccessor, name string) (*ToolsResponse[Tool], error) {
+func handleServerTools(
+	ctx context.Context,
+	accessor contracts.MCPClientAccessor,
+	catalog contracts.MCPCatalogAccessor,
+	configs contracts.MCPServerConfigAccessor,
+	name string,
+) (*ToolsResponse[Tool], error) {
 	mcpClient, clientOk := accessor.Client(name)
 	if !clientOk {
 		return nil, fmt.Errorf("%w: %s", errors.ErrServerNotFound, name)
 	}
 
-	allowedTools, toolsOk := accessor.Tools(name)
-	if !toolsOk || len(allowedTools) == 0 {
+	allowedTools, _ := accessor.Tools(name)
+	entry, _ := serverConfig(configs, name)
+	vts := serverVirtualTools(configs, name)
+	if len(allowedTools) == 0 && len(vts) == 0 {
 		return nil, fmt.Errorf("%w: %s", errors.ErrToolsNotFound, name)
 	}
 
-	// TODO: How to get context from Huma/request for the instance of the request without passing it in?
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
-	defer cancel()
+	listing, cache, cached := cachedListing(catalog, name, func(c domain.ServerCatalog) domain.CatalogListing[mcp.Tool] {
+		return c.Tools
+	})
 
-	result, err := mcpClient.ListTools(ctx, mcp.ListToolsRequest{})
-	if err != nil {
-		return nil, fmt.Errorf("%w: %s", errors.ErrToolListFailed, name)
-	}
-	if result == nil {
-		return nil, fmt.Errorf("%w: %s: no result", errors.ErrToolListFailed, name)
+	mcpTools := listing.Items
+	if !cached {
+		var err error
+		mcpTools, err = listAllTools(ctx, mcpClient, name)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// Only return data on allowed tools.
-	tools := make([]Tool, 0, len(result.Tools))
-	for _, tool := range result.Tools {
+	// Only return data on allowed tools, virtual tools are allowed unless they call a denied tool,
+	// or a tool which isn't permitted by the server's tool policy.
+	// Tools named like a virtual tool (e.g. allowed by a pattern) are left out, as calls are made to the virtual tool.
+	exposed := make([]mcp.Tool, 0, len(mcpTools)+len(vts))
+	for _, tool := range mcpTools {
+		if _, shadowed := entry.VirtualTool(tool.Name); shadowed {
+			continue
+		}
 		if slices.Contains(allowedTools, filter.NormalizeString(tool.Name)) {
-			data, err := domainTool(tool).ToAPIType()
-			if err != nil {
-				return nil, err
-			}
-			tools = append(tools, data)
+			exposed = append(exposed, tool)
 		}
 	}
+	exposed = append(exposed, virtualTools(mcpTools, vts, entry.ToolPermitted)...)
+
+	tools := make([]Tool, 0, len(exposed))
+	for _, tool := range exposed {
+		// Tools outside the caller's scope are left out.
+		if authorizeTool(ctx, name, tool.Name) != nil {
+			continue
+		}
+
+		data, err := domainTool(tool).ToAPIType()
+		if err != nil {
+			return nil, err
+		}
+		tools = append(tools, data)
+	}
 
 	resp := &ToolsResponse[Tool]{}
 	resp.Body.Tools = tools
+	resp.Body.Cache = cache
 
 	return resp, nil
 }
 
+// listAllTools requests every tool offered by the server, following pagination cursors.
+func listAllTools(ctx context.Context, mcpClient client.MCPClient, name string) ([]mcp.Tool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	var tools []mcp.Tool
+	seen := make(map[mcp.Cursor]struct{})
+	req := mcp.ListToolsRequest{}
+	for {
+		result, err := mcpClient.ListTools(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errors.ErrToolListFailed, name)
+		}
+		if result == nil {
+			return nil, fmt.Errorf("%w: %s: no result", errors.ErrToolListFailed, name)
+		}
+		tools = append(tools, result.Tools...)
+
+		if result.NextCursor == "" {
+			return tools, nil
+		}
+		if _, ok := seen[result.NextCursor]; ok {
+			return nil, fmt.Errorf("%w: %s: repeated pagination cursor", errors.ErrToolListFailed, name)
+		}
+		seen[result.NextCursor] = struct{}{}
+		req.Params.Cursor = result.NextCursor
+	}
+}
+
 // handleServerToolCall handles making a call to a specific tool which exists on an MCP server.
+// Calls to virtual tools are made to the upstream tool.
 func handleServerToolCall(
 	ctx context.Context,
 	accessor contracts.MCPClientAccessor,
+	catalog contracts.MCPCatalogAccessor,
+	configs contracts.MCPServerConfigAccessor,
 	server string,
 	tool string,
 	data map[string]any,
 	timeout time.Duration,
 ) (*ToolCallResponse, error) {
+	mcpClient, tool, data, err := allowedToolCall(accessor, catalog, configs, server, tool, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if timeout <= 0 {
+		timeout = DefaultToolCallTimeout()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	message, err := callTool(ctx, mcpClient, server, tool, data, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ToolCallResponse{}
+	resp.Status = http.StatusOK
+	resp.Body = message
+
+	return resp, nil
+}
+
+// handleServerToolCallAsync validates a tool call and starts it as a background job.
+// The response carries the job ID in the body, and the job location in the Location header.
+func handleServerToolCallAsync(
+	ctx context.Context,
+	accessor contracts.MCPClientAccessor,
+	catalog contracts.MCPCatalogAccessor,
+	configs contracts.MCPServerConfigAccessor,
+	jobs *JobStore,
+	server string,
+	tool string,
+	data map[string]any,
+) (*ToolCallResponse, error) {
+	mcpClient, upstreamTool, args, err := allowedToolCall(accessor, catalog, configs, server, tool, data)
+	if err != nil {
+		return nil, err
+	}
+
+	job := jobs.Start(ctx, server, tool, func(ctx context.Context, progressToken mcp.ProgressToken) (string, error) {
+		return callTool(ctx, mcpClient, server, upstreamTool, args, &mcp.Meta{ProgressToken: progressToken})
+	})
+
+	resp := &ToolCallResponse{}
+	resp.Status = http.StatusAccepted
+	resp.Location = jobs.Location(job.ID)
+	resp.Body = job.ID
+
+	return resp, nil
+}
+
+// serverConfig returns the configuration of a server, when configurations are available.
+func serverConfig(configs contracts.MCPServerConfigAccessor, server string) (config.ServerEntry, bool) {
+	if configs == nil {
+		return config.ServerEntry{}, false
+	}
+
+	return configs.ServerConfig(server)
+}
+
+// allowedToolCall returns the client for a server, provided the tool is allowed to be called,
+// along with the tool and arguments to call it with.
+// Virtual tools are allowed unless they call a denied tool, or a tool which isn't permitted by the server's tool
+// policy, and resolve to their upstream tool and arguments.
+func allowedToolCall(
+	accessor contracts.MCPClientAccessor,
+	catalog contracts.MCPCatalogAccessor,
+	configs contracts.MCPServerConfigAccessor,
+	server string,
+	tool string,
+	data map[string]any,
+) (client.MCPClient, string, map[string]any, error) {
 	mcpClient, clientOk := accessor.Client(server)
 	if !clientOk {
-		return nil, fmt.Errorf("%w: %s", errors.ErrServerNotFound, server)
+		return nil, "", nil, fmt.Errorf("%w: %s", errors.ErrServerNotFound, server)
+	}
+
+	vt, ok, err := serverVirtualTool(catalog, configs, server, tool)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	if ok {
+		args, err := virtualToolArgs(server, vt, data)
+		if err != nil {
+			return nil, "", nil, err
+		}
+		return mcpClient, vt.Tool, args, nil
 	}
 
 	allowedTools, toolsOk := accessor.Tools(server)
 	if !toolsOk || len(allowedTools) == 0 {
-		return nil, fmt.Errorf("%w: %s", errors.ErrToolsNotFound, server)
+		return nil, "", nil, fmt.Errorf("%w: %s", errors.ErrToolsNotFound, server)
 	}
 
 	// Normalize the tool name before comparing.
 	normalizedToolName := filter.NormalizeString(tool)
 	if !slices.Contains(allowedTools, normalizedToolName) {
-		return nil, fmt.Errorf("%w: %s/%s", errors.ErrToolForbidden, server, tool)
+		return nil, "", nil, fmt.Errorf("%w: %s/%s", errors.ErrToolForbidden, server, tool)
 	}
 
-	if timeout <= 0 {
-		timeout = DefaultToolCallTimeout()
-	}
-
-	ctx, cancel := context.WithTimeout(ctx, timeout)
-	defer cancel()
+	return mcpClient, tool, data, nil
+}
 
+// callTool calls the tool using the supplied client and extracts the resulting message.
+// Meta is optional and is sent as the _meta of the request (e.g. to request progress notifications).
+func callTool(
+	ctx context.Context,
+	mcpClient client.MCPClient,
+	server string,
+	tool string,
+	data map[string]any,
+	meta *mcp.Meta,
+) (string, error) {
 	result, err := mcpClient.CallTool(ctx, mcp.CallToolRequest{
 		Params: mcp.CallToolParams{
 			Name:      tool,
 			Arguments: data,
+			Meta:      meta,
 		},
 	})
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s/%s: %w", errors.ErrToolCallFailed, server, tool, err)
+		return "", fmt.Errorf("%w: %s/%s: %w", errors.ErrToolCallFailed, server, tool, err)
 	} else if result == nil {
-		return nil, fmt.Errorf("%w: %s/%s: result was nil", errors.ErrToolCallFailedUnknown, server, tool)
+		return "", fmt.Errorf("%w: %s/%s: result was nil", errors.ErrToolCallFailedUnknown, server, tool)
 	} else if result.IsError {
-		return nil, fmt.Errorf("%w: %s/%s: %v", errors.ErrToolCallFailed, server, tool, extractMessage(result.Content))
+		return "", fmt.Errorf("%w: %s/%s: %v", errors.ErrToolCallFailed, server, tool, extractMessage(result.Content))
 	}
 
-	resp := &ToolCallResponse{}
-	resp.Body = extractMessage(result.Content)
-
-	return resp, nil
+	return extractMessage(result.Content), nil
 }
 
 // extractMessage attempts to extract a single message from content that is returned from a tool call.
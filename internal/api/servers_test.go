@@ -71,6 +71,7 @@ type mockMCPClient struct {
 	callToolResult  *mcp.CallToolResult
 	callToolError   error
 	callToolContext context.Context
+	callToolRequest mcp.CallToolRequest
 	// Prompts
 	listPromptsResult *mcp.ListPromptsResult
 	listPromptsError  error
@@ -83,6 +84,10 @@ type mockMCPClient struct {
 	listTemplatesError  error
 	readResourceResult  *mcp.ReadResourceResult
 	readResourceError   error
+	// Completions
+	completeResult  *mcp.CompleteResult
+	completeError   error
+	completeRequest mcp.CompleteRequest
 }
 
 func (m *mockMCPClient) Initialize(_ context.Context, _ mcp.InitializeRequest) (*mcp.InitializeResult, error) {
@@ -165,8 +170,9 @@ func (m *mockMCPClient) ListTools(_ context.Context, _ mcp.ListToolsRequest) (*m
 	return m.listToolsResult, m.listToolsError
 }
 
-func (m *mockMCPClient) CallTool(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+func (m *mockMCPClient) CallTool(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	m.callToolContext = ctx
+	m.callToolRequest = req
 	return m.callToolResult, m.callToolError
 }
 
@@ -174,8 +180,9 @@ func (m *mockMCPClient) SetLevel(_ context.Context, _ mcp.SetLevelRequest) error
 	return nil
 }
 
-func (m *mockMCPClient) Complete(_ context.Context, _ mcp.CompleteRequest) (*mcp.CompleteResult, error) {
-	return nil, nil
+func (m *mockMCPClient) Complete(_ context.Context, req mcp.CompleteRequest) (*mcp.CompleteResult, error) {
+	m.completeRequest = req
+	return m.completeResult, m.completeError
 }
 
 func (m *mockMCPClient) Close() error {
@@ -204,7 +211,7 @@ func TestHandleServerTools_CaseInsensitiveFiltering(t *testing.T) {
 	allowedTools := []string{"gettime", "set_alarm"}
 	accessor.Add("testserver", mockClient, allowedTools)
 
-	result, err := handleServerTools(accessor, "testserver")
+	result, err := handleServerTools(context.Background(), accessor, nil, nil, "testserver")
 	require.NoError(t, err)
 	require.NotNil(t, result)
 
@@ -244,6 +251,8 @@ func TestHandleServerToolCall_ToolNameNormalization(t *testing.T) {
 	result, err := handleServerToolCall(
 		context.Background(),
 		accessor,
+		nil,
+		nil,
 		"testserver",
 		" GetTime ",
 		map[string]any{},
@@ -272,6 +281,8 @@ func TestHandleServerToolCall_UsesConfiguredTimeout(t *testing.T) {
 	result, err := handleServerToolCall(
 		context.Background(),
 		accessor,
+		nil,
+		nil,
 		"testserver",
 		"gettime",
 		map[string]any{},
@@ -302,6 +313,8 @@ func TestHandleServerToolCall_ToolNotAllowed(t *testing.T) {
 	result, err := handleServerToolCall(
 		context.Background(),
 		accessor,
+		nil,
+		nil,
 		"testserver",
 		"forbidden_tool",
 		map[string]any{},
@@ -321,6 +334,8 @@ func TestHandleServerToolCall_ServerNotFound(t *testing.T) {
 	result, err := handleServerToolCall(
 		context.Background(),
 		accessor,
+		nil,
+		nil,
 		"nonexistent",
 		"tool",
 		map[string]any{},
@@ -337,7 +352,7 @@ func TestHandleServerTools_ServerNotFound(t *testing.T) {
 
 	accessor := newMockMCPClientAccessor()
 
-	result, err := handleServerTools(accessor, "nonexistent")
+	result, err := handleServerTools(context.Background(), accessor, nil, nil, "nonexistent")
 	require.Error(t, err)
 	require.Nil(t, result)
 
@@ -353,7 +368,7 @@ func TestHandleServerTools_NoTools(t *testing.T) {
 	// Add server with no tools.
 	accessor.Add("testserver", mockClient, []string{})
 
-	result, err := handleServerTools(accessor, "testserver")
+	result, err := handleServerTools(context.Background(), accessor, nil, nil, "testserver")
 	require.Error(t, err)
 	require.Nil(t, result)
 
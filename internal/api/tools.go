@@ -2,13 +2,16 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/mark3labs/mcp-go/mcp"
 
 	"github.com/mozilla-ai/mcpd/internal/contracts"
+	"github.com/mozilla-ai/mcpd/internal/errors"
 	"github.com/mozilla-ai/mcpd/internal/filter"
 )
 
@@ -30,8 +33,14 @@ const (
 type toolDetailLevel string
 
 // ToolCallResponse represents the wrapped API response for calling a tool.
+// When the call is made asynchronously the status is 202 Accepted, the body contains the job ID,
+// and the Location header points at the job.
+// When the call is held pending approval the status is 202 Accepted, the body contains the approval ID,
+// and the Location header points at the approval.
 type ToolCallResponse struct {
-	Body string
+	Status   int
+	Location string `doc:"Location of the job (or approval) for asynchronous tool calls" header:"Location"`
+	Body     string
 }
 
 // ToolView is a union constraint for all tool view types.
@@ -43,6 +52,9 @@ type ToolView interface {
 // ToolsResponseBody represents the body of a tools response.
 type ToolsResponseBody[T ToolView] struct {
 	Tools []T `json:"tools"`
+
+	// Cache describes the cached listing the tools were served from, when the daemon caches the server's tools.
+	Cache *ListingCache `doc:"State of the cached tools listing" json:"cache,omitempty"`
 }
 
 // ToolsResponse represents a generic wrapped API response for tool collections.
@@ -53,6 +65,9 @@ type ToolsResponse[T ToolView] struct {
 
 // ToolMinimal represents minimal tool information with name and title only.
 type ToolMinimal struct {
+	// Server is the name of the server offering the tool, present when listing tools across servers.
+	Server string `doc:"Name of the server offering the tool" json:"server,omitempty"`
+
 	// Name of the tool.
 	Name string `doc:"Name of the tool" json:"name"`
 
@@ -220,8 +235,9 @@ func (d domainTool) ToAPIType() (Tool, error) {
 // ToAPIType projects Tool to ToolMinimal.
 func (t domainToolMinimal) ToAPIType() (ToolMinimal, error) {
 	return ToolMinimal{
-		Name:  t.Name,
-		Title: t.Title,
+		Server: t.Server,
+		Name:   t.Name,
+		Title:  t.Title,
 	}, nil
 }
 
@@ -269,7 +285,13 @@ func outputSchemaPresent(s mcp.ToolOutputSchema) bool {
 }
 
 // RegisterToolRoutes registers the tool listing and tool call endpoints on the provided API group.
-func RegisterToolRoutes(parentAPI huma.API, accessor contracts.MCPClientAccessor, options RouteOptions) {
+func RegisterToolRoutes(
+	parentAPI huma.API,
+	accessor contracts.MCPClientAccessor,
+	jobs *JobStore,
+	approvals *ApprovalStore,
+	options RouteOptions,
+) {
 	tags := []string{"Tools"}
 
 	huma.Register(
@@ -283,7 +305,7 @@ func RegisterToolRoutes(parentAPI huma.API, accessor contracts.MCPClientAccessor
 			Tags:        tags,
 		},
 		func(ctx context.Context, input *ServerToolsRequest) (*ToolsResponse[Tool], error) {
-			return handleServerTools(accessor, input.Name)
+			return handleServerTools(ctx, accessor, options.Catalog, options.ServerConfigs, input.Name)
 		},
 	)
 
@@ -294,12 +316,90 @@ func RegisterToolRoutes(parentAPI huma.API, accessor contracts.MCPClientAccessor
 			Method:      http.MethodPost,
 			Path:        "/{server}/tools/{tool}",
 			Summary:     "Call a tool for a server",
-			Tags:        tags,
+			Description: "Calls a tool and returns its result, use ?async=true to run the call as a background job " +
+				"and receive the job ID (202 Accepted) instead. " +
+				"Calls to tools which require approval are held pending approval, and the approval ID is returned " +
+				"(202 Accepted) instead",
+			Tags: tags,
 		},
 		func(ctx context.Context, input *ServerToolCallRequest) (*ToolCallResponse, error) {
-			return handleServerToolCall(ctx, accessor, input.Server, input.Tool, input.Body, options.ToolCallTimeout)
+			if approvalRequired(options, input.Server, input.Tool) {
+				return handleServerToolCallApproval(
+					ctx,
+					accessor,
+					options.Catalog,
+					options.ServerConfigs,
+					approvals,
+					jobs,
+					input.Server,
+					input.Tool,
+					input.Body,
+				)
+			}
+			if input.Async {
+				return handleServerToolCallAsync(
+					ctx,
+					accessor,
+					options.Catalog,
+					options.ServerConfigs,
+					jobs,
+					input.Server,
+					input.Tool,
+					input.Body,
+				)
+			}
+			timeout, err := resolveToolCallTimeout(options, input.Server, input.Tool, input.Timeout)
+			if err != nil {
+				return nil, err
+			}
+			return handleServerToolCall(
+				ctx,
+				accessor,
+				options.Catalog,
+				options.ServerConfigs,
+				input.Server,
+				input.Tool,
+				input.Body,
+				timeout,
+			)
 		},
 	)
+
+	RegisterToolStreamRoute(parentAPI, accessor, options)
+}
+
+// resolveToolCallTimeout returns the timeout to apply to a tool call, using the most specific configured value:
+// the tool's timeout, then the server's default timeout, then the daemon-wide tool call timeout.
+// A timeout requested by the client (e.g. via the X-Mcpd-Timeout header) is applied when it is shorter than
+// the configured timeout, it cannot be used to extend it.
+func resolveToolCallTimeout(options RouteOptions, server string, tool string, requested string) (time.Duration, error) {
+	timeout := options.ToolCallTimeout
+	if options.ServerConfigs != nil {
+		if entry, ok := options.ServerConfigs.ServerConfig(server); ok {
+			if t, ok := entry.ToolCallTimeout(tool); ok {
+				timeout = t
+			}
+		}
+	}
+	if timeout <= 0 {
+		timeout = DefaultToolCallTimeout()
+	}
+
+	requested = strings.TrimSpace(requested)
+	if requested == "" {
+		return timeout, nil
+	}
+
+	d, err := time.ParseDuration(requested)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf(
+			"%w: invalid timeout '%s', must be a positive duration (e.g. 30s)",
+			errors.ErrBadRequest,
+			requested,
+		)
+	}
+
+	return min(d, timeout), nil
 }
 
 // toolFieldSelectTransformer transforms tool responses based on the detail query parameter.
@@ -333,7 +433,7 @@ func toolFieldSelectTransformer(ctx huma.Context, _ string, v any) (any, error)
 			}
 			minimal[i] = m
 		}
-		return ToolsResponseBody[ToolMinimal]{Tools: minimal}, nil
+		return ToolsResponseBody[ToolMinimal]{Tools: minimal, Cache: body.Cache}, nil
 
 	case toolDetailSummary:
 		summary := make([]ToolSummary, len(body.Tools))
@@ -344,7 +444,7 @@ func toolFieldSelectTransformer(ctx huma.Context, _ string, v any) (any, error)
 			}
 			summary[i] = sum
 		}
-		return ToolsResponseBody[ToolSummary]{Tools: summary}, nil
+		return ToolsResponseBody[ToolSummary]{Tools: summary, Cache: body.Cache}, nil
 
 	default:
 		// Shouldn't reach here due to Normalize(), but pass through as safety.
@@ -0,0 +1,237 @@
+package api
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/mozilla-ai/mcpd/internal/contracts"
+	"github.com/mozilla-ai/mcpd/internal/errors"
+	"github.com/mozilla-ai/mcpd/internal/filter"
+	"github.com/mozilla-ai/mcpd/internal/search"
+)
+
+const (
+	// searchWeightName is the weight applied to terms in a tool's name when searching.
+	searchWeightName = 3
+
+	// searchWeightTitle is the weight applied to terms in a tool's title when searching.
+	searchWeightTitle = 2
+
+	// searchWeightDescription is the weight applied to terms in a tool's description when searching.
+	searchWeightDescription = 1
+)
+
+// ToolsSearchRequest represents the incoming API request for listing and searching tools across all servers.
+type ToolsSearchRequest struct {
+	// Query contains keywords used to rank tools by relevance, tools matching none of the keywords are excluded.
+	Query string `doc:"Keywords to search for in tool names, titles, and descriptions" example:"current time" query:"q"`
+
+	// Detail specifies the level of detail to return (minimal, summary, or full).
+	// NOTE: This field is not used by the handler itself; it exists solely for OpenAPI documentation.
+	// The actual filtering is performed by toolFieldSelectTransformer, which reads the query parameter directly.
+	Detail toolDetailLevel `default:"full" doc:"Level of detail to return" enum:"minimal,summary,full" query:"detail"`
+
+	// ReadOnlyHint filters tools by their read-only annotation (tools without the annotation are not read-only).
+	ReadOnlyHint string `doc:"Only include tools with this readOnlyHint" enum:"true,false" query:"readOnlyHint"`
+
+	// DestructiveHint filters tools by their destructive annotation.
+	// Tools without the annotation are destructive unless they are read-only.
+	DestructiveHint string `doc:"Only include tools with this destructiveHint" enum:"true,false" query:"destructiveHint"`
+
+	// Tags filters tools to those offered by servers configured with all the supplied tags.
+	Tags []string `doc:"Only include tools from servers with all of these tags" query:"tag"`
+}
+
+// toolsSearchFilter holds the parsed filters for listing tools across servers.
+type toolsSearchFilter struct {
+	query       string
+	readOnly    *bool
+	destructive *bool
+	tags        []string
+}
+
+// RegisterToolSearchRoutes registers the endpoint for listing and searching tools across all servers.
+func RegisterToolSearchRoutes(
+	routerAPI huma.API,
+	accessor contracts.MCPClientAccessor,
+	apiPathPrefix string,
+	options RouteOptions,
+) {
+	huma.Register(
+		routerAPI,
+		huma.Operation{
+			OperationID: "searchTools",
+			Method:      http.MethodGet,
+			Path:        apiPathPrefix,
+			Summary:     "List and search tools across all servers",
+			Description: "Returns the allowed tools of every server, including the server name. " +
+				"Use ?q= to rank tools by relevance to keywords (excluding tools that don't match), " +
+				"?readOnlyHint= and ?destructiveHint= to filter by annotations, ?tag= to filter by server tags, " +
+				"and ?detail= to select the level of detail (minimal, summary, full)",
+			Tags: []string{"Tools"},
+		},
+		func(ctx context.Context, input *ToolsSearchRequest) (*ToolsResponse[Tool], error) {
+			f, err := newToolsSearchFilter(input)
+			if err != nil {
+				return nil, err
+			}
+			return handleToolsSearch(ctx, accessor, options, f)
+		},
+	)
+}
+
+// newToolsSearchFilter parses the filters supplied with the request.
+func newToolsSearchFilter(input *ToolsSearchRequest) (toolsSearchFilter, error) {
+	f := toolsSearchFilter{
+		query: input.Query,
+		tags:  filter.NormalizeSlice(input.Tags),
+	}
+
+	var err error
+	if f.readOnly, err = parseOptionalBool("readOnlyHint", input.ReadOnlyHint); err != nil {
+		return toolsSearchFilter{}, err
+	}
+	if f.destructive, err = parseOptionalBool("destructiveHint", input.DestructiveHint); err != nil {
+		return toolsSearchFilter{}, err
+	}
+
+	return f, nil
+}
+
+// handleToolsSearch returns the allowed tools of every server that match the filter, within the caller's scope.
+// Without a query tools are ordered by server then tool name, otherwise by descending relevance to the query.
+// Servers whose tools cannot be listed are omitted.
+func handleToolsSearch(
+	ctx context.Context,
+	accessor contracts.MCPClientAccessor,
+	options RouteOptions,
+	f toolsSearchFilter,
+) (*ToolsResponse[Tool], error) {
+	servers := accessor.List()
+	slices.Sort(servers)
+
+	tools := make([]Tool, 0)
+	for _, server := range servers {
+		if !serverHasTags(options.ServerConfigs, server, f.tags) || authorizeServer(ctx, server) != nil {
+			continue
+		}
+
+		serverTools, err := handleServerTools(ctx, accessor, options.Catalog, options.ServerConfigs, server)
+		if err != nil {
+			continue
+		}
+
+		for _, tool := range serverTools.Body.Tools {
+			if !f.matchesAnnotations(tool.Annotations) {
+				continue
+			}
+			tool.Server = server
+			tools = append(tools, tool)
+		}
+	}
+
+	slices.SortStableFunc(tools, func(a, b Tool) int {
+		return cmp.Or(cmp.Compare(a.Server, b.Server), cmp.Compare(a.Name, b.Name))
+	})
+
+	if f.query != "" {
+		tools = rankTools(tools, f.query)
+	}
+
+	resp := &ToolsResponse[Tool]{}
+	resp.Body.Tools = tools
+
+	return resp, nil
+}
+
+// rankTools returns the tools that match the query, ordered by descending relevance.
+func rankTools(tools []Tool, query string) []Tool {
+	docs := make([]search.Document, len(tools))
+	for i, tool := range tools {
+		docs[i] = search.Document{
+			Fields: []search.Field{
+				{Text: tool.Name, Weight: searchWeightName},
+				{Text: tool.Title, Weight: searchWeightTitle},
+				{Text: tool.Description, Weight: searchWeightDescription},
+			},
+		}
+	}
+
+	results := search.NewIndex(docs).Search(query)
+	ranked := make([]Tool, 0, len(results))
+	for _, result := range results {
+		ranked = append(ranked, tools[result.Index])
+	}
+
+	return ranked
+}
+
+// matchesAnnotations reports whether a tool's annotations match the filter.
+// Missing hints use the defaults defined by the MCP specification:
+// tools are not read-only, and tools that aren't read-only are destructive.
+func (f toolsSearchFilter) matchesAnnotations(annotations *ToolAnnotations) bool {
+	readOnly := false
+	destructive := true
+	if annotations != nil {
+		if annotations.ReadOnlyHint != nil {
+			readOnly = *annotations.ReadOnlyHint
+		}
+		if annotations.DestructiveHint != nil {
+			destructive = *annotations.DestructiveHint
+		}
+	}
+	destructive = destructive && !readOnly
+
+	if f.readOnly != nil && *f.readOnly != readOnly {
+		return false
+	}
+	if f.destructive != nil && *f.destructive != destructive {
+		return false
+	}
+
+	return true
+}
+
+// serverHasTags reports whether the server is configured with all the supplied (normalized) tags.
+func serverHasTags(configs contracts.MCPServerConfigAccessor, server string, tags []string) bool {
+	if len(tags) == 0 {
+		return true
+	}
+	if configs == nil {
+		return false
+	}
+
+	entry, ok := configs.ServerConfig(server)
+	if !ok {
+		return false
+	}
+
+	serverTags := filter.NormalizeSlice(entry.Tags)
+	for _, tag := range tags {
+		if !slices.Contains(serverTags, tag) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseOptionalBool parses an optional boolean query parameter, returning nil when it is empty.
+func parseOptionalBool(name string, value string) (*bool, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	v, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid value for %s: %s", errors.ErrBadRequest, name, value)
+	}
+
+	return &v, nil
+}
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/errors"
+)
+
+func newToolsSearchAccessor() *mockMCPClientAccessor {
+	readOnly := true
+	notDestructive := false
+
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("time", &mockMCPClient{
+		listToolsResult: &mcp.ListToolsResult{
+			Tools: []mcp.Tool{
+				{
+					Name:        "get_current_time",
+					Description: "Get the current time in a specific timezone",
+					Annotations: mcp.ToolAnnotation{ReadOnlyHint: &readOnly},
+				},
+				{
+					Name:        "convert_time",
+					Description: "Convert time between timezones",
+					Annotations: mcp.ToolAnnotation{ReadOnlyHint: &readOnly},
+				},
+			},
+		},
+	}, []string{"get_current_time", "convert_time"})
+	accessor.Add("files", &mockMCPClient{
+		listToolsResult: &mcp.ListToolsResult{
+			Tools: []mcp.Tool{
+				{Name: "delete_file", Description: "Delete a file"},
+				{
+					Name:        "write_file",
+					Description: "Write content to a file, creating it if needed",
+					Annotations: mcp.ToolAnnotation{DestructiveHint: &notDestructive},
+				},
+				{Name: "move_file", Description: "Move a file"},
+			},
+		},
+	}, []string{"delete_file", "write_file"})
+	accessor.Add("broken", &mockMCPClient{listToolsError: fmt.Errorf("connection refused")}, []string{"tool"})
+
+	return accessor
+}
+
+func toolServerNames(tools []Tool) []string {
+	names := make([]string, 0, len(tools))
+	for _, tool := range tools {
+		names = append(names, tool.Server+"__"+tool.Name)
+	}
+	return names
+}
+
+func TestHandleToolsSearch(t *testing.T) {
+	t.Parallel()
+
+	configs := &mockServerConfigAccessor{
+		configs: map[string]config.ServerEntry{
+			"time":  {Name: "time", Tags: []string{"Utility"}},
+			"files": {Name: "files", Tags: []string{"filesystem", "utility"}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		input    ToolsSearchRequest
+		expected []string
+	}{
+		{
+			name:  "all allowed tools ordered by server and name",
+			input: ToolsSearchRequest{},
+			expected: []string{
+				"files__delete_file",
+				"files__write_file",
+				"time__convert_time",
+				"time__get_current_time",
+			},
+		},
+		{
+			name:     "query ranks by relevance and excludes non-matches",
+			input:    ToolsSearchRequest{Query: "current time"},
+			expected: []string{"time__get_current_time", "time__convert_time"},
+		},
+		{
+			name:     "query matching no tools",
+			input:    ToolsSearchRequest{Query: "weather"},
+			expected: []string{},
+		},
+		{
+			name:     "read-only tools",
+			input:    ToolsSearchRequest{ReadOnlyHint: "true"},
+			expected: []string{"time__convert_time", "time__get_current_time"},
+		},
+		{
+			name:     "destructive tools default when not annotated",
+			input:    ToolsSearchRequest{DestructiveHint: "true"},
+			expected: []string{"files__delete_file"},
+		},
+		{
+			name:     "non-destructive tools include read-only tools",
+			input:    ToolsSearchRequest{DestructiveHint: "false", ReadOnlyHint: "false"},
+			expected: []string{"files__write_file"},
+		},
+		{
+			name:     "single tag",
+			input:    ToolsSearchRequest{Tags: []string{"UTILITY"}},
+			expected: []string{"files__delete_file", "files__write_file", "time__convert_time", "time__get_current_time"},
+		},
+		{
+			name:     "all tags must match",
+			input:    ToolsSearchRequest{Tags: []string{"utility", "filesystem"}},
+			expected: []string{"files__delete_file", "files__write_file"},
+		},
+		{
+			name:     "unknown tag",
+			input:    ToolsSearchRequest{Tags: []string{"database"}},
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := newToolsSearchFilter(&tc.input)
+			require.NoError(t, err)
+
+			options := RouteOptions{ServerConfigs: configs}
+			resp, err := handleToolsSearch(context.Background(), newToolsSearchAccessor(), options, f)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, toolServerNames(resp.Body.Tools))
+		})
+	}
+}
+
+func TestHandleToolsSearch_TagsWithoutServerConfigs(t *testing.T) {
+	t.Parallel()
+
+	f, err := newToolsSearchFilter(&ToolsSearchRequest{Tags: []string{"utility"}})
+	require.NoError(t, err)
+
+	resp, err := handleToolsSearch(context.Background(), newToolsSearchAccessor(), RouteOptions{}, f)
+	require.NoError(t, err)
+	require.Empty(t, resp.Body.Tools)
+}
+
+func TestNewToolsSearchFilter_InvalidHint(t *testing.T) {
+	t.Parallel()
+
+	_, err := newToolsSearchFilter(&ToolsSearchRequest{ReadOnlyHint: "maybe"})
+	require.ErrorIs(t, err, errors.ErrBadRequest)
+	assert.ErrorContains(t, err, "readOnlyHint")
+}
@@ -0,0 +1,293 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/mozilla-ai/mcpd/internal/contracts"
+	"github.com/mozilla-ai/mcpd/internal/errors"
+)
+
+const (
+	// streamEventProgress is the SSE event name for progress notifications.
+	streamEventProgress = "progress"
+
+	// streamEventLog is the SSE event name for logging notifications.
+	streamEventLog = "log"
+
+	// streamEventResult is the SSE event name for the final tool call result.
+	streamEventResult = "result"
+
+	// streamEventError is the SSE event name for a failed tool call.
+	streamEventError = "error"
+
+	// streamEventBuffer is the number of notifications buffered while events are written to the client.
+	// Notifications that arrive while the buffer is full are dropped.
+	streamEventBuffer = 64
+)
+
+// ToolCallLogEvent represents a logging notification sent by the MCP server during a tool call.
+type ToolCallLogEvent struct {
+	// Level is the severity of the log message.
+	Level string `json:"level"`
+
+	// Logger is the optional name of the logger issuing the message.
+	Logger string `json:"logger,omitempty"`
+
+	// Data is the logged data, such as a string message or an object.
+	Data any `json:"data"`
+}
+
+// ToolCallResultEvent represents the final result of a streamed tool call.
+type ToolCallResultEvent struct {
+	// Result is the tool call result.
+	Result string `json:"result"`
+}
+
+// ToolCallErrorEvent represents the failure of a streamed tool call.
+type ToolCallErrorEvent struct {
+	// Error describes why the tool call failed.
+	Error string `json:"error"`
+}
+
+// streamEvent is a named SSE event and its data.
+type streamEvent struct {
+	name string
+	data any
+}
+
+// sseWriter writes Server-Sent Events, flushing after each event.
+type sseWriter struct {
+	w io.Writer
+}
+
+// RegisterToolStreamRoute registers the streaming tool call endpoint on the provided API group.
+func RegisterToolStreamRoute(
+	parentAPI huma.API,
+	accessor contracts.MCPClientAccessor,
+	options RouteOptions,
+) {
+	huma.Register(
+		parentAPI,
+		huma.Operation{
+			OperationID: "callToolStream",
+			Method:      http.MethodPost,
+			Path:        "/{server}/tools/{tool}/stream",
+			Summary:     "Call a tool for a server and stream its progress",
+			Description: "Calls a tool and streams Server-Sent Events while it runs: 'progress' and 'log' events " +
+				"for notifications sent by the MCP server, followed by a single 'result' or 'error' event. " +
+				"Disconnecting cancels the tool call.",
+			Tags: []string{"Tools"},
+			Responses: map[string]*huma.Response{
+				"200": {
+					Description: "Server-Sent Events stream",
+					Content: map[string]*huma.MediaType{
+						"text/event-stream": {
+							Schema: &huma.Schema{
+								Type: huma.TypeString,
+								Description: "Events named progress, log, result, and error, " +
+									"each with JSON encoded data",
+							},
+						},
+					},
+				},
+			},
+		},
+		func(ctx context.Context, input *ServerToolCallRequest) (*huma.StreamResponse, error) {
+			// Streamed tool calls cannot be held pending approval.
+			if approvalRequired(options, input.Server, input.Tool) {
+				return nil, fmt.Errorf("%w: %s/%s", errors.ErrToolApprovalRequired, input.Server, input.Tool)
+			}
+			timeout, err := resolveToolCallTimeout(options, input.Server, input.Tool, input.Timeout)
+			if err != nil {
+				return nil, err
+			}
+			return handleServerToolCallStream(
+				accessor,
+				options.Catalog,
+				options.ServerConfigs,
+				options.NotificationSubscriber,
+				input.Server,
+				input.Tool,
+				input.Body,
+				timeout,
+			)
+		},
+	)
+}
+
+// handleServerToolCallStream validates a tool call and returns a response that streams it as Server-Sent Events.
+// Validation errors are returned before the stream starts, so they can be mapped to status codes as usual.
+func handleServerToolCallStream(
+	accessor contracts.MCPClientAccessor,
+	catalog contracts.MCPCatalogAccessor,
+	configs contracts.MCPServerConfigAccessor,
+	notifications contracts.MCPNotificationSubscriber,
+	server string,
+	tool string,
+	data map[string]any,
+	timeout time.Duration,
+) (*huma.StreamResponse, error) {
+	mcpClient, tool, data, err := allowedToolCall(accessor, catalog, configs, server, tool, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if timeout <= 0 {
+		timeout = DefaultToolCallTimeout()
+	}
+
+	return &huma.StreamResponse{
+		Body: func(hctx huma.Context) {
+			hctx.SetHeader("Content-Type", "text/event-stream")
+			hctx.SetHeader("Cache-Control", "no-cache")
+			hctx.SetStatus(http.StatusOK)
+
+			ctx, cancel := context.WithTimeout(hctx.Context(), timeout)
+			defer cancel()
+
+			streamToolCall(ctx, &sseWriter{w: hctx.BodyWriter()}, notifications, mcpClient, server, tool, data)
+		},
+	}, nil
+}
+
+// streamToolCall calls the tool, writing notifications for the call as events until the final result is written.
+// If writing fails (e.g. the client disconnected) or the context is done, the MCP request is cancelled.
+func streamToolCall(
+	ctx context.Context,
+	w *sseWriter,
+	notifications contracts.MCPNotificationSubscriber,
+	mcpClient client.MCPClient,
+	server string,
+	tool string,
+	data map[string]any,
+) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	token := newToken()
+	events := make(chan streamEvent, streamEventBuffer)
+
+	if notifications != nil {
+		unsubscribe := notifications.Subscribe(server, func(n mcp.JSONRPCNotification) {
+			event, ok := notificationToStreamEvent(n, token)
+			if !ok {
+				return
+			}
+			select {
+			case events <- event:
+			default:
+			}
+		})
+		defer unsubscribe()
+	}
+
+	// Commit the headers so the client knows the stream has started.
+	if err := w.flush(); err != nil {
+		return
+	}
+
+	type callResult struct {
+		message string
+		err     error
+	}
+	done := make(chan callResult, 1)
+
+	go func() {
+		message, err := callTool(ctx, mcpClient, server, tool, data, &mcp.Meta{ProgressToken: token})
+		done <- callResult{message: message, err: err}
+	}()
+
+	for {
+		select {
+		case event := <-events:
+			if err := w.write(event); err != nil {
+				return
+			}
+		case result := <-done:
+			// Deliver notifications that arrived before the result.
+			for len(events) > 0 {
+				if err := w.write(<-events); err != nil {
+					return
+				}
+			}
+
+			if result.err != nil {
+				_ = w.write(streamEvent{name: streamEventError, data: ToolCallErrorEvent{Error: result.err.Error()}})
+				return
+			}
+			_ = w.write(streamEvent{name: streamEventResult, data: ToolCallResultEvent{Result: result.message}})
+			return
+		}
+	}
+}
+
+// notificationToStreamEvent converts a notification into an event, when it is relevant to the tool call.
+// Progress notifications must carry the token of the tool call, logging notifications are not tied to a request
+// so any sent by the server while the tool call is running are included.
+func notificationToStreamEvent(n mcp.JSONRPCNotification, token string) (streamEvent, bool) {
+	switch n.Method {
+	case string(mcp.MethodNotificationProgress):
+		progress, ok := parseProgress(n, token)
+		if !ok {
+			return streamEvent{}, false
+		}
+		progress.UpdatedAt = time.Now().UTC()
+		return streamEvent{name: streamEventProgress, data: progress}, true
+	case string(mcp.MethodNotificationMessage):
+		fields := n.Params.AdditionalFields
+		event := ToolCallLogEvent{Data: fields["data"]}
+		if v, ok := fields["level"].(string); ok {
+			event.Level = v
+		}
+		if v, ok := fields["logger"].(string); ok {
+			event.Logger = v
+		}
+		return streamEvent{name: streamEventLog, data: event}, true
+	default:
+		return streamEvent{}, false
+	}
+}
+
+// write writes a single event and flushes it to the client.
+func (s *sseWriter) write(event streamEvent) error {
+	data, err := json.Marshal(event.data)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", event.name, err)
+	}
+
+	if _, err := fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", event.name, data); err != nil {
+		return err
+	}
+
+	return s.flush()
+}
+
+// comment writes a comment, which clients ignore, and flushes it to the client (e.g. to keep the stream alive).
+func (s *sseWriter) comment(text string) error {
+	if _, err := fmt.Fprintf(s.w, ": %s\n\n", text); err != nil {
+		return err
+	}
+
+	return s.flush()
+}
+
+// flush sends buffered data to the client.
+func (s *sseWriter) flush() error {
+	if rw, ok := s.w.(http.ResponseWriter); ok {
+		return http.NewResponseController(rw).Flush()
+	}
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+		return nil
+	}
+	return fmt.Errorf("streaming not supported: %w", http.ErrNotSupported)
+}
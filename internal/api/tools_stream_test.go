@@ -0,0 +1,178 @@
+package api
+
+import (
+	"context"
+	stdErrors "errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/errors"
+)
+
+// notifyingMCPClient is a mock client which publishes notifications while a tool call is in flight.
+type notifyingMCPClient struct {
+	mockMCPClient
+
+	server        string
+	notifications *mockNotificationSubscriber
+}
+
+func (m *notifyingMCPClient) CallTool(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	token := req.Params.Meta.ProgressToken
+
+	m.notifications.publish(m.server, progressNotification("other-call", 1, 2, "ignored"))
+	m.notifications.publish(m.server, progressNotification(token, 1, 2, "halfway"))
+	m.notifications.publish(m.server, mcp.JSONRPCNotification{
+		Notification: mcp.Notification{
+			Method: string(mcp.MethodNotificationMessage),
+			Params: mcp.NotificationParams{
+				AdditionalFields: map[string]any{
+					"level":  "info",
+					"logger": "crawler",
+					"data":   "fetched page",
+				},
+			},
+		},
+	})
+
+	return m.callToolResult, m.callToolError
+}
+
+// failingWriter is a response writer that fails once events are written.
+type failingWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (f *failingWriter) Write([]byte) (int, error) {
+	return 0, stdErrors.New("client disconnected")
+}
+
+func TestStreamToolCall_EventsAndResult(t *testing.T) {
+	t.Parallel()
+
+	notifications := newMockNotificationSubscriber()
+	mockClient := &notifyingMCPClient{
+		mockMCPClient: mockMCPClient{
+			callToolResult: &mcp.CallToolResult{
+				Content: []mcp.Content{mcp.TextContent{Text: "report ready"}},
+			},
+		},
+		server:        "testserver",
+		notifications: notifications,
+	}
+
+	rec := httptest.NewRecorder()
+	streamToolCall(context.Background(), &sseWriter{w: rec}, notifications, mockClient, "testserver", "report", nil)
+
+	events := parseSSE(t, rec.Body.String())
+	require.Len(t, events, 3)
+
+	assert.Equal(t, streamEventProgress, events[0].name)
+	assert.Contains(t, events[0].data, `"progress":1`)
+	assert.Contains(t, events[0].data, `"message":"halfway"`)
+
+	assert.Equal(t, streamEventLog, events[1].name)
+	assert.JSONEq(t, `{"level":"info","logger":"crawler","data":"fetched page"}`, events[1].data)
+
+	assert.Equal(t, streamEventResult, events[2].name)
+	assert.JSONEq(t, `{"result":"report ready"}`, events[2].data)
+	assert.True(t, rec.Flushed)
+}
+
+func TestStreamToolCall_Error(t *testing.T) {
+	t.Parallel()
+
+	mockClient := &mockMCPClient{
+		callToolResult: &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{mcp.TextContent{Text: "crawl failed"}},
+		},
+	}
+
+	rec := httptest.NewRecorder()
+	streamToolCall(context.Background(), &sseWriter{w: rec}, nil, mockClient, "testserver", "crawl", nil)
+
+	events := parseSSE(t, rec.Body.String())
+	require.Len(t, events, 1)
+	assert.Equal(t, streamEventError, events[0].name)
+	assert.Contains(t, events[0].data, "crawl failed")
+}
+
+func TestStreamToolCall_DisconnectCancelsCall(t *testing.T) {
+	t.Parallel()
+
+	notifications := newMockNotificationSubscriber()
+	mockClient := newBlockingMCPClient()
+
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		w := &sseWriter{w: &failingWriter{ResponseRecorder: httptest.NewRecorder()}}
+		streamToolCall(context.Background(), w, notifications, mockClient, "testserver", "report", nil)
+	}()
+
+	// Once the call is in flight, a notification triggers a write which fails.
+	require.Eventually(t, func() bool { return mockClient.meta() != nil }, 2*time.Second, 10*time.Millisecond)
+	notifications.publish("testserver", progressNotification(mockClient.meta().ProgressToken, 1, 2, ""))
+
+	select {
+	case <-finished:
+	case <-time.After(2 * time.Second):
+		t.Fatal("stream did not finish after the client disconnected")
+	}
+
+	require.Eventually(t, mockClient.wasCancelled, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestHandleServerToolCallStream_ValidatesBeforeStreaming(t *testing.T) {
+	t.Parallel()
+
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("testserver", &mockMCPClient{}, []string{"report"})
+	timeout := DefaultToolCallTimeout()
+
+	_, err := handleServerToolCallStream(accessor, nil, nil, nil, "nonexistent", "report", nil, timeout)
+	require.ErrorIs(t, err, errors.ErrServerNotFound)
+
+	_, err = handleServerToolCallStream(accessor, nil, nil, nil, "testserver", "forbidden", nil, timeout)
+	require.ErrorIs(t, err, errors.ErrToolForbidden)
+
+	resp, err := handleServerToolCallStream(accessor, nil, nil, nil, "testserver", "report", nil, timeout)
+	require.NoError(t, err)
+	require.NotNil(t, resp.Body)
+}
+
+type sseEvent struct {
+	name string
+	data string
+}
+
+// parseSSE parses a Server-Sent Events stream into its events.
+func parseSSE(t *testing.T, stream string) []sseEvent {
+	t.Helper()
+
+	var events []sseEvent
+	for block := range strings.SplitSeq(strings.TrimSpace(stream), "\n\n") {
+		if block == "" {
+			continue
+		}
+		var event sseEvent
+		for line := range strings.SplitSeq(block, "\n") {
+			if v, ok := strings.CutPrefix(line, "event: "); ok {
+				event.name = v
+			}
+			if v, ok := strings.CutPrefix(line, "data: "); ok {
+				event.data = v
+			}
+		}
+		events = append(events, event)
+	}
+
+	return events
+}
@@ -13,6 +13,9 @@ import (
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/errors"
 )
 
 // mockHumaContext implements huma.Context for testing.
@@ -500,3 +503,115 @@ func TestDomainTool_ToAPIType_OutputSchema(t *testing.T) {
 		})
 	}
 }
+
+// mockServerConfigAccessor provides server configuration for tests.
+type mockServerConfigAccessor struct {
+	configs map[string]config.ServerEntry
+}
+
+func (m *mockServerConfigAccessor) ServerConfig(name string) (config.ServerEntry, bool) {
+	entry, ok := m.configs[name]
+	return entry, ok
+}
+
+func TestResolveToolCallTimeout(t *testing.T) {
+	t.Parallel()
+
+	serverTimeout := config.Duration(time.Minute)
+	toolTimeout := config.Duration(2 * time.Minute)
+	configs := &mockServerConfigAccessor{
+		configs: map[string]config.ServerEntry{
+			"testserver": {
+				Name:         "testserver",
+				Timeout:      &serverTimeout,
+				ToolTimeouts: map[string]*config.Duration{"slow": &toolTimeout},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		options   RouteOptions
+		server    string
+		tool      string
+		requested string
+		expected  time.Duration
+		expectErr bool
+	}{
+		{
+			name:     "daemon timeout without server config",
+			options:  newRouteOptions(WithToolCallTimeout(10 * time.Second)),
+			server:   "testserver",
+			tool:     "fast",
+			expected: 10 * time.Second,
+		},
+		{
+			name:     "daemon timeout for unconfigured server",
+			options:  newRouteOptions(WithToolCallTimeout(10*time.Second), WithServerConfigAccessor(configs)),
+			server:   "other",
+			tool:     "fast",
+			expected: 10 * time.Second,
+		},
+		{
+			name:     "server timeout",
+			options:  newRouteOptions(WithToolCallTimeout(10*time.Second), WithServerConfigAccessor(configs)),
+			server:   "testserver",
+			tool:     "fast",
+			expected: time.Minute,
+		},
+		{
+			name:     "tool timeout",
+			options:  newRouteOptions(WithToolCallTimeout(10*time.Second), WithServerConfigAccessor(configs)),
+			server:   "testserver",
+			tool:     "slow",
+			expected: 2 * time.Minute,
+		},
+		{
+			name:      "requested timeout shorter than configured",
+			options:   newRouteOptions(WithServerConfigAccessor(configs)),
+			server:    "testserver",
+			tool:      "slow",
+			requested: "5s",
+			expected:  5 * time.Second,
+		},
+		{
+			name:      "requested timeout capped by configured",
+			options:   newRouteOptions(WithServerConfigAccessor(configs)),
+			server:    "testserver",
+			tool:      "fast",
+			requested: "1h",
+			expected:  time.Minute,
+		},
+		{
+			name:      "invalid requested timeout",
+			options:   newRouteOptions(),
+			server:    "testserver",
+			tool:      "fast",
+			requested: "soon",
+			expectErr: true,
+		},
+		{
+			name:      "non-positive requested timeout",
+			options:   newRouteOptions(),
+			server:    "testserver",
+			tool:      "fast",
+			requested: "-5s",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			timeout, err := resolveToolCallTimeout(tc.options, tc.server, tc.tool, tc.requested)
+			if tc.expectErr {
+				require.ErrorIs(t, err, errors.ErrBadRequest)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, timeout)
+		})
+	}
+}
@@ -15,8 +15,10 @@ import "github.com/danielgtaylor/huma/v2"
 //
 // Current transformers:
 //   - toolFieldSelectTransformer: Filters tool responses based on ?detail= query parameter.
+//   - serverFieldSelectTransformer: Filters server listings based on ?detail= query parameter.
 func Transformers() []huma.Transformer {
 	return []huma.Transformer{
 		toolFieldSelectTransformer,
+		serverFieldSelectTransformer,
 	}
 }
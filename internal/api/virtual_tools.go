@@ -0,0 +1,126 @@
+package api
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/contracts"
+	"github.com/mozilla-ai/mcpd/internal/errors"
+	"github.com/mozilla-ai/mcpd/internal/filter"
+)
+
+// serverVirtualTools returns the virtual tools configured for a server, omitting those which call a denied tool.
+func serverVirtualTools(configs contracts.MCPServerConfigAccessor, server string) []config.VirtualToolEntry {
+	entry, ok := serverConfig(configs, server)
+	if !ok {
+		return nil
+	}
+
+	return slices.DeleteFunc(slices.Clone(entry.VirtualTools), func(vt config.VirtualToolEntry) bool {
+		return entry.ToolDenied(vt.Tool)
+	})
+}
+
+// serverVirtualTool returns the virtual tool with the given name, if one is configured for the server.
+// An error wrapping errors.ErrToolForbidden is returned when the virtual tool calls a denied tool,
+// or a tool which isn't permitted by the server's tool policy (based on its hints from the catalog, when cached).
+func serverVirtualTool(
+	catalog contracts.MCPCatalogAccessor,
+	configs contracts.MCPServerConfigAccessor,
+	server string,
+	tool string,
+) (config.VirtualToolEntry, bool, error) {
+	entry, ok := serverConfig(configs, server)
+	if !ok {
+		return config.VirtualToolEntry{}, false, nil
+	}
+
+	vt, ok := entry.VirtualTool(tool)
+	if !ok {
+		return config.VirtualToolEntry{}, false, nil
+	}
+
+	if entry.ToolDenied(vt.Tool) || !entry.ToolPermitted(vt.Tool, cachedToolHints(catalog, server, vt.Tool)) {
+		return config.VirtualToolEntry{}, false, fmt.Errorf("%w: %s/%s", errors.ErrToolForbidden, server, tool)
+	}
+
+	return vt, true, nil
+}
+
+// virtualTools returns the tools exposed by the virtual tools, derived from the upstream tools they call.
+// Virtual tools that call a tool the server doesn't offer, or which isn't permitted (e.g. by the server's tool
+// policy), are omitted.
+func virtualTools(
+	upstream []mcp.Tool,
+	vts []config.VirtualToolEntry,
+	permitted func(tool string, hints *config.ToolHints) bool,
+) []mcp.Tool {
+	if len(vts) == 0 {
+		return nil
+	}
+
+	tools := make([]mcp.Tool, 0, len(vts))
+	for _, vt := range vts {
+		name := filter.NormalizeString(vt.Tool)
+		i := slices.IndexFunc(upstream, func(t mcp.Tool) bool {
+			return filter.NormalizeString(t.Name) == name
+		})
+		if i < 0 || !permitted(upstream[i].Name, toolHints(upstream[i])) {
+			continue
+		}
+		tools = append(tools, virtualTool(upstream[i], vt))
+	}
+
+	return tools
+}
+
+// virtualTool returns the tool exposed by the virtual tool, based on the upstream tool.
+// Arguments supplied by the virtual tool are removed from the input schema.
+func virtualTool(upstream mcp.Tool, vt config.VirtualToolEntry) mcp.Tool {
+	tool := upstream
+	tool.Name = vt.Name
+	if vt.Title != "" {
+		tool.Title = vt.Title
+		tool.Annotations.Title = vt.Title
+	}
+	if vt.Description != "" {
+		tool.Description = vt.Description
+	}
+
+	hidden := vt.HiddenArgs()
+	if len(hidden) == 0 {
+		return tool
+	}
+
+	tool.InputSchema.Properties = maps.Clone(upstream.InputSchema.Properties)
+	for _, arg := range hidden {
+		delete(tool.InputSchema.Properties, arg)
+	}
+	tool.InputSchema.Required = slices.DeleteFunc(slices.Clone(upstream.InputSchema.Required), func(arg string) bool {
+		return slices.Contains(hidden, arg)
+	})
+
+	return tool
+}
+
+// virtualToolArgs returns the arguments to call the upstream tool with.
+// Pinned arguments are always applied and cannot be supplied by the caller,
+// default arguments are applied when the caller doesn't supply them.
+func virtualToolArgs(server string, vt config.VirtualToolEntry, data map[string]any) (map[string]any, error) {
+	for _, arg := range slices.Sorted(maps.Keys(vt.PinnedArgs)) {
+		if _, ok := data[arg]; ok {
+			return nil, fmt.Errorf("%w: %s/%s: argument '%s' cannot be supplied", errors.ErrBadRequest, server, vt.Name, arg)
+		}
+	}
+
+	args := make(map[string]any, len(data)+len(vt.PinnedArgs)+len(vt.DefaultArgs))
+	maps.Copy(args, vt.DefaultArgs)
+	maps.Copy(args, data)
+	maps.Copy(args, vt.PinnedArgs)
+
+	return args, nil
+}
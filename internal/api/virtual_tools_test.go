@@ -0,0 +1,351 @@
+package api
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/domain"
+	"github.com/mozilla-ai/mcpd/internal/errors"
+)
+
+func newVirtualToolsConfigs() *mockServerConfigAccessor {
+	return &mockServerConfigAccessor{
+		configs: map[string]config.ServerEntry{
+			"github": {
+				Name: "github",
+				VirtualTools: []config.VirtualToolEntry{
+					{
+						Name:        "search_org_issues",
+						Tool:        "search_issues",
+						Title:       "Search Org Issues",
+						Description: "Search issues in the organization's repositories",
+						PinnedArgs:  map[string]any{"owner": "mozilla-ai"},
+						DefaultArgs: map[string]any{"per_page": 10},
+					},
+					{Name: "missing", Tool: "not_offered"},
+				},
+			},
+		},
+	}
+}
+
+func newVirtualToolsClient() *mockMCPClient {
+	return &mockMCPClient{
+		listToolsResult: &mcp.ListToolsResult{
+			Tools: []mcp.Tool{
+				{
+					Name:        "search_issues",
+					Description: "Search issues",
+					InputSchema: mcp.ToolInputSchema{
+						Type: "object",
+						Properties: map[string]any{
+							"owner":    map[string]any{"type": "string"},
+							"query":    map[string]any{"type": "string"},
+							"per_page": map[string]any{"type": "number"},
+						},
+						Required: []string{"owner", "query"},
+					},
+				},
+				{Name: "create_issue", Description: "Create an issue"},
+			},
+		},
+		callToolResult: &mcp.CallToolResult{
+			Content: []mcp.Content{mcp.TextContent{Type: "text", Text: "found"}},
+		},
+	}
+}
+
+func TestHandleServerTools_VirtualTools(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newVirtualToolsClient()
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("github", mockClient, []string{"create_issue"})
+
+	result, err := handleServerTools(context.Background(), accessor, nil, newVirtualToolsConfigs(), "github")
+	require.NoError(t, err)
+	require.Len(t, result.Body.Tools, 2)
+
+	assert.Equal(t, "create_issue", result.Body.Tools[0].Name)
+
+	// The upstream tool isn't allowed, but is exposed through the virtual tool.
+	tool := result.Body.Tools[1]
+	assert.Equal(t, "search_org_issues", tool.Name)
+	assert.Equal(t, "Search Org Issues", tool.Title)
+	assert.Equal(t, "Search issues in the organization's repositories", tool.Description)
+	require.NotNil(t, tool.InputSchema)
+	assert.Equal(t, map[string]any{"query": map[string]any{"type": "string"}}, tool.InputSchema.Properties)
+	assert.Equal(t, []string{"query"}, tool.InputSchema.Required)
+
+	// The upstream tool's schema is unchanged.
+	upstream := mockClient.listToolsResult.Tools[0]
+	assert.Len(t, upstream.InputSchema.Properties, 3)
+	assert.Equal(t, []string{"owner", "query"}, upstream.InputSchema.Required)
+}
+
+func TestHandleServerTools_OnlyVirtualTools(t *testing.T) {
+	t.Parallel()
+
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("github", newVirtualToolsClient(), nil)
+
+	result, err := handleServerTools(context.Background(), accessor, nil, newVirtualToolsConfigs(), "github")
+	require.NoError(t, err)
+	require.Len(t, result.Body.Tools, 1)
+	assert.Equal(t, "search_org_issues", result.Body.Tools[0].Name)
+}
+
+func TestHandleServerTools_VirtualToolShadowsUpstreamTool(t *testing.T) {
+	t.Parallel()
+
+	// The server offers a tool with the same name as the virtual tool, which is allowed (e.g. by a pattern).
+	mockClient := newVirtualToolsClient()
+	mockClient.listToolsResult.Tools = append(mockClient.listToolsResult.Tools, mcp.Tool{Name: "search_org_issues"})
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("github", mockClient, []string{"create_issue", "search_org_issues"})
+	configs := newVirtualToolsConfigs()
+
+	result, err := handleServerTools(context.Background(), accessor, nil, configs, "github")
+	require.NoError(t, err)
+
+	// Each tool is listed once, the virtual tool is listed as it's the tool which is called.
+	names := make([]string, 0, len(result.Body.Tools))
+	for _, tool := range result.Body.Tools {
+		names = append(names, tool.Name)
+	}
+	assert.Equal(t, []string{"create_issue", "search_org_issues"}, names)
+	assert.Equal(t, "Search Org Issues", result.Body.Tools[1].Title)
+
+	_, err = handleServerToolCall(
+		context.Background(),
+		accessor,
+		nil,
+		configs,
+		"github",
+		"search_org_issues",
+		map[string]any{"query": "bug"},
+		time.Second,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "search_issues", mockClient.callToolRequest.Params.Name)
+}
+
+func TestHandleServerToolCall_VirtualTool(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		data     map[string]any
+		expected map[string]any
+	}{
+		{
+			name:     "pinned and default arguments applied",
+			data:     map[string]any{"query": "bug"},
+			expected: map[string]any{"owner": "mozilla-ai", "query": "bug", "per_page": 10},
+		},
+		{
+			name:     "default arguments can be overridden",
+			data:     map[string]any{"query": "bug", "per_page": 50},
+			expected: map[string]any{"owner": "mozilla-ai", "query": "bug", "per_page": 50},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockClient := newVirtualToolsClient()
+			accessor := newMockMCPClientAccessor()
+			accessor.Add("github", mockClient, []string{"create_issue"})
+
+			result, err := handleServerToolCall(
+				context.Background(),
+				accessor,
+				nil,
+				newVirtualToolsConfigs(),
+				"github",
+				"Search_Org_Issues",
+				tc.data,
+				DefaultToolCallTimeout(),
+			)
+			require.NoError(t, err)
+			assert.Equal(t, "found", result.Body)
+
+			assert.Equal(t, "search_issues", mockClient.callToolRequest.Params.Name)
+			assert.Equal(t, tc.expected, mockClient.callToolRequest.Params.Arguments)
+		})
+	}
+}
+
+func TestHandleServerToolCall_VirtualToolPinnedArgument(t *testing.T) {
+	t.Parallel()
+
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("github", newVirtualToolsClient(), []string{"create_issue"})
+
+	_, err := handleServerToolCall(
+		context.Background(),
+		accessor,
+		nil,
+		newVirtualToolsConfigs(),
+		"github",
+		"search_org_issues",
+		map[string]any{"query": "bug", "owner": "someone-else"},
+		DefaultToolCallTimeout(),
+	)
+	require.ErrorIs(t, err, errors.ErrBadRequest)
+	assert.ErrorContains(t, err, "owner")
+}
+
+func TestHandleServerToolCall_UpstreamToolNotAllowed(t *testing.T) {
+	t.Parallel()
+
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("github", newVirtualToolsClient(), []string{"create_issue"})
+
+	// The upstream tool can only be called through the virtual tool.
+	_, err := handleServerToolCall(
+		context.Background(),
+		accessor,
+		nil,
+		newVirtualToolsConfigs(),
+		"github",
+		"search_issues",
+		map[string]any{"query": "bug", "owner": "someone-else"},
+		DefaultToolCallTimeout(),
+	)
+	require.ErrorIs(t, err, errors.ErrToolForbidden)
+}
+
+func TestVirtualTools_UpstreamToolDenied(t *testing.T) {
+	t.Parallel()
+
+	configs := newVirtualToolsConfigs()
+	entry := configs.configs["github"]
+	entry.ToolsDeny = []string{"search_*"}
+	configs.configs["github"] = entry
+
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("github", newVirtualToolsClient(), []string{"create_issue"})
+
+	// The virtual tool isn't listed.
+	result, err := handleServerTools(context.Background(), accessor, nil, configs, "github")
+	require.NoError(t, err)
+	require.Len(t, result.Body.Tools, 1)
+	assert.Equal(t, "create_issue", result.Body.Tools[0].Name)
+
+	// Calls to the virtual tool are rejected.
+	_, err = handleServerToolCall(
+		context.Background(),
+		accessor,
+		nil,
+		configs,
+		"github",
+		"search_org_issues",
+		map[string]any{"query": "bug"},
+		DefaultToolCallTimeout(),
+	)
+	require.ErrorIs(t, err, errors.ErrToolForbidden)
+}
+
+func TestVirtualTools_ToolPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		policy      config.ToolPolicy
+		tools       []string
+		annotations mcp.ToolAnnotation
+		permitted   bool
+	}{
+		{
+			name:   "read-only policy without hints",
+			policy: config.ToolPolicy{ReadOnly: mcp.ToBoolPtr(true)},
+		},
+		{
+			name:        "read-only policy with read-only hint",
+			policy:      config.ToolPolicy{ReadOnly: mcp.ToBoolPtr(true)},
+			annotations: mcp.ToolAnnotation{ReadOnlyHint: mcp.ToBoolPtr(true)},
+			permitted:   true,
+		},
+		{
+			name:   "destructive blocked",
+			policy: config.ToolPolicy{BlockDestructive: mcp.ToBoolPtr(true)},
+		},
+		{
+			name:        "not destructive",
+			policy:      config.ToolPolicy{BlockDestructive: mcp.ToBoolPtr(true)},
+			annotations: mcp.ToolAnnotation{DestructiveHint: mcp.ToBoolPtr(false)},
+			permitted:   true,
+		},
+		{
+			name:   "open world blocked",
+			policy: config.ToolPolicy{BlockOpenWorld: mcp.ToBoolPtr(true)},
+		},
+		{
+			name:      "upstream tool named explicitly",
+			policy:    config.ToolPolicy{BlockDestructive: mcp.ToBoolPtr(true)},
+			tools:     []string{"search_issues"},
+			permitted: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			configs := newVirtualToolsConfigs()
+			entry := configs.configs["github"]
+			entry.Tools = tc.tools
+			entry.ToolPolicy = &tc.policy
+			configs.configs["github"] = entry
+
+			mockClient := newVirtualToolsClient()
+			mockClient.listToolsResult.Tools[0].Annotations = tc.annotations
+			accessor := newMockMCPClientAccessor()
+			accessor.Add("github", mockClient, []string{"create_issue"})
+
+			// Listings take the hints from the server, calls take them from the catalog.
+			refreshedAt := time.Now()
+			catalog := &mockCatalogAccessor{catalogs: map[string]domain.ServerCatalog{
+				"github": {
+					Tools: domain.CatalogListing[mcp.Tool]{
+						Items:       mockClient.listToolsResult.Tools,
+						RefreshedAt: &refreshedAt,
+					},
+				},
+			}}
+
+			result, err := handleServerTools(context.Background(), accessor, nil, configs, "github")
+			require.NoError(t, err)
+			names := make([]string, 0, len(result.Body.Tools))
+			for _, tool := range result.Body.Tools {
+				names = append(names, tool.Name)
+			}
+			assert.Equal(t, tc.permitted, slices.Contains(names, "search_org_issues"))
+
+			_, err = handleServerToolCall(
+				context.Background(),
+				accessor,
+				catalog,
+				configs,
+				"github",
+				"search_org_issues",
+				map[string]any{"query": "bug"},
+				DefaultToolCallTimeout(),
+			)
+			if tc.permitted {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, errors.ErrToolForbidden)
+		})
+	}
+}
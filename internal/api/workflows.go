@@ -0,0 +1,254 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/contracts"
+	"github.com/mozilla-ai/mcpd/internal/errors"
+	"github.com/mozilla-ai/mcpd/internal/filter"
+	"github.com/mozilla-ai/mcpd/internal/workflow"
+)
+
+// WorkflowsRequest represents the incoming API request for listing workflows.
+type WorkflowsRequest struct {
+	// Detail specifies the level of detail to return (minimal, summary, or full).
+	// NOTE: This field is not used by the handler itself; it exists solely for OpenAPI documentation.
+	// The actual filtering is performed by toolFieldSelectTransformer, which reads the query parameter directly.
+	Detail toolDetailLevel `default:"full" doc:"Level of detail to return" enum:"minimal,summary,full" query:"detail"`
+}
+
+// WorkflowCallRequest represents the incoming API request to run a workflow.
+type WorkflowCallRequest struct {
+	Name string         `doc:"Name of the workflow"    example:"triage_issue" path:"name"`
+	Body map[string]any `doc:"Inputs for the workflow"                        required:"false"`
+}
+
+// WorkflowCallResponse represents the wrapped API response for running a workflow.
+type WorkflowCallResponse struct {
+	Status int
+	Body   WorkflowCallResult
+}
+
+// WorkflowCallResult is the outcome of running a workflow.
+type WorkflowCallResult struct {
+	// Output is the result of the workflow, present when all steps succeeded.
+	Output string `doc:"Result of the workflow" json:"output,omitempty"`
+
+	// Error describes why the workflow failed.
+	Error string `doc:"Error message" json:"error,omitempty"`
+
+	// Steps contains the outcome of each step, in order, including partial results when a step failed.
+	Steps []WorkflowStepResult `doc:"Results of the steps, in order" json:"steps"`
+}
+
+// WorkflowStepResult is the outcome of a single workflow step.
+type WorkflowStepResult struct {
+	// ID identifies the step within the workflow.
+	ID string `doc:"Identifier of the step" json:"id"`
+
+	// Server is the name of the server offering the tool.
+	Server string `doc:"Name of the server" json:"server"`
+
+	// Tool is the name of the tool that was called.
+	Tool string `doc:"Name of the tool" json:"tool"`
+
+	// Status is the outcome of the step.
+	Status workflow.StepStatus `doc:"Outcome of the step" enum:"succeeded,failed,skipped" json:"status"`
+
+	// Result is the tool call result, present when the step succeeded.
+	Result string `doc:"Result of the tool call" json:"result,omitempty"`
+
+	// Error describes why the step failed.
+	Error string `doc:"Error message" json:"error,omitempty"`
+
+	// DurationMs is how long the step took, in milliseconds.
+	DurationMs int64 `doc:"Duration of the step in milliseconds" json:"durationMs"`
+}
+
+// newWorkflows compiles the configured workflows, keyed by their normalized name.
+func newWorkflows(entries []config.WorkflowEntry) (map[string]*workflow.Workflow, error) {
+	workflows := make(map[string]*workflow.Workflow, len(entries))
+	for _, entry := range entries {
+		w, err := workflow.New(entry)
+		if err != nil {
+			return nil, err
+		}
+		workflows[filter.NormalizeString(entry.Name)] = w
+	}
+
+	return workflows, nil
+}
+
+// RegisterWorkflowRoutes registers the workflow listing and call endpoints.
+func RegisterWorkflowRoutes(
+	routerAPI huma.API,
+	accessor contracts.MCPClientAccessor,
+	workflows map[string]*workflow.Workflow,
+	apiPathPrefix string,
+	options RouteOptions,
+) {
+	workflowsAPI := huma.NewGroup(routerAPI, apiPathPrefix)
+	tags := []string{"Workflows"}
+
+	huma.Register(
+		workflowsAPI,
+		huma.Operation{
+			OperationID: "listWorkflows",
+			Method:      http.MethodGet,
+			Summary:     "List workflows",
+			Description: "Returns the configured workflows as tools, with configurable detail level via ?detail= " +
+				"query parameter (minimal, summary, full)",
+			Tags: tags,
+		},
+		func(ctx context.Context, _ *WorkflowsRequest) (*ToolsResponse[Tool], error) {
+			return handleWorkflows(ctx, workflows)
+		},
+	)
+
+	huma.Register(
+		workflowsAPI,
+		huma.Operation{
+			OperationID: "callWorkflow",
+			Method:      http.MethodPost,
+			Path:        "/{name}",
+			Summary:     "Run a workflow",
+			Description: "Runs the steps of the workflow in order and returns the result of each step. " +
+				"When a step fails the remaining steps are skipped, and the results of the steps which ran are " +
+				"returned with the status the failed tool call would have returned",
+			Tags: tags,
+		},
+		func(ctx context.Context, input *WorkflowCallRequest) (*WorkflowCallResponse, error) {
+			return handleWorkflowCall(ctx, workflows, input.Name, input.Body, workflowToolCaller(accessor, options))
+		},
+	)
+}
+
+// handleWorkflows returns the configured workflows as tools, ordered by name.
+// Workflows calling tools outside the caller's scope are left out.
+func handleWorkflows(ctx context.Context, workflows map[string]*workflow.Workflow) (*ToolsResponse[Tool], error) {
+	tools := make([]Tool, 0, len(workflows))
+	for _, w := range workflows {
+		if authorizeWorkflow(ctx, w) != nil {
+			continue
+		}
+
+		data, err := domainTool(w.Tool()).ToAPIType()
+		if err != nil {
+			return nil, err
+		}
+		tools = append(tools, data)
+	}
+
+	slices.SortFunc(tools, func(a, b Tool) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	resp := &ToolsResponse[Tool]{}
+	resp.Body.Tools = tools
+
+	return resp, nil
+}
+
+// handleWorkflowCall runs the workflow, returning the result of each step.
+// Errors which occur before any step runs (e.g. invalid inputs) are returned as errors,
+// step failures are reported in the result along with the status of the failure.
+func handleWorkflowCall(
+	ctx context.Context,
+	workflows map[string]*workflow.Workflow,
+	name string,
+	inputs map[string]any,
+	call workflow.Caller,
+) (*WorkflowCallResponse, error) {
+	w, ok := workflows[filter.NormalizeString(name)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errors.ErrWorkflowNotFound, name)
+	}
+
+	// Steps call tools directly, so the caller must be able to call every tool before the workflow runs.
+	if err := authorizeWorkflow(ctx, w); err != nil {
+		return nil, err
+	}
+
+	result, err := w.Run(ctx, inputs, call)
+	if err != nil && len(result.Steps) == 0 {
+		return nil, err
+	}
+
+	resp := &WorkflowCallResponse{}
+	resp.Status = http.StatusOK
+	resp.Body.Output = result.Output
+	resp.Body.Steps = make([]WorkflowStepResult, 0, len(result.Steps))
+	for _, step := range result.Steps {
+		stepResult := WorkflowStepResult{
+			ID:         step.ID,
+			Server:     step.Server,
+			Tool:       step.Tool,
+			Status:     step.Status,
+			Result:     step.Result,
+			DurationMs: step.Duration.Milliseconds(),
+		}
+		if step.Error != nil {
+			stepResult.Error = step.Error.Error()
+		}
+		resp.Body.Steps = append(resp.Body.Steps, stepResult)
+	}
+
+	if err != nil {
+		statusErr := huma.NewErrorWithContext(nil, http.StatusInternalServerError, err.Error(), err)
+		resp.Status = statusErr.GetStatus()
+		resp.Body.Error = err.Error()
+	}
+
+	return resp, nil
+}
+
+// workflowToolCaller returns a caller which makes the tool calls of workflow steps,
+// applying the same allowlist and timeouts as an individual tool call.
+func workflowToolCaller(accessor contracts.MCPClientAccessor, options RouteOptions) workflow.Caller {
+	return func(
+		ctx context.Context,
+		server string,
+		tool string,
+		args map[string]any,
+		timeout time.Duration,
+	) (string, error) {
+		// Workflow steps cannot be held pending approval.
+		if approvalRequired(options, server, tool) {
+			return "", fmt.Errorf("%w: %s/%s", errors.ErrToolApprovalRequired, server, tool)
+		}
+
+		var requested string
+		if timeout > 0 {
+			requested = timeout.String()
+		}
+
+		resolved, err := resolveToolCallTimeout(options, server, tool, requested)
+		if err != nil {
+			return "", err
+		}
+
+		resp, err := handleServerToolCall(
+			ctx,
+			accessor,
+			options.Catalog,
+			options.ServerConfigs,
+			server,
+			tool,
+			args,
+			resolved,
+		)
+		if err != nil {
+			return "", err
+		}
+
+		return resp.Body, nil
+	}
+}
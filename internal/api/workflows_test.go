@@ -0,0 +1,155 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/errors"
+	"github.com/mozilla-ai/mcpd/internal/workflow"
+)
+
+func newTestWorkflows(t *testing.T) map[string]*workflow.Workflow {
+	t.Helper()
+
+	workflows, err := newWorkflows([]config.WorkflowEntry{
+		{
+			Name:        "Report_Time",
+			Description: "Reports the current time to a channel",
+			Inputs: []config.WorkflowInput{
+				{Name: "channel", Description: "Channel to post to", Required: true},
+			},
+			Steps: []config.WorkflowStep{
+				{ID: "now", Server: "time", Tool: "get_current_time", Args: map[string]any{"timezone": "UTC"}},
+				{
+					ID:     "post",
+					Server: "chat",
+					Tool:   "post_message",
+					Args: map[string]any{
+						"channel": "{{ .inputs.channel }}",
+						"text":    "It is {{ .steps.now.result }}",
+					},
+				},
+			},
+			Output: "Posted: {{ .steps.post.result }}",
+		},
+		{
+			Name:  "delete_all",
+			Steps: []config.WorkflowStep{{ID: "delete", Server: "chat", Tool: "delete_messages"}},
+		},
+	})
+	require.NoError(t, err)
+
+	return workflows
+}
+
+func TestHandleWorkflows(t *testing.T) {
+	t.Parallel()
+
+	resp, err := handleWorkflows(context.Background(), newTestWorkflows(t))
+	require.NoError(t, err)
+	require.Len(t, resp.Body.Tools, 2)
+
+	assert.Equal(t, "delete_all", resp.Body.Tools[0].Name)
+
+	tool := resp.Body.Tools[1]
+	assert.Equal(t, "report_time", tool.Name)
+	assert.Equal(t, "Reports the current time to a channel", tool.Description)
+	require.NotNil(t, tool.InputSchema)
+	assert.Equal(t, []string{"channel"}, tool.InputSchema.Required)
+	assert.Contains(t, tool.InputSchema.Properties, "channel")
+}
+
+func TestHandleWorkflowCall(t *testing.T) {
+	t.Parallel()
+
+	timeClient := &mockMCPClient{
+		callToolResult: &mcp.CallToolResult{
+			Content: []mcp.Content{mcp.TextContent{Type: "text", Text: "12:00"}},
+		},
+	}
+	chatClient := &mockMCPClient{
+		callToolResult: &mcp.CallToolResult{
+			Content: []mcp.Content{mcp.TextContent{Type: "text", Text: "message 1"}},
+		},
+	}
+
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("time", timeClient, []string{"get_current_time"})
+	accessor.Add("chat", chatClient, []string{"post_message"})
+
+	resp, err := handleWorkflowCall(
+		context.Background(),
+		newTestWorkflows(t),
+		"report_time",
+		map[string]any{"channel": "general"},
+		workflowToolCaller(accessor, newRouteOptions()),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.Status)
+	assert.Equal(t, "Posted: message 1", resp.Body.Output)
+	assert.Empty(t, resp.Body.Error)
+	require.Len(t, resp.Body.Steps, 2)
+	assert.Equal(t, workflow.StepSucceeded, resp.Body.Steps[0].Status)
+	assert.Equal(t, "12:00", resp.Body.Steps[0].Result)
+	assert.Equal(t, workflow.StepSucceeded, resp.Body.Steps[1].Status)
+
+	assert.Equal(t, "post_message", chatClient.callToolRequest.Params.Name)
+	assert.Equal(
+		t,
+		map[string]any{"channel": "general", "text": "It is 12:00"},
+		chatClient.callToolRequest.Params.Arguments,
+	)
+}
+
+func TestHandleWorkflowCall_StepFailure(t *testing.T) {
+	t.Parallel()
+
+	accessor := newMockMCPClientAccessor()
+	accessor.Add("time", &mockMCPClient{
+		callToolResult: &mcp.CallToolResult{
+			Content: []mcp.Content{mcp.TextContent{Type: "text", Text: "12:00"}},
+		},
+	}, []string{"get_current_time"})
+	accessor.Add("chat", &mockMCPClient{}, []string{"read_messages"})
+
+	// The workflow's steps are subject to the same allowlist as any other tool call.
+	resp, err := handleWorkflowCall(
+		context.Background(),
+		newTestWorkflows(t),
+		"report_time",
+		map[string]any{"channel": "general"},
+		workflowToolCaller(accessor, newRouteOptions()),
+	)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, http.StatusOK, resp.Status)
+	assert.Empty(t, resp.Body.Output)
+	assert.Contains(t, resp.Body.Error, "step 'post' failed")
+	assert.Contains(t, resp.Body.Error, "not allowed")
+	require.Len(t, resp.Body.Steps, 2)
+	assert.Equal(t, workflow.StepSucceeded, resp.Body.Steps[0].Status)
+	assert.Equal(t, "12:00", resp.Body.Steps[0].Result)
+	assert.Equal(t, workflow.StepFailed, resp.Body.Steps[1].Status)
+	assert.Contains(t, resp.Body.Steps[1].Error, "not allowed")
+}
+
+func TestHandleWorkflowCall_Errors(t *testing.T) {
+	t.Parallel()
+
+	accessor := newMockMCPClientAccessor()
+	call := workflowToolCaller(accessor, newRouteOptions())
+
+	_, err := handleWorkflowCall(context.Background(), newTestWorkflows(t), "unknown", nil, call)
+	require.ErrorIs(t, err, errors.ErrWorkflowNotFound)
+
+	_, err = handleWorkflowCall(context.Background(), newTestWorkflows(t), "report_time", nil, call)
+	require.ErrorIs(t, err, errors.ErrBadRequest)
+	require.ErrorContains(t, err, "missing required input 'channel'")
+}
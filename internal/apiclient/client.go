@@ -0,0 +1,249 @@
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mozilla-ai/mcpd/internal/api"
+	"github.com/mozilla-ai/mcpd/internal/config"
+)
+
+const (
+	// DefaultAddr is the address of the daemon API when started in development mode.
+	DefaultAddr = "localhost:8090"
+
+	// apiPathPrefix is the path prefix for all daemon API endpoints.
+	apiPathPrefix = "/api/v1"
+
+	// defaultTimeout is the default timeout for requests made to the daemon API.
+	defaultTimeout = 30 * time.Second
+
+	// unixSocketHost is the host of requests made to a daemon API served on a Unix domain socket.
+	unixSocketHost = "mcpd"
+)
+
+// Client makes requests to the API of a running mcpd daemon.
+// NewClient should be used to create instances of Client.
+type Client struct {
+	// addr is the address of the daemon API, as given.
+	addr string
+
+	baseURL    *url.URL
+	httpClient *http.Client
+
+	// apiKey authenticates requests to the daemon API, when configured.
+	apiKey string
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// Error is returned when the daemon API responds with an error status.
+type Error struct {
+	// Status is the HTTP status code of the response.
+	Status int `json:"status"`
+
+	// Title is the short description of the error.
+	Title string `json:"title"`
+
+	// Detail is the detailed description of the error, if any.
+	Detail string `json:"detail"`
+}
+
+// NewClient creates a Client for the daemon API at the given address (e.g. 'localhost:8090').
+// Addresses without a scheme are assumed to be http, and 'unix:///path/to/mcpd.sock' addresses connect to the
+// daemon API served on a Unix domain socket.
+func NewClient(addr string, opts ...Option) (*Client, error) {
+	addr = strings.TrimSpace(addr)
+	if addr == "" {
+		return nil, fmt.Errorf("daemon address cannot be empty")
+	}
+
+	if path, ok := config.UnixSocketPath(addr); ok {
+		return newUnixClient(addr, path, opts...)
+	}
+
+	if !strings.Contains(addr, "://") {
+		addr = "http://" + addr
+	}
+
+	baseURL, err := url.Parse(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid daemon address '%s': %w", addr, err)
+	}
+	if baseURL.Host == "" {
+		return nil, fmt.Errorf("invalid daemon address '%s': missing host", addr)
+	}
+
+	c := &Client{
+		addr:       baseURL.Host,
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: defaultTimeout},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// newUnixClient creates a Client which connects to the daemon API on the Unix domain socket at the path.
+func newUnixClient(addr string, path string, opts ...Option) (*Client, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, fmt.Errorf("invalid daemon address '%s': missing socket path", addr)
+	}
+
+	dialer := &net.Dialer{}
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, _ string, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", path)
+		},
+	}
+
+	c := &Client{
+		addr:       addr,
+		baseURL:    &url.URL{Scheme: "http", Host: unixSocketHost},
+		httpClient: &http.Client{Timeout: defaultTimeout, Transport: transport},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// WithHTTPClient configures the HTTP client used to make requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+// WithAPIKey configures the API key presented to the daemon API, for daemons which authenticate requests.
+func WithAPIKey(key string) Option {
+	return func(c *Client) {
+		c.apiKey = strings.TrimSpace(key)
+	}
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	msg := e.Title
+	if msg == "" {
+		msg = http.StatusText(e.Status)
+	}
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	return fmt.Sprintf("daemon API error (%d): %s", e.Status, msg)
+}
+
+// ListApprovals returns the tool call approvals known to the daemon, optionally filtered by status.
+func (c *Client) ListApprovals(ctx context.Context, status api.ApprovalStatus) ([]api.Approval, error) {
+	query := url.Values{}
+	if status != "" {
+		query.Set("status", string(status))
+	}
+
+	var out struct {
+		Approvals []api.Approval `json:"approvals"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/approvals", query, nil, &out); err != nil {
+		return nil, err
+	}
+
+	return out.Approvals, nil
+}
+
+// Approve approves the pending tool call with the given approval ID.
+func (c *Client) Approve(ctx context.Context, id string, reason string) (api.Approval, error) {
+	return c.decide(ctx, id, "approve", reason)
+}
+
+// Deny denies the pending tool call with the given approval ID.
+func (c *Client) Deny(ctx context.Context, id string, reason string) (api.Approval, error) {
+	return c.decide(ctx, id, "deny", reason)
+}
+
+func (c *Client) decide(ctx context.Context, id string, decision string, reason string) (api.Approval, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return api.Approval{}, fmt.Errorf("approval ID cannot be empty")
+	}
+
+	var out api.Approval
+	body := api.ApprovalDecision{Reason: reason}
+	if err := c.do(ctx, http.MethodPost, "/approvals/"+url.PathEscape(id)+"/"+decision, nil, body, &out); err != nil {
+		return api.Approval{}, err
+	}
+
+	return out, nil
+}
+
+// do makes a request to the daemon API, decoding the JSON response into out.
+// Error responses are returned as an *Error.
+func (c *Client) do(ctx context.Context, method string, path string, query url.Values, in any, out any) error {
+	endpoint := c.baseURL.JoinPath(apiPathPrefix, path)
+	endpoint.RawQuery = query.Encode()
+
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint.String(), body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach daemon at %s: %w", c.addr, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		apiErr := &Error{}
+		_ = json.Unmarshal(data, apiErr)
+		apiErr.Status = resp.StatusCode
+		return apiErr
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
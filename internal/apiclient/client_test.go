@@ -0,0 +1,204 @@
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/api"
+)
+
+func TestNewClient(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		addr          string
+		expectedURL   string
+		expectedError string
+	}{
+		{name: "host and port", addr: "localhost:8090", expectedURL: "http://localhost:8090"},
+		{name: "with scheme", addr: "https://mcpd.example.com", expectedURL: "https://mcpd.example.com"},
+		{name: "surrounding whitespace", addr: " 127.0.0.1:8090 ", expectedURL: "http://127.0.0.1:8090"},
+		{name: "empty", addr: "  ", expectedError: "daemon address cannot be empty"},
+		{name: "missing host", addr: "http://", expectedError: "invalid daemon address 'http://': missing host"},
+		{name: "unix socket", addr: "unix:///run/mcpd/mcpd.sock", expectedURL: "http://mcpd"},
+		{
+			name:          "unix socket missing path",
+			addr:          "unix://",
+			expectedError: "invalid daemon address 'unix://': missing socket path",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c, err := NewClient(tc.addr)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedURL, c.baseURL.String())
+		})
+	}
+}
+
+func TestClient_ListApprovals(t *testing.T) {
+	t.Parallel()
+
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/api/v1/approvals", r.URL.Path)
+		query = r.URL.RawQuery
+
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"approvals": []api.Approval{{ID: "abc", Server: "github", Tool: "delete_repo"}},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := NewClient(server.URL)
+	require.NoError(t, err)
+
+	approvals, err := c.ListApprovals(context.Background(), api.ApprovalStatusPending)
+	require.NoError(t, err)
+	require.Len(t, approvals, 1)
+	assert.Equal(t, "abc", approvals[0].ID)
+	assert.Equal(t, "status=pending", query)
+
+	_, err = c.ListApprovals(context.Background(), "")
+	require.NoError(t, err)
+	assert.Empty(t, query)
+}
+
+func TestClient_UnixSocket(t *testing.T) {
+	t.Parallel()
+
+	// Socket paths are limited to around 100 characters, so avoid the test's (long) temporary directory.
+	dir, err := os.MkdirTemp("", "mcpd")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	path := filepath.Join(dir, "mcpd.sock")
+
+	listener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/approvals", r.URL.Path)
+		_ = json.NewEncoder(w).Encode(map[string]any{"approvals": []api.Approval{{ID: "abc"}}})
+	}))
+	server.Listener = listener
+	server.Start()
+	t.Cleanup(server.Close)
+
+	c, err := NewClient("unix://" + path)
+	require.NoError(t, err)
+
+	approvals, err := c.ListApprovals(context.Background(), "")
+	require.NoError(t, err)
+	require.Len(t, approvals, 1)
+
+	c, err = NewClient("unix://" + filepath.Join(dir, "missing.sock"))
+	require.NoError(t, err)
+
+	_, err = c.ListApprovals(context.Background(), "")
+	require.ErrorContains(t, err, "failed to reach daemon at unix://"+filepath.Join(dir, "missing.sock"))
+}
+
+func TestClient_Decide(t *testing.T) {
+	t.Parallel()
+
+	var path string
+	var decision api.ApprovalDecision
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		path = r.URL.Path
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&decision))
+
+		_ = json.NewEncoder(w).Encode(api.Approval{ID: "abc", Status: api.ApprovalStatusApproved, JobID: "job1"})
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := NewClient(server.URL)
+	require.NoError(t, err)
+
+	approval, err := c.Approve(context.Background(), "abc", "ok")
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/approvals/abc/approve", path)
+	assert.Equal(t, "ok", decision.Reason)
+	assert.Equal(t, "job1", approval.JobID)
+
+	_, err = c.Deny(context.Background(), "abc", "")
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/approvals/abc/deny", path)
+
+	_, err = c.Deny(context.Background(), " ", "")
+	require.EqualError(t, err, "approval ID cannot be empty")
+}
+
+func TestClient_ErrorResponse(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"title":"Conflict","status":409,"detail":"approval is not pending: abc is denied"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := NewClient(server.URL)
+	require.NoError(t, err)
+
+	_, err = c.Approve(context.Background(), "abc", "")
+
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusConflict, apiErr.Status)
+	assert.EqualError(t, err, "daemon API error (409): Conflict: approval is not pending: abc is denied")
+}
+
+func TestClient_ErrorResponseWithoutBody(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := NewClient(server.URL)
+	require.NoError(t, err)
+
+	_, err = c.ListApprovals(context.Background(), "")
+	assert.EqualError(t, err, "daemon API error (502): Bad Gateway")
+}
+
+func TestClient_WithAPIKey(t *testing.T) {
+	t.Parallel()
+
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		_ = json.NewEncoder(w).Encode(map[string]any{"approvals": []api.Approval{}})
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := NewClient(server.URL, WithAPIKey("mcpd_key"))
+	require.NoError(t, err)
+
+	_, err = c.ListApprovals(context.Background(), "")
+	require.NoError(t, err)
+	require.Equal(t, "Bearer mcpd_key", authorization)
+}
@@ -0,0 +1,149 @@
+// Package audit provides a tamper-evident audit log of the tool calls, prompt gets and resource reads
+// made through the daemon.
+//
+// Records are appended to a JSON Lines file, each carrying the hash of the record before it,
+// so that modifying, removing or reordering records breaks the chain and is detected by Verify.
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// Operation is the kind of request made to an MCP server which is recorded in the audit log.
+type Operation string
+
+const (
+	// OperationToolCall is a call to a tool.
+	OperationToolCall Operation = "tool_call"
+
+	// OperationPromptGet is a request for a prompt.
+	OperationPromptGet Operation = "prompt_get"
+
+	// OperationResourceRead is a read of a resource.
+	OperationResourceRead Operation = "resource_read"
+)
+
+// Outcome is the result of a recorded request.
+type Outcome string
+
+const (
+	// OutcomeSuccess is recorded when the request succeeded.
+	OutcomeSuccess Outcome = "success"
+
+	// OutcomeError is recorded when the request failed, or the tool returned an error result.
+	OutcomeError Outcome = "error"
+)
+
+// hashPrefix prefixes the hex encoded SHA-256 hashes in records.
+const hashPrefix = "sha256:"
+
+// Event describes a request made to an MCP server, which is recorded by a Recorder.
+type Event struct {
+	// Operation is the kind of request.
+	Operation Operation
+
+	// Caller is the name of the authenticated caller which made the request, empty when unauthenticated.
+	Caller string
+
+	// AuthMethod is the method used to authenticate the caller.
+	AuthMethod string
+
+	// Server is the name of the MCP server the request was made to.
+	Server string
+
+	// Tool is the name of the tool called.
+	Tool string
+
+	// Prompt is the name of the prompt requested.
+	Prompt string
+
+	// Resource is the URI of the resource read.
+	Resource string
+
+	// Arguments are the arguments of the request.
+	Arguments any
+
+	// Err is the error which the request failed with.
+	Err error
+
+	// Started is when the request was made.
+	Started time.Time
+
+	// Latency is how long the request took.
+	Latency time.Duration
+}
+
+// Recorder records requests made to MCP servers.
+type Recorder interface {
+	// Record records the request described by the event.
+	Record(event Event) error
+}
+
+// Record is a single entry (line) in the audit log.
+type Record struct {
+	// Time is when the request was made (UTC).
+	Time time.Time `json:"time"`
+
+	// Operation is the kind of request.
+	Operation Operation `json:"operation"`
+
+	// Caller is the name of the authenticated caller, omitted when requests aren't authenticated.
+	Caller string `json:"caller,omitempty"`
+
+	// AuthMethod is the method used to authenticate the caller.
+	AuthMethod string `json:"authMethod,omitempty"`
+
+	// Server is the name of the MCP server.
+	Server string `json:"server"`
+
+	// Tool is the name of the tool called.
+	Tool string `json:"tool,omitempty"`
+
+	// Prompt is the name of the prompt requested.
+	Prompt string `json:"prompt,omitempty"`
+
+	// Resource is the URI of the resource read.
+	Resource string `json:"resource,omitempty"`
+
+	// ArgumentsHash is the hash of the arguments of the request, omitted when the redacted arguments are recorded.
+	ArgumentsHash string `json:"argumentsHash,omitempty"`
+
+	// Arguments are the redacted arguments of the request, when arguments are recorded.
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+
+	// Outcome is the result of the request.
+	Outcome Outcome `json:"outcome"`
+
+	// Error is the reason the request failed.
+	Error string `json:"error,omitempty"`
+
+	// LatencyMS is how long the request took in milliseconds.
+	LatencyMS int64 `json:"latencyMs"`
+
+	// PrevHash is the hash of the previous record in the log, empty for the first record.
+	PrevHash string `json:"prevHash,omitempty"`
+
+	// Hash is the hash of this record, computed with the Hash field empty.
+	Hash string `json:"hash"`
+}
+
+// computeHash returns the hash of the record, which covers every field other than Hash itself.
+func (r Record) computeHash() (string, error) {
+	r.Hash = ""
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+
+	return hashBytes(data), nil
+}
+
+// hashBytes returns the prefixed, hex encoded SHA-256 hash of the data.
+func hashBytes(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hashPrefix + hex.EncodeToString(sum[:])
+}
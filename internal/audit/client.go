@@ -0,0 +1,170 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/mozilla-ai/mcpd/internal/auth"
+	"github.com/mozilla-ai/mcpd/internal/contracts"
+)
+
+// clientAccessor wraps an MCPClientAccessor so the clients it returns record their requests.
+type clientAccessor struct {
+	contracts.MCPClientAccessor
+	recorder Recorder
+	logger   hclog.Logger
+
+	// mu guards clients.
+	mu sync.Mutex
+
+	// clients holds the wrapper of each server's client, so repeated lookups return the same client.
+	clients map[string]*auditedClient
+}
+
+// auditedClient wraps an MCP client to record the tool calls, prompt gets and resource reads made with it.
+type auditedClient struct {
+	client.MCPClient
+	server   string
+	recorder Recorder
+	logger   hclog.Logger
+}
+
+// NewClientAccessor returns an accessor whose clients record every tool call, prompt get and resource read
+// made with them. The caller is taken from the identity (see auth.FromContext) in the context of each request.
+// Failures to record are logged, and don't fail the request.
+func NewClientAccessor(
+	accessor contracts.MCPClientAccessor,
+	recorder Recorder,
+	logger hclog.Logger,
+) contracts.MCPClientAccessor {
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+
+	return &clientAccessor{
+		MCPClientAccessor: accessor,
+		recorder:          recorder,
+		logger:            logger,
+		clients:           make(map[string]*auditedClient),
+	}
+}
+
+// Client returns the client for the given server name, wrapped to record its requests.
+// The same wrapper is returned until the server's client changes (e.g. the server was restarted).
+func (a *clientAccessor) Client(name string) (client.MCPClient, bool) {
+	c, ok := a.MCPClientAccessor.Client(name)
+	if !ok {
+		return nil, false
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if audited, ok := a.clients[name]; ok && audited.MCPClient == c {
+		return audited, true
+	}
+
+	audited := &auditedClient{
+		MCPClient: c,
+		server:    name,
+		recorder:  a.recorder,
+		logger:    a.logger,
+	}
+	a.clients[name] = audited
+
+	return audited, true
+}
+
+// Remove deletes the client and its tools by server name, along with the client's wrapper.
+func (a *clientAccessor) Remove(name string) {
+	a.MCPClientAccessor.Remove(name)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	delete(a.clients, name)
+}
+
+// Unwrap implements contracts.MCPClientUnwrapper, returning the client whose requests are recorded.
+func (c *auditedClient) Unwrap() client.MCPClient {
+	return c.MCPClient
+}
+
+// CallTool calls the tool and records the call.
+// Tool results which are errors are recorded with an error outcome.
+func (c *auditedClient) CallTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	started := time.Now()
+	result, err := c.MCPClient.CallTool(ctx, request)
+
+	recordErr := err
+	if recordErr == nil && result != nil && result.IsError {
+		recordErr = fmt.Errorf("tool returned an error result")
+	}
+
+	c.record(ctx, Event{
+		Operation: OperationToolCall,
+		Tool:      request.Params.Name,
+		Arguments: request.Params.Arguments,
+		Err:       recordErr,
+		Started:   started,
+		Latency:   time.Since(started),
+	})
+
+	return result, err
+}
+
+// GetPrompt gets the prompt and records the request.
+func (c *auditedClient) GetPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	started := time.Now()
+	result, err := c.MCPClient.GetPrompt(ctx, request)
+
+	c.record(ctx, Event{
+		Operation: OperationPromptGet,
+		Prompt:    request.Params.Name,
+		Arguments: request.Params.Arguments,
+		Err:       err,
+		Started:   started,
+		Latency:   time.Since(started),
+	})
+
+	return result, err
+}
+
+// ReadResource reads the resource and records the read.
+func (c *auditedClient) ReadResource(
+	ctx context.Context,
+	request mcp.ReadResourceRequest,
+) (*mcp.ReadResourceResult, error) {
+	started := time.Now()
+	result, err := c.MCPClient.ReadResource(ctx, request)
+
+	c.record(ctx, Event{
+		Operation: OperationResourceRead,
+		Resource:  request.Params.URI,
+		Arguments: request.Params.Arguments,
+		Err:       err,
+		Started:   started,
+		Latency:   time.Since(started),
+	})
+
+	return result, err
+}
+
+// record completes the event with the server and the caller from the context, and records it.
+func (c *auditedClient) record(ctx context.Context, event Event) {
+	event.Server = c.server
+	if identity, ok := auth.FromContext(ctx); ok {
+		event.Caller = identity.Name
+		event.AuthMethod = identity.Method
+	}
+
+	if err := c.recorder.Record(event); err != nil {
+		c.logger.Error("Failed to record audit event", "operation", event.Operation, "server", c.server, "error", err)
+	}
+}
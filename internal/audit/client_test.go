@@ -0,0 +1,151 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/auth"
+	"github.com/mozilla-ai/mcpd/internal/contracts"
+)
+
+// testRecorder keeps the events it records.
+type testRecorder struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (r *testRecorder) Record(event Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, event)
+	return nil
+}
+
+// testClient answers tool calls, prompt gets and resource reads, leaving other methods unimplemented.
+type testClient struct {
+	client.MCPClient
+	toolResult *mcp.CallToolResult
+	err        error
+}
+
+func (c *testClient) CallTool(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return c.toolResult, c.err
+}
+
+func (c *testClient) GetPrompt(context.Context, mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	return &mcp.GetPromptResult{}, c.err
+}
+
+func (c *testClient) ReadResource(context.Context, mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	return &mcp.ReadResourceResult{}, c.err
+}
+
+// testAccessor returns its clients, leaving other methods unimplemented.
+type testAccessor struct {
+	contracts.MCPClientAccessor
+	clients map[string]client.MCPClient
+}
+
+func (a *testAccessor) Add(name string, c client.MCPClient, _ []string) {
+	a.clients[name] = c
+}
+
+func (a *testAccessor) Client(name string) (client.MCPClient, bool) {
+	c, ok := a.clients[name]
+	return c, ok
+}
+
+func (a *testAccessor) Remove(name string) {
+	delete(a.clients, name)
+}
+
+func TestClientAccessor_SameClient(t *testing.T) {
+	t.Parallel()
+
+	before := &testClient{}
+	clients := map[string]client.MCPClient{"time": before}
+	accessor := NewClientAccessor(&testAccessor{clients: clients}, &testRecorder{}, nil)
+
+	// Repeated lookups return the same wrapper.
+	first, ok := accessor.Client("time")
+	require.True(t, ok)
+	second, ok := accessor.Client("time")
+	require.True(t, ok)
+	require.Same(t, first, second)
+	require.Same(t, before, first.(contracts.MCPClientUnwrapper).Unwrap())
+
+	// The server was restarted, so its new client is wrapped.
+	after := &testClient{}
+	accessor.Add("time", after, nil)
+	restarted, ok := accessor.Client("time")
+	require.True(t, ok)
+	require.NotSame(t, first, restarted)
+	require.Same(t, after, restarted.(contracts.MCPClientUnwrapper).Unwrap())
+
+	accessor.Remove("time")
+	_, ok = accessor.Client("time")
+	require.False(t, ok)
+}
+
+func TestClientAccessor(t *testing.T) {
+	t.Parallel()
+
+	recorder := &testRecorder{}
+	accessor := NewClientAccessor(&testAccessor{clients: map[string]client.MCPClient{
+		"time":  &testClient{toolResult: &mcp.CallToolResult{IsError: true}},
+		"files": &testClient{err: fmt.Errorf("connection closed")},
+	}}, recorder, nil)
+
+	_, ok := accessor.Client("missing")
+	require.False(t, ok)
+
+	ctx := auth.NewContext(context.Background(), auth.Identity{Name: "agent", Method: "api_key"})
+
+	timeClient, ok := accessor.Client("time")
+	require.True(t, ok)
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "now", Arguments: map[string]any{"tz": "UTC"}}}
+	result, err := timeClient.CallTool(ctx, req)
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+
+	_, err = timeClient.GetPrompt(context.Background(), mcp.GetPromptRequest{
+		Params: mcp.GetPromptParams{Name: "summarize", Arguments: map[string]string{"style": "brief"}},
+	})
+	require.NoError(t, err)
+
+	filesClient, ok := accessor.Client("files")
+	require.True(t, ok)
+	_, err = filesClient.ReadResource(ctx, mcp.ReadResourceRequest{Params: mcp.ReadResourceParams{URI: "file:///a"}})
+	require.EqualError(t, err, "connection closed")
+
+	require.Len(t, recorder.events, 3)
+
+	call := recorder.events[0]
+	require.Equal(t, OperationToolCall, call.Operation)
+	require.Equal(t, "agent", call.Caller)
+	require.Equal(t, "api_key", call.AuthMethod)
+	require.Equal(t, "time", call.Server)
+	require.Equal(t, "now", call.Tool)
+	require.Equal(t, map[string]any{"tz": "UTC"}, call.Arguments)
+	require.EqualError(t, call.Err, "tool returned an error result")
+	require.False(t, call.Started.IsZero())
+
+	prompt := recorder.events[1]
+	require.Equal(t, OperationPromptGet, prompt.Operation)
+	require.Empty(t, prompt.Caller)
+	require.Equal(t, "summarize", prompt.Prompt)
+	require.NoError(t, prompt.Err)
+
+	read := recorder.events[2]
+	require.Equal(t, OperationResourceRead, read.Operation)
+	require.Equal(t, "files", read.Server)
+	require.Equal(t, "file:///a", read.Resource)
+	require.EqualError(t, read.Err, "connection closed")
+}
@@ -0,0 +1,453 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mozilla-ai/mcpd/internal/config"
+)
+
+const (
+	// DefaultMaxSizeMB is the size in megabytes the audit log file reaches before it is rotated, when not configured.
+	DefaultMaxSizeMB = 100
+
+	// DefaultMaxFiles is the number of rotated audit log files which are kept, when not configured.
+	DefaultMaxFiles = 10
+
+	// rotatedTimeFormat is the format of the time in the names of rotated audit log files,
+	// which sorts lexically in the order the files were rotated.
+	rotatedTimeFormat = "20060102T150405.000000000Z"
+)
+
+// Options configures the audit log.
+type Options struct {
+	// Path of the JSON Lines file the audit log is written to.
+	Path string
+
+	// Arguments is how the arguments of requests are recorded (see config.AuditArgumentModes).
+	Arguments string
+
+	// RedactKeys are the patterns of the argument keys whose values are redacted, when arguments are recorded.
+	RedactKeys []string
+
+	// MaxSize is the size in bytes the file can reach before it is rotated.
+	MaxSize int64
+
+	// MaxFiles is the number of rotated files which are kept, 0 keeps all files.
+	MaxFiles int
+}
+
+// NewOptions returns the options for the configured audit log, applying defaults for settings which aren't set.
+func NewOptions(cfg *config.AuditConfigSection) (Options, error) {
+	if cfg == nil || cfg.Path == nil || strings.TrimSpace(*cfg.Path) == "" {
+		return Options{}, fmt.Errorf("audit log path is required")
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Options{}, err
+	}
+
+	opts := Options{
+		Path:       *cfg.Path,
+		Arguments:  config.AuditArgumentsHash,
+		RedactKeys: DefaultRedactKeys(),
+		MaxSize:    DefaultMaxSizeMB << 20,
+		MaxFiles:   DefaultMaxFiles,
+	}
+	if cfg.Arguments != nil {
+		opts.Arguments = *cfg.Arguments
+	}
+	if len(cfg.RedactKeys) > 0 {
+		opts.RedactKeys = slices.Clone(cfg.RedactKeys)
+	}
+	if cfg.MaxSizeMB != nil {
+		opts.MaxSize = int64(*cfg.MaxSizeMB) << 20
+	}
+	if cfg.MaxFiles != nil {
+		opts.MaxFiles = *cfg.MaxFiles
+	}
+
+	return opts, nil
+}
+
+// Log is a Recorder which appends hash chained records to a JSON Lines file, rotating the file as it grows.
+// The chain continues across rotated files, and across restarts of the daemon.
+// Log is safe for concurrent use.
+type Log struct {
+	mu       sync.Mutex
+	opts     Options
+	redactor redactor
+	file     *os.File
+	size     int64
+	lastHash string
+	now      func() time.Time
+}
+
+// Open opens (or creates) the audit log file, continuing the hash chain from the last record written to it.
+// A partial record at the end of the file, left by a write which was interrupted (e.g. by a crash), is removed.
+func Open(opts Options) (*Log, error) {
+	if strings.TrimSpace(opts.Path) == "" {
+		return nil, fmt.Errorf("audit log path is required")
+	}
+	if opts.MaxSize <= 0 {
+		return nil, fmt.Errorf("audit log max size must be positive")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(opts.Path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+
+	if err := removePartialRecord(opts.Path); err != nil {
+		return nil, err
+	}
+
+	lastHash, err := chainHead(opts.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &Log{
+		opts:     opts,
+		redactor: newRedactor(opts.RedactKeys),
+		lastHash: lastHash,
+		now:      time.Now,
+	}
+	if err := l.openFile(); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// Record appends a record of the event to the audit log.
+func (l *Log) Record(event Event) error {
+	record, err := l.newRecord(event)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return fmt.Errorf("audit log is closed")
+	}
+
+	record.PrevHash = l.lastHash
+	if record.Hash, err = record.computeHash(); err != nil {
+		return fmt.Errorf("failed to hash audit record: %w", err)
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record: %w", err)
+	}
+	line = append(line, '\n')
+
+	// The record is still written when the file couldn't be rotated, provided it's open.
+	var rotateErr error
+	if l.size > 0 && l.size+int64(len(line)) > l.opts.MaxSize {
+		if rotateErr = l.rotate(); l.file == nil {
+			return rotateErr
+		}
+	}
+
+	n, err := l.file.Write(line)
+	l.size += int64(n)
+	if err != nil {
+		return errors.Join(rotateErr, fmt.Errorf("failed to write audit record: %w", err))
+	}
+
+	l.lastHash = record.Hash
+
+	return rotateErr
+}
+
+// Close closes the audit log file.
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+
+	err := l.file.Close()
+	l.file = nil
+
+	return err
+}
+
+// newRecord returns the record of the event, without its place in the chain.
+func (l *Log) newRecord(event Event) (Record, error) {
+	started := event.Started
+	if started.IsZero() {
+		started = l.now()
+	}
+
+	record := Record{
+		Time:       started.UTC(),
+		Operation:  event.Operation,
+		Caller:     event.Caller,
+		AuthMethod: event.AuthMethod,
+		Server:     event.Server,
+		Tool:       event.Tool,
+		Prompt:     event.Prompt,
+		Resource:   event.Resource,
+		Outcome:    OutcomeSuccess,
+		LatencyMS:  event.Latency.Milliseconds(),
+	}
+	if event.Err != nil {
+		record.Outcome = OutcomeError
+		record.Error = event.Err.Error()
+	}
+
+	if event.Arguments == nil {
+		return record, nil
+	}
+
+	data, err := json.Marshal(event.Arguments)
+	if err != nil {
+		return Record{}, fmt.Errorf("failed to encode audit record arguments: %w", err)
+	}
+	if string(data) == "null" {
+		return record, nil
+	}
+
+	// Only the redacted arguments are recorded, since the hash of low-entropy values (e.g. PINs) can be reversed.
+	if l.opts.Arguments == config.AuditArgumentsRedacted {
+		var decoded any
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			return Record{}, fmt.Errorf("failed to decode audit record arguments: %w", err)
+		}
+		if record.Arguments, err = json.Marshal(l.redactor.redact(decoded)); err != nil {
+			return Record{}, fmt.Errorf("failed to encode audit record arguments: %w", err)
+		}
+		return record, nil
+	}
+	record.ArgumentsHash = hashBytes(data)
+
+	return record, nil
+}
+
+// openFile opens the audit log file for appending.
+func (l *Log) openFile() error {
+	f, err := os.OpenFile(l.opts.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+
+	l.file = f
+	l.size = info.Size()
+
+	return nil
+}
+
+// rotate renames the current file with the time it was rotated, opens a new file,
+// and removes the oldest rotated files beyond the number that are kept.
+func (l *Log) rotate() error {
+	if err := l.file.Close(); err != nil {
+		return fmt.Errorf("failed to close audit log for rotation: %w", err)
+	}
+	l.file = nil
+
+	renameErr := os.Rename(l.opts.Path, rotatedPath(l.opts.Path, l.now()))
+
+	// Reopen the file even when it couldn't be renamed, so records continue to be written to it.
+	if err := l.openFile(); err != nil {
+		return err
+	}
+	if renameErr != nil {
+		return fmt.Errorf("failed to rotate audit log: %w", renameErr)
+	}
+
+	if l.opts.MaxFiles <= 0 {
+		return nil
+	}
+
+	rotated, err := rotatedFiles(l.opts.Path)
+	if err != nil {
+		return err
+	}
+	for len(rotated) > l.opts.MaxFiles {
+		if err := os.Remove(rotated[0]); err != nil {
+			return fmt.Errorf("failed to remove rotated audit log: %w", err)
+		}
+		rotated = rotated[1:]
+	}
+
+	return nil
+}
+
+// Files returns the rotated audit log files for the path, oldest first, followed by the path itself when it exists.
+func Files(path string) ([]string, error) {
+	files, err := rotatedFiles(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		files = append(files, path)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	return files, nil
+}
+
+// rotatedPath returns the name of the file the audit log at the path is rotated to, e.g.
+// 'audit.20250102T150405.000000000Z.jsonl' for 'audit.jsonl'.
+func rotatedPath(path string, at time.Time) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + at.UTC().Format(rotatedTimeFormat) + ext
+}
+
+// rotatedFiles returns the rotated files of the audit log at the path, oldest first.
+func rotatedFiles(path string) ([]string, error) {
+	ext := filepath.Ext(path)
+	matches, err := filepath.Glob(escapeGlob(strings.TrimSuffix(path, ext)) + ".*" + escapeGlob(ext))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rotated audit logs: %w", err)
+	}
+
+	rotated := make([]string, 0, len(matches))
+	for _, match := range matches {
+		stamp := strings.TrimSuffix(strings.TrimPrefix(match, strings.TrimSuffix(path, ext)+"."), ext)
+		if _, err := time.Parse(rotatedTimeFormat, stamp); err == nil {
+			rotated = append(rotated, match)
+		}
+	}
+	slices.Sort(rotated)
+
+	return rotated, nil
+}
+
+// escapeGlob escapes the characters in the path which have a special meaning in glob patterns.
+func escapeGlob(path string) string {
+	var b strings.Builder
+	for _, r := range path {
+		if strings.ContainsRune(`*?[\`, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// chainHead returns the hash of the last record written to the audit log at the path,
+// or to its latest rotated file when the current file is empty.
+// An empty hash is returned when nothing has been written yet.
+func chainHead(path string) (string, error) {
+	files, err := Files(path)
+	if err != nil {
+		return "", err
+	}
+
+	for _, file := range slices.Backward(files) {
+		hash, err := lastHash(file)
+		if err != nil {
+			return "", err
+		}
+		if hash != "" {
+			return hash, nil
+		}
+	}
+
+	return "", nil
+}
+
+// removePartialRecord truncates the audit log file at the path to the end of its last complete line,
+// removing a record which was only partially written. Records are always written with a trailing newline.
+func removePartialRecord(path string) error {
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+
+	// Search backwards from the end of the file for the newline ending the last complete line.
+	end := info.Size()
+	buf := make([]byte, 4096)
+	for end > 0 {
+		n := min(int64(len(buf)), end)
+		chunk := buf[:n]
+		if _, err := f.ReadAt(chunk, end-n); err != nil {
+			return fmt.Errorf("failed to read audit log: %w", err)
+		}
+		if i := bytes.LastIndexByte(chunk, '\n'); i >= 0 {
+			end = end - n + int64(i) + 1
+			break
+		}
+		end -= n
+	}
+
+	if end == info.Size() {
+		return nil
+	}
+
+	if err := f.Truncate(end); err != nil {
+		return fmt.Errorf("failed to remove partial record from audit log: %w", err)
+	}
+
+	return nil
+}
+
+// lastHash returns the hash of the last record in the file, or an empty hash when the file has no records.
+func lastHash(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read audit log: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var last []byte
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			last = line
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to read audit log: %w", err)
+		}
+	}
+
+	if last == nil {
+		return "", nil
+	}
+
+	var record Record
+	if err := json.Unmarshal(last, &record); err != nil {
+		return "", fmt.Errorf("failed to read last record of audit log '%s': %w", path, err)
+	}
+
+	return record.Hash, nil
+}
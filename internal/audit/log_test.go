@@ -0,0 +1,304 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/config"
+)
+
+func TestLog_Record(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit", "audit.jsonl")
+	opts := Options{
+		Path:       path,
+		Arguments:  config.AuditArgumentsRedacted,
+		RedactKeys: DefaultRedactKeys(),
+		MaxSize:    1 << 20,
+	}
+
+	l, err := Open(opts)
+	require.NoError(t, err)
+
+	started := time.Date(2025, 1, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600))
+	require.NoError(t, l.Record(Event{
+		Operation:  OperationToolCall,
+		Caller:     "agent",
+		AuthMethod: "api_key",
+		Server:     "github",
+		Tool:       "create_issue",
+		Arguments:  map[string]any{"title": "bug", "api_token": "t0k3n"},
+		Started:    started,
+		Latency:    1500 * time.Millisecond,
+	}))
+	require.NoError(t, l.Record(Event{
+		Operation: OperationResourceRead,
+		Server:    "files",
+		Resource:  "file:///etc/hosts",
+		Err:       fmt.Errorf("not found"),
+	}))
+	require.NoError(t, l.Close())
+	require.ErrorContains(t, l.Record(Event{Operation: OperationToolCall}), "audit log is closed")
+
+	// Reopening continues the chain.
+	l, err = Open(opts)
+	require.NoError(t, err)
+	require.NoError(t, l.Record(Event{Operation: OperationPromptGet, Server: "time", Prompt: "summarize"}))
+	require.NoError(t, l.Close())
+
+	records := readRecords(t, path)
+	require.Len(t, records, 3)
+
+	first := records[0]
+	require.Equal(t, started.UTC(), first.Time)
+	require.Equal(t, "agent", first.Caller)
+	require.Equal(t, OutcomeSuccess, first.Outcome)
+	require.Equal(t, int64(1500), first.LatencyMS)
+	require.Empty(t, first.PrevHash)
+	require.Empty(t, first.ArgumentsHash, "the hash of the unredacted arguments could reveal redacted values")
+	require.JSONEq(t, `{"title":"bug","api_token":"[REDACTED]"}`, string(first.Arguments))
+
+	require.Equal(t, OutcomeError, records[1].Outcome)
+	require.Equal(t, "not found", records[1].Error)
+	require.Equal(t, first.Hash, records[1].PrevHash)
+	require.Equal(t, records[1].Hash, records[2].PrevHash)
+
+	result, err := Verify([]string{path}, VerifyOptions{})
+	require.NoError(t, err)
+	require.Equal(t, VerifyResult{Files: 1, Records: 3}, result)
+}
+
+func TestLog_Record_ArgumentsHash(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	l, err := Open(Options{Path: path, Arguments: config.AuditArgumentsHash, MaxSize: 1 << 20})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+
+	args := map[string]any{"b": 2, "a": 1}
+	require.NoError(t, l.Record(Event{Operation: OperationToolCall, Arguments: args}))
+	require.NoError(t, l.Record(Event{Operation: OperationToolCall, Arguments: map[string]any{"a": 1, "b": 2}}))
+	require.NoError(t, l.Record(Event{Operation: OperationToolCall, Arguments: map[string]any(nil)}))
+
+	records := readRecords(t, path)
+	require.Len(t, records, 3)
+	require.Nil(t, records[0].Arguments)
+	require.Equal(t, records[0].ArgumentsHash, records[1].ArgumentsHash)
+	require.Empty(t, records[2].ArgumentsHash)
+}
+
+func TestLog_Rotate(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.jsonl")
+
+	l, err := Open(Options{Path: path, MaxSize: 1, MaxFiles: 2})
+	require.NoError(t, err)
+
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	l.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+
+	// Each record rotates the file holding the previous record.
+	for i := range 5 {
+		event := Event{Operation: OperationToolCall, Server: "time", Tool: fmt.Sprint(i), Started: time.Now()}
+		require.NoError(t, l.Record(event))
+	}
+	require.NoError(t, l.Close())
+
+	files, err := Files(path)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		filepath.Join(dir, "audit.20250102T030408.000000000Z.jsonl"),
+		filepath.Join(dir, "audit.20250102T030409.000000000Z.jsonl"),
+		path,
+	}, files)
+
+	// The oldest records were removed with their files, which fails verification unless it's allowed.
+	_, err = Verify(files, VerifyOptions{})
+	require.ErrorIs(t, err, ErrTruncated)
+	require.ErrorContains(t, err, files[0]+":1: ")
+
+	result, err := Verify(files, VerifyOptions{AllowTruncated: true})
+	require.NoError(t, err)
+	require.Equal(t, VerifyResult{Files: 3, Records: 3, Truncated: true}, result)
+
+	// The chain continues from the latest rotated file when the current file is empty.
+	require.NoError(t, os.Truncate(path, 0))
+	head, err := chainHead(path)
+	require.NoError(t, err)
+	require.Equal(t, readRecords(t, files[1])[0].Hash, head)
+}
+
+func TestOpen_PartialRecord(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		records int
+	}{
+		{name: "after complete records", records: 2},
+		{name: "only record", records: 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "audit.jsonl")
+			l, err := Open(Options{Path: path, MaxSize: 1 << 20})
+			require.NoError(t, err)
+			for i := range tc.records {
+				require.NoError(t, l.Record(Event{Operation: OperationToolCall, Server: "time", Tool: fmt.Sprint(i)}))
+			}
+			require.NoError(t, l.Close())
+
+			// A crash while writing a record leaves a partial line at the end of the file.
+			f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+			require.NoError(t, err)
+			_, err = f.WriteString(`{"time":"2025-01-02T03:04:05Z","operation":"tool_`)
+			require.NoError(t, err)
+			require.NoError(t, f.Close())
+
+			// The partial record is removed, and the chain continues from the last complete record.
+			l, err = Open(Options{Path: path, MaxSize: 1 << 20})
+			require.NoError(t, err)
+			require.NoError(t, l.Record(Event{Operation: OperationToolCall, Server: "time", Tool: "after"}))
+			require.NoError(t, l.Close())
+
+			records := readRecords(t, path)
+			require.Len(t, records, tc.records+1)
+			require.Equal(t, "after", records[tc.records].Tool)
+
+			result, err := Verify([]string{path}, VerifyOptions{})
+			require.NoError(t, err)
+			require.Equal(t, VerifyResult{Files: 1, Records: tc.records + 1}, result)
+		})
+	}
+}
+
+func TestVerify_Tampering(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		tamper  func(lines []string) []string
+		wantErr string
+	}{
+		{
+			name: "modified record",
+			tamper: func(lines []string) []string {
+				lines[1] = strings.Replace(lines[1], `"server":"time"`, `"server":"other"`, 1)
+				return lines
+			},
+			wantErr: "audit.jsonl:2: record hash mismatch, the record was modified",
+		},
+		{
+			name: "removed record",
+			tamper: func(lines []string) []string {
+				return append(lines[:1], lines[2:]...)
+			},
+			wantErr: "audit.jsonl:2: record does not follow the previous record",
+		},
+		{
+			name: "reordered records",
+			tamper: func(lines []string) []string {
+				lines[1], lines[2] = lines[2], lines[1]
+				return lines
+			},
+			wantErr: "audit.jsonl:2: record does not follow the previous record",
+		},
+		{
+			name: "invalid record",
+			tamper: func(lines []string) []string {
+				return append(lines, `{"time":`)
+			},
+			wantErr: "audit.jsonl:4: record is not valid JSON",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "audit.jsonl")
+			l, err := Open(Options{Path: path, MaxSize: 1 << 20})
+			require.NoError(t, err)
+			for i := range 3 {
+				require.NoError(t, l.Record(Event{Operation: OperationToolCall, Server: "time", Tool: fmt.Sprint(i)}))
+			}
+			require.NoError(t, l.Close())
+
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			lines := tc.tamper(strings.Split(strings.TrimSpace(string(data)), "\n"))
+			require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o600))
+
+			_, err = Verify([]string{path}, VerifyOptions{})
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestNewOptions(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewOptions(&config.AuditConfigSection{})
+	require.EqualError(t, err, "audit log path is required")
+
+	path := "audit.jsonl"
+	opts, err := NewOptions(&config.AuditConfigSection{Path: &path})
+	require.NoError(t, err)
+	require.Equal(t, Options{
+		Path:       path,
+		Arguments:  config.AuditArgumentsHash,
+		RedactKeys: DefaultRedactKeys(),
+		MaxSize:    DefaultMaxSizeMB << 20,
+		MaxFiles:   DefaultMaxFiles,
+	}, opts)
+
+	size, files := 5, 0
+	opts, err = NewOptions(&config.AuditConfigSection{
+		Path:       &path,
+		RedactKeys: []string{"*pin*"},
+		MaxSizeMB:  &size,
+		MaxFiles:   &files,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"*pin*"}, opts.RedactKeys)
+	require.Equal(t, int64(5<<20), opts.MaxSize)
+	require.Zero(t, opts.MaxFiles)
+}
+
+// readRecords returns the records in the audit log file.
+func readRecords(t *testing.T, path string) []Record {
+	t.Helper()
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record Record
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	require.NoError(t, scanner.Err())
+
+	return records
+}
@@ -0,0 +1,77 @@
+package audit
+
+import (
+	"path"
+	"strings"
+)
+
+// RedactedValue replaces the values of redacted arguments.
+const RedactedValue = "[REDACTED]"
+
+// DefaultRedactKeys returns the patterns of the argument keys which are redacted when none are configured.
+func DefaultRedactKeys() []string {
+	return []string{
+		"*password*",
+		"*secret*",
+		"*token*",
+		"*api_key*",
+		"*apikey*",
+		"*authorization*",
+		"*credential*",
+	}
+}
+
+// redactor replaces the values of argument keys which match any of its patterns.
+// Patterns are glob patterns (see path.Match) which are matched against lower case keys.
+type redactor struct {
+	patterns []string
+}
+
+// newRedactor returns a redactor for the patterns, which are lower cased.
+func newRedactor(patterns []string) redactor {
+	lowered := make([]string, 0, len(patterns))
+	for _, p := range patterns {
+		if p = strings.ToLower(strings.TrimSpace(p)); p != "" {
+			lowered = append(lowered, p)
+		}
+	}
+
+	return redactor{patterns: lowered}
+}
+
+// redact returns a copy of the value with redacted values replaced, at any depth of nested objects and arrays.
+// The value is expected to be decoded JSON.
+func (r redactor) redact(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+		for key, val := range v {
+			if r.matches(key) {
+				redacted[key] = RedactedValue
+				continue
+			}
+			redacted[key] = r.redact(val)
+		}
+		return redacted
+	case []any:
+		redacted := make([]any, len(v))
+		for i, val := range v {
+			redacted[i] = r.redact(val)
+		}
+		return redacted
+	default:
+		return v
+	}
+}
+
+// matches returns true when the key matches any of the patterns.
+func (r redactor) matches(key string) bool {
+	key = strings.ToLower(key)
+	for _, pattern := range r.patterns {
+		if matched, err := path.Match(pattern, key); err == nil && matched {
+			return true
+		}
+	}
+
+	return false
+}
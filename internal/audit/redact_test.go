@@ -0,0 +1,57 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactor_Redact(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		patterns []string
+		value    any
+		want     any
+	}{
+		{
+			name:     "default patterns ignore case",
+			patterns: DefaultRedactKeys(),
+			value:    map[string]any{"Password": "hunter2", "GITHUB_TOKEN": "ghp", "query": "mcpd"},
+			want:     map[string]any{"Password": RedactedValue, "GITHUB_TOKEN": RedactedValue, "query": "mcpd"},
+		},
+		{
+			name:     "nested objects and arrays",
+			patterns: []string{"pin"},
+			value: map[string]any{
+				"cards": []any{map[string]any{"number": "4242", "pin": "1234"}},
+				"pin":   map[string]any{"value": "1234"},
+			},
+			want: map[string]any{
+				"cards": []any{map[string]any{"number": "4242", "pin": RedactedValue}},
+				"pin":   RedactedValue,
+			},
+		},
+		{
+			name:     "no patterns",
+			patterns: nil,
+			value:    map[string]any{"password": "hunter2"},
+			want:     map[string]any{"password": "hunter2"},
+		},
+		{
+			name:     "scalar values",
+			patterns: []string{"*"},
+			value:    "hunter2",
+			want:     "hunter2",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.want, newRedactor(tc.patterns).redact(tc.value))
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ErrTruncated is returned by Verify when the first record follows records which are no longer present,
+// unless the log is allowed to be truncated.
+var ErrTruncated = errors.New("the first record follows records which are no longer present")
+
+// VerifyOptions configures how the audit log is verified.
+type VerifyOptions struct {
+	// AllowTruncated accepts a log whose first record follows records which are no longer present,
+	// e.g. because the oldest rotated files were removed beyond the number of files that are kept.
+	AllowTruncated bool
+}
+
+// VerifyResult summarizes the audit log files which were verified.
+type VerifyResult struct {
+	// Files is the number of files verified.
+	Files int
+
+	// Records is the number of records verified.
+	Records int
+
+	// Truncated is true when the first record follows records which are no longer present,
+	// e.g. because the oldest rotated files were removed.
+	Truncated bool
+}
+
+// Verify checks the hash chain across the audit log files, which must be given oldest first (see Files).
+// An error identifying the file and line is returned for the first record which was modified,
+// or whose place in the chain shows that records were removed, inserted or reordered.
+// Records removed from the start of the log fail verification with ErrTruncated, unless truncation is allowed.
+func Verify(files []string, opts VerifyOptions) (VerifyResult, error) {
+	var result VerifyResult
+	var prevHash string
+
+	for _, file := range files {
+		err := verifyFile(file, opts, &result, &prevHash)
+		if err != nil {
+			return result, err
+		}
+		result.Files++
+	}
+
+	return result, nil
+}
+
+// verifyFile checks the records in the file, continuing the chain from the previous hash.
+func verifyFile(path string, opts VerifyOptions, result *VerifyResult, prevHash *string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to read audit log: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	reader := bufio.NewReader(f)
+	for lineNum := 1; ; lineNum++ {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("failed to read audit log: %w", err)
+		}
+
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			if verr := verifyRecord(line, opts, result, prevHash); verr != nil {
+				return fmt.Errorf("%s:%d: %w", path, lineNum, verr)
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+	}
+}
+
+// verifyRecord checks the record's hash, and that it follows the previous record in the chain.
+func verifyRecord(line []byte, opts VerifyOptions, result *VerifyResult, prevHash *string) error {
+	var record Record
+	if err := json.Unmarshal(line, &record); err != nil {
+		return fmt.Errorf("record is not valid JSON: %w", err)
+	}
+
+	hash, err := record.computeHash()
+	if err != nil {
+		return fmt.Errorf("failed to hash record: %w", err)
+	}
+	if hash != record.Hash {
+		return fmt.Errorf("record hash mismatch, the record was modified")
+	}
+
+	if result.Records == 0 {
+		result.Truncated = record.PrevHash != ""
+		if result.Truncated && !opts.AllowTruncated {
+			return ErrTruncated
+		}
+	} else if record.PrevHash != *prevHash {
+		return fmt.Errorf("record does not follow the previous record, records were removed, inserted or reordered")
+	}
+
+	*prevHash = record.Hash
+	result.Records++
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mozilla-ai/mcpd/internal/config"
+)
+
+const (
+	// MethodAPIKey is the authentication method of callers identified by an API key.
+	MethodAPIKey = "api_key"
+
+	// apiKeyPrefix prefixes generated API keys, so they can be recognized (e.g. by secret scanners).
+	apiKeyPrefix = "mcpd_"
+
+	// apiKeySize is the number of random bytes in a generated API key.
+	apiKeySize = 32
+)
+
+// APIKeys authenticates callers presenting one of the configured API keys.
+// Keys are verified against their configured hashes, the keys themselves are never held.
+// NewAPIKeys should be used to create instances of APIKeys.
+type APIKeys struct {
+	keys []apiKey
+}
+
+// apiKey is a configured API key, and the identity of callers presenting it.
+type apiKey struct {
+	hash     []byte
+	identity Identity
+}
+
+// NewAPIKeys creates an APIKeys authenticator for the configured API keys.
+func NewAPIKeys(entries []config.APIKeyEntry) (*APIKeys, error) {
+	keys := make([]apiKey, 0, len(entries))
+	for _, entry := range entries {
+		digest, _ := strings.CutPrefix(strings.TrimSpace(entry.Hash), config.APIKeyHashPrefix)
+		hash, err := hex.DecodeString(digest)
+		if err != nil || len(hash) != sha256.Size {
+			return nil, fmt.Errorf("API key '%s' has an invalid hash", entry.Name)
+		}
+
+		keys = append(keys, apiKey{
+			hash: hash,
+			identity: Identity{
+				Name:     strings.TrimSpace(entry.Name),
+				Method:   MethodAPIKey,
+				Servers:  slices.Clone(entry.Servers),
+				Tools:    slices.Clone(entry.Tools),
+				ReadOnly: entry.ReadOnly,
+			},
+		})
+	}
+
+	return &APIKeys{keys: keys}, nil
+}
+
+// Authenticate implements Authenticator.
+func (k *APIKeys) Authenticate(_ context.Context, token string) (Identity, error) {
+	if token == "" {
+		return Identity{}, unauthorized("missing API key")
+	}
+
+	hash := sha256.Sum256([]byte(token))
+
+	// Compare against every key, so the time taken doesn't reveal which keys were compared.
+	var identity Identity
+	found := false
+	for _, key := range k.keys {
+		if subtle.ConstantTimeCompare(hash[:], key.hash) == 1 {
+			identity = key.identity
+			found = true
+		}
+	}
+
+	if !found {
+		return Identity{}, unauthorized("invalid API key")
+	}
+
+	return identity, nil
+}
+
+// GenerateAPIKey returns a new random API key.
+func GenerateAPIKey() (string, error) {
+	b := make([]byte, apiKeySize)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+
+	return apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashAPIKey returns the hash of the API key, in the form configured for API keys (see config.APIKeyEntry).
+func HashAPIKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return config.APIKeyHashPrefix + hex.EncodeToString(hash[:])
+}
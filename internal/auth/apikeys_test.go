@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/errors"
+)
+
+func TestAPIKeys_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	key, err := GenerateAPIKey()
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(key, apiKeyPrefix))
+
+	keys, err := NewAPIKeys([]config.APIKeyEntry{
+		{Name: "ops", Hash: HashAPIKey("other-key")},
+		{Name: "ci", Hash: HashAPIKey(key), Servers: []string{"time"}, ReadOnly: true},
+	})
+	require.NoError(t, err)
+
+	identity, err := keys.Authenticate(context.Background(), key)
+	require.NoError(t, err)
+	require.Equal(t, Identity{Name: "ci", Method: MethodAPIKey, Servers: []string{"time"}, ReadOnly: true}, identity)
+
+	_, err = keys.Authenticate(context.Background(), "")
+	require.ErrorIs(t, err, errors.ErrUnauthorized)
+
+	_, err = keys.Authenticate(context.Background(), key+"x")
+	require.ErrorIs(t, err, errors.ErrUnauthorized)
+}
+
+func TestNewAPIKeys_InvalidHash(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewAPIKeys([]config.APIKeyEntry{{Name: "ci", Hash: "sha256:abc"}})
+	require.EqualError(t, err, "API key 'ci' has an invalid hash")
+}
+
+func TestNewAuthenticator(t *testing.T) {
+	t.Parallel()
+
+	authenticator, err := NewAuthenticator(nil)
+	require.NoError(t, err)
+	require.Nil(t, authenticator)
+
+	authenticator, err = NewAuthenticator(&config.APIAuthConfigSection{})
+	require.NoError(t, err)
+	require.Nil(t, authenticator)
+
+	authenticator, err = NewAuthenticator(&config.APIAuthConfigSection{
+		Keys: []config.APIKeyEntry{{Name: "ci", Hash: HashAPIKey("key")}},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, authenticator)
+
+	_, err = NewAuthenticator(&config.APIAuthConfigSection{
+		Keys: []config.APIKeyEntry{{Name: "ci", Hash: "key"}},
+	})
+	require.ErrorContains(t, err, "invalid API auth configuration")
+}
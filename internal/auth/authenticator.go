@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"strings"
+
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/errors"
+)
+
+// Authenticator identifies the callers of the daemon API from the bearer tokens they present.
+type Authenticator interface {
+	// Authenticate returns the identity of the caller presenting the token.
+	// An error wrapping errors.ErrUnauthorized is returned when the token isn't valid,
+	// or wrapping errors.ErrForbidden when the caller is identified but not granted access to anything.
+	Authenticate(ctx context.Context, token string) (Identity, error)
+}
+
+// NewAuthenticator creates an Authenticator for the authentication methods configured for the daemon API.
+// Returns nil when no methods are configured, in which case requests should not be authenticated.
+func NewAuthenticator(cfg *config.APIAuthConfigSection) (Authenticator, error) {
+	if cfg.IsEmpty() {
+		return nil, nil
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid API auth configuration: %w", err)
+	}
+
+	var keys *APIKeys
+	if len(cfg.Keys) > 0 {
+		var err error
+		if keys, err = NewAPIKeys(cfg.Keys); err != nil {
+			return nil, err
+		}
+	}
+
+	var jwt *JWT
+	if cfg.JWT != nil {
+		var err error
+		if jwt, err = NewJWT(cfg.JWT); err != nil {
+			return nil, err
+		}
+	}
+
+	var clientCerts *ClientCerts
+	if len(cfg.ClientCerts) > 0 {
+		clientCerts = NewClientCerts(cfg.ClientCerts)
+	}
+
+	if clientCerts == nil {
+		switch {
+		case keys != nil && jwt != nil:
+			return &methods{apiKeys: keys, jwt: jwt}, nil
+		case jwt != nil:
+			return jwt, nil
+		default:
+			return keys, nil
+		}
+	}
+
+	return &methods{apiKeys: keys, jwt: jwt, clientCerts: clientCerts}, nil
+}
+
+// methods authenticates callers using the configured methods: API keys or JWTs, depending on the form of the token
+// presented, or client certificates when no token is presented.
+type methods struct {
+	apiKeys     *APIKeys
+	jwt         *JWT
+	clientCerts *ClientCerts
+}
+
+// Authenticate implements Authenticator.
+// Tokens made up of three dot separated segments are treated as JWTs, API keys never contain dots.
+func (m *methods) Authenticate(ctx context.Context, token string) (Identity, error) {
+	switch {
+	case m.jwt != nil && (m.apiKeys == nil || strings.Count(token, ".") == 2):
+		return m.jwt.Authenticate(ctx, token)
+	case m.apiKeys != nil:
+		return m.apiKeys.Authenticate(ctx, token)
+	case token == "":
+		return Identity{}, unauthorized("missing client certificate")
+	default:
+		return Identity{}, unauthorized("bearer tokens are not accepted")
+	}
+}
+
+// AuthenticateCertificate implements CertificateAuthenticator.
+// When client certificates aren't configured, the caller must present a bearer token instead.
+func (m *methods) AuthenticateCertificate(ctx context.Context, cert *x509.Certificate) (Identity, error) {
+	if m.clientCerts == nil {
+		return m.Authenticate(ctx, "")
+	}
+
+	return m.clientCerts.AuthenticateCertificate(ctx, cert)
+}
+
+// unauthorized returns an error wrapping errors.ErrUnauthorized with the reason authentication failed.
+func unauthorized(reason string) error {
+	return fmt.Errorf("%w: %s", errors.ErrUnauthorized, reason)
+}
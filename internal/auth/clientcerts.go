@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/errors"
+)
+
+// MethodClientCert is the authentication method of callers identified by a verified client certificate (mTLS).
+const MethodClientCert = "client_cert"
+
+// CertificateAuthenticator identifies the callers of the daemon API from the verified client certificates they
+// present, when the API is served over mutual TLS.
+type CertificateAuthenticator interface {
+	// AuthenticateCertificate returns the identity of the caller presenting the certificate.
+	// The certificate must have been verified against the configured client CA.
+	// An error wrapping errors.ErrForbidden is returned when no access is granted to the certificate's subject.
+	AuthenticateCertificate(ctx context.Context, cert *x509.Certificate) (Identity, error)
+}
+
+// ClientCerts identifies callers by the subject common name of their client certificates.
+// NewClientCerts should be used to create instances of ClientCerts.
+type ClientCerts struct {
+	entries []config.ClientCertEntry
+}
+
+// NewClientCerts creates a ClientCerts authenticator for the configured client certificates.
+func NewClientCerts(entries []config.ClientCertEntry) *ClientCerts {
+	return &ClientCerts{entries: slices.Clone(entries)}
+}
+
+// AuthenticateCertificate implements CertificateAuthenticator.
+// The caller is granted the scope of the first entry matching the certificate's common name.
+func (c *ClientCerts) AuthenticateCertificate(_ context.Context, cert *x509.Certificate) (Identity, error) {
+	commonName := cert.Subject.CommonName
+	for _, entry := range c.entries {
+		if !match(strings.TrimSpace(entry.CommonName), commonName) {
+			continue
+		}
+
+		return Identity{
+			Name:     commonName,
+			Method:   MethodClientCert,
+			Servers:  slices.Clone(entry.Servers),
+			Tools:    slices.Clone(entry.Tools),
+			ReadOnly: entry.ReadOnly,
+		}, nil
+	}
+
+	return Identity{}, fmt.Errorf(
+		"%w: client certificate '%s' isn't granted access to any servers",
+		errors.ErrForbidden,
+		cert.Subject.String(),
+	)
+}
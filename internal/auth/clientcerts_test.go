@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/errors"
+)
+
+func TestClientCerts_AuthenticateCertificate(t *testing.T) {
+	t.Parallel()
+
+	certs := NewClientCerts([]config.ClientCertEntry{
+		{CommonName: "ops.example.com"},
+		{CommonName: "agent-*.example.com", Tools: []string{"time/*"}, ReadOnly: true},
+	})
+
+	identity, err := certs.AuthenticateCertificate(context.Background(), &x509.Certificate{
+		Subject: pkix.Name{CommonName: "agent-1.example.com", Organization: []string{"Example"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, Identity{
+		Name:     "agent-1.example.com",
+		Method:   MethodClientCert,
+		Tools:    []string{"time/*"},
+		ReadOnly: true,
+	}, identity)
+
+	identity, err = certs.AuthenticateCertificate(context.Background(), &x509.Certificate{
+		Subject: pkix.Name{CommonName: "ops.example.com"},
+	})
+	require.NoError(t, err)
+	require.True(t, identity.Unrestricted())
+
+	_, err = certs.AuthenticateCertificate(context.Background(), &x509.Certificate{
+		Subject: pkix.Name{CommonName: "other.example.com"},
+	})
+	require.ErrorIs(t, err, errors.ErrForbidden)
+}
+
+func TestNewAuthenticator_ClientCerts(t *testing.T) {
+	t.Parallel()
+
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "agent"}}
+
+	authenticator, err := NewAuthenticator(&config.APIAuthConfigSection{
+		ClientCerts: []config.ClientCertEntry{{CommonName: "agent"}},
+	})
+	require.NoError(t, err)
+
+	certAuthenticator, ok := authenticator.(CertificateAuthenticator)
+	require.True(t, ok)
+
+	identity, err := certAuthenticator.AuthenticateCertificate(context.Background(), cert)
+	require.NoError(t, err)
+	require.Equal(t, MethodClientCert, identity.Method)
+
+	_, err = authenticator.Authenticate(context.Background(), "")
+	require.ErrorIs(t, err, errors.ErrUnauthorized)
+	require.ErrorContains(t, err, "missing client certificate")
+
+	_, err = authenticator.Authenticate(context.Background(), "key")
+	require.ErrorIs(t, err, errors.ErrUnauthorized)
+
+	// Certificates not matching any entry are forbidden, while API keys continue to be accepted.
+	authenticator, err = NewAuthenticator(&config.APIAuthConfigSection{
+		Keys:        []config.APIKeyEntry{{Name: "ci", Hash: HashAPIKey("key")}},
+		ClientCerts: []config.ClientCertEntry{{CommonName: "other"}},
+	})
+	require.NoError(t, err)
+	certAuthenticator, ok = authenticator.(CertificateAuthenticator)
+	require.True(t, ok)
+
+	_, err = certAuthenticator.AuthenticateCertificate(context.Background(), cert)
+	require.ErrorIs(t, err, errors.ErrForbidden)
+
+	identity, err = authenticator.Authenticate(context.Background(), "key")
+	require.NoError(t, err)
+	require.Equal(t, MethodAPIKey, identity.Method)
+}
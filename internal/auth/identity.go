@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/mozilla-ai/mcpd/internal/filter"
+)
+
+// identityContextKey is the key of the Identity stored in a request's context.
+type identityContextKey struct{}
+
+// Identity is the authenticated caller of the daemon API, along with the scope of what it may access.
+type Identity struct {
+	// Name identifies the caller, e.g. the name of an API key.
+	Name string
+
+	// Method is the authentication method used to identify the caller, e.g. 'api_key'.
+	Method string
+
+	// Servers are the names (or glob patterns) of the servers the caller may access.
+	// When empty, the servers named by Tools may be accessed, or all servers when Tools is also empty.
+	Servers []string
+
+	// Tools are the tools the caller may call, as '<server>/<tool>' names (or glob patterns).
+	// When empty, all tools of the servers the caller may access can be called.
+	Tools []string
+
+	// ReadOnly limits the caller to requests which don't make changes.
+	ReadOnly bool
+}
+
+// NewContext returns a copy of the context which carries the identity.
+func NewContext(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityContextKey{}, identity)
+}
+
+// FromContext returns the identity carried by the context.
+// No identity is present when requests aren't authenticated.
+func FromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityContextKey{}).(Identity)
+	return identity, ok
+}
+
+// Unrestricted returns true when the identity may access all servers and tools.
+func (i Identity) Unrestricted() bool {
+	return len(i.Servers) == 0 && len(i.Tools) == 0
+}
+
+// AllowsServer returns true when the identity may access the server.
+func (i Identity) AllowsServer(server string) bool {
+	server = filter.NormalizeString(server)
+
+	switch {
+	case len(i.Servers) > 0:
+		return matchAny(i.Servers, server)
+	case len(i.Tools) > 0:
+		// Servers are implied by the tools which may be called.
+		for _, pattern := range i.Tools {
+			serverPattern, _, _ := strings.Cut(filter.NormalizeString(pattern), "/")
+			if match(serverPattern, server) {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+// AllowsTool returns true when the identity may call the tool of the server.
+func (i Identity) AllowsTool(server string, tool string) bool {
+	if !i.AllowsServer(server) {
+		return false
+	}
+
+	if len(i.Tools) == 0 {
+		return true
+	}
+
+	return matchAny(i.Tools, filter.NormalizeString(server)+"/"+filter.NormalizeString(tool))
+}
+
+// matchAny returns true when the name matches any of the names or glob patterns.
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if match(filter.NormalizeString(pattern), name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// match returns true when the name matches the name or glob pattern.
+func match(pattern string, name string) bool {
+	matched, err := path.Match(pattern, name)
+	return err == nil && matched
+}
@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentity_Allows(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		identity     Identity
+		server       string
+		tool         string
+		allowsServer bool
+		allowsTool   bool
+	}{
+		{
+			name:         "unrestricted",
+			identity:     Identity{Name: "ops"},
+			server:       "github",
+			tool:         "create_issue",
+			allowsServer: true,
+			allowsTool:   true,
+		},
+		{
+			name:         "server in scope",
+			identity:     Identity{Name: "ci", Servers: []string{"time", "github-*"}},
+			server:       "github-enterprise",
+			tool:         "create_issue",
+			allowsServer: true,
+			allowsTool:   true,
+		},
+		{
+			name:     "server out of scope",
+			identity: Identity{Name: "ci", Servers: []string{"time"}},
+			server:   "github",
+			tool:     "create_issue",
+		},
+		{
+			name:         "tool in scope",
+			identity:     Identity{Name: "agent", Tools: []string{"github/list_*"}},
+			server:       "GitHub",
+			tool:         "List_Issues",
+			allowsServer: true,
+			allowsTool:   true,
+		},
+		{
+			name:         "tool out of scope",
+			identity:     Identity{Name: "agent", Tools: []string{"github/list_*"}},
+			server:       "github",
+			tool:         "create_issue",
+			allowsServer: true,
+		},
+		{
+			name:     "server implied by tools",
+			identity: Identity{Name: "agent", Tools: []string{"github/list_*"}},
+			server:   "time",
+			tool:     "get_current_time",
+		},
+		{
+			name:         "tools limited within servers",
+			identity:     Identity{Name: "agent", Servers: []string{"*"}, Tools: []string{"*/search"}},
+			server:       "docs",
+			tool:         "fetch",
+			allowsServer: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.allowsServer, tc.identity.AllowsServer(tc.server))
+			require.Equal(t, tc.allowsTool, tc.identity.AllowsTool(tc.server, tc.tool))
+		})
+	}
+}
+
+func TestIdentity_Context(t *testing.T) {
+	t.Parallel()
+
+	_, ok := FromContext(context.Background())
+	require.False(t, ok)
+
+	identity := Identity{Name: "ci", Method: MethodAPIKey, Servers: []string{"time"}}
+	got, ok := FromContext(NewContext(context.Background(), identity))
+	require.True(t, ok)
+	require.Equal(t, identity, got)
+	require.False(t, got.Unrestricted())
+}
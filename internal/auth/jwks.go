@@ -0,0 +1,329 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// jwksMinRefresh limits how often the JWKS is reloaded because a token was signed by an unknown key,
+	// so tokens with made up key IDs can't be used to flood the JWKS source with requests.
+	jwksMinRefresh = time.Minute
+
+	// jwksFetchTimeout is how long to wait for the JWKS to be fetched from a URL.
+	jwksFetchTimeout = 10 * time.Second
+
+	// jwksMaxSize is the maximum size of a JWKS, in bytes.
+	jwksMaxSize = 1 << 20
+)
+
+// jwksLoader loads the raw JSON Web Key Set.
+type jwksLoader func(ctx context.Context) ([]byte, error)
+
+// keySet holds the public keys of a JSON Web Key Set (RFC 7517), which are reloaded periodically,
+// and when a token is signed by an unknown key.
+type keySet struct {
+	load    jwksLoader
+	refresh time.Duration
+	now     func() time.Time
+
+	// loads ensures the JWKS is loaded by one request at a time, which the other requests needing it wait for.
+	loads singleflight.Group
+
+	mu       sync.Mutex
+	keys     []publicKey
+	loadedAt time.Time
+}
+
+// publicKey is a key of the key set, which tokens may be signed by.
+type publicKey struct {
+	// id is the key ID ('kid') which tokens signed by the key refer to, may be empty.
+	id string
+
+	// alg is the algorithm the key must be used with, may be empty.
+	alg string
+
+	// key is the *rsa.PublicKey or *ecdsa.PublicKey.
+	key crypto.PublicKey
+}
+
+// jsonWebKey is the JSON representation of a key, only the parameters of RSA and EC keys are included.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// newKeySet creates a key set which is loaded using the loader, and reloaded after the refresh interval.
+func newKeySet(load jwksLoader, refresh time.Duration) *keySet {
+	return &keySet{
+		load:    load,
+		refresh: refresh,
+		now:     time.Now,
+	}
+}
+
+// fileJWKS returns a loader reading the JWKS from the file.
+func fileJWKS(path string) jwksLoader {
+	return func(_ context.Context) ([]byte, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+		}
+		return data, nil
+	}
+}
+
+// urlJWKS returns a loader fetching the JWKS from the URL.
+func urlJWKS(url string) jwksLoader {
+	client := &http.Client{Timeout: jwksFetchTimeout}
+
+	return func(ctx context.Context) ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create JWKS request: %w", err)
+		}
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to fetch JWKS: unexpected status %d", resp.StatusCode)
+		}
+
+		data, err := io.ReadAll(io.LimitReader(resp.Body, jwksMaxSize))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWKS: %w", err)
+		}
+		return data, nil
+	}
+}
+
+// key returns the key with the ID (or the only key for the algorithm when the ID is empty).
+func (s *keySet) key(ctx context.Context, id string, alg string) (crypto.PublicKey, error) {
+	// The current keys are used when they can't be reloaded.
+	keys, err := s.reload(ctx, s.refresh)
+	if keys == nil {
+		return nil, err
+	}
+
+	if key, ok := findKey(keys, id, alg); ok {
+		return key, nil
+	}
+
+	// The key may have been added since the key set was loaded (i.e. keys were rotated).
+	keys, err = s.reload(ctx, jwksMinRefresh)
+	if err != nil {
+		return nil, err
+	}
+	if key, ok := findKey(keys, id, alg); ok {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("no key found for key ID '%s'", id)
+}
+
+// reload replaces the keys with those loaded from the JWKS, unless they were loaded less than maxAge ago,
+// and returns the current keys.
+// The JWKS is loaded without holding the lock, so requests using the current keys aren't blocked while it's fetched.
+// The keys are kept when loading fails, but not reloaded again until maxAge has passed.
+func (s *keySet) reload(ctx context.Context, maxAge time.Duration) ([]publicKey, error) {
+	if keys, ok := s.current(maxAge); ok {
+		return keys, nil
+	}
+
+	// The requests waiting for the JWKS shouldn't fail when the request loading it is canceled.
+	_, err, _ := s.loads.Do("", func() (any, error) {
+		return nil, s.loadKeys(context.WithoutCancel(ctx), maxAge)
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.keys, err
+}
+
+// current returns the keys when they were loaded less than maxAge ago.
+func (s *keySet) current(maxAge time.Duration) ([]publicKey, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.keys == nil || s.now().Sub(s.loadedAt) >= maxAge {
+		return nil, false
+	}
+
+	return s.keys, true
+}
+
+// loadKeys loads the keys from the JWKS, unless another request loaded them less than maxAge ago.
+// The load time is recorded once loading finishes, so requests which need the keys reloaded until then wait for them.
+func (s *keySet) loadKeys(ctx context.Context, maxAge time.Duration) error {
+	if _, ok := s.current(maxAge); ok {
+		return nil
+	}
+
+	data, err := s.load(ctx)
+	var keys []publicKey
+	if err == nil {
+		keys, err = parseJWKS(data)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.loadedAt = s.now()
+	if err != nil {
+		return err
+	}
+	s.keys = keys
+
+	return nil
+}
+
+// findKey returns the key with the ID, or when the ID is empty, the only key which can be used with the algorithm.
+func findKey(keys []publicKey, id string, alg string) (crypto.PublicKey, bool) {
+	var candidates []publicKey
+	for _, k := range keys {
+		if (k.alg != "" && k.alg != alg) || !keyFitsAlgorithm(k.key, alg) {
+			continue
+		}
+		if id != "" && k.id == id {
+			return k.key, true
+		}
+		candidates = append(candidates, k)
+	}
+
+	if id == "" && len(candidates) == 1 {
+		return candidates[0].key, true
+	}
+
+	return nil, false
+}
+
+// parseJWKS parses the RSA and EC (P-256) signing keys of a JWKS, other keys are ignored.
+func parseJWKS(data []byte) ([]publicKey, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS: %w", err)
+	}
+
+	keys := make([]publicKey, 0, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		var (
+			key crypto.PublicKey
+			err error
+		)
+		switch jwk.Kty {
+		case "RSA":
+			key, err = jwk.rsaPublicKey()
+		case "EC":
+			if jwk.Crv != "P-256" {
+				continue
+			}
+			key, err = jwk.ecdsaPublicKey()
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWKS key '%s': %w", jwk.Kid, err)
+		}
+
+		keys = append(keys, publicKey{id: jwk.Kid, alg: jwk.Alg, key: key})
+	}
+
+	return keys, nil
+}
+
+// rsaPublicKey decodes the modulus and exponent of an RSA key.
+func (k jsonWebKey) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := decodeBigInt(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus: %w", err)
+	}
+	e, err := decodeBigInt(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exponent: %w", err)
+	}
+	if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+		return nil, fmt.Errorf("invalid exponent")
+	}
+
+	return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
+
+// ecdsaPublicKey decodes the coordinates of a P-256 key, ensuring the point is on the curve.
+func (k jsonWebKey) ecdsaPublicKey() (*ecdsa.PublicKey, error) {
+	x, err := decodeBigInt(k.X)
+	if err != nil {
+		return nil, fmt.Errorf("invalid x coordinate: %w", err)
+	}
+	y, err := decodeBigInt(k.Y)
+	if err != nil {
+		return nil, fmt.Errorf("invalid y coordinate: %w", err)
+	}
+
+	curve := elliptic.P256()
+	if !curve.IsOnCurve(x, y) {
+		return nil, fmt.Errorf("point is not on curve %s", k.Crv)
+	}
+
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+// decodeBigInt decodes an unsigned big-endian integer encoded as unpadded base64url.
+func decodeBigInt(s string) (*big.Int, error) {
+	if s == "" {
+		return nil, fmt.Errorf("missing value")
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
+
+// keyFitsAlgorithm returns true when the key can verify signatures made with the algorithm.
+func keyFitsAlgorithm(key crypto.PublicKey, alg string) bool {
+	switch alg {
+	case algRS256:
+		_, ok := key.(*rsa.PublicKey)
+		return ok
+	case algES256:
+		_, ok := key.(*ecdsa.PublicKey)
+		return ok
+	default:
+		return false
+	}
+}
@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeySet_ReloadDoesNotBlock(t *testing.T) {
+	t.Parallel()
+
+	oldSigner := newRSASigner(t, "old")
+	newSigner := newECSigner(t, "new")
+	oldJWKS := jwksJSON(t, oldSigner)
+	rotatedJWKS := jwksJSON(t, oldSigner, newSigner)
+
+	var loads atomic.Int32
+	loading := make(chan struct{}, 1)
+	release := make(chan struct{})
+	keys := newKeySet(func(context.Context) ([]byte, error) {
+		if loads.Add(1) == 1 {
+			return oldJWKS, nil
+		}
+		select {
+		case loading <- struct{}{}:
+		default:
+		}
+		<-release
+		return rotatedJWKS, nil
+	}, time.Hour)
+
+	_, err := keys.key(context.Background(), "old", algRS256)
+	require.NoError(t, err)
+
+	keys.mu.Lock()
+	keys.loadedAt = keys.loadedAt.Add(-jwksMinRefresh)
+	keys.mu.Unlock()
+
+	// Tokens signed by the new key reload the JWKS, which is fetched once for all of them.
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := keys.key(context.Background(), "new", algES256)
+			errs <- err
+		}()
+	}
+	<-loading
+
+	// Tokens signed by a known key are verified while the JWKS is fetched.
+	done := make(chan error, 1)
+	go func() {
+		_, err := keys.key(context.Background(), "old", algRS256)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("key lookup blocked while the JWKS was fetched")
+	}
+
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, int32(2), loads.Load())
+}
@@ -0,0 +1,310 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/errors"
+)
+
+const (
+	// MethodJWT is the authentication method of callers identified by a JWT bearer token.
+	MethodJWT = "jwt"
+
+	// DefaultJWKSRefresh is how often the JWKS is reloaded when not configured.
+	DefaultJWKSRefresh = time.Hour
+
+	// DefaultJWTClockSkew is the leeway allowed when checking the times of tokens when not configured.
+	DefaultJWTClockSkew = time.Minute
+
+	// DefaultJWTNameClaim is the claim identifying the caller when not configured.
+	DefaultJWTNameClaim = "sub"
+
+	// algRS256 is the RSASSA-PKCS1-v1_5 using SHA-256 signature algorithm.
+	algRS256 = "RS256"
+
+	// algES256 is the ECDSA using P-256 and SHA-256 signature algorithm.
+	algES256 = "ES256"
+)
+
+// JWT authenticates callers presenting a JWT bearer token, signed by a key of the configured JWKS,
+// and issued by the configured issuer for one of the configured audiences.
+// NewJWT should be used to create instances of JWT.
+type JWT struct {
+	keys      *keySet
+	issuer    string
+	audience  []string
+	clockSkew time.Duration
+	nameClaim string
+	scopes    []config.JWTScopeEntry
+	now       func() time.Time
+}
+
+// jwtHeader is the JOSE header of a token.
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// NewJWT creates a JWT authenticator for the configuration.
+// When the JWKS is loaded from a file, it is loaded upfront to ensure it is valid.
+func NewJWT(cfg *config.APIJWTConfigSection) (*JWT, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid JWT configuration: %w", err)
+	}
+
+	refresh := DefaultJWKSRefresh
+	if cfg.JWKSRefresh != nil {
+		refresh = time.Duration(*cfg.JWKSRefresh)
+	}
+
+	clockSkew := DefaultJWTClockSkew
+	if cfg.ClockSkew != nil {
+		clockSkew = time.Duration(*cfg.ClockSkew)
+	}
+
+	nameClaim := DefaultJWTNameClaim
+	if cfg.NameClaim != nil {
+		nameClaim = strings.TrimSpace(*cfg.NameClaim)
+	}
+
+	var keys *keySet
+	if cfg.JWKSFile != nil {
+		keys = newKeySet(fileJWKS(strings.TrimSpace(*cfg.JWKSFile)), refresh)
+		if _, err := keys.reload(context.Background(), 0); err != nil {
+			return nil, err
+		}
+	} else {
+		keys = newKeySet(urlJWKS(strings.TrimSpace(*cfg.JWKSURL)), refresh)
+	}
+
+	return &JWT{
+		keys:      keys,
+		issuer:    strings.TrimSpace(*cfg.Issuer),
+		audience:  slices.Clone(cfg.Audience),
+		clockSkew: clockSkew,
+		nameClaim: nameClaim,
+		scopes:    slices.Clone(cfg.Scopes),
+		now:       time.Now,
+	}, nil
+}
+
+// Authenticate implements Authenticator.
+// Callers whose token is valid, but whose claims don't match any configured scope, are forbidden.
+func (j *JWT) Authenticate(ctx context.Context, token string) (Identity, error) {
+	if token == "" {
+		return Identity{}, unauthorized("missing bearer token")
+	}
+
+	claims, err := j.verify(ctx, token)
+	if err != nil {
+		return Identity{}, unauthorized(err.Error())
+	}
+
+	if err := j.validateClaims(claims); err != nil {
+		return Identity{}, unauthorized(err.Error())
+	}
+
+	name, ok := claims[j.nameClaim].(string)
+	if !ok || name == "" {
+		return Identity{}, unauthorized(fmt.Sprintf("token has no '%s' claim", j.nameClaim))
+	}
+
+	return j.identity(name, claims)
+}
+
+// verify checks the signature of the token, returning its claims.
+func (j *JWT) verify(ctx context.Context, token string) (map[string]any, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed token")
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("malformed token header")
+	}
+
+	if header.Alg != algRS256 && header.Alg != algES256 {
+		return nil, fmt.Errorf("unsupported token signing algorithm '%s'", header.Alg)
+	}
+
+	key, err := j.keys.key(ctx, header.Kid, header.Alg)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("malformed token signature")
+	}
+
+	if !verifySignature(key, header.Alg, parts[0]+"."+parts[1], signature) {
+		return nil, fmt.Errorf("invalid token signature")
+	}
+
+	var claims map[string]any
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("malformed token claims")
+	}
+
+	return claims, nil
+}
+
+// validateClaims checks the issuer, audience, and times of the token.
+func (j *JWT) validateClaims(claims map[string]any) error {
+	if iss, _ := claims["iss"].(string); iss != j.issuer {
+		return fmt.Errorf("token issuer '%s' is not accepted", iss)
+	}
+
+	if !slices.ContainsFunc(claimValues(claims, "aud"), func(aud string) bool {
+		return slices.Contains(j.audience, aud)
+	}) {
+		return fmt.Errorf("token audience is not accepted")
+	}
+
+	now := j.now()
+
+	exp, ok := numericDate(claims, "exp")
+	if !ok {
+		return fmt.Errorf("token has no expiry")
+	}
+	if now.After(exp.Add(j.clockSkew)) {
+		return fmt.Errorf("token has expired")
+	}
+
+	if nbf, ok := numericDate(claims, "nbf"); ok && now.Add(j.clockSkew).Before(nbf) {
+		return fmt.Errorf("token is not yet valid")
+	}
+
+	if iat, ok := numericDate(claims, "iat"); ok && now.Add(j.clockSkew).Before(iat) {
+		return fmt.Errorf("token was issued in the future")
+	}
+
+	return nil
+}
+
+// identity returns the identity of the caller, with the combined scope of every configured scope the claims match.
+func (j *JWT) identity(name string, claims map[string]any) (Identity, error) {
+	identity := Identity{Name: name, Method: MethodJWT}
+
+	if len(j.scopes) == 0 {
+		return identity, nil
+	}
+
+	matched := false
+	unrestricted := false
+	readOnly := true
+	for _, scope := range j.scopes {
+		if !slices.Contains(claimValues(claims, scope.Claim), scope.Value) {
+			continue
+		}
+
+		matched = true
+		readOnly = readOnly && scope.ReadOnly
+
+		switch {
+		case len(scope.Tools) > 0:
+			identity.Tools = append(identity.Tools, scope.Tools...)
+		case len(scope.Servers) > 0:
+			// Scopes are combined as tools, since scopes limited to tools would otherwise limit those to servers.
+			for _, server := range scope.Servers {
+				identity.Tools = append(identity.Tools, server+"/*")
+			}
+		default:
+			unrestricted = true
+		}
+	}
+
+	if !matched {
+		err := fmt.Errorf("%w: token for '%s' doesn't grant access to any servers", errors.ErrForbidden, name)
+		return Identity{}, err
+	}
+
+	if unrestricted {
+		identity.Tools = nil
+	}
+	identity.ReadOnly = readOnly
+
+	return identity, nil
+}
+
+// verifySignature returns true when the signature of the signing input was made by the key using the algorithm.
+func verifySignature(key crypto.PublicKey, alg string, signingInput string, signature []byte) bool {
+	digest := sha256.Sum256([]byte(signingInput))
+
+	switch alg {
+	case algRS256:
+		pub, ok := key.(*rsa.PublicKey)
+		return ok && rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], signature) == nil
+	case algES256:
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok || len(signature) != 64 {
+			return false
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		return ecdsa.Verify(pub, digest[:], r, s)
+	default:
+		return false
+	}
+}
+
+// decodeSegment decodes a base64url encoded JSON segment of a token.
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// claimValues returns the values of the claim, which may be nested (e.g. 'realm_access.roles').
+// String claims are split on whitespace (as used by 'scope'), arrays of strings are returned as is.
+func claimValues(claims map[string]any, name string) []string {
+	var value any = claims
+	for _, part := range strings.Split(name, ".") {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = m[part]
+	}
+
+	switch v := value.(type) {
+	case string:
+		return strings.Fields(v)
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// numericDate returns the time of a NumericDate claim (seconds since the epoch), such as 'exp'.
+func numericDate(claims map[string]any, name string) (time.Time, bool) {
+	seconds, ok := claims[name].(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	return time.Unix(int64(seconds), 0), true
+}
@@ -0,0 +1,430 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/errors"
+)
+
+const (
+	testIssuer   = "https://issuer.example.com"
+	testAudience = "mcpd"
+)
+
+// testSigner signs tokens with a locally generated key.
+type testSigner struct {
+	kid string
+	key crypto.Signer
+}
+
+func newRSASigner(t *testing.T, kid string) testSigner {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	return testSigner{kid: kid, key: key}
+}
+
+func newECSigner(t *testing.T, kid string) testSigner {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	return testSigner{kid: kid, key: key}
+}
+
+// jwk returns the public key of the signer as a JSON Web Key.
+func (s testSigner) jwk() map[string]string {
+	enc := base64.RawURLEncoding
+	switch key := s.key.(type) {
+	case *rsa.PrivateKey:
+		return map[string]string{
+			"kty": "RSA",
+			"kid": s.kid,
+			"use": "sig",
+			"n":   enc.EncodeToString(key.N.Bytes()),
+			"e":   enc.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}
+	case *ecdsa.PrivateKey:
+		return map[string]string{
+			"kty": "EC",
+			"kid": s.kid,
+			"crv": "P-256",
+			"x":   enc.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+			"y":   enc.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+		}
+	default:
+		panic("unsupported key")
+	}
+}
+
+// sign returns a token with the claims, signed by the signer.
+func (s testSigner) sign(t *testing.T, claims map[string]any) string {
+	t.Helper()
+
+	alg := algRS256
+	if _, ok := s.key.(*ecdsa.PrivateKey); ok {
+		alg = algES256
+	}
+
+	header, err := json.Marshal(map[string]string{"alg": alg, "typ": "JWT", "kid": s.kid})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	enc := base64.RawURLEncoding
+	signingInput := enc.EncodeToString(header) + "." + enc.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	var signature []byte
+	switch key := s.key.(type) {
+	case *rsa.PrivateKey:
+		signature, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+		require.NoError(t, err)
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+
+	return signingInput + "." + enc.EncodeToString(signature)
+}
+
+// writeJWKS writes a JWKS file with the public keys of the signers, returning its path.
+func writeJWKS(t *testing.T, signers ...testSigner) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, jwksJSON(t, signers...), 0o600))
+
+	return path
+}
+
+func jwksJSON(t *testing.T, signers ...testSigner) []byte {
+	t.Helper()
+
+	keys := make([]map[string]string, 0, len(signers))
+	for _, s := range signers {
+		keys = append(keys, s.jwk())
+	}
+
+	data, err := json.Marshal(map[string]any{"keys": keys})
+	require.NoError(t, err)
+
+	return data
+}
+
+// validClaims returns the claims of a token which is valid now.
+func validClaims(extra map[string]any) map[string]any {
+	now := time.Now()
+	claims := map[string]any{
+		"iss": testIssuer,
+		"aud": testAudience,
+		"sub": "agent",
+		"iat": now.Unix(),
+		"exp": now.Add(time.Hour).Unix(),
+	}
+	for k, v := range extra {
+		claims[k] = v
+	}
+
+	return claims
+}
+
+func testJWTConfig(jwksFile string) *config.APIJWTConfigSection {
+	issuer := testIssuer
+	return &config.APIJWTConfigSection{
+		Issuer:   &issuer,
+		Audience: []string{testAudience},
+		JWKSFile: &jwksFile,
+	}
+}
+
+func TestJWT_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	rsaSigner := newRSASigner(t, "rsa-1")
+	ecSigner := newECSigner(t, "ec-1")
+	unknownSigner := newRSASigner(t, "rsa-1")
+
+	jwt, err := NewJWT(testJWTConfig(writeJWKS(t, rsaSigner, ecSigner)))
+	require.NoError(t, err)
+
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr string
+	}{
+		{name: "RS256", token: rsaSigner.sign(t, validClaims(nil))},
+		{name: "ES256", token: ecSigner.sign(t, validClaims(nil))},
+		{
+			name:  "audience array",
+			token: rsaSigner.sign(t, validClaims(map[string]any{"aud": []string{"other", testAudience}})),
+		},
+		{
+			name:  "expired within clock skew",
+			token: rsaSigner.sign(t, validClaims(map[string]any{"exp": now.Add(-30 * time.Second).Unix()})),
+		},
+		{name: "missing token", wantErr: "missing bearer token"},
+		{name: "malformed token", token: "a.b.c", wantErr: "malformed token header"},
+		{
+			name:    "unknown key",
+			token:   unknownSigner.sign(t, validClaims(nil)),
+			wantErr: "invalid token signature",
+		},
+		{
+			name:    "wrong issuer",
+			token:   rsaSigner.sign(t, validClaims(map[string]any{"iss": "https://other.example.com"})),
+			wantErr: "token issuer 'https://other.example.com' is not accepted",
+		},
+		{
+			name:    "wrong audience",
+			token:   rsaSigner.sign(t, validClaims(map[string]any{"aud": "other"})),
+			wantErr: "token audience is not accepted",
+		},
+		{
+			name:    "expired",
+			token:   rsaSigner.sign(t, validClaims(map[string]any{"exp": now.Add(-2 * time.Minute).Unix()})),
+			wantErr: "token has expired",
+		},
+		{
+			name:    "not yet valid",
+			token:   rsaSigner.sign(t, validClaims(map[string]any{"nbf": now.Add(2 * time.Minute).Unix()})),
+			wantErr: "token is not yet valid",
+		},
+		{
+			name:    "missing expiry",
+			token:   rsaSigner.sign(t, validClaims(map[string]any{"exp": nil})),
+			wantErr: "token has no expiry",
+		},
+		{
+			name:    "missing subject",
+			token:   rsaSigner.sign(t, validClaims(map[string]any{"sub": nil})),
+			wantErr: "token has no 'sub' claim",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			identity, err := jwt.Authenticate(context.Background(), tc.token)
+			if tc.wantErr != "" {
+				require.ErrorIs(t, err, errors.ErrUnauthorized)
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, Identity{Name: "agent", Method: MethodJWT}, identity)
+		})
+	}
+}
+
+func TestJWT_AuthenticateAlgNone(t *testing.T) {
+	t.Parallel()
+
+	signer := newRSASigner(t, "rsa-1")
+	jwt, err := NewJWT(testJWTConfig(writeJWKS(t, signer)))
+	require.NoError(t, err)
+
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`))
+	payload, err := json.Marshal(validClaims(nil))
+	require.NoError(t, err)
+	token := header + "." + base64.RawURLEncoding.EncodeToString(payload) + "."
+
+	_, err = jwt.Authenticate(context.Background(), token)
+	require.ErrorIs(t, err, errors.ErrUnauthorized)
+	require.ErrorContains(t, err, "unsupported token signing algorithm 'none'")
+}
+
+func TestJWT_Scopes(t *testing.T) {
+	t.Parallel()
+
+	signer := newECSigner(t, "ec-1")
+	cfg := testJWTConfig(writeJWKS(t, signer))
+	cfg.Scopes = []config.JWTScopeEntry{
+		{Claim: "groups", Value: "platform"},
+		{Claim: "groups", Value: "time-users", Servers: []string{"time"}},
+		{Claim: "scope", Value: "github:read", Tools: []string{"github/list_*"}, ReadOnly: true},
+		{Claim: "realm_access.roles", Value: "viewer", ReadOnly: true},
+	}
+	jwt, err := NewJWT(cfg)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		claims  map[string]any
+		want    Identity
+		wantErr error
+	}{
+		{
+			name:   "unrestricted group",
+			claims: map[string]any{"groups": []string{"platform", "time-users"}},
+			want:   Identity{Name: "agent", Method: MethodJWT},
+		},
+		{
+			name:   "server group",
+			claims: map[string]any{"groups": []string{"time-users"}},
+			want:   Identity{Name: "agent", Method: MethodJWT, Tools: []string{"time/*"}},
+		},
+		{
+			name:   "space separated scope",
+			claims: map[string]any{"scope": "openid github:read"},
+			want:   Identity{Name: "agent", Method: MethodJWT, Tools: []string{"github/list_*"}, ReadOnly: true},
+		},
+		{
+			name:   "combined scopes",
+			claims: map[string]any{"groups": []string{"time-users"}, "scope": "github:read"},
+			want:   Identity{Name: "agent", Method: MethodJWT, Tools: []string{"time/*", "github/list_*"}},
+		},
+		{
+			name:   "nested claim",
+			claims: map[string]any{"realm_access": map[string]any{"roles": []string{"viewer"}}},
+			want:   Identity{Name: "agent", Method: MethodJWT, ReadOnly: true},
+		},
+		{
+			name:    "no matching scope",
+			claims:  map[string]any{"groups": []string{"other"}},
+			wantErr: errors.ErrForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			identity, err := jwt.Authenticate(context.Background(), signer.sign(t, validClaims(tc.claims)))
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, identity)
+		})
+	}
+}
+
+func TestJWT_NameClaim(t *testing.T) {
+	t.Parallel()
+
+	signer := newRSASigner(t, "rsa-1")
+	cfg := testJWTConfig(writeJWKS(t, signer))
+	nameClaim := "client_id"
+	cfg.NameClaim = &nameClaim
+
+	jwt, err := NewJWT(cfg)
+	require.NoError(t, err)
+
+	identity, err := jwt.Authenticate(context.Background(), signer.sign(t, validClaims(map[string]any{
+		"client_id": "ci-agent",
+	})))
+	require.NoError(t, err)
+	require.Equal(t, "ci-agent", identity.Name)
+}
+
+func TestJWT_JWKSURL(t *testing.T) {
+	t.Parallel()
+
+	oldSigner := newRSASigner(t, "old")
+	newSigner := newECSigner(t, "new")
+
+	var (
+		jwks     atomic.Value
+		requests atomic.Int32
+	)
+	jwks.Store(jwksJSON(t, oldSigner))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(jwks.Load().([]byte))
+	}))
+	t.Cleanup(server.Close)
+
+	issuer := testIssuer
+	url := server.URL
+	jwt, err := NewJWT(&config.APIJWTConfigSection{
+		Issuer:   &issuer,
+		Audience: []string{testAudience},
+		JWKSURL:  &url,
+	})
+	require.NoError(t, err)
+	require.Zero(t, requests.Load(), "JWKS should be fetched when first needed")
+
+	_, err = jwt.Authenticate(context.Background(), oldSigner.sign(t, validClaims(nil)))
+	require.NoError(t, err)
+	require.Equal(t, int32(1), requests.Load())
+
+	// Keys are rotated, tokens signed by the new key are accepted once the JWKS may be reloaded.
+	jwks.Store(jwksJSON(t, newSigner))
+	token := newSigner.sign(t, validClaims(nil))
+
+	_, err = jwt.Authenticate(context.Background(), token)
+	require.ErrorIs(t, err, errors.ErrUnauthorized)
+	require.Equal(t, int32(1), requests.Load(), "JWKS should not be reloaded more than once a minute")
+
+	jwt.keys.mu.Lock()
+	jwt.keys.loadedAt = jwt.keys.loadedAt.Add(-jwksMinRefresh)
+	jwt.keys.mu.Unlock()
+
+	_, err = jwt.Authenticate(context.Background(), token)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), requests.Load())
+}
+
+func TestNewJWT_InvalidJWKSFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"keys":[{"kty":"EC","crv":"P-256","x":"AQ","y":"AQ"}]}`), 0o600))
+
+	_, err := NewJWT(testJWTConfig(path))
+	require.EqualError(t, err, "invalid JWKS key '': point is not on curve P-256")
+
+	_, err = NewJWT(testJWTConfig(filepath.Join(t.TempDir(), "missing.json")))
+	require.ErrorContains(t, err, "failed to read JWKS file")
+}
+
+func TestNewAuthenticator_APIKeysAndJWT(t *testing.T) {
+	t.Parallel()
+
+	signer := newRSASigner(t, "rsa-1")
+	authenticator, err := NewAuthenticator(&config.APIAuthConfigSection{
+		Keys: []config.APIKeyEntry{{Name: "ci", Hash: HashAPIKey("key")}},
+		JWT:  testJWTConfig(writeJWKS(t, signer)),
+	})
+	require.NoError(t, err)
+
+	identity, err := authenticator.Authenticate(context.Background(), "key")
+	require.NoError(t, err)
+	require.Equal(t, MethodAPIKey, identity.Method)
+
+	identity, err = authenticator.Authenticate(context.Background(), signer.sign(t, validClaims(nil)))
+	require.NoError(t, err)
+	require.Equal(t, MethodJWT, identity.Method)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/mozilla-ai/mcpd/internal/cache"
 	"github.com/mozilla-ai/mcpd/internal/cmd/output"
 	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/context"
 	"github.com/mozilla-ai/mcpd/internal/flags"
 	"github.com/mozilla-ai/mcpd/internal/perms"
 	"github.com/mozilla-ai/mcpd/internal/provider/mcpm"
@@ -71,10 +72,12 @@ func (c *BaseCmd) Logger() (hclog.Logger, error) {
 		}
 	}
 
+	// Sub-loggers have independent levels, so the level of a single component (e.g. an MCP server) can be changed.
 	c.logger = hclog.New(&hclog.LoggerOptions{
-		Name:   AppName(),
-		Level:  hclog.LevelFromString(logLevel),
-		Output: output,
+		Name:              AppName(),
+		Level:             hclog.LevelFromString(logLevel),
+		Output:            output,
+		IndependentLevels: true,
 	})
 
 	return c.logger, nil
@@ -200,6 +203,20 @@ func (c *BaseCmd) LoadConfig(loader config.Loader) (*config.Config, error) {
 	return cfg, nil
 }
 
+// LoadRuntimeContext is a utility method that loads the runtime context configuration (e.g. secrets.dev.toml).
+func (c *BaseCmd) LoadRuntimeContext(loader context.Loader) (*context.ExecutionContextConfig, error) {
+	modifier, err := loader.Load(flags.RuntimeFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load execution context config: %w", err)
+	}
+
+	execCtx, ok := modifier.(*context.ExecutionContextConfig)
+	if !ok {
+		return nil, fmt.Errorf("invalid execution context config structure")
+	}
+	return execCtx, nil
+}
+
 // RequireTogether validates that a set of flags are either all provided or all omitted.
 // Returns an error if only some of the flags are provided.
 func (c *BaseCmd) RequireTogether(cmd *cobra.Command, names ...string) error {
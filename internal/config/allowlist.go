@@ -0,0 +1,191 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/mozilla-ai/mcpd/internal/filter"
+)
+
+const (
+	// resourcePrefixSuffix marks a resource pattern which matches any URI starting with the rest of the pattern.
+	resourcePrefixSuffix = "**"
+
+	// globMetaChars are the characters which make a name a glob pattern (see path.Match).
+	globMetaChars = "*?["
+)
+
+// ToolAllowed returns true when the tool matches an entry of the server's tools allowlist,
+// and doesn't match an entry of its deny list.
+func (s *ServerEntry) ToolAllowed(tool string) bool {
+	tool = filter.NormalizeString(tool)
+
+	return matchAnyTool(s.Tools, tool) && !s.ToolDenied(tool)
+}
+
+// ToolDenied returns true when the tool matches an entry of the server's tools deny list.
+func (s *ServerEntry) ToolDenied(tool string) bool {
+	return matchAnyTool(s.ToolsDeny, filter.NormalizeString(tool))
+}
+
+// AllowedTools resolves the server's tools allowlist against the tools it offers, returning the sorted,
+// normalized names of the allowed tools.
+// When available is nil (i.e. the tools offered by the server are unknown), patterns cannot be resolved,
+// so only the tools named exactly in the allowlist (and not denied) are returned.
+func (s *ServerEntry) AllowedTools(available []string) []string {
+	if available == nil {
+		available = slices.DeleteFunc(filter.NormalizeSlice(s.Tools), IsToolPattern)
+	}
+
+	allowed := make([]string, 0, len(available))
+	for _, tool := range available {
+		if s.ToolAllowed(tool) {
+			allowed = append(allowed, filter.NormalizeString(tool))
+		}
+	}
+	slices.Sort(allowed)
+
+	return slices.Compact(allowed)
+}
+
+// MatchTools returns the sorted, normalized names of the available tools which match the tool name or pattern.
+func MatchTools(pattern string, available []string) []string {
+	var matched []string
+	for _, tool := range available {
+		tool = filter.NormalizeString(tool)
+		if matchTool(pattern, tool) {
+			matched = append(matched, tool)
+		}
+	}
+	slices.Sort(matched)
+
+	return slices.Compact(matched)
+}
+
+// IsToolPattern returns true when the tool name is a glob pattern (e.g. 'list_*') rather than an exact name.
+func IsToolPattern(name string) bool {
+	return strings.ContainsAny(name, globMetaChars)
+}
+
+// validateTools ensures the tool patterns of the allowlist and deny list are well-formed.
+func (s *ServerEntry) validateTools() error {
+	var errs error
+
+	for _, pattern := range slices.Concat(s.Tools, s.ToolsDeny) {
+		if _, err := path.Match(filter.NormalizeString(pattern), ""); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("tool pattern '%s' is invalid: %w", pattern, err))
+		}
+	}
+
+	return errs
+}
+
+// matchAnyTool returns true when the normalized tool name matches any of the names or patterns.
+func matchAnyTool(patterns []string, tool string) bool {
+	return slices.ContainsFunc(patterns, func(pattern string) bool {
+		return matchTool(pattern, tool)
+	})
+}
+
+// matchTool returns true when the normalized tool name matches the name or pattern.
+func matchTool(pattern string, tool string) bool {
+	pattern = filter.NormalizeString(pattern)
+	if !IsToolPattern(pattern) {
+		return pattern == tool
+	}
+
+	matched, err := path.Match(pattern, tool)
+
+	return err == nil && matched
+}
+
+// PromptAllowed returns true when the prompt is allowed by the server's prompt allowlist.
+// All prompts are allowed when no allowlist is configured.
+func (s *ServerEntry) PromptAllowed(name string) bool {
+	if len(s.Prompts) == 0 {
+		return true
+	}
+
+	name = filter.NormalizeString(name)
+
+	return slices.ContainsFunc(s.Prompts, func(p string) bool {
+		return filter.NormalizeString(p) == name
+	})
+}
+
+// ResourceAllowed returns true when the resource URI is allowed by the server's resource allowlist.
+// All resources are allowed when no allowlist is configured.
+// URIs which attempt to traverse out of a directory (i.e. contain a '..' path segment) are never allowed
+// by an allowlist, so that a pattern such as 'file:///workspace/**' cannot be escaped.
+func (s *ServerEntry) ResourceAllowed(uri string) bool {
+	if len(s.Resources) == 0 {
+		return true
+	}
+
+	if hasTraversal(uri) {
+		return false
+	}
+
+	return slices.ContainsFunc(s.Resources, func(pattern string) bool {
+		return matchResource(pattern, uri)
+	})
+}
+
+// ValidateResourcePattern ensures the resource pattern is well-formed.
+func ValidateResourcePattern(pattern string) error {
+	if strings.TrimSpace(pattern) == "" {
+		return fmt.Errorf("resource pattern is empty")
+	}
+
+	if _, err := path.Match(strings.TrimSuffix(pattern, resourcePrefixSuffix), ""); err != nil {
+		return fmt.Errorf("resource pattern '%s' is invalid: %w", pattern, err)
+	}
+
+	return nil
+}
+
+// validateResources ensures the resource patterns are well-formed.
+func (s *ServerEntry) validateResources() error {
+	var errs error
+
+	for i, pattern := range s.Resources {
+		if err := ValidateResourcePattern(pattern); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("resource pattern at index %d: %w", i, err))
+		}
+	}
+
+	return errs
+}
+
+// matchResource returns true when the URI matches the pattern.
+// A pattern ending in '**' matches URIs with the preceding prefix, any other pattern is matched using path.Match.
+func matchResource(pattern string, uri string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, resourcePrefixSuffix); ok {
+		return strings.HasPrefix(uri, prefix)
+	}
+
+	matched, err := path.Match(pattern, uri)
+
+	return err == nil && matched
+}
+
+// hasTraversal returns true when the URI cannot be parsed, or its path contains a '..' segment.
+// The decoded path is checked, so that percent-encoded segments are also detected.
+func hasTraversal(uri string) bool {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return true
+	}
+
+	for _, p := range []string{u.Path, u.Opaque} {
+		if slices.Contains(strings.Split(p, "/"), "..") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,197 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerEntry_ToolAllowed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		tools    []string
+		deny     []string
+		tool     string
+		expected bool
+	}{
+		{name: "exact", tools: []string{"create_issue"}, tool: "Create_Issue", expected: true},
+		{name: "exact mismatch", tools: []string{"create_issue"}, tool: "create_issues", expected: false},
+		{name: "prefix pattern", tools: []string{"list_*"}, tool: "list_issues", expected: true},
+		{name: "prefix pattern mismatch", tools: []string{"list_*"}, tool: "get_issue", expected: false},
+		{name: "all", tools: []string{"*"}, tool: "delete_repo", expected: true},
+		{name: "denied", tools: []string{"*"}, deny: []string{"delete_repo"}, tool: "delete_repo", expected: false},
+		{
+			name:     "denied pattern",
+			tools:    []string{"*"},
+			deny:     []string{"DELETE_*"},
+			tool:     "delete_repo",
+			expected: false,
+		},
+		{name: "deny wins", tools: []string{"delete_repo"}, deny: []string{"delete_*"}, tool: "delete_repo"},
+		{name: "no allowlist", deny: []string{"delete_*"}, tool: "list_issues", expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			entry := &ServerEntry{Name: "test", Tools: tc.tools, ToolsDeny: tc.deny}
+			require.Equal(t, tc.expected, entry.ToolAllowed(tc.tool))
+		})
+	}
+}
+
+func TestServerEntry_ToolDenied(t *testing.T) {
+	t.Parallel()
+
+	entry := &ServerEntry{Name: "test", ToolsDeny: []string{"delete_*", "merge_pull_request"}}
+
+	require.True(t, entry.ToolDenied("Delete_Repo"))
+	require.True(t, entry.ToolDenied("merge_pull_request"))
+	require.False(t, entry.ToolDenied("list_issues"))
+
+	// Tools aren't denied without a deny list, even when they aren't allowed.
+	require.False(t, (&ServerEntry{Name: "test"}).ToolDenied("delete_repo"))
+}
+
+func TestServerEntry_AllowedTools(t *testing.T) {
+	t.Parallel()
+
+	entry := &ServerEntry{
+		Name:      "github",
+		Tools:     []string{"list_*", "Create_Issue", "missing_tool", "list_issues"},
+		ToolsDeny: []string{"list_secrets"},
+	}
+
+	available := []string{"list_issues", "List_Repos", "list_secrets", "create_issue", "delete_repo"}
+	require.Equal(t, []string{"create_issue", "list_issues", "list_repos"}, entry.AllowedTools(available))
+
+	// Unknown tools resolve to the exact names in the allowlist.
+	require.Equal(t, []string{"create_issue", "list_issues", "missing_tool"}, entry.AllowedTools(nil))
+
+	// No offered tools means nothing is allowed.
+	require.Empty(t, entry.AllowedTools([]string{}))
+}
+
+func TestMatchTools(t *testing.T) {
+	t.Parallel()
+
+	available := []string{"list_issues", "List_Repos", "create_issue"}
+
+	require.Equal(t, []string{"list_issues", "list_repos"}, MatchTools("list_*", available))
+	require.Equal(t, []string{"create_issue", "list_issues", "list_repos"}, MatchTools("*", available))
+	require.Equal(t, []string{"create_issue"}, MatchTools("create_issue", available))
+	require.Empty(t, MatchTools("delete_*", available))
+}
+
+func TestServerEntry_ValidateTools(t *testing.T) {
+	t.Parallel()
+
+	entry := &ServerEntry{
+		Name:      "test",
+		Tools:     []string{"list_*", "get_?ssue", "create_[ai]*"},
+		ToolsDeny: []string{"*"},
+	}
+	require.NoError(t, entry.validateTools())
+
+	entry = &ServerEntry{Name: "test", Tools: []string{"list_["}, ToolsDeny: []string{"delete_[a-"}}
+	err := entry.validateTools()
+	require.ErrorContains(t, err, "tool pattern 'list_[' is invalid")
+	require.ErrorContains(t, err, "tool pattern 'delete_[a-' is invalid")
+}
+
+func TestServerEntry_PromptAllowed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		prompts  []string
+		prompt   string
+		expected bool
+	}{
+		{name: "no allowlist", prompt: "anything", expected: true},
+		{name: "allowed", prompts: []string{"code_review"}, prompt: "code_review", expected: true},
+		{name: "allowed normalized", prompts: []string{"Code_Review"}, prompt: " code_review ", expected: true},
+		{name: "not allowed", prompts: []string{"code_review"}, prompt: "summarize", expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			entry := &ServerEntry{Name: "test", Prompts: tc.prompts}
+			require.Equal(t, tc.expected, entry.PromptAllowed(tc.prompt))
+		})
+	}
+}
+
+func TestServerEntry_ResourceAllowed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		resources []string
+		uri       string
+		expected  bool
+	}{
+		{name: "no allowlist", uri: "file:///etc/passwd", expected: true},
+		{name: "no allowlist traversal", uri: "file:///workspace/../etc/passwd", expected: true},
+		{name: "exact", resources: []string{"config://app"}, uri: "config://app", expected: true},
+		{name: "exact mismatch", resources: []string{"config://app"}, uri: "config://app/secrets", expected: false},
+		{name: "prefix", resources: []string{"file:///workspace/**"}, uri: "file:///workspace/a/b.md", expected: true},
+		{
+			name:      "prefix mismatch",
+			resources: []string{"file:///workspace/**"},
+			uri:       "file:///etc/passwd",
+			expected:  false,
+		},
+		{
+			name:      "prefix traversal",
+			resources: []string{"file:///workspace/**"},
+			uri:       "file:///workspace/../etc/passwd",
+			expected:  false,
+		},
+		{
+			name:      "prefix encoded traversal",
+			resources: []string{"file:///workspace/**"},
+			uri:       "file:///workspace/%2e%2e/etc/passwd",
+			expected:  false,
+		},
+		{name: "glob", resources: []string{"file:///workspace/*.md"}, uri: "file:///workspace/a.md", expected: true},
+		{
+			name:      "glob does not cross segments",
+			resources: []string{"file:///workspace/*.md"},
+			uri:       "file:///workspace/docs/a.md",
+			expected:  false,
+		},
+		{
+			name:      "any pattern",
+			resources: []string{"config://app", "file:///workspace/**"},
+			uri:       "file:///workspace/a.md",
+			expected:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			entry := &ServerEntry{Name: "test", Resources: tc.resources}
+			require.Equal(t, tc.expected, entry.ResourceAllowed(tc.uri))
+		})
+	}
+}
+
+func TestServerEntry_ValidateResources(t *testing.T) {
+	t.Parallel()
+
+	entry := &ServerEntry{Name: "test", Resources: []string{"file:///workspace/**", "file:///*.md"}}
+	require.NoError(t, entry.validateResources())
+
+	entry = &ServerEntry{Name: "test", Resources: []string{" ", "file:///[a-/**"}}
+	err := entry.validateResources()
+	require.ErrorContains(t, err, "resource pattern at index 0: resource pattern is empty")
+	require.ErrorContains(t, err, "resource pattern at index 1: resource pattern 'file:///[a-/**' is invalid")
+}
@@ -0,0 +1,397 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/mozilla-ai/mcpd/internal/context"
+	"github.com/mozilla-ai/mcpd/internal/filter"
+)
+
+// APIKeyHashPrefix prefixes the hashes of API keys, identifying the hash function used (SHA-256).
+const APIKeyHashPrefix = "sha256:"
+
+// APIAuthConfigSection contains authentication settings for the daemon API.
+// When no authentication methods are configured, requests to the API are not authenticated.
+//
+// NOTE: if you add/remove fields you must review the associated Getter, Setter and Validator implementations,
+// along with /docs/daemon-configuration.md.
+type APIAuthConfigSection struct {
+	// API keys which may be used to authenticate requests, each with its own scope.
+	// Managed with 'mcpd config daemon api-keys'.
+	Keys []APIKeyEntry `json:"keys,omitempty" toml:"keys,omitempty" yaml:"keys,omitempty"`
+
+	// JWT configures authentication with JWT bearer tokens (e.g. workload identity tokens).
+	JWT *APIJWTConfigSection `json:"jwt,omitempty" toml:"jwt,omitempty" yaml:"jwt,omitempty"`
+
+	// ClientCerts identify callers by the client certificates they present,
+	// which requires mutual TLS (see APITLSConfigSection).
+	// Callers presenting a bearer token are identified by the token instead.
+	ClientCerts []ClientCertEntry `json:"clientCerts,omitempty" toml:"client_certs,omitempty" yaml:"client_certs,omitempty"`
+}
+
+// ClientCertEntry grants callers, whose verified client certificate has a matching subject common name,
+// access to servers and tools.
+type ClientCertEntry struct {
+	// CommonName is the common name (or glob pattern) of the certificate's subject, e.g. 'agent.example.com'.
+	CommonName string `json:"commonName" toml:"common_name" yaml:"common_name"`
+
+	// Servers are the names (or glob patterns) of the servers which may be accessed.
+	// When empty, the servers named by Tools may be accessed, or all servers when Tools is also empty.
+	Servers []string `json:"servers,omitempty" toml:"servers,omitempty" yaml:"servers,omitempty"`
+
+	// Tools are the tools which may be called, as '<server>/<tool>' names (or glob patterns).
+	// When empty, all tools of the servers which may be accessed can be called.
+	Tools []string `json:"tools,omitempty" toml:"tools,omitempty" yaml:"tools,omitempty"`
+
+	// ReadOnly limits the caller to requests which don't make changes.
+	ReadOnly bool `json:"readOnly,omitempty" toml:"read_only,omitempty" yaml:"read_only,omitempty"`
+}
+
+// APIKeyEntry represents an API key which may be used to authenticate requests to the daemon API.
+// Only the hash of the key is configured, the key itself is stored in the runtime file.
+type APIKeyEntry struct {
+	// Name identifies the key, e.g. in logs.
+	Name string `json:"name" toml:"name" yaml:"name"`
+
+	// Hash is the SHA-256 hash of the key, hex encoded and prefixed with 'sha256:'.
+	Hash string `json:"hash" toml:"hash" yaml:"hash"`
+
+	// Servers are the names (or glob patterns) of the servers which the key may access.
+	// When empty, the servers named by Tools may be accessed, or all servers when Tools is also empty.
+	Servers []string `json:"servers,omitempty" toml:"servers,omitempty" yaml:"servers,omitempty"`
+
+	// Tools are the tools which the key may call, as '<server>/<tool>' names (or glob patterns).
+	// e.g. 'time/get_current_time', 'github/list_*' or '*/search'.
+	// When empty, all tools of the servers which the key may access can be called.
+	Tools []string `json:"tools,omitempty" toml:"tools,omitempty" yaml:"tools,omitempty"`
+
+	// ReadOnly limits the key to requests which don't make changes (i.e. GET requests),
+	// so tools can be listed but not called.
+	ReadOnly bool `json:"readOnly,omitempty" toml:"read_only,omitempty" yaml:"read_only,omitempty"`
+}
+
+// APIKey retrieves the configured API key with the given name.
+func (c *Config) APIKey(name string) (APIKeyEntry, bool) {
+	auth := c.apiAuth()
+	if auth == nil {
+		return APIKeyEntry{}, false
+	}
+
+	name = strings.TrimSpace(name)
+	for _, key := range auth.Keys {
+		if key.Name == name {
+			return key, true
+		}
+	}
+
+	return APIKeyEntry{}, false
+}
+
+// AddAPIKey persists a new API key to the configuration file (.mcpd.toml).
+func (c *Config) AddAPIKey(entry APIKeyEntry) error {
+	if _, exists := c.APIKey(entry.Name); exists {
+		return fmt.Errorf("API key '%s' already exists", entry.Name)
+	}
+
+	if c.Daemon == nil {
+		c.Daemon = &DaemonConfig{}
+	}
+	if c.Daemon.API == nil {
+		c.Daemon.API = &APIConfigSection{}
+	}
+	if c.Daemon.API.Auth == nil {
+		c.Daemon.API.Auth = &APIAuthConfigSection{}
+	}
+	c.Daemon.API.Auth.Keys = append(c.Daemon.API.Auth.Keys, entry)
+
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	if err := c.saveConfig(); err != nil {
+		return fmt.Errorf("failed to save updated config: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveAPIKey removes an API key by name from the configuration file (.mcpd.toml).
+func (c *Config) RemoveAPIKey(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("API key name cannot be empty")
+	}
+
+	if _, exists := c.APIKey(name); !exists {
+		return fmt.Errorf("API key '%s' not found in config", name)
+	}
+
+	auth := c.apiAuth()
+	auth.Keys = slices.DeleteFunc(auth.Keys, func(key APIKeyEntry) bool {
+		return key.Name == name
+	})
+	if len(auth.Keys) == 0 {
+		auth.Keys = nil
+	}
+
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	if err := c.saveConfig(); err != nil {
+		return fmt.Errorf("failed to save updated config: %w", err)
+	}
+
+	return nil
+}
+
+// ListAPIKeys returns a copy of the configured API keys.
+func (c *Config) ListAPIKeys() []APIKeyEntry {
+	auth := c.apiAuth()
+	if auth == nil {
+		return nil
+	}
+
+	return slices.Clone(auth.Keys)
+}
+
+// apiAuth returns the API authentication section of the daemon configuration, if present.
+func (c *Config) apiAuth() *APIAuthConfigSection {
+	if c.Daemon == nil || c.Daemon.API == nil {
+		return nil
+	}
+
+	return c.Daemon.API.Auth
+}
+
+// IsEmpty returns true when no authentication methods are configured.
+func (a *APIAuthConfigSection) IsEmpty() bool {
+	return a == nil || (len(a.Keys) == 0 && a.JWT == nil && len(a.ClientCerts) == 0)
+}
+
+// AvailableKeys implements SchemaProvider for APIAuthConfigSection.
+// API keys aren't included, as they are managed with 'mcpd config daemon api-keys'.
+func (a *APIAuthConfigSection) AvailableKeys() []SchemaKey {
+	jwtSection := &APIJWTConfigSection{}
+	keys := make([]SchemaKey, 0, len(jwtSection.AvailableKeys()))
+	for _, key := range jwtSection.AvailableKeys() {
+		keys = append(keys, SchemaKey{
+			Path:        "jwt." + key.Path,
+			Type:        key.Type,
+			Description: key.Description,
+		})
+	}
+
+	return keys
+}
+
+// Get implements Getter for APIAuthConfigSection.
+// Returns all auth configuration when called with no keys, API keys are returned without their hashes.
+func (a *APIAuthConfigSection) Get(keys ...string) (any, error) {
+	if len(keys) == 0 {
+		return a.getAll()
+	}
+
+	key := normalizeKey(keys[0])
+
+	if key == "jwt" {
+		if a.JWT == nil {
+			return nil, fmt.Errorf("api.auth.jwt not set")
+		}
+		return a.JWT.Get(keys[1:]...)
+	}
+
+	if len(keys) > 1 {
+		return nil, fmt.Errorf("API auth config does not support nested keys")
+	}
+
+	switch key {
+	case "keys":
+		if len(a.Keys) == 0 {
+			return nil, fmt.Errorf("api.auth.keys not set")
+		}
+		return a.keySummaries(), nil
+	case "client_certs":
+		if len(a.ClientCerts) == 0 {
+			return nil, fmt.Errorf("api.auth.client_certs not set")
+		}
+		return a.ClientCerts, nil
+	default:
+		return nil, fmt.Errorf("API auth %w: %s", ErrInvalidKey, key)
+	}
+}
+
+// Set implements Setter for APIAuthConfigSection.
+// Routes to the JWT subsection, API keys can't be set by path as they are generated along with their hashes.
+func (a *APIAuthConfigSection) Set(path string, value string) (context.UpsertResult, error) {
+	if strings.TrimSpace(path) == "" {
+		return context.Noop, fmt.Errorf("path cannot be empty")
+	}
+
+	parts := strings.Split(path, ".")
+	key := normalizeKey(parts[0])
+
+	if key == "jwt" {
+		if len(parts) > 1 {
+			if a.JWT == nil {
+				a.JWT = &APIJWTConfigSection{}
+			}
+			return a.JWT.Set(strings.Join(parts[1:], "."), value)
+		}
+
+		// The whole section can only be removed.
+		if value != "" {
+			return context.Noop, fmt.Errorf("API JWT settings must be set individually, e.g. jwt.issuer")
+		}
+		if a.JWT == nil {
+			return context.Noop, nil
+		}
+		a.JWT = nil
+		return context.Deleted, nil
+	}
+
+	switch key {
+	case "keys":
+		return context.Noop, fmt.Errorf("API keys are managed with: mcpd config daemon api-keys")
+	case "client_certs":
+		return context.Noop, fmt.Errorf(
+			"client certificates are configured by editing [[daemon.api.auth.client_certs]] in .mcpd.toml",
+		)
+	default:
+		return context.Noop, fmt.Errorf("unknown API auth config key: %s", key)
+	}
+}
+
+// Validate implements Validator for APIAuthConfigSection.
+// Validates the configured API keys, which must have distinct names, the JWT configuration and client certificates.
+func (a *APIAuthConfigSection) Validate() error {
+	var validationErrors []error
+
+	names := make(map[string]struct{}, len(a.Keys))
+	for i, key := range a.Keys {
+		name := strings.TrimSpace(key.Name)
+		if name == "" {
+			validationErrors = append(validationErrors, fmt.Errorf("API key at index %d has no name", i))
+			continue
+		}
+		if _, exists := names[name]; exists {
+			validationErrors = append(validationErrors, fmt.Errorf("duplicate API key name '%s'", name))
+		}
+		names[name] = struct{}{}
+
+		if err := key.Validate(); err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("API key '%s': %w", name, err))
+		}
+	}
+
+	if a.JWT != nil {
+		if err := a.JWT.Validate(); err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("JWT configuration error: %w", err))
+		}
+	}
+
+	for i, cert := range a.ClientCerts {
+		if err := cert.Validate(); err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("client certificate at index %d: %w", i, err))
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+// Validate ensures the API key's hash and scope patterns are well-formed.
+func (k *APIKeyEntry) Validate() error {
+	var errs error
+
+	digest, ok := strings.CutPrefix(strings.TrimSpace(k.Hash), APIKeyHashPrefix)
+	if b, err := hex.DecodeString(digest); !ok || err != nil || len(b) != sha256.Size {
+		err := fmt.Errorf("hash must be a hex encoded SHA-256 hash prefixed with '%s'", APIKeyHashPrefix)
+		errs = errors.Join(errs, err)
+	}
+
+	return errors.Join(errs, validateScope(k.Servers, k.Tools))
+}
+
+// Validate ensures the common name and scope patterns are well-formed.
+func (e *ClientCertEntry) Validate() error {
+	var errs error
+
+	commonName := strings.TrimSpace(e.CommonName)
+	if _, err := path.Match(commonName, ""); err != nil || commonName == "" {
+		errs = errors.Join(errs, fmt.Errorf("common_name '%s' is invalid", e.CommonName))
+	}
+
+	return errors.Join(errs, validateScope(e.Servers, e.Tools))
+}
+
+// validateScope ensures the server and '<server>/<tool>' patterns limiting the scope of a caller are well-formed.
+func validateScope(servers []string, tools []string) error {
+	var errs error
+
+	for _, pattern := range servers {
+		if _, err := path.Match(filter.NormalizeString(pattern), ""); err != nil || strings.TrimSpace(pattern) == "" {
+			errs = errors.Join(errs, fmt.Errorf("server pattern '%s' is invalid", pattern))
+		}
+	}
+
+	for _, pattern := range tools {
+		server, tool, found := strings.Cut(filter.NormalizeString(pattern), "/")
+		if !found || server == "" || tool == "" || strings.Contains(tool, "/") {
+			errs = errors.Join(errs, fmt.Errorf("tool pattern '%s' must be in the form '<server>/<tool>'", pattern))
+			continue
+		}
+		if _, err := path.Match(filter.NormalizeString(pattern), ""); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("tool pattern '%s' is invalid: %w", pattern, err))
+		}
+	}
+
+	return errs
+}
+
+// getAll returns all configured values for the APIAuthConfigSection.
+func (a *APIAuthConfigSection) getAll() (any, error) {
+	result := make(map[string]any)
+
+	if len(a.Keys) > 0 {
+		result["keys"] = a.keySummaries()
+	}
+
+	if len(a.ClientCerts) > 0 {
+		result["client_certs"] = a.ClientCerts
+	}
+
+	if a.JWT != nil {
+		jwtResult, _ := a.JWT.Get()
+		if jwtMap, ok := jwtResult.(map[string]any); ok && len(jwtMap) > 0 {
+			result["jwt"] = jwtResult
+		}
+	}
+
+	return result, nil
+}
+
+// keySummaries describes the configured API keys and their scopes, excluding their hashes.
+func (a *APIAuthConfigSection) keySummaries() []map[string]any {
+	summaries := make([]map[string]any, 0, len(a.Keys))
+	for _, key := range a.Keys {
+		summary := map[string]any{"name": key.Name}
+		if len(key.Servers) > 0 {
+			summary["servers"] = key.Servers
+		}
+		if len(key.Tools) > 0 {
+			summary["tools"] = key.Tools
+		}
+		if key.ReadOnly {
+			summary["read_only"] = true
+		}
+		summaries = append(summaries, summary)
+	}
+
+	return summaries
+}
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/stretchr/testify/require"
+)
+
+// testAPIKeyHash is a well-formed API key hash (of 'key').
+const testAPIKeyHash = "sha256:2c70e12b7a0646f92279f427c7b38e7334d8e5389cff167a1dc30e73f826b683"
+
+func TestAPIKeyEntry_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		entry   APIKeyEntry
+		wantErr string
+	}{
+		{
+			name:  "valid",
+			entry: APIKeyEntry{Name: "ci", Hash: testAPIKeyHash, Servers: []string{"time", "github-*"}},
+		},
+		{
+			name:  "valid tools",
+			entry: APIKeyEntry{Name: "ci", Hash: testAPIKeyHash, Tools: []string{"time/get_current_time", "*/search"}},
+		},
+		{
+			name:    "missing hash prefix",
+			entry:   APIKeyEntry{Name: "ci", Hash: strings.TrimPrefix(testAPIKeyHash, APIKeyHashPrefix)},
+			wantErr: "hash must be a hex encoded SHA-256 hash",
+		},
+		{
+			name:    "short hash",
+			entry:   APIKeyEntry{Name: "ci", Hash: "sha256:abcd"},
+			wantErr: "hash must be a hex encoded SHA-256 hash",
+		},
+		{
+			name:    "invalid server pattern",
+			entry:   APIKeyEntry{Name: "ci", Hash: testAPIKeyHash, Servers: []string{"github-["}},
+			wantErr: "server pattern 'github-[' is invalid",
+		},
+		{
+			name:    "tool without server",
+			entry:   APIKeyEntry{Name: "ci", Hash: testAPIKeyHash, Tools: []string{"get_current_time"}},
+			wantErr: "must be in the form '<server>/<tool>'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.entry.Validate()
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestAPIAuthConfigSection_Validate(t *testing.T) {
+	t.Parallel()
+
+	auth := &APIAuthConfigSection{Keys: []APIKeyEntry{
+		{Name: "ci", Hash: testAPIKeyHash},
+		{Name: "ci", Hash: testAPIKeyHash},
+		{Hash: testAPIKeyHash},
+	}}
+
+	err := auth.Validate()
+	require.ErrorContains(t, err, "duplicate API key name 'ci'")
+	require.ErrorContains(t, err, "API key at index 2 has no name")
+}
+
+func TestAPIAuthConfigSection_Get(t *testing.T) {
+	t.Parallel()
+
+	auth := &APIAuthConfigSection{Keys: []APIKeyEntry{
+		{Name: "ci", Hash: testAPIKeyHash, Servers: []string{"time"}, ReadOnly: true},
+	}}
+
+	got, err := auth.Get("keys")
+	require.NoError(t, err)
+	require.Equal(t, []map[string]any{{"name": "ci", "servers": []string{"time"}, "read_only": true}}, got)
+
+	_, err = auth.Get("hash")
+	require.ErrorIs(t, err, ErrInvalidKey)
+
+	_, err = auth.Set("keys", "value")
+	require.ErrorContains(t, err, "mcpd config daemon api-keys")
+}
+
+func TestConfig_APIKeys(t *testing.T) {
+	t.Parallel()
+
+	tempFile, err := os.CreateTemp(t.TempDir(), ".mcpd.toml")
+	require.NoError(t, err)
+	require.NoError(t, tempFile.Close())
+
+	cfg := &Config{configFilePath: tempFile.Name()}
+
+	entry := APIKeyEntry{Name: "ci", Hash: testAPIKeyHash, Tools: []string{"time/*"}}
+	require.NoError(t, cfg.AddAPIKey(entry))
+	require.ErrorContains(t, cfg.AddAPIKey(entry), "already exists")
+
+	var loaded Config
+	_, err = toml.DecodeFile(tempFile.Name(), &loaded)
+	require.NoError(t, err)
+	require.Equal(t, []APIKeyEntry{entry}, loaded.ListAPIKeys())
+
+	got, ok := cfg.APIKey("ci")
+	require.True(t, ok)
+	require.Equal(t, entry, got)
+
+	require.NoError(t, cfg.RemoveAPIKey("ci"))
+	require.ErrorContains(t, cfg.RemoveAPIKey("ci"), "not found")
+	require.Empty(t, cfg.ListAPIKeys())
+}
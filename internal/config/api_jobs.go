@@ -0,0 +1,152 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mozilla-ai/mcpd/internal/context"
+)
+
+// APIJobsConfigSection contains settings for asynchronous tool calls (jobs) made via the API.
+//
+// NOTE: if you add/remove fields you must review the associated Getter, Setter and Validator implementations,
+// along with /docs/daemon-configuration.md.
+type APIJobsConfigSection struct {
+	// Timeout bounds how long a single asynchronous tool call may run, '1h' by default.
+	Timeout *Duration `json:"timeout,omitempty" toml:"timeout,omitempty" yaml:"timeout,omitempty"`
+
+	// MaxFinished is the maximum number of finished asynchronous tool calls which are retained, 100 by default.
+	MaxFinished *int `json:"maxFinished,omitempty" toml:"max_finished,omitempty" yaml:"max_finished,omitempty"`
+
+	// Retention is how long finished asynchronous tool calls are retained, '1h' by default.
+	Retention *Duration `json:"retention,omitempty" toml:"retention,omitempty" yaml:"retention,omitempty"`
+}
+
+// AvailableKeys implements SchemaProvider for APIJobsConfigSection.
+func (j *APIJobsConfigSection) AvailableKeys() []SchemaKey {
+	return []SchemaKey{
+		{Path: "timeout", Type: "duration", Description: "Timeout for asynchronous tool calls"},
+		{Path: "max_finished", Type: "int", Description: "Maximum number of finished asynchronous tool calls retained"},
+		{Path: "retention", Type: "duration", Description: "How long finished asynchronous tool calls are retained"},
+	}
+}
+
+// Get implements Getter for APIJobsConfigSection.
+// Returns all jobs configuration when called with no keys, or specific values when keys are provided.
+func (j *APIJobsConfigSection) Get(keys ...string) (any, error) {
+	if len(keys) == 0 {
+		return j.getAll()
+	}
+
+	if err := ensureSingleKey(keys, "API jobs"); err != nil {
+		return nil, err
+	}
+
+	key := normalizeKey(keys[0])
+
+	switch key {
+	case "timeout":
+		if j.Timeout == nil {
+			return nil, fmt.Errorf("api.jobs.timeout not set")
+		}
+		return *j.Timeout, nil
+	case "max_finished":
+		if j.MaxFinished == nil {
+			return nil, fmt.Errorf("api.jobs.max_finished not set")
+		}
+		return *j.MaxFinished, nil
+	case "retention":
+		if j.Retention == nil {
+			return nil, fmt.Errorf("api.jobs.retention not set")
+		}
+		return *j.Retention, nil
+	default:
+		return nil, fmt.Errorf("API jobs %w: %s", ErrInvalidKey, key)
+	}
+}
+
+// Set implements Setter for APIJobsConfigSection.
+// Handles API jobs configuration at the leaf level, an empty value removes the setting.
+func (j *APIJobsConfigSection) Set(path string, value string) (context.UpsertResult, error) {
+	if strings.TrimSpace(path) == "" {
+		return context.Noop, fmt.Errorf("path cannot be empty")
+	}
+
+	key := normalizeKey(path)
+
+	switch key {
+	case "timeout":
+		return setDurationPtr(&j.Timeout, key, value)
+	case "retention":
+		return setDurationPtr(&j.Retention, key, value)
+	case "max_finished":
+		oldValue := j.MaxFinished
+		if value == "" {
+			j.MaxFinished = nil
+		} else {
+			maxFinished, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return context.Noop, fmt.Errorf("%w: %w", NewErrInvalidValue(key, value), err)
+			}
+			j.MaxFinished = &maxFinished
+		}
+		return determineIntPtrResult(oldValue, j.MaxFinished), nil
+	default:
+		return context.Noop, fmt.Errorf("unknown API jobs config key: %s", key)
+	}
+}
+
+// Validate implements Validator for APIJobsConfigSection.
+// Validates that the timeout, number of finished jobs and retention are positive.
+func (j *APIJobsConfigSection) Validate() error {
+	var validationErrors []error
+
+	if j.Timeout != nil && *j.Timeout <= 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("jobs timeout must be positive"))
+	}
+
+	if j.MaxFinished != nil && *j.MaxFinished <= 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("jobs max_finished must be positive"))
+	}
+
+	if j.Retention != nil && *j.Retention <= 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("jobs retention must be positive"))
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+// getAll returns all configured values for the APIJobsConfigSection.
+func (j *APIJobsConfigSection) getAll() (any, error) {
+	result := make(map[string]any)
+
+	if j.Timeout != nil {
+		result["timeout"] = *j.Timeout
+	}
+	if j.MaxFinished != nil {
+		result["max_finished"] = *j.MaxFinished
+	}
+	if j.Retention != nil {
+		result["retention"] = *j.Retention
+	}
+
+	return result, nil
+}
+
+// setDurationPtr parses the value into the duration field, an empty value removes the setting.
+func setDurationPtr(field **Duration, key string, value string) (context.UpsertResult, error) {
+	oldValue := *field
+	if value == "" {
+		*field = nil
+	} else {
+		duration, err := parseDuration(value)
+		if err != nil {
+			return context.Noop, fmt.Errorf("%w: %w", NewErrInvalidValue(key, value), err)
+		}
+		*field = &duration
+	}
+
+	return determineDurationPtrResult(oldValue, *field), nil
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/context"
+)
+
+func TestAPIConfigSection_SetJobs(t *testing.T) {
+	t.Parallel()
+
+	api := &APIConfigSection{}
+
+	result, err := api.Set("jobs.timeout", "10m")
+	require.NoError(t, err)
+	require.Equal(t, context.Created, result)
+
+	_, err = api.Set("jobs.max_finished", "50")
+	require.NoError(t, err)
+
+	got, err := api.Get("jobs")
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"timeout": Duration(10 * time.Minute), "max_finished": 50}, got)
+
+	got, err = api.Get("jobs", "max_finished")
+	require.NoError(t, err)
+	require.Equal(t, 50, got)
+
+	_, err = api.Get("jobs", "retention")
+	require.EqualError(t, err, "api.jobs.retention not set")
+
+	_, err = api.Set("jobs.retention", "soon")
+	require.ErrorContains(t, err, "config value invalid: 'retention' (value: 'soon')")
+
+	_, err = api.Set("jobs.max_finished", "many")
+	require.ErrorContains(t, err, "config value invalid: 'max_finished' (value: 'many')")
+
+	_, err = api.Set("jobs.unknown", "value")
+	require.EqualError(t, err, "unknown API jobs config key: unknown")
+
+	result, err = api.Set("jobs.timeout", "")
+	require.NoError(t, err)
+	require.Equal(t, context.Deleted, result)
+}
+
+func TestAPIJobsConfigSection_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		config   *APIJobsConfigSection
+		errorMsg string
+	}{
+		{
+			name:   "empty config is valid",
+			config: &APIJobsConfigSection{},
+		},
+		{
+			name: "positive values are valid",
+			config: &APIJobsConfigSection{
+				Timeout:     testDurationPtr(t, time.Minute),
+				MaxFinished: testIntPtr(t, 10),
+				Retention:   testDurationPtr(t, time.Hour),
+			},
+		},
+		{
+			name: "zero and negative values are invalid",
+			config: &APIJobsConfigSection{
+				Timeout:     testDurationPtr(t, 0),
+				MaxFinished: testIntPtr(t, 0),
+				Retention:   testDurationPtr(t, -time.Second),
+			},
+			errorMsg: "jobs timeout must be positive\n" +
+				"jobs max_finished must be positive\n" +
+				"jobs retention must be positive",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.config.Validate()
+			if tc.errorMsg != "" {
+				require.EqualError(t, err, tc.errorMsg)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,322 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/mozilla-ai/mcpd/internal/context"
+)
+
+// APIJWTConfigSection contains settings for authenticating requests to the daemon API with JWT bearer tokens,
+// such as workload identity tokens issued by an OIDC provider.
+// Tokens must be signed (RS256 or ES256) by a key in the JSON Web Key Set (JWKS) loaded from a file or URL.
+//
+// NOTE: if you add/remove fields you must review the associated Getter, Setter and Validator implementations,
+// along with /docs/daemon-configuration.md.
+type APIJWTConfigSection struct {
+	// Issuer is the required value of the 'iss' claim of tokens.
+	Issuer *string `json:"issuer,omitempty" toml:"issuer,omitempty" yaml:"issuer,omitempty"`
+
+	// Audience contains the accepted values of the 'aud' claim of tokens, tokens must include at least one of them.
+	Audience []string `json:"audience,omitempty" toml:"audience,omitempty" yaml:"audience,omitempty"`
+
+	// JWKSFile is the path of a file containing the JWKS used to verify tokens.
+	// Mutually exclusive with JWKSURL.
+	JWKSFile *string `json:"jwksFile,omitempty" toml:"jwks_file,omitempty" yaml:"jwks_file,omitempty"`
+
+	// JWKSURL is the URL the JWKS used to verify tokens is fetched from (e.g. an OIDC provider's 'jwks_uri').
+	// Mutually exclusive with JWKSFile.
+	JWKSURL *string `json:"jwksUrl,omitempty" toml:"jwks_url,omitempty" yaml:"jwks_url,omitempty"`
+
+	// JWKSRefresh is how often the JWKS is reloaded, so rotated keys are picked up.
+	// The JWKS is also reloaded (at most once a minute) when a token is signed by an unknown key.
+	JWKSRefresh *Duration `json:"jwksRefresh,omitempty" toml:"jwks_refresh,omitempty" yaml:"jwks_refresh,omitempty"`
+
+	// ClockSkew is the leeway allowed when checking the 'exp', 'nbf' and 'iat' claims of tokens.
+	ClockSkew *Duration `json:"clockSkew,omitempty" toml:"clock_skew,omitempty" yaml:"clock_skew,omitempty"`
+
+	// NameClaim is the claim identifying the caller (e.g. in logs), defaults to 'sub'.
+	NameClaim *string `json:"nameClaim,omitempty" toml:"name_claim,omitempty" yaml:"name_claim,omitempty"`
+
+	// Scopes map the claims of tokens to the servers and tools callers may access.
+	// When no scopes are configured, callers with valid tokens may access all servers and tools.
+	Scopes []JWTScopeEntry `json:"scopes,omitempty" toml:"scopes,omitempty" yaml:"scopes,omitempty"`
+}
+
+// JWTScopeEntry grants callers, whose tokens have a claim containing a value, access to servers or tools.
+// Callers are granted the combined access of every entry their token matches.
+type JWTScopeEntry struct {
+	// Claim is the name of the claim, e.g. 'groups', 'scope', or a path to a nested claim such as 'realm_access.roles'.
+	// Claims may be strings (space separated values, as used by 'scope') or arrays of strings.
+	Claim string `json:"claim" toml:"claim" yaml:"claim"`
+
+	// Value is the value the claim must contain.
+	Value string `json:"value" toml:"value" yaml:"value"`
+
+	// Servers are the names (or glob patterns) of the servers which may be accessed.
+	// When neither Servers nor Tools are set, all servers and tools may be accessed.
+	Servers []string `json:"servers,omitempty" toml:"servers,omitempty" yaml:"servers,omitempty"`
+
+	// Tools are the tools which may be called, as '<server>/<tool>' names (or glob patterns).
+	// Mutually exclusive with Servers.
+	Tools []string `json:"tools,omitempty" toml:"tools,omitempty" yaml:"tools,omitempty"`
+
+	// ReadOnly limits the access granted to requests which don't make changes.
+	// Callers are only read-only when every entry their token matches is read-only.
+	ReadOnly bool `json:"readOnly,omitempty" toml:"read_only,omitempty" yaml:"read_only,omitempty"`
+}
+
+// AvailableKeys implements SchemaProvider for APIJWTConfigSection.
+func (j *APIJWTConfigSection) AvailableKeys() []SchemaKey {
+	return []SchemaKey{
+		{Path: "issuer", Type: "string", Description: "Required issuer ('iss' claim) of JWT bearer tokens"},
+		{Path: "audience", Type: "[]string", Description: "Accepted audiences ('aud' claim) of JWT bearer tokens"},
+		{Path: "jwks_file", Type: "string", Description: "Path of the JWKS file used to verify JWT bearer tokens"},
+		{Path: "jwks_url", Type: "string", Description: "URL of the JWKS used to verify JWT bearer tokens"},
+		{Path: "jwks_refresh", Type: "duration", Description: "How often the JWKS is reloaded"},
+		{Path: "clock_skew", Type: "duration", Description: "Leeway allowed when checking token expiry"},
+		{Path: "name_claim", Type: "string", Description: "Claim identifying the caller (default: sub)"},
+	}
+}
+
+// Get implements Getter for APIJWTConfigSection.
+// Returns all JWT configuration when called with no keys, or specific values when keys are provided.
+func (j *APIJWTConfigSection) Get(keys ...string) (any, error) {
+	if len(keys) == 0 {
+		return j.getAll()
+	}
+
+	if err := ensureSingleKey(keys, "API JWT"); err != nil {
+		return nil, err
+	}
+
+	key := normalizeKey(keys[0])
+
+	switch key {
+	case "issuer":
+		if j.Issuer == nil {
+			return nil, fmt.Errorf("api.auth.jwt.issuer not set")
+		}
+		return *j.Issuer, nil
+	case "audience":
+		if len(j.Audience) == 0 {
+			return nil, fmt.Errorf("api.auth.jwt.audience not set")
+		}
+		return j.Audience, nil
+	case "jwks_file":
+		if j.JWKSFile == nil {
+			return nil, fmt.Errorf("api.auth.jwt.jwks_file not set")
+		}
+		return *j.JWKSFile, nil
+	case "jwks_url":
+		if j.JWKSURL == nil {
+			return nil, fmt.Errorf("api.auth.jwt.jwks_url not set")
+		}
+		return *j.JWKSURL, nil
+	case "jwks_refresh":
+		if j.JWKSRefresh == nil {
+			return nil, fmt.Errorf("api.auth.jwt.jwks_refresh not set")
+		}
+		return *j.JWKSRefresh, nil
+	case "clock_skew":
+		if j.ClockSkew == nil {
+			return nil, fmt.Errorf("api.auth.jwt.clock_skew not set")
+		}
+		return *j.ClockSkew, nil
+	case "name_claim":
+		if j.NameClaim == nil {
+			return nil, fmt.Errorf("api.auth.jwt.name_claim not set")
+		}
+		return *j.NameClaim, nil
+	case "scopes":
+		if len(j.Scopes) == 0 {
+			return nil, fmt.Errorf("api.auth.jwt.scopes not set")
+		}
+		return j.Scopes, nil
+	default:
+		return nil, fmt.Errorf("API JWT %w: %s", ErrInvalidKey, key)
+	}
+}
+
+// Set implements Setter for APIJWTConfigSection.
+// Handles API JWT configuration at the leaf level, an empty value removes the setting.
+func (j *APIJWTConfigSection) Set(path string, value string) (context.UpsertResult, error) {
+	if strings.TrimSpace(path) == "" {
+		return context.Noop, fmt.Errorf("path cannot be empty")
+	}
+
+	key := normalizeKey(path)
+
+	setString := func(field **string) context.UpsertResult {
+		oldValue := *field
+		if value == "" {
+			*field = nil
+		} else {
+			*field = &value
+		}
+		return determineStringPtrResult(oldValue, *field)
+	}
+
+	setDuration := func(field **Duration) (context.UpsertResult, error) {
+		oldValue := *field
+		if value == "" {
+			*field = nil
+		} else {
+			duration, err := parseDuration(value)
+			if err != nil {
+				return context.Noop, fmt.Errorf("%w: %w", NewErrInvalidValue(key, value), err)
+			}
+			*field = &duration
+		}
+		return determineDurationPtrResult(oldValue, *field), nil
+	}
+
+	switch key {
+	case "issuer":
+		return setString(&j.Issuer), nil
+	case "audience":
+		oldValue := j.Audience
+		j.Audience = parseStringArray(value)
+		return determineStringSliceResult(oldValue, j.Audience), nil
+	case "jwks_file":
+		return setString(&j.JWKSFile), nil
+	case "jwks_url":
+		return setString(&j.JWKSURL), nil
+	case "jwks_refresh":
+		return setDuration(&j.JWKSRefresh)
+	case "clock_skew":
+		return setDuration(&j.ClockSkew)
+	case "name_claim":
+		return setString(&j.NameClaim), nil
+	case "scopes":
+		return context.Noop, fmt.Errorf(
+			"JWT scopes are configured by editing [[daemon.api.auth.jwt.scopes]] in .mcpd.toml",
+		)
+	default:
+		return context.Noop, fmt.Errorf("unknown API JWT config key: %s", key)
+	}
+}
+
+// Validate implements Validator for APIJWTConfigSection.
+// Validates the token checks, the source of the JWKS, and the scopes.
+func (j *APIJWTConfigSection) Validate() error {
+	var validationErrors []error
+
+	if j.Issuer == nil || strings.TrimSpace(*j.Issuer) == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("issuer is required"))
+	}
+
+	if len(j.Audience) == 0 || slices.Contains(j.Audience, "") {
+		validationErrors = append(validationErrors, fmt.Errorf("audience is required, and cannot contain empty values"))
+	}
+
+	switch {
+	case j.JWKSFile != nil && j.JWKSURL != nil:
+		validationErrors = append(validationErrors, fmt.Errorf("only one of jwks_file or jwks_url can be set"))
+	case j.JWKSFile != nil:
+		if strings.TrimSpace(*j.JWKSFile) == "" {
+			validationErrors = append(validationErrors, fmt.Errorf("jwks_file cannot be empty"))
+		}
+	case j.JWKSURL != nil:
+		if err := validateJWKSURL(*j.JWKSURL); err != nil {
+			validationErrors = append(validationErrors, err)
+		}
+	default:
+		validationErrors = append(validationErrors, fmt.Errorf("one of jwks_file or jwks_url is required"))
+	}
+
+	if j.JWKSRefresh != nil && *j.JWKSRefresh <= 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("jwks_refresh must be positive"))
+	}
+
+	if j.ClockSkew != nil && *j.ClockSkew < 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("clock_skew cannot be negative"))
+	}
+
+	if j.NameClaim != nil && strings.TrimSpace(*j.NameClaim) == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("name_claim cannot be empty"))
+	}
+
+	for i, scope := range j.Scopes {
+		if err := scope.Validate(); err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("scope at index %d: %w", i, err))
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+// Validate ensures the scope names a claim and value, and its server or tool patterns are well-formed.
+func (s *JWTScopeEntry) Validate() error {
+	var errs error
+
+	if strings.TrimSpace(s.Claim) == "" {
+		errs = errors.Join(errs, fmt.Errorf("claim is required"))
+	}
+
+	if strings.TrimSpace(s.Value) == "" {
+		errs = errors.Join(errs, fmt.Errorf("value is required"))
+	}
+
+	if len(s.Servers) > 0 && len(s.Tools) > 0 {
+		errs = errors.Join(errs, fmt.Errorf("only one of servers or tools can be set"))
+	}
+
+	return errors.Join(errs, validateScope(s.Servers, s.Tools))
+}
+
+// getAll returns all configured values for the APIJWTConfigSection.
+func (j *APIJWTConfigSection) getAll() (any, error) {
+	result := make(map[string]any)
+
+	if j.Issuer != nil {
+		result["issuer"] = *j.Issuer
+	}
+	if len(j.Audience) > 0 {
+		result["audience"] = j.Audience
+	}
+	if j.JWKSFile != nil {
+		result["jwks_file"] = *j.JWKSFile
+	}
+	if j.JWKSURL != nil {
+		result["jwks_url"] = *j.JWKSURL
+	}
+	if j.JWKSRefresh != nil {
+		result["jwks_refresh"] = *j.JWKSRefresh
+	}
+	if j.ClockSkew != nil {
+		result["clock_skew"] = *j.ClockSkew
+	}
+	if j.NameClaim != nil {
+		result["name_claim"] = *j.NameClaim
+	}
+	if len(j.Scopes) > 0 {
+		result["scopes"] = j.Scopes
+	}
+
+	return result, nil
+}
+
+// validateJWKSURL ensures the JWKS is fetched over HTTPS, except from the local host (e.g. during development).
+func validateJWKSURL(rawURL string) error {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("jwks_url '%s' is not a valid URL", rawURL)
+	}
+
+	switch u.Scheme {
+	case "https":
+		return nil
+	case "http":
+		host := u.Hostname()
+		if ip := net.ParseIP(host); host == "localhost" || (ip != nil && ip.IsLoopback()) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("jwks_url '%s' must use https", rawURL)
+}
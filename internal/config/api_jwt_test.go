@@ -0,0 +1,184 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/context"
+)
+
+func TestAPIJWTConfigSection_Validate(t *testing.T) {
+	t.Parallel()
+
+	issuer := testStringPtr(t, "https://issuer.example.com")
+	file := testStringPtr(t, "/etc/mcpd/jwks.json")
+	negative := testDurationPtr(t, -time.Second)
+
+	tests := []struct {
+		name    string
+		section APIJWTConfigSection
+		wantErr []string
+	}{
+		{
+			name:    "valid file",
+			section: APIJWTConfigSection{Issuer: issuer, Audience: []string{"mcpd"}, JWKSFile: file},
+		},
+		{
+			name: "valid url",
+			section: APIJWTConfigSection{
+				Issuer:   issuer,
+				Audience: []string{"mcpd"},
+				JWKSURL:  testStringPtr(t, "https://idp/jwks"),
+			},
+		},
+		{
+			name: "valid localhost url",
+			section: APIJWTConfigSection{
+				Issuer:   issuer,
+				Audience: []string{"mcpd"},
+				JWKSURL:  testStringPtr(t, "http://localhost:8080"),
+			},
+		},
+		{
+			name:    "missing everything",
+			section: APIJWTConfigSection{},
+			wantErr: []string{"issuer is required", "audience is required", "one of jwks_file or jwks_url is required"},
+		},
+		{
+			name: "both JWKS sources",
+			section: APIJWTConfigSection{
+				Issuer:   issuer,
+				Audience: []string{"mcpd"},
+				JWKSFile: file,
+				JWKSURL:  testStringPtr(t, "https://idp/jwks"),
+			},
+			wantErr: []string{"only one of jwks_file or jwks_url can be set"},
+		},
+		{
+			name: "insecure url",
+			section: APIJWTConfigSection{
+				Issuer:   issuer,
+				Audience: []string{"mcpd"},
+				JWKSURL:  testStringPtr(t, "http://idp/jwks"),
+			},
+			wantErr: []string{"jwks_url 'http://idp/jwks' must use https"},
+		},
+		{
+			name: "invalid durations and name claim",
+			section: APIJWTConfigSection{
+				Issuer:      issuer,
+				Audience:    []string{"mcpd"},
+				JWKSFile:    file,
+				JWKSRefresh: negative,
+				ClockSkew:   negative,
+				NameClaim:   testStringPtr(t, ""),
+			},
+			wantErr: []string{
+				"jwks_refresh must be positive",
+				"clock_skew cannot be negative",
+				"name_claim cannot be empty",
+			},
+		},
+		{
+			name: "invalid scopes",
+			section: APIJWTConfigSection{
+				Issuer:   issuer,
+				Audience: []string{"mcpd"},
+				JWKSFile: file,
+				Scopes: []JWTScopeEntry{
+					{Claim: "groups"},
+					{Claim: "groups", Value: "ops", Servers: []string{"time"}, Tools: []string{"time/*"}},
+					{Claim: "groups", Value: "dev", Tools: []string{"get_current_time"}},
+				},
+			},
+			wantErr: []string{
+				"scope at index 0: value is required",
+				"scope at index 1: only one of servers or tools can be set",
+				"scope at index 2: tool pattern 'get_current_time' must be in the form '<server>/<tool>'",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.section.Validate()
+			if len(tc.wantErr) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			for _, want := range tc.wantErr {
+				require.ErrorContains(t, err, want)
+			}
+		})
+	}
+}
+
+func TestAPIAuthConfigSection_SetJWT(t *testing.T) {
+	t.Parallel()
+
+	auth := &APIAuthConfigSection{}
+
+	result, err := auth.Set("jwt.issuer", "https://issuer.example.com")
+	require.NoError(t, err)
+	require.Equal(t, context.Created, result)
+	require.NotNil(t, auth.JWT)
+
+	_, err = auth.Set("jwt.audience", "mcpd,agents")
+	require.NoError(t, err)
+	require.Equal(t, []string{"mcpd", "agents"}, auth.JWT.Audience)
+
+	_, err = auth.Set("jwt.clock_skew", "30s")
+	require.NoError(t, err)
+
+	got, err := auth.Get("jwt", "clock_skew")
+	require.NoError(t, err)
+	require.Equal(t, Duration(30*time.Second), got)
+
+	_, err = auth.Set("jwt.clock_skew", "soon")
+	require.Error(t, err)
+
+	_, err = auth.Set("jwt.scopes", "groups")
+	require.ErrorContains(t, err, "[[daemon.api.auth.jwt.scopes]]")
+
+	_, err = auth.Set("jwt", "value")
+	require.Error(t, err)
+
+	result, err = auth.Set("jwt", "")
+	require.NoError(t, err)
+	require.Equal(t, context.Deleted, result)
+	require.Nil(t, auth.JWT)
+	require.True(t, auth.IsEmpty())
+}
+
+func TestAPIJWTConfigSection_DecodeScopes(t *testing.T) {
+	t.Parallel()
+
+	var cfg struct {
+		Auth APIAuthConfigSection `toml:"auth"`
+	}
+	_, err := toml.Decode(`
+[auth.jwt]
+issuer = "https://issuer.example.com"
+audience = ["mcpd"]
+jwks_file = "/etc/mcpd/jwks.json"
+clock_skew = "30s"
+
+[[auth.jwt.scopes]]
+claim = "groups"
+value = "time-users"
+servers = ["time"]
+read_only = true
+`, &cfg)
+	require.NoError(t, err)
+	require.NoError(t, cfg.Auth.Validate())
+
+	require.Equal(t, Duration(30*time.Second), *cfg.Auth.JWT.ClockSkew)
+	require.Equal(t, []JWTScopeEntry{
+		{Claim: "groups", Value: "time-users", Servers: []string{"time"}, ReadOnly: true},
+	}, cfg.Auth.JWT.Scopes)
+}
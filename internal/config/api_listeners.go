@@ -0,0 +1,87 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	// RouteGroupAdmin is the group of operational API routes, such as health, server log levels,
+	// tool call approvals and elicitations.
+	RouteGroupAdmin = "admin"
+
+	// RouteGroupData is the group of API routes used by agents, such as listing and calling tools, prompts,
+	// and resources, batches, workflows, and jobs.
+	RouteGroupData = "data"
+)
+
+// RouteGroups returns all groups of API routes.
+func RouteGroups() []string {
+	return []string{RouteGroupAdmin, RouteGroupData}
+}
+
+// APIListenerEntry configures an additional address the daemon API is served on, limited to some groups of routes.
+// This allows operational routes to be served on a separate (e.g. loopback or Unix socket) address to tool calls.
+type APIListenerEntry struct {
+	// Addr is the address to bind, "host:port" or "unix:///path/to/socket".
+	Addr string `json:"addr" toml:"addr" yaml:"addr"`
+
+	// Routes are the groups of routes served on the address (admin, data).
+	Routes []string `json:"routes" toml:"routes" yaml:"routes"`
+}
+
+// Validate validates the address and route groups of the listener.
+func (e *APIListenerEntry) Validate() error {
+	var validationErrors []error
+
+	if err := validateListenAddr(e.Addr); err != nil {
+		validationErrors = append(validationErrors, err)
+	}
+
+	if len(e.Routes) == 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("routes are required"))
+	}
+	if err := validateRouteGroups(e.Routes); err != nil {
+		validationErrors = append(validationErrors, err)
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+// validateListenAddr returns an error when the address isn't a valid "host:port" or "unix:///path" address.
+func validateListenAddr(addr string) error {
+	if strings.TrimSpace(addr) == "" {
+		return fmt.Errorf("API address cannot be empty")
+	}
+
+	if path, ok := UnixSocketPath(addr); ok {
+		if strings.TrimSpace(path) == "" {
+			return fmt.Errorf("API address \"%s\" is missing the socket path", addr)
+		}
+		return nil
+	}
+
+	if !isValidAddr(addr) {
+		return fmt.Errorf("API address \"%s\" appears to be invalid (expected format: host:port or unix:///path)", addr)
+	}
+
+	return nil
+}
+
+// validateRouteGroups returns an error for each group which isn't a known group of routes.
+func validateRouteGroups(groups []string) error {
+	var validationErrors []error
+
+	for _, group := range groups {
+		if !slices.Contains(RouteGroups(), group) {
+			validationErrors = append(
+				validationErrors,
+				fmt.Errorf("unknown route group '%s' (expected one of: %s)", group, strings.Join(RouteGroups(), ", ")),
+			)
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
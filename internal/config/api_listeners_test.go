@@ -0,0 +1,92 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/context"
+)
+
+func TestAPIConfigSection_ValidateListeners(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		section APIConfigSection
+		wantErr []string
+	}{
+		{
+			name: "valid",
+			section: APIConfigSection{
+				Addr:   testStringPtr(t, "0.0.0.0:8090"),
+				Routes: []string{RouteGroupData},
+				Listeners: []APIListenerEntry{
+					{Addr: "127.0.0.1:8091", Routes: []string{RouteGroupAdmin}},
+					{Addr: "unix:///run/mcpd/admin.sock", Routes: []string{RouteGroupAdmin, RouteGroupData}},
+				},
+			},
+		},
+		{
+			name:    "unknown route group",
+			section: APIConfigSection{Routes: []string{"metrics"}},
+			wantErr: []string{
+				"routes configuration error: unknown route group 'metrics' (expected one of: admin, data)",
+			},
+		},
+		{
+			name: "invalid listener",
+			section: APIConfigSection{
+				Listeners: []APIListenerEntry{{Addr: "localhost"}},
+			},
+			wantErr: []string{
+				`listener at index 0: API address "localhost" appears to be invalid`,
+				"routes are required",
+			},
+		},
+		{
+			name: "duplicate address",
+			section: APIConfigSection{
+				Addr:      testStringPtr(t, "127.0.0.1:8090"),
+				Listeners: []APIListenerEntry{{Addr: "127.0.0.1:8090", Routes: []string{RouteGroupAdmin}}},
+			},
+			wantErr: []string{`listener at index 0: API address "127.0.0.1:8090" is already in use`},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.section.Validate()
+			if len(tc.wantErr) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			for _, want := range tc.wantErr {
+				require.ErrorContains(t, err, want)
+			}
+		})
+	}
+}
+
+func TestAPIConfigSection_SetRoutes(t *testing.T) {
+	t.Parallel()
+
+	api := &APIConfigSection{}
+
+	result, err := api.Set("routes", "data, admin")
+	require.NoError(t, err)
+	require.Equal(t, context.Created, result)
+
+	got, err := api.Get("routes")
+	require.NoError(t, err)
+	require.Equal(t, []string{RouteGroupData, RouteGroupAdmin}, got)
+
+	_, err = api.Set("listeners", "127.0.0.1:8091")
+	require.ErrorContains(t, err, "[[daemon.api.listeners]]")
+
+	result, err = api.Set("routes", "")
+	require.NoError(t, err)
+	require.Equal(t, context.Deleted, result)
+}
@@ -0,0 +1,162 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mozilla-ai/mcpd/internal/context"
+)
+
+// UnixAddrPrefix is the prefix of API addresses which are Unix domain sockets (e.g. 'unix:///run/mcpd/mcpd.sock').
+const UnixAddrPrefix = "unix://"
+
+// APISocketConfigSection contains settings for the Unix domain socket the daemon API is served on,
+// when the API address is a 'unix://' address.
+//
+// NOTE: if you add/remove fields you must review the associated Getter, Setter and Validator implementations,
+// along with /docs/daemon-configuration.md.
+type APISocketConfigSection struct {
+	// Mode is the octal file mode of the socket (e.g. '0660'), '0600' by default.
+	Mode *string `json:"mode,omitempty" toml:"mode,omitempty" yaml:"mode,omitempty"`
+
+	// Owner is the name or numeric ID of the user which owns the socket.
+	// When not set, the socket is owned by the user running the daemon.
+	Owner *string `json:"owner,omitempty" toml:"owner,omitempty" yaml:"owner,omitempty"`
+
+	// Group is the name or numeric ID of the group which owns the socket.
+	// When not set, the socket is owned by the primary group of the user running the daemon.
+	Group *string `json:"group,omitempty" toml:"group,omitempty" yaml:"group,omitempty"`
+}
+
+// UnixSocketPath returns the path of the socket when the address is a 'unix://' address.
+func UnixSocketPath(addr string) (string, bool) {
+	return strings.CutPrefix(strings.TrimSpace(addr), UnixAddrPrefix)
+}
+
+// ParseSocketMode parses an octal file mode for a socket (e.g. '0660').
+func ParseSocketMode(value string) (uint32, error) {
+	mode, err := strconv.ParseUint(strings.TrimSpace(value), 8, 32)
+	if err != nil || mode > 0o777 {
+		return 0, fmt.Errorf("invalid socket mode '%s' (expected octal permissions, e.g. 0660)", value)
+	}
+
+	return uint32(mode), nil
+}
+
+// AvailableKeys implements SchemaProvider for APISocketConfigSection.
+func (s *APISocketConfigSection) AvailableKeys() []SchemaKey {
+	return []SchemaKey{
+		{Path: "mode", Type: "string", Description: "Octal file mode of the API Unix socket (e.g. 0660)"},
+		{Path: "owner", Type: "string", Description: "User (name or ID) which owns the API Unix socket"},
+		{Path: "group", Type: "string", Description: "Group (name or ID) which owns the API Unix socket"},
+	}
+}
+
+// Get implements Getter for APISocketConfigSection.
+// Returns all socket configuration when called with no keys, or specific values when keys are provided.
+func (s *APISocketConfigSection) Get(keys ...string) (any, error) {
+	if len(keys) == 0 {
+		return s.getAll()
+	}
+
+	if err := ensureSingleKey(keys, "API socket"); err != nil {
+		return nil, err
+	}
+
+	key := normalizeKey(keys[0])
+
+	var value *string
+	switch key {
+	case "mode":
+		value = s.Mode
+	case "owner":
+		value = s.Owner
+	case "group":
+		value = s.Group
+	default:
+		return nil, fmt.Errorf("API socket %w: %s", ErrInvalidKey, key)
+	}
+
+	if value == nil {
+		return nil, fmt.Errorf("api.socket.%s not set", key)
+	}
+
+	return *value, nil
+}
+
+// Set implements Setter for APISocketConfigSection.
+// Handles API socket configuration at the leaf level, an empty value removes the setting.
+func (s *APISocketConfigSection) Set(path string, value string) (context.UpsertResult, error) {
+	if strings.TrimSpace(path) == "" {
+		return context.Noop, fmt.Errorf("path cannot be empty")
+	}
+
+	key := normalizeKey(path)
+
+	var field **string
+	switch key {
+	case "mode":
+		if value != "" {
+			if _, err := ParseSocketMode(value); err != nil {
+				return context.Noop, err
+			}
+		}
+		field = &s.Mode
+	case "owner":
+		field = &s.Owner
+	case "group":
+		field = &s.Group
+	default:
+		return context.Noop, fmt.Errorf("unknown API socket config key: %s", key)
+	}
+
+	oldValue := *field
+	if value == "" {
+		*field = nil
+	} else {
+		*field = &value
+	}
+
+	return determineStringPtrResult(oldValue, *field), nil
+}
+
+// Validate implements Validator for APISocketConfigSection.
+// Validates the socket file mode, and that the owner and group aren't empty when set.
+func (s *APISocketConfigSection) Validate() error {
+	var validationErrors []error
+
+	if s.Mode != nil {
+		if _, err := ParseSocketMode(*s.Mode); err != nil {
+			validationErrors = append(validationErrors, err)
+		}
+	}
+
+	if s.Owner != nil && strings.TrimSpace(*s.Owner) == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("owner cannot be empty"))
+	}
+
+	if s.Group != nil && strings.TrimSpace(*s.Group) == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("group cannot be empty"))
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+// getAll returns all configured values for the APISocketConfigSection.
+func (s *APISocketConfigSection) getAll() (any, error) {
+	result := make(map[string]any)
+
+	if s.Mode != nil {
+		result["mode"] = *s.Mode
+	}
+	if s.Owner != nil {
+		result["owner"] = *s.Owner
+	}
+	if s.Group != nil {
+		result["group"] = *s.Group
+	}
+
+	return result, nil
+}
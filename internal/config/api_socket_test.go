@@ -0,0 +1,78 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/context"
+)
+
+func TestUnixSocketPath(t *testing.T) {
+	t.Parallel()
+
+	path, ok := UnixSocketPath(" unix:///run/mcpd/mcpd.sock ")
+	require.True(t, ok)
+	require.Equal(t, "/run/mcpd/mcpd.sock", path)
+
+	_, ok = UnixSocketPath("localhost:8090")
+	require.False(t, ok)
+}
+
+func TestParseSocketMode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		value   string
+		want    uint32
+		wantErr bool
+	}{
+		{name: "leading zero", value: "0660", want: 0o660},
+		{name: "without leading zero", value: "600", want: 0o600},
+		{name: "not octal", value: "0690", wantErr: true},
+		{name: "symbolic", value: "rw-------", wantErr: true},
+		{name: "special bits", value: "4755", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseSocketMode(tc.value)
+			if tc.wantErr {
+				require.ErrorContains(t, err, "invalid socket mode")
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestAPIConfigSection_SetSocket(t *testing.T) {
+	t.Parallel()
+
+	api := &APIConfigSection{}
+
+	result, err := api.Set("socket.mode", "0660")
+	require.NoError(t, err)
+	require.Equal(t, context.Created, result)
+
+	_, err = api.Set("socket.group", "mcpd")
+	require.NoError(t, err)
+
+	got, err := api.Get("socket")
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"mode": "0660", "group": "mcpd"}, got)
+
+	_, err = api.Set("socket.mode", "0999")
+	require.ErrorContains(t, err, "invalid socket mode '0999'")
+
+	_, err = api.Set("socket.unknown", "value")
+	require.ErrorContains(t, err, "unknown API socket config key: unknown")
+
+	result, err = api.Set("socket.group", "")
+	require.NoError(t, err)
+	require.Equal(t, context.Deleted, result)
+}
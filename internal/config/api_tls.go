@@ -0,0 +1,193 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mozilla-ai/mcpd/internal/context"
+)
+
+const (
+	// TLSVersion12 is the min_version value for TLS 1.2.
+	TLSVersion12 = "1.2"
+
+	// TLSVersion13 is the min_version value for TLS 1.3.
+	TLSVersion13 = "1.3"
+
+	// ClientAuthRequire is the client_auth value requiring clients to present a certificate signed by the client CA.
+	ClientAuthRequire = "require"
+
+	// ClientAuthOptional is the client_auth value verifying the certificates clients present, without requiring one.
+	ClientAuthOptional = "optional"
+)
+
+// APITLSConfigSection contains settings for serving the daemon API over TLS, and optionally mutual TLS (mTLS).
+// The certificate and key are reloaded when the daemon receives SIGHUP.
+//
+// NOTE: if you add/remove fields you must review the associated Getter, Setter and Validator implementations,
+// along with /docs/daemon-configuration.md.
+type APITLSConfigSection struct {
+	// CertFile is the path of the PEM encoded certificate (chain) presented by the API server.
+	CertFile *string `json:"certFile,omitempty" toml:"cert_file,omitempty" yaml:"cert_file,omitempty"`
+
+	// KeyFile is the path of the PEM encoded private key of the certificate.
+	KeyFile *string `json:"keyFile,omitempty" toml:"key_file,omitempty" yaml:"key_file,omitempty"`
+
+	// MinVersion is the minimum TLS version accepted, '1.2' (default) or '1.3'.
+	MinVersion *string `json:"minVersion,omitempty" toml:"min_version,omitempty" yaml:"min_version,omitempty"`
+
+	// ClientCAFile is the path of the PEM encoded CA certificates which client certificates are verified against.
+	// When set, clients authenticate with certificates (mTLS).
+	ClientCAFile *string `json:"clientCaFile,omitempty" toml:"client_ca_file,omitempty" yaml:"client_ca_file,omitempty"`
+
+	// ClientAuth determines whether clients must present a certificate when ClientCAFile is set,
+	// 'require' (default) or 'optional'.
+	ClientAuth *string `json:"clientAuth,omitempty" toml:"client_auth,omitempty" yaml:"client_auth,omitempty"`
+}
+
+// AvailableKeys implements SchemaProvider for APITLSConfigSection.
+func (t *APITLSConfigSection) AvailableKeys() []SchemaKey {
+	return []SchemaKey{
+		{Path: "cert_file", Type: "string", Description: "Path of the TLS certificate presented by the API server"},
+		{Path: "key_file", Type: "string", Description: "Path of the private key of the TLS certificate"},
+		{Path: "min_version", Type: "string", Description: "Minimum TLS version (1.2 or 1.3)"},
+		{Path: "client_ca_file", Type: "string", Description: "Path of the CA certificates for client certificates"},
+		{Path: "client_auth", Type: "string", Description: "Whether client certificates are required or optional"},
+	}
+}
+
+// Get implements Getter for APITLSConfigSection.
+// Returns all TLS configuration when called with no keys, or specific values when keys are provided.
+func (t *APITLSConfigSection) Get(keys ...string) (any, error) {
+	if len(keys) == 0 {
+		return t.getAll()
+	}
+
+	if err := ensureSingleKey(keys, "API TLS"); err != nil {
+		return nil, err
+	}
+
+	key := normalizeKey(keys[0])
+
+	var value *string
+	switch key {
+	case "cert_file":
+		value = t.CertFile
+	case "key_file":
+		value = t.KeyFile
+	case "min_version":
+		value = t.MinVersion
+	case "client_ca_file":
+		value = t.ClientCAFile
+	case "client_auth":
+		value = t.ClientAuth
+	default:
+		return nil, fmt.Errorf("API TLS %w: %s", ErrInvalidKey, key)
+	}
+
+	if value == nil {
+		return nil, fmt.Errorf("api.tls.%s not set", key)
+	}
+
+	return *value, nil
+}
+
+// Set implements Setter for APITLSConfigSection.
+// Handles API TLS configuration at the leaf level, an empty value removes the setting.
+func (t *APITLSConfigSection) Set(path string, value string) (context.UpsertResult, error) {
+	if strings.TrimSpace(path) == "" {
+		return context.Noop, fmt.Errorf("path cannot be empty")
+	}
+
+	key := normalizeKey(path)
+
+	var field **string
+	switch key {
+	case "cert_file":
+		field = &t.CertFile
+	case "key_file":
+		field = &t.KeyFile
+	case "min_version":
+		field = &t.MinVersion
+	case "client_ca_file":
+		field = &t.ClientCAFile
+	case "client_auth":
+		field = &t.ClientAuth
+	default:
+		return context.Noop, fmt.Errorf("unknown API TLS config key: %s", key)
+	}
+
+	oldValue := *field
+	if value == "" {
+		*field = nil
+	} else {
+		*field = &value
+	}
+
+	return determineStringPtrResult(oldValue, *field), nil
+}
+
+// Validate implements Validator for APITLSConfigSection.
+// Validates that the certificate and key are both configured, along with the TLS version and client auth mode.
+func (t *APITLSConfigSection) Validate() error {
+	var validationErrors []error
+
+	if t.CertFile == nil || strings.TrimSpace(*t.CertFile) == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("cert_file is required"))
+	}
+
+	if t.KeyFile == nil || strings.TrimSpace(*t.KeyFile) == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("key_file is required"))
+	}
+
+	if t.MinVersion != nil && !slices.Contains([]string{TLSVersion12, TLSVersion13}, *t.MinVersion) {
+		err := fmt.Errorf("min_version must be one of '%s' or '%s'", TLSVersion12, TLSVersion13)
+		validationErrors = append(validationErrors, err)
+	}
+
+	if t.ClientCAFile != nil && strings.TrimSpace(*t.ClientCAFile) == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("client_ca_file cannot be empty"))
+	}
+
+	if t.ClientAuth != nil {
+		if !slices.Contains([]string{ClientAuthRequire, ClientAuthOptional}, *t.ClientAuth) {
+			err := fmt.Errorf("client_auth must be one of '%s' or '%s'", ClientAuthRequire, ClientAuthOptional)
+			validationErrors = append(validationErrors, err)
+		}
+		if t.ClientCAFile == nil {
+			validationErrors = append(validationErrors, fmt.Errorf("client_auth requires client_ca_file"))
+		}
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+// MutualTLS returns true when clients are authenticated with certificates.
+func (t *APITLSConfigSection) MutualTLS() bool {
+	return t != nil && t.ClientCAFile != nil
+}
+
+// getAll returns all configured values for the APITLSConfigSection.
+func (t *APITLSConfigSection) getAll() (any, error) {
+	result := make(map[string]any)
+
+	if t.CertFile != nil {
+		result["cert_file"] = *t.CertFile
+	}
+	if t.KeyFile != nil {
+		result["key_file"] = *t.KeyFile
+	}
+	if t.MinVersion != nil {
+		result["min_version"] = *t.MinVersion
+	}
+	if t.ClientCAFile != nil {
+		result["client_ca_file"] = *t.ClientCAFile
+	}
+	if t.ClientAuth != nil {
+		result["client_auth"] = *t.ClientAuth
+	}
+
+	return result, nil
+}
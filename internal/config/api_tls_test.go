@@ -0,0 +1,121 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/context"
+)
+
+func TestAPITLSConfigSection_Validate(t *testing.T) {
+	t.Parallel()
+
+	cert := testStringPtr(t, "/etc/mcpd/tls.crt")
+	key := testStringPtr(t, "/etc/mcpd/tls.key")
+
+	tests := []struct {
+		name    string
+		section APITLSConfigSection
+		wantErr []string
+	}{
+		{
+			name:    "valid",
+			section: APITLSConfigSection{CertFile: cert, KeyFile: key},
+		},
+		{
+			name: "valid mTLS",
+			section: APITLSConfigSection{
+				CertFile:     cert,
+				KeyFile:      key,
+				MinVersion:   testStringPtr(t, TLSVersion13),
+				ClientCAFile: testStringPtr(t, "/etc/mcpd/ca.crt"),
+				ClientAuth:   testStringPtr(t, ClientAuthOptional),
+			},
+		},
+		{
+			name:    "missing certificate and key",
+			section: APITLSConfigSection{},
+			wantErr: []string{"cert_file is required", "key_file is required"},
+		},
+		{
+			name:    "invalid min version",
+			section: APITLSConfigSection{CertFile: cert, KeyFile: key, MinVersion: testStringPtr(t, "1.0")},
+			wantErr: []string{"min_version must be one of '1.2' or '1.3'"},
+		},
+		{
+			name: "invalid client auth",
+			section: APITLSConfigSection{
+				CertFile:   cert,
+				KeyFile:    key,
+				ClientAuth: testStringPtr(t, "sometimes"),
+			},
+			wantErr: []string{
+				"client_auth must be one of 'require' or 'optional'",
+				"client_auth requires client_ca_file",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.section.Validate()
+			if len(tc.wantErr) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			for _, want := range tc.wantErr {
+				require.ErrorContains(t, err, want)
+			}
+		})
+	}
+}
+
+func TestAPIConfigSection_SetTLS(t *testing.T) {
+	t.Parallel()
+
+	api := &APIConfigSection{}
+
+	result, err := api.Set("tls.cert_file", "/etc/mcpd/tls.crt")
+	require.NoError(t, err)
+	require.Equal(t, context.Created, result)
+
+	_, err = api.Set("tls.key_file", "/etc/mcpd/tls.key")
+	require.NoError(t, err)
+
+	got, err := api.Get("tls", "cert_file")
+	require.NoError(t, err)
+	require.Equal(t, "/etc/mcpd/tls.crt", got)
+
+	got, err = api.Get("tls")
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"cert_file": "/etc/mcpd/tls.crt", "key_file": "/etc/mcpd/tls.key"}, got)
+
+	_, err = api.Set("tls.unknown", "value")
+	require.ErrorContains(t, err, "unknown API TLS config key: unknown")
+
+	require.NoError(t, api.Validate())
+}
+
+func TestAPIConfigSection_ValidateClientCerts(t *testing.T) {
+	t.Parallel()
+
+	api := &APIConfigSection{
+		Auth: &APIAuthConfigSection{ClientCerts: []ClientCertEntry{
+			{CommonName: "agent-*", Servers: []string{"time"}},
+		}},
+		TLS: &APITLSConfigSection{
+			CertFile: testStringPtr(t, "/etc/mcpd/tls.crt"),
+			KeyFile:  testStringPtr(t, "/etc/mcpd/tls.key"),
+		},
+	}
+	require.ErrorContains(t, api.Validate(), "client_certs require tls.client_ca_file")
+
+	api.TLS.ClientCAFile = testStringPtr(t, "/etc/mcpd/ca.crt")
+	require.NoError(t, api.Validate())
+
+	api.Auth.ClientCerts = append(api.Auth.ClientCerts, ClientCertEntry{CommonName: "agent-["})
+	require.ErrorContains(t, api.Validate(), "client certificate at index 1: common_name 'agent-[' is invalid")
+}
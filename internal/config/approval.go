@@ -0,0 +1,59 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/mozilla-ai/mcpd/internal/filter"
+)
+
+// ApprovalConfig marks the tools of a server which require approval before calls to them are made,
+// either by name (or glob pattern), or by the hints (annotations) the server provides for them.
+type ApprovalConfig struct {
+	// Tools lists the names (or glob patterns) of the tools which require approval.
+	// e.g. 'delete_repository', 'send_*'
+	Tools []string `json:"tools,omitempty" toml:"tools,omitempty" yaml:"tools,omitempty"`
+
+	// Destructive requires approval for calls to tools which may be destructive (destructiveHint).
+	Destructive *bool `json:"destructive,omitempty" toml:"destructive,omitempty" yaml:"destructive,omitempty"`
+
+	// OpenWorld requires approval for calls to tools which may interact with external entities (openWorldHint).
+	OpenWorld *bool `json:"openWorld,omitempty" toml:"open_world,omitempty" yaml:"open_world,omitempty"`
+}
+
+// ApprovalRequired returns true when calls to the tool require approval.
+// When the tool's hints are unknown (nil), the defaults defined by the MCP specification are assumed.
+func (s *ServerEntry) ApprovalRequired(tool string, hints *ToolHints) bool {
+	a := s.Approval
+	if a == nil {
+		return false
+	}
+
+	if matchAnyTool(a.Tools, filter.NormalizeString(tool)) {
+		return true
+	}
+
+	if hints == nil {
+		hints = &ToolHints{}
+	}
+
+	return (a.Destructive != nil && *a.Destructive && hints.isDestructive()) ||
+		(a.OpenWorld != nil && *a.OpenWorld && hints.isOpenWorld())
+}
+
+// validateApproval ensures the tool patterns which require approval are well-formed.
+func (s *ServerEntry) validateApproval() error {
+	if s.Approval == nil {
+		return nil
+	}
+
+	var errs error
+	for _, pattern := range s.Approval.Tools {
+		if _, err := path.Match(filter.NormalizeString(pattern), ""); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("tool pattern '%s' is invalid: %w", pattern, err))
+		}
+	}
+
+	return errs
+}
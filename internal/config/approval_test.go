@@ -0,0 +1,61 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerEntry_ApprovalRequired(t *testing.T) {
+	t.Parallel()
+
+	readOnly := &ToolHints{ReadOnly: testBoolPtr(t, true)}
+	destructive := &ToolHints{Destructive: testBoolPtr(t, true), OpenWorld: testBoolPtr(t, false)}
+	additive := &ToolHints{Destructive: testBoolPtr(t, false), OpenWorld: testBoolPtr(t, false)}
+	openWorld := &ToolHints{Destructive: testBoolPtr(t, false), OpenWorld: testBoolPtr(t, true)}
+
+	named := &ApprovalConfig{Tools: []string{"delete_repo", "send_*"}}
+	onDestructive := &ApprovalConfig{Destructive: testBoolPtr(t, true)}
+	onOpenWorld := &ApprovalConfig{OpenWorld: testBoolPtr(t, true)}
+
+	tests := []struct {
+		name     string
+		approval *ApprovalConfig
+		tool     string
+		hints    *ToolHints
+		expected bool
+	}{
+		{name: "no approval", tool: "delete_repo", hints: destructive},
+		{name: "named", approval: named, tool: "Delete_Repo", expected: true},
+		{name: "pattern", approval: named, tool: "send_email", expected: true},
+		{name: "not named", approval: named, tool: "create_repo", hints: destructive},
+		{name: "destructive", approval: onDestructive, tool: "delete_repo", hints: destructive, expected: true},
+		{name: "destructive unknown", approval: onDestructive, tool: "x", expected: true},
+		{name: "destructive additive", approval: onDestructive, tool: "x", hints: additive},
+		{name: "destructive read only", approval: onDestructive, tool: "x", hints: readOnly},
+		{name: "open world", approval: onOpenWorld, tool: "fetch", hints: openWorld, expected: true},
+		{name: "open world closed", approval: onOpenWorld, tool: "x", hints: destructive},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			entry := &ServerEntry{Name: "github", Approval: tc.approval}
+			require.Equal(t, tc.expected, entry.ApprovalRequired(tc.tool, tc.hints))
+		})
+	}
+}
+
+func TestServerEntry_ValidateApproval(t *testing.T) {
+	t.Parallel()
+
+	entry := &ServerEntry{Name: "test"}
+	require.NoError(t, entry.validateApproval())
+
+	entry.Approval = &ApprovalConfig{Tools: []string{"delete_*", "send_email"}}
+	require.NoError(t, entry.validateApproval())
+
+	entry.Approval = &ApprovalConfig{Tools: []string{"delete_["}}
+	require.ErrorContains(t, entry.validateApproval(), "tool pattern 'delete_[' is invalid")
+}
@@ -0,0 +1,222 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mozilla-ai/mcpd/internal/context"
+)
+
+const (
+	// AuditArgumentsHash records only a hash of the arguments of each call in the audit log.
+	AuditArgumentsHash = "hash"
+
+	// AuditArgumentsRedacted records the arguments of each call in the audit log,
+	// with the values of keys matching the redaction rules replaced, instead of their hash.
+	AuditArgumentsRedacted = "redacted"
+)
+
+// AuditArgumentModes returns all modes for recording the arguments of calls in the audit log.
+func AuditArgumentModes() []string {
+	return []string{AuditArgumentsHash, AuditArgumentsRedacted}
+}
+
+// AuditConfigSection configures the built-in audit log of tool calls, prompt gets and resource reads.
+// The audit log is enabled when a path is configured.
+//
+// NOTE: if you add/remove fields you must review the associated Getter, Setter and Validator implementations,
+// along with /docs/daemon-configuration.md.
+type AuditConfigSection struct {
+	// Path of the JSON Lines file the audit log is written to, enables the audit log when set.
+	Path *string `json:"path,omitempty" toml:"path,omitempty" yaml:"path,omitempty"`
+
+	// Arguments is how the arguments of calls are recorded (hash, redacted).
+	Arguments *string `json:"arguments,omitempty" toml:"arguments,omitempty" yaml:"arguments,omitempty"`
+
+	// RedactKeys are patterns (e.g. "*token*") of the argument keys whose values are redacted,
+	// when arguments are recorded.
+	RedactKeys []string `json:"redactKeys,omitempty" toml:"redact_keys,omitempty" yaml:"redact_keys,omitempty"`
+
+	// MaxSizeMB is the size in megabytes the audit log file can reach before it is rotated.
+	MaxSizeMB *int `json:"maxSizeMb,omitempty" toml:"max_size_mb,omitempty" yaml:"max_size_mb,omitempty"`
+
+	// MaxFiles is the number of rotated audit log files which are kept, 0 keeps all files.
+	MaxFiles *int `json:"maxFiles,omitempty" toml:"max_files,omitempty" yaml:"max_files,omitempty"`
+}
+
+// AvailableKeys implements SchemaProvider for AuditConfigSection.
+func (a *AuditConfigSection) AvailableKeys() []SchemaKey {
+	return []SchemaKey{
+		{Path: "path", Type: "string", Description: "Path of the JSON Lines audit log file (enables the audit log)"},
+		{Path: "arguments", Type: "string", Description: "How call arguments are recorded (hash, redacted)"},
+		{Path: "redact_keys", Type: "[]string", Description: "Patterns of argument keys whose values are redacted"},
+		{Path: "max_size_mb", Type: "int", Description: "Size in megabytes at which the audit log file is rotated"},
+		{Path: "max_files", Type: "int", Description: "Number of rotated audit log files to keep (0 keeps all)"},
+	}
+}
+
+// Get implements Getter for AuditConfigSection.
+// Returns all audit configuration when called with no keys, or specific values when keys are provided.
+func (a *AuditConfigSection) Get(keys ...string) (any, error) {
+	if len(keys) == 0 {
+		return a.getAll()
+	}
+
+	if err := ensureSingleKey(keys, "audit"); err != nil {
+		return nil, err
+	}
+
+	key := normalizeKey(keys[0])
+
+	switch key {
+	case "path":
+		if a.Path == nil {
+			return nil, fmt.Errorf("audit.path not set")
+		}
+		return *a.Path, nil
+	case "arguments":
+		if a.Arguments == nil {
+			return nil, fmt.Errorf("audit.arguments not set")
+		}
+		return *a.Arguments, nil
+	case "redact_keys":
+		if a.RedactKeys == nil {
+			return nil, fmt.Errorf("audit.redact_keys not set")
+		}
+		return a.RedactKeys, nil
+	case "max_size_mb":
+		if a.MaxSizeMB == nil {
+			return nil, fmt.Errorf("audit.max_size_mb not set")
+		}
+		return *a.MaxSizeMB, nil
+	case "max_files":
+		if a.MaxFiles == nil {
+			return nil, fmt.Errorf("audit.max_files not set")
+		}
+		return *a.MaxFiles, nil
+	default:
+		return nil, fmt.Errorf("audit %w: %s", ErrInvalidKey, key)
+	}
+}
+
+// Set implements Setter for AuditConfigSection.
+// Handles audit configuration at the leaf level, an empty value removes the setting.
+func (a *AuditConfigSection) Set(path string, value string) (context.UpsertResult, error) {
+	if strings.TrimSpace(path) == "" {
+		return context.Noop, fmt.Errorf("path cannot be empty")
+	}
+
+	key := normalizeKey(path)
+
+	switch key {
+	case "path", "arguments":
+		field := &a.Path
+		if key == "arguments" {
+			if value != "" && !slices.Contains(AuditArgumentModes(), value) {
+				return context.Noop, invalidAuditArguments(value)
+			}
+			field = &a.Arguments
+		}
+
+		oldValue := *field
+		if value == "" {
+			*field = nil
+		} else {
+			*field = &value
+		}
+		return determineStringPtrResult(oldValue, *field), nil
+	case "redact_keys":
+		oldValue := a.RedactKeys
+		a.RedactKeys = parseStringArray(value)
+		return determineStringSliceResult(oldValue, a.RedactKeys), nil
+	case "max_size_mb", "max_files":
+		field := &a.MaxSizeMB
+		if key == "max_files" {
+			field = &a.MaxFiles
+		}
+
+		oldValue := *field
+		if value == "" {
+			*field = nil
+		} else {
+			n, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return context.Noop, fmt.Errorf("%w: %w", NewErrInvalidValue(key, value), err)
+			}
+			*field = &n
+		}
+		return determineIntPtrResult(oldValue, *field), nil
+	default:
+		return context.Noop, fmt.Errorf("unknown audit config key: %s", key)
+	}
+}
+
+// Validate implements Validator for AuditConfigSection.
+// Validates the audit log path, argument mode, redaction patterns and rotation limits.
+func (a *AuditConfigSection) Validate() error {
+	var validationErrors []error
+
+	if a.Path != nil && strings.TrimSpace(*a.Path) == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("path cannot be empty"))
+	}
+
+	if a.Arguments != nil && !slices.Contains(AuditArgumentModes(), *a.Arguments) {
+		validationErrors = append(validationErrors, invalidAuditArguments(*a.Arguments))
+	}
+
+	for _, pattern := range a.RedactKeys {
+		if strings.TrimSpace(pattern) == "" {
+			validationErrors = append(validationErrors, fmt.Errorf("redact keys cannot be empty"))
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("invalid redact key pattern '%s': %w", pattern, err))
+		}
+	}
+
+	if a.MaxSizeMB != nil && *a.MaxSizeMB <= 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("max size must be positive"))
+	}
+
+	if a.MaxFiles != nil && *a.MaxFiles < 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("max files cannot be negative"))
+	}
+
+	return errors.Join(validationErrors...)
+}
+
+// getAll returns all configured values for the AuditConfigSection.
+func (a *AuditConfigSection) getAll() (any, error) {
+	result := make(map[string]any)
+
+	if a.Path != nil {
+		result["path"] = *a.Path
+	}
+	if a.Arguments != nil {
+		result["arguments"] = *a.Arguments
+	}
+	if len(a.RedactKeys) > 0 {
+		result["redact_keys"] = a.RedactKeys
+	}
+	if a.MaxSizeMB != nil {
+		result["max_size_mb"] = *a.MaxSizeMB
+	}
+	if a.MaxFiles != nil {
+		result["max_files"] = *a.MaxFiles
+	}
+
+	return result, nil
+}
+
+// invalidAuditArguments returns the error for an unknown mode of recording call arguments.
+func invalidAuditArguments(value string) error {
+	return fmt.Errorf(
+		"invalid audit arguments '%s' (expected one of: %s)",
+		value,
+		strings.Join(AuditArgumentModes(), ", "),
+	)
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/context"
+)
+
+func TestAuditConfigSection_Validate(t *testing.T) {
+	t.Parallel()
+
+	negative := -1
+	zero := 0
+
+	tests := []struct {
+		name    string
+		section AuditConfigSection
+		wantErr []string
+	}{
+		{
+			name: "valid",
+			section: AuditConfigSection{
+				Path:       testStringPtr(t, "/var/log/mcpd/audit.jsonl"),
+				Arguments:  testStringPtr(t, AuditArgumentsRedacted),
+				RedactKeys: []string{"*token*", "password"},
+				MaxFiles:   &zero,
+			},
+		},
+		{
+			name:    "empty path",
+			section: AuditConfigSection{Path: testStringPtr(t, " ")},
+			wantErr: []string{"path cannot be empty"},
+		},
+		{
+			name:    "unknown arguments mode",
+			section: AuditConfigSection{Arguments: testStringPtr(t, "full")},
+			wantErr: []string{"invalid audit arguments 'full' (expected one of: hash, redacted)"},
+		},
+		{
+			name: "invalid limits",
+			section: AuditConfigSection{
+				RedactKeys: []string{"", "[token"},
+				MaxSizeMB:  &zero,
+				MaxFiles:   &negative,
+			},
+			wantErr: []string{
+				"redact keys cannot be empty",
+				"invalid redact key pattern '[token'",
+				"max size must be positive",
+				"max files cannot be negative",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.section.Validate()
+			if len(tc.wantErr) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			for _, want := range tc.wantErr {
+				require.ErrorContains(t, err, want)
+			}
+		})
+	}
+}
+
+func TestDaemonConfig_SetAudit(t *testing.T) {
+	t.Parallel()
+
+	daemon := &DaemonConfig{}
+
+	result, err := daemon.Set("audit.path", "audit.jsonl")
+	require.NoError(t, err)
+	require.Equal(t, context.Created, result)
+
+	result, err = daemon.Set("audit.redact_keys", "*token*, password")
+	require.NoError(t, err)
+	require.Equal(t, context.Created, result)
+
+	result, err = daemon.Set("audit.max_files", "5")
+	require.NoError(t, err)
+	require.Equal(t, context.Created, result)
+
+	_, err = daemon.Set("audit.arguments", "full")
+	require.ErrorContains(t, err, "invalid audit arguments 'full'")
+
+	_, err = daemon.Set("audit.max_size_mb", "big")
+	require.ErrorContains(t, err, "max_size_mb")
+
+	got, err := daemon.Get("audit")
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"path":        "audit.jsonl",
+		"redact_keys": []string{"*token*", "password"},
+		"max_files":   5,
+	}, got)
+
+	result, err = daemon.Set("audit.path", "")
+	require.NoError(t, err)
+	require.Equal(t, context.Deleted, result)
+
+	_, err = daemon.Get("audit", "path")
+	require.EqualError(t, err, "audit.path not set")
+}
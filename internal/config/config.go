@@ -266,6 +266,10 @@ func (c *Config) validate() error {
 		return err
 	}
 
+	if err := c.validateWorkflows(); err != nil {
+		return fmt.Errorf("workflow configuration error: %w", err)
+	}
+
 	if c.Daemon != nil {
 		if err := c.Daemon.Validate(); err != nil {
 			return fmt.Errorf("daemon configuration error: %w", err)
@@ -278,6 +282,10 @@ func (c *Config) validate() error {
 		}
 	}
 
+	if err := c.validateSampling(); err != nil {
+		return fmt.Errorf("sampling configuration error: %w", err)
+	}
+
 	return nil
 }
 
@@ -307,6 +315,30 @@ func (c *Config) validateFields() error {
 		if strings.TrimSpace(entry.Package) == "" {
 			return fmt.Errorf("server entry has empty package")
 		}
+		if err := entry.validateTools(); err != nil {
+			return fmt.Errorf("server '%s' has invalid tools: %w", entry.Name, err)
+		}
+		if err := entry.validateTimeouts(); err != nil {
+			return fmt.Errorf("server '%s' has invalid timeouts: %w", entry.Name, err)
+		}
+		if err := entry.validateVirtualTools(); err != nil {
+			return fmt.Errorf("server '%s' has invalid virtual tools: %w", entry.Name, err)
+		}
+		if err := entry.validateResources(); err != nil {
+			return fmt.Errorf("server '%s' has invalid resources: %w", entry.Name, err)
+		}
+		if err := entry.validateApproval(); err != nil {
+			return fmt.Errorf("server '%s' has invalid approval: %w", entry.Name, err)
+		}
+		if err := entry.validateSampling(); err != nil {
+			return fmt.Errorf("server '%s' has invalid sampling: %w", entry.Name, err)
+		}
+		if err := entry.validateRoots(); err != nil {
+			return fmt.Errorf("server '%s' has invalid roots: %w", entry.Name, err)
+		}
+		if err := entry.validateLogLevel(); err != nil {
+			return fmt.Errorf("server '%s' has invalid log level: %w", entry.Name, err)
+		}
 	}
 	return nil
 }
@@ -17,7 +17,7 @@ import (
 // NOTE: if you add/remove fields you must review the associated Getter, Setter and Validator implementations,
 // along with /docs/daemon-configuration.md.
 type APIConfigSection struct {
-	// Address to bind the API server (e.g., "0.0.0.0:8090")
+	// Address to bind the API server (e.g., "0.0.0.0:8090"), or a Unix socket (e.g., "unix:///run/mcpd/mcpd.sock")
 	// Maps to CLI flag --addr
 	Addr *string `json:"addr,omitempty" toml:"addr,omitempty" yaml:"addr,omitempty"`
 
@@ -26,6 +26,37 @@ type APIConfigSection struct {
 
 	// Nested CORS configuration for cross-origin requests
 	CORS *CORSConfigSection `json:"cors,omitempty" toml:"cors,omitempty" yaml:"cors,omitempty"`
+
+	// Nested batch configuration for batched tool calls
+	Batch *APIBatchConfigSection `json:"batch,omitempty" toml:"batch,omitempty" yaml:"batch,omitempty"`
+
+	// Nested configuration for asynchronous tool calls (jobs)
+	Jobs *APIJobsConfigSection `json:"jobs,omitempty" toml:"jobs,omitempty" yaml:"jobs,omitempty"`
+
+	// Nested authentication configuration for API requests
+	Auth *APIAuthConfigSection `json:"auth,omitempty" toml:"auth,omitempty" yaml:"auth,omitempty"`
+
+	// Nested TLS configuration for serving the API over HTTPS
+	TLS *APITLSConfigSection `json:"tls,omitempty" toml:"tls,omitempty" yaml:"tls,omitempty"`
+
+	// Nested Unix socket configuration, applied when the address is a Unix socket
+	Socket *APISocketConfigSection `json:"socket,omitempty" toml:"socket,omitempty" yaml:"socket,omitempty"`
+
+	// Groups of routes (admin, data) served on Addr, all groups when empty
+	Routes []string `json:"routes,omitempty" toml:"routes,omitempty" yaml:"routes,omitempty"`
+
+	// Additional addresses the API is served on, each limited to some groups of routes
+	Listeners []APIListenerEntry `json:"listeners,omitempty" toml:"listeners,omitempty" yaml:"listeners,omitempty"`
+}
+
+// APIBatchConfigSection contains settings for batched tool calls.
+//
+// NOTE: if you add/remove fields you must review the associated Getter, Setter and Validator implementations,
+// along with /docs/daemon-configuration.md.
+type APIBatchConfigSection struct {
+	// Maximum number of tool calls from a single batch that are executed concurrently
+	// Maps to CLI flag --batch-concurrency
+	Concurrency *int `json:"concurrency,omitempty" toml:"concurrency,omitempty" yaml:"concurrency,omitempty"`
 }
 
 // APITimeoutConfigSection contains timeout settings for API operations.
@@ -83,6 +114,9 @@ type DaemonConfig struct {
 
 	// MCP configuration (includes nested timeout and interval settings)
 	MCP *MCPConfigSection `json:"mcp,omitempty" toml:"mcp,omitempty" yaml:"mcp,omitempty"`
+
+	// Audit configuration for the built-in audit log of tool calls, prompt gets and resource reads
+	Audit *AuditConfigSection `json:"audit,omitempty" toml:"audit,omitempty" yaml:"audit,omitempty"`
 }
 
 // Duration is a custom time.Duration type that provides improved marshaling.
@@ -135,7 +169,9 @@ type MCPTimeoutConfigSection struct {
 // AvailableKeys implements SchemaProvider for APIConfigSection.
 func (a *APIConfigSection) AvailableKeys() []SchemaKey {
 	keys := []SchemaKey{
-		{Path: "addr", Type: "string", Description: "API server address (host:port)"},
+		{Path: "addr", Type: "string", Description: "API server address (host:port or unix:///path/to/mcpd.sock)"},
+		{Path: "routes", Type: "[]string", Description: "Groups of routes served on the API address (admin, data)"},
+		{Path: "listeners", Type: "[]object", Description: "Additional API addresses, each serving groups of routes"},
 	}
 
 	// Always return timeout keys regardless of whether timeout section exists
@@ -158,6 +194,56 @@ func (a *APIConfigSection) AvailableKeys() []SchemaKey {
 		})
 	}
 
+	// Always return batch keys regardless of whether batch section exists
+	batchSection := &APIBatchConfigSection{}
+	for _, key := range batchSection.AvailableKeys() {
+		keys = append(keys, SchemaKey{
+			Path:        "batch." + key.Path,
+			Type:        key.Type,
+			Description: key.Description,
+		})
+	}
+
+	// Always return jobs keys regardless of whether jobs section exists
+	jobsSection := &APIJobsConfigSection{}
+	for _, key := range jobsSection.AvailableKeys() {
+		keys = append(keys, SchemaKey{
+			Path:        "jobs." + key.Path,
+			Type:        key.Type,
+			Description: key.Description,
+		})
+	}
+
+	// Always return auth keys regardless of whether auth section exists
+	authSection := &APIAuthConfigSection{}
+	for _, key := range authSection.AvailableKeys() {
+		keys = append(keys, SchemaKey{
+			Path:        "auth." + key.Path,
+			Type:        key.Type,
+			Description: key.Description,
+		})
+	}
+
+	// Always return TLS keys regardless of whether TLS section exists
+	tlsSection := &APITLSConfigSection{}
+	for _, key := range tlsSection.AvailableKeys() {
+		keys = append(keys, SchemaKey{
+			Path:        "tls." + key.Path,
+			Type:        key.Type,
+			Description: key.Description,
+		})
+	}
+
+	// Always return socket keys regardless of whether socket section exists
+	socketSection := &APISocketConfigSection{}
+	for _, key := range socketSection.AvailableKeys() {
+		keys = append(keys, SchemaKey{
+			Path:        "socket." + key.Path,
+			Type:        key.Type,
+			Description: key.Description,
+		})
+	}
+
 	return keys
 }
 
@@ -177,6 +263,16 @@ func (a *APIConfigSection) Get(keys ...string) (any, error) {
 				return nil, fmt.Errorf("api.addr not set")
 			}
 			return *a.Addr, nil
+		case "routes":
+			if len(a.Routes) == 0 {
+				return nil, fmt.Errorf("api.routes not set")
+			}
+			return a.Routes, nil
+		case "listeners":
+			if len(a.Listeners) == 0 {
+				return nil, fmt.Errorf("api.listeners not set")
+			}
+			return a.Listeners, nil
 		case "timeout":
 			if a.Timeout == nil {
 				return nil, fmt.Errorf("api.timeout not set")
@@ -187,6 +283,31 @@ func (a *APIConfigSection) Get(keys ...string) (any, error) {
 				return nil, fmt.Errorf("api.cors not set")
 			}
 			return a.CORS.Get()
+		case "batch":
+			if a.Batch == nil {
+				return nil, fmt.Errorf("api.batch not set")
+			}
+			return a.Batch.Get()
+		case "jobs":
+			if a.Jobs == nil {
+				return nil, fmt.Errorf("api.jobs not set")
+			}
+			return a.Jobs.Get()
+		case "auth":
+			if a.Auth == nil {
+				return nil, fmt.Errorf("api.auth not set")
+			}
+			return a.Auth.Get()
+		case "tls":
+			if a.TLS == nil {
+				return nil, fmt.Errorf("api.tls not set")
+			}
+			return a.TLS.Get()
+		case "socket":
+			if a.Socket == nil {
+				return nil, fmt.Errorf("api.socket not set")
+			}
+			return a.Socket.Get()
 		default:
 			return nil, fmt.Errorf("unknown API config key: %s", key)
 		}
@@ -204,6 +325,31 @@ func (a *APIConfigSection) Get(keys ...string) (any, error) {
 			return nil, fmt.Errorf("api.cors not set")
 		}
 		return a.CORS.Get(keys[1:]...)
+	case "batch":
+		if a.Batch == nil {
+			return nil, fmt.Errorf("api.batch not set")
+		}
+		return a.Batch.Get(keys[1:]...)
+	case "jobs":
+		if a.Jobs == nil {
+			return nil, fmt.Errorf("api.jobs not set")
+		}
+		return a.Jobs.Get(keys[1:]...)
+	case "auth":
+		if a.Auth == nil {
+			return nil, fmt.Errorf("api.auth not set")
+		}
+		return a.Auth.Get(keys[1:]...)
+	case "tls":
+		if a.TLS == nil {
+			return nil, fmt.Errorf("api.tls not set")
+		}
+		return a.TLS.Get(keys[1:]...)
+	case "socket":
+		if a.Socket == nil {
+			return nil, fmt.Errorf("api.socket not set")
+		}
+		return a.Socket.Get(keys[1:]...)
 	default:
 		return nil, fmt.Errorf("unknown API subsection: %s", key)
 	}
@@ -230,6 +376,18 @@ func (a *APIConfigSection) Set(path string, value string) (context.UpsertResult,
 				a.Addr = &value
 			}
 			return determineStringPtrResult(oldValue, a.Addr), nil
+		case "routes":
+			oldValue := a.Routes
+			if value == "" {
+				a.Routes = nil
+			} else {
+				a.Routes = parseStringArray(value)
+			}
+			return determineStringSliceResult(oldValue, a.Routes), nil
+		case "listeners":
+			return context.Noop, fmt.Errorf(
+				"listeners are configured by editing [[daemon.api.listeners]] in .mcpd.toml",
+			)
 		default:
 			return context.Noop, fmt.Errorf("unknown API config key: %s", key)
 		}
@@ -247,6 +405,31 @@ func (a *APIConfigSection) Set(path string, value string) (context.UpsertResult,
 			a.CORS = &CORSConfigSection{}
 		}
 		return a.CORS.Set(strings.Join(parts[1:], "."), value)
+	case "batch":
+		if a.Batch == nil {
+			a.Batch = &APIBatchConfigSection{}
+		}
+		return a.Batch.Set(strings.Join(parts[1:], "."), value)
+	case "jobs":
+		if a.Jobs == nil {
+			a.Jobs = &APIJobsConfigSection{}
+		}
+		return a.Jobs.Set(strings.Join(parts[1:], "."), value)
+	case "auth":
+		if a.Auth == nil {
+			a.Auth = &APIAuthConfigSection{}
+		}
+		return a.Auth.Set(strings.Join(parts[1:], "."), value)
+	case "tls":
+		if a.TLS == nil {
+			a.TLS = &APITLSConfigSection{}
+		}
+		return a.TLS.Set(strings.Join(parts[1:], "."), value)
+	case "socket":
+		if a.Socket == nil {
+			a.Socket = &APISocketConfigSection{}
+		}
+		return a.Socket.Set(strings.Join(parts[1:], "."), value)
 	default:
 		return context.Noop, fmt.Errorf("unknown API subsection: %s", key)
 	}
@@ -263,14 +446,31 @@ func (a *APIConfigSection) Validate() error {
 
 	// Validate address.
 	if a.Addr != nil {
-		if *a.Addr == "" {
-			validationErrors = append(validationErrors, fmt.Errorf("API address cannot be empty"))
-		} else if !isValidAddr(*a.Addr) {
+		if err := validateListenAddr(*a.Addr); err != nil {
+			validationErrors = append(validationErrors, err)
+		}
+	}
+
+	// Validate route groups and additional listeners.
+	if err := validateRouteGroups(a.Routes); err != nil {
+		validationErrors = append(validationErrors, fmt.Errorf("routes configuration error: %w", err))
+	}
+	addrs := make(map[string]struct{}, len(a.Listeners))
+	if a.Addr != nil {
+		addrs[strings.TrimSpace(*a.Addr)] = struct{}{}
+	}
+	for i, listener := range a.Listeners {
+		if err := listener.Validate(); err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("listener at index %d: %w", i, err))
+		}
+		addr := strings.TrimSpace(listener.Addr)
+		if _, ok := addrs[addr]; ok && addr != "" {
 			validationErrors = append(
 				validationErrors,
-				fmt.Errorf("API address \"%s\" appears to be invalid (expected format: host:port)", *a.Addr),
+				fmt.Errorf("listener at index %d: API address \"%s\" is already in use", i, addr),
 			)
 		}
+		addrs[addr] = struct{}{}
 	}
 
 	// Validate subsections.
@@ -286,6 +486,44 @@ func (a *APIConfigSection) Validate() error {
 		}
 	}
 
+	if a.Batch != nil {
+		if err := a.Batch.Validate(); err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("batch configuration error: %w", err))
+		}
+	}
+
+	if a.Jobs != nil {
+		if err := a.Jobs.Validate(); err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("jobs configuration error: %w", err))
+		}
+	}
+
+	if a.Auth != nil {
+		if err := a.Auth.Validate(); err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("auth configuration error: %w", err))
+		}
+	}
+
+	if a.TLS != nil {
+		if err := a.TLS.Validate(); err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("TLS configuration error: %w", err))
+		}
+	}
+
+	if a.Socket != nil {
+		if err := a.Socket.Validate(); err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("socket configuration error: %w", err))
+		}
+	}
+
+	// Client certificates can only identify callers when clients present them.
+	if a.Auth != nil && len(a.Auth.ClientCerts) > 0 && !a.TLS.MutualTLS() {
+		validationErrors = append(
+			validationErrors,
+			fmt.Errorf("auth configuration error: client_certs require tls.client_ca_file"),
+		)
+	}
+
 	return errors.Join(validationErrors...)
 }
 
@@ -363,6 +601,75 @@ func (a *APITimeoutConfigSection) Validate() error {
 	return nil
 }
 
+// AvailableKeys implements SchemaProvider for APIBatchConfigSection.
+func (a *APIBatchConfigSection) AvailableKeys() []SchemaKey {
+	return []SchemaKey{
+		{Path: "concurrency", Type: "int", Description: "Maximum concurrent tool calls per batch request"},
+	}
+}
+
+// Get implements Getter for APIBatchConfigSection.
+// Returns all batch configuration when called with no keys, or specific values when keys are provided.
+func (a *APIBatchConfigSection) Get(keys ...string) (any, error) {
+	if len(keys) == 0 {
+		return a.getAll()
+	}
+
+	if len(keys) > 1 {
+		return nil, fmt.Errorf("API batch config does not support nested keys")
+	}
+
+	key := normalizeKey(keys[0])
+
+	switch key {
+	case "concurrency":
+		if a.Concurrency == nil {
+			return nil, fmt.Errorf("api.batch.concurrency not set")
+		}
+		return *a.Concurrency, nil
+	default:
+		return nil, fmt.Errorf("API batch %w: %s", ErrInvalidKey, key)
+	}
+}
+
+// Set implements Setter for APIBatchConfigSection.
+// Handles API batch configuration at the leaf level.
+func (a *APIBatchConfigSection) Set(path string, value string) (context.UpsertResult, error) {
+	if strings.TrimSpace(path) == "" {
+		return context.Noop, fmt.Errorf("path cannot be empty")
+	}
+
+	key := normalizeKey(path)
+
+	switch key {
+	case "concurrency":
+		oldValue := a.Concurrency
+		if value == "" {
+			a.Concurrency = nil
+		} else {
+			concurrency, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return context.Noop, fmt.Errorf("%w: %w", NewErrInvalidValue("concurrency", value), err)
+			}
+			a.Concurrency = &concurrency
+		}
+		return determineIntPtrResult(oldValue, a.Concurrency), nil
+	default:
+		return context.Noop, fmt.Errorf("unknown API batch config key: %s", key)
+	}
+}
+
+// Validate implements Validator for APIBatchConfigSection.
+// Validates API batch configuration values.
+func (a *APIBatchConfigSection) Validate() error {
+	if a.Concurrency != nil {
+		if *a.Concurrency <= 0 {
+			return fmt.Errorf("batch concurrency must be positive")
+		}
+	}
+	return nil
+}
+
 // AvailableKeys implements SchemaProvider for CORSConfigSection.
 func (c *CORSConfigSection) AvailableKeys() []SchemaKey {
 	return []SchemaKey{
@@ -609,6 +916,16 @@ func (d *DaemonConfig) AvailableKeys() []SchemaKey {
 		})
 	}
 
+	// Always return audit keys regardless of whether audit section exists
+	auditSection := &AuditConfigSection{}
+	for _, key := range auditSection.AvailableKeys() {
+		keys = append(keys, SchemaKey{
+			Path:        "audit." + key.Path,
+			Type:        key.Type,
+			Description: key.Description,
+		})
+	}
+
 	return keys
 }
 
@@ -633,6 +950,11 @@ func (d *DaemonConfig) Get(keys ...string) (any, error) {
 			return nil, fmt.Errorf("no MCP configuration found")
 		}
 		return d.MCP.Get(keys[1:]...)
+	case "audit":
+		if d.Audit == nil {
+			return nil, fmt.Errorf("no audit configuration found")
+		}
+		return d.Audit.Get(keys[1:]...)
 	default:
 		return nil, fmt.Errorf("unknown daemon config section: %s", section)
 	}
@@ -659,6 +981,11 @@ func (d *DaemonConfig) Set(path string, value string) (context.UpsertResult, err
 			d.MCP = &MCPConfigSection{}
 		}
 		return d.MCP.Set(strings.Join(parts[1:], "."), value)
+	case "audit":
+		if d.Audit == nil {
+			d.Audit = &AuditConfigSection{}
+		}
+		return d.Audit.Set(strings.Join(parts[1:], "."), value)
 	default:
 		return context.Noop, fmt.Errorf("unknown daemon config section: %s", section)
 	}
@@ -686,6 +1013,12 @@ func (d *DaemonConfig) Validate() error {
 		}
 	}
 
+	if d.Audit != nil {
+		if err := d.Audit.Validate(); err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("audit configuration error: %w", err))
+		}
+	}
+
 	return errors.Join(validationErrors...)
 }
 
@@ -1102,6 +1435,14 @@ func (a *APIConfigSection) getAll() (any, error) {
 		result["addr"] = *a.Addr
 	}
 
+	if len(a.Routes) > 0 {
+		result["routes"] = a.Routes
+	}
+
+	if len(a.Listeners) > 0 {
+		result["listeners"] = a.Listeners
+	}
+
 	if a.Timeout != nil {
 		timeoutResult, _ := a.Timeout.Get()
 		if timeoutResult != nil {
@@ -1120,6 +1461,62 @@ func (a *APIConfigSection) getAll() (any, error) {
 		}
 	}
 
+	if a.Batch != nil {
+		batchResult, _ := a.Batch.Get()
+		if batchResult != nil {
+			if batchMap, ok := batchResult.(map[string]any); ok && len(batchMap) > 0 {
+				result["batch"] = batchResult
+			}
+		}
+	}
+
+	if a.Jobs != nil {
+		jobsResult, _ := a.Jobs.Get()
+		if jobsResult != nil {
+			if jobsMap, ok := jobsResult.(map[string]any); ok && len(jobsMap) > 0 {
+				result["jobs"] = jobsResult
+			}
+		}
+	}
+
+	if a.Auth != nil {
+		authResult, _ := a.Auth.Get()
+		if authResult != nil {
+			if authMap, ok := authResult.(map[string]any); ok && len(authMap) > 0 {
+				result["auth"] = authResult
+			}
+		}
+	}
+
+	if a.TLS != nil {
+		tlsResult, _ := a.TLS.Get()
+		if tlsResult != nil {
+			if tlsMap, ok := tlsResult.(map[string]any); ok && len(tlsMap) > 0 {
+				result["tls"] = tlsResult
+			}
+		}
+	}
+
+	if a.Socket != nil {
+		socketResult, _ := a.Socket.Get()
+		if socketResult != nil {
+			if socketMap, ok := socketResult.(map[string]any); ok && len(socketMap) > 0 {
+				result["socket"] = socketResult
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// getAll returns all configured values for the APIBatchConfigSection.
+func (a *APIBatchConfigSection) getAll() (any, error) {
+	result := make(map[string]any)
+
+	if a.Concurrency != nil {
+		result["concurrency"] = *a.Concurrency
+	}
+
 	return result, nil
 }
 
@@ -1185,6 +1582,15 @@ func (d *DaemonConfig) getAll() (any, error) {
 		}
 	}
 
+	if d.Audit != nil {
+		auditResult, _ := d.Audit.Get()
+		if auditResult != nil {
+			if auditMap, ok := auditResult.(map[string]any); ok && len(auditMap) > 0 {
+				result["audit"] = auditResult
+			}
+		}
+	}
+
 	return result, nil
 }
 
@@ -1359,6 +1765,22 @@ func determineDurationPtrResult(old *Duration, new *Duration) context.UpsertResu
 	}
 }
 
+// determineIntPtrResult determines the UpsertResult for int pointer changes.
+func determineIntPtrResult(old *int, new *int) context.UpsertResult {
+	switch {
+	case old == nil && new == nil:
+		return context.Noop
+	case old == nil:
+		return context.Created
+	case new == nil:
+		return context.Deleted
+	case *old != *new:
+		return context.Updated
+	default:
+		return context.Noop
+	}
+}
+
 // determineStringPtrResult determines the UpsertResult for string pointer changes.
 func determineStringPtrResult(old *string, new *string) context.UpsertResult {
 	switch {
@@ -454,6 +454,11 @@ func testBoolPtr(t *testing.T, b bool) *bool {
 	return &b
 }
 
+func testIntPtr(t *testing.T, i int) *int {
+	t.Helper()
+	return &i
+}
+
 func TestDaemonConfig_Set(t *testing.T) {
 	t.Parallel()
 
@@ -1107,6 +1112,132 @@ func TestAPITimeoutConfigSection_Validate(t *testing.T) {
 	}
 }
 
+func TestAPIBatchConfigSection_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		config      *APIBatchConfigSection
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name:        "empty config is valid",
+			config:      &APIBatchConfigSection{},
+			expectError: false,
+		},
+		{
+			name:        "positive concurrency is valid",
+			config:      &APIBatchConfigSection{Concurrency: testIntPtr(t, 4)},
+			expectError: false,
+		},
+		{
+			name:        "zero concurrency is invalid",
+			config:      &APIBatchConfigSection{Concurrency: testIntPtr(t, 0)},
+			expectError: true,
+			errorMsg:    "batch concurrency must be positive",
+		},
+		{
+			name:        "negative concurrency is invalid",
+			config:      &APIBatchConfigSection{Concurrency: testIntPtr(t, -1)},
+			expectError: true,
+			errorMsg:    "batch concurrency must be positive",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.config.Validate()
+
+			if tc.expectError {
+				require.Error(t, err)
+				require.EqualError(t, err, tc.errorMsg)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAPIBatchConfigSection_Set(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		config         *APIBatchConfigSection
+		path           string
+		value          string
+		expectedResult context.UpsertResult
+		expected       *int
+		expectedError  string
+	}{
+		{
+			name:           "set concurrency creates new value",
+			config:         &APIBatchConfigSection{},
+			path:           "concurrency",
+			value:          "8",
+			expectedResult: context.Created,
+			expected:       testIntPtr(t, 8),
+		},
+		{
+			name:           "set concurrency updates existing value",
+			config:         &APIBatchConfigSection{Concurrency: testIntPtr(t, 4)},
+			path:           "concurrency",
+			value:          "8",
+			expectedResult: context.Updated,
+			expected:       testIntPtr(t, 8),
+		},
+		{
+			name:           "set same concurrency is noop",
+			config:         &APIBatchConfigSection{Concurrency: testIntPtr(t, 4)},
+			path:           "concurrency",
+			value:          "4",
+			expectedResult: context.Noop,
+			expected:       testIntPtr(t, 4),
+		},
+		{
+			name:           "empty value deletes concurrency",
+			config:         &APIBatchConfigSection{Concurrency: testIntPtr(t, 4)},
+			path:           "concurrency",
+			value:          "",
+			expectedResult: context.Deleted,
+		},
+		{
+			name:          "invalid concurrency returns error",
+			config:        &APIBatchConfigSection{},
+			path:          "concurrency",
+			value:         "lots",
+			expectedError: "config value invalid: 'concurrency' (value: 'lots')",
+		},
+		{
+			name:          "unknown key returns error",
+			config:        &APIBatchConfigSection{},
+			path:          "size",
+			value:         "1",
+			expectedError: "unknown API batch config key: size",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tc.config.Set(tc.path, tc.value)
+
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedResult, result)
+			require.Equal(t, tc.expected, tc.config.Concurrency)
+		})
+	}
+}
+
 func TestCORSConfigSection_Validate(t *testing.T) {
 	t.Parallel()
 
@@ -1474,7 +1605,34 @@ func TestAPIConfigSection_Validate(t *testing.T) {
 				Addr: testStringPtr(t, "invalid-address"),
 			},
 			expectError: true,
-			errorMsg:    `API address "invalid-address" appears to be invalid (expected format: host:port)`,
+			errorMsg: `API address "invalid-address" appears to be invalid ` +
+				`(expected format: host:port or unix:///path)`,
+		},
+		{
+			name: "unix socket address is valid",
+			config: &APIConfigSection{
+				Addr:   testStringPtr(t, "unix:///run/mcpd/mcpd.sock"),
+				Socket: &APISocketConfigSection{Mode: testStringPtr(t, "0660"), Group: testStringPtr(t, "mcpd")},
+			},
+			expectError: false,
+		},
+		{
+			name: "unix socket address without path is rejected",
+			config: &APIConfigSection{
+				Addr: testStringPtr(t, "unix://"),
+			},
+			expectError: true,
+			errorMsg:    `API address "unix://" is missing the socket path`,
+		},
+		{
+			name: "invalid socket mode is rejected",
+			config: &APIConfigSection{
+				Addr:   testStringPtr(t, "unix:///run/mcpd/mcpd.sock"),
+				Socket: &APISocketConfigSection{Mode: testStringPtr(t, "rw-rw----")},
+			},
+			expectError: true,
+			errorMsg: "socket configuration error: " +
+				"invalid socket mode 'rw-rw----' (expected octal permissions, e.g. 0660)",
 		},
 		{
 			name: "timeout validation error propagates",
@@ -1512,7 +1670,7 @@ func TestAPIConfigSection_Validate(t *testing.T) {
 				},
 			},
 			expectError: true,
-			errorMsg:    "API address \"invalid\" appears to be invalid (expected format: host:port)\ntimeout configuration error: API shutdown timeout must be positive\nCORS configuration error: CORS method INVALID is not a valid HTTP request method",
+			errorMsg:    "API address \"invalid\" appears to be invalid (expected format: host:port or unix:///path)\ntimeout configuration error: API shutdown timeout must be positive\nCORS configuration error: CORS method INVALID is not a valid HTTP request method",
 		},
 	}
 
@@ -1674,7 +1832,7 @@ func TestDaemonConfig_Validate(t *testing.T) {
 				},
 			},
 			expectError: true,
-			errorMsg:    "API configuration error: API address \"invalid\" appears to be invalid (expected format: host:port)",
+			errorMsg:    "API configuration error: API address \"invalid\" appears to be invalid (expected format: host:port or unix:///path)",
 		},
 		{
 			name: "MCP validation error propagates",
@@ -1701,7 +1859,7 @@ func TestDaemonConfig_Validate(t *testing.T) {
 				},
 			},
 			expectError: true,
-			errorMsg:    "API configuration error: API address \"invalid\" appears to be invalid (expected format: host:port)\nMCP configuration error: timeout configuration error: MCP shutdown timeout must be positive",
+			errorMsg:    "API configuration error: API address \"invalid\" appears to be invalid (expected format: host:port or unix:///path)\nMCP configuration error: timeout configuration error: MCP shutdown timeout must be positive",
 		},
 	}
 
@@ -2478,6 +2636,65 @@ func TestAPITimeoutConfigSection_Get(t *testing.T) {
 	}
 }
 
+func TestAPIBatchConfigSection_Get(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		config         *APIBatchConfigSection
+		keys           []string
+		expectedResult any
+		expectedError  string
+	}{
+		{
+			name:           "get all config with concurrency",
+			config:         &APIBatchConfigSection{Concurrency: testIntPtr(t, 4)},
+			keys:           []string{},
+			expectedResult: map[string]any{"concurrency": 4},
+		},
+		{
+			name:           "get concurrency",
+			config:         &APIBatchConfigSection{Concurrency: testIntPtr(t, 4)},
+			keys:           []string{"concurrency"},
+			expectedResult: 4,
+		},
+		{
+			name:          "get missing concurrency returns error",
+			config:        &APIBatchConfigSection{},
+			keys:          []string{"concurrency"},
+			expectedError: "api.batch.concurrency not set",
+		},
+		{
+			name:          "unknown key returns error",
+			config:        &APIBatchConfigSection{},
+			keys:          []string{"invalid"},
+			expectedError: "API batch config key invalid: invalid",
+		},
+		{
+			name:          "nested keys are not supported",
+			config:        &APIBatchConfigSection{Concurrency: testIntPtr(t, 4)},
+			keys:          []string{"concurrency", "sub"},
+			expectedError: "API batch config does not support nested keys",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tc.config.Get(tc.keys...)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				require.EqualError(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedResult, result)
+			}
+		})
+	}
+}
+
 func TestMCPTimeoutConfigSection_Get(t *testing.T) {
 	t.Parallel()
 
@@ -2728,6 +2945,10 @@ func TestAPIConfigSection_AvailableKeys(t *testing.T) {
 		"cors.expose_headers",
 		"cors.allow_credentials",
 		"cors.max_age",
+		"batch.concurrency",
+		"jobs.timeout",
+		"jobs.max_finished",
+		"jobs.retention",
 	}
 
 	// Extract key paths for comparison
@@ -0,0 +1,19 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// serverLogLevels are the log levels defined by MCP logging, ordered from least to most severe.
+var serverLogLevels = []string{"debug", "info", "notice", "warning", "error", "critical", "alert", "emergency"}
+
+// validateLogLevel ensures the server's log level is one of the levels defined by MCP logging.
+func (s *ServerEntry) validateLogLevel() error {
+	if s.LogLevel == "" || slices.Contains(serverLogLevels, s.LogLevel) {
+		return nil
+	}
+
+	return fmt.Errorf("log level '%s' must be one of: %s", s.LogLevel, strings.Join(serverLogLevels, ", "))
+}
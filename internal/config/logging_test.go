@@ -0,0 +1,45 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerEntry_ValidateLogLevel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		level   string
+		wantErr string
+	}{
+		{name: "not set"},
+		{name: "debug", level: "debug"},
+		{name: "emergency", level: "emergency"},
+		{
+			name:    "hclog level",
+			level:   "trace",
+			wantErr: "log level 'trace' must be one of: debug, info, notice, warning, error, critical, alert, emergency",
+		},
+		{
+			name:    "wrong case",
+			level:   "INFO",
+			wantErr: "log level 'INFO' must be one of",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			entry := ServerEntry{Name: "time", LogLevel: tc.level}
+			err := entry.validateLogLevel()
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// rootURIScheme is the only URI scheme the MCP specification currently allows for roots.
+const rootURIScheme = "file"
+
+// RootPath returns the filesystem path of a configured root, which may be an absolute path or a 'file://' URI.
+// Returns false when the root is neither.
+func RootPath(root string) (string, bool) {
+	root = strings.TrimSpace(root)
+	if strings.HasPrefix(root, rootURIScheme+"://") {
+		u, err := url.Parse(root)
+		if err != nil || (u.Host != "" && u.Host != "localhost") || !filepath.IsAbs(u.Path) {
+			return "", false
+		}
+		return filepath.Clean(u.Path), true
+	}
+
+	if !filepath.IsAbs(root) {
+		return "", false
+	}
+
+	return filepath.Clean(root), true
+}
+
+// validateRoots ensures the server's roots are absolute paths or 'file://' URIs.
+func (s *ServerEntry) validateRoots() error {
+	var errs error
+	for _, root := range s.Roots {
+		if _, ok := RootPath(root); !ok {
+			errs = errors.Join(errs, fmt.Errorf("root '%s' must be an absolute path or a file:// URI", root))
+		}
+	}
+
+	return errs
+}
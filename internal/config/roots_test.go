@@ -0,0 +1,48 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRootPath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		root     string
+		expected string
+		valid    bool
+	}{
+		{name: "absolute path", root: "/Users/foo/repos/", expected: "/Users/foo/repos", valid: true},
+		{name: "file URI", root: "file:///Users/foo/repos", expected: "/Users/foo/repos", valid: true},
+		{name: "file URI with localhost", root: "file://localhost/tmp", expected: "/tmp", valid: true},
+		{name: "relative path", root: "repos"},
+		{name: "file URI with remote host", root: "file://server/share"},
+		{name: "other scheme", root: "https://example.com/repos"},
+		{name: "empty", root: " "},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			path, ok := RootPath(tc.root)
+			require.Equal(t, tc.valid, ok)
+			require.Equal(t, tc.expected, path)
+		})
+	}
+}
+
+func TestServerEntry_ValidateRoots(t *testing.T) {
+	t.Parallel()
+
+	entry := ServerEntry{Name: "filesystem", Roots: []string{"/workspace", "file:///data"}}
+	require.NoError(t, entry.validateRoots())
+
+	entry.Roots = append(entry.Roots, "workspace", "s3://bucket")
+	err := entry.validateRoots()
+	require.ErrorContains(t, err, "root 'workspace' must be an absolute path or a file:// URI")
+	require.ErrorContains(t, err, "root 's3://bucket' must be an absolute path or a file:// URI")
+}
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// SamplingConfig configures how sampling requests (sampling/createMessage) from MCP servers are fulfilled.
+// Requests are forwarded to an OpenAI-compatible chat completions endpoint.
+// Only servers which enable sampling (see ServerSampling) may make sampling requests.
+//
+// NOTE: if you add/remove fields you must review Validate, and /docs/configuration.md.
+type SamplingConfig struct {
+	// Endpoint is the base URL of the OpenAI-compatible API, requests are made to '{endpoint}/chat/completions'.
+	// e.g. 'http://localhost:8080/v1'
+	Endpoint string `json:"endpoint" toml:"endpoint" yaml:"endpoint"`
+
+	// APIKeyEnv is the name of the environment variable which holds the API key for the endpoint, if one is required.
+	// e.g. 'OPENAI_API_KEY'
+	APIKeyEnv string `json:"apiKeyEnv,omitempty" toml:"api_key_env,omitempty" yaml:"api_key_env,omitempty"`
+
+	// Model is the default model used for sampling requests.
+	Model string `json:"model" toml:"model" yaml:"model"`
+
+	// Models maps the model hints provided by servers to the models available on the endpoint.
+	// Hints which aren't mapped are ignored.
+	// e.g. 'claude-3-sonnet' = 'qwen2.5-7b-instruct'
+	Models map[string]string `json:"models,omitempty" toml:"models,omitempty" yaml:"models,omitempty"`
+
+	// MaxTokens limits the number of tokens which can be generated for a single sampling request.
+	MaxTokens *int `json:"maxTokens,omitempty" toml:"max_tokens,omitempty" yaml:"max_tokens,omitempty"`
+
+	// Timeout is the time allowed for the endpoint to respond to a sampling request.
+	// e.g. '2m'
+	Timeout *Duration `json:"timeout,omitempty" toml:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
+// ServerSampling configures whether a server may make sampling requests, and the limits applied to them.
+type ServerSampling struct {
+	// Enabled allows the server to make sampling requests.
+	Enabled bool `json:"enabled" toml:"enabled" yaml:"enabled"`
+
+	// Model is the model used for all sampling requests made by the server, ignoring the server's model hints.
+	Model string `json:"model,omitempty" toml:"model,omitempty" yaml:"model,omitempty"`
+
+	// MaxTokens limits the number of tokens which can be generated for a single sampling request made by the server.
+	// It overrides the global limit.
+	MaxTokens *int `json:"maxTokens,omitempty" toml:"max_tokens,omitempty" yaml:"max_tokens,omitempty"`
+}
+
+// Validate implements Validator for SamplingConfig.
+func (s *SamplingConfig) Validate() error {
+	var errs error
+
+	endpoint := strings.TrimSpace(s.Endpoint)
+	if endpoint == "" {
+		errs = errors.Join(errs, fmt.Errorf("endpoint is required"))
+	} else if u, err := url.Parse(endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = errors.Join(errs, fmt.Errorf("endpoint '%s' must be an http(s) URL", s.Endpoint))
+	}
+
+	if strings.TrimSpace(s.Model) == "" {
+		errs = errors.Join(errs, fmt.Errorf("model is required"))
+	}
+
+	for _, hint := range slices.Sorted(maps.Keys(s.Models)) {
+		if strings.TrimSpace(hint) == "" {
+			errs = errors.Join(errs, fmt.Errorf("model mapping has empty hint"))
+		}
+		if strings.TrimSpace(s.Models[hint]) == "" {
+			errs = errors.Join(errs, fmt.Errorf("model mapping for hint '%s' has empty model", hint))
+		}
+	}
+
+	if s.MaxTokens != nil && *s.MaxTokens <= 0 {
+		errs = errors.Join(errs, fmt.Errorf("max tokens must be positive, got %d", *s.MaxTokens))
+	}
+
+	if s.Timeout != nil && *s.Timeout <= 0 {
+		errs = errors.Join(errs, fmt.Errorf("timeout must be positive, got %s", s.Timeout.String()))
+	}
+
+	return errs
+}
+
+// SamplingEnabled returns true when the server is allowed to make sampling requests.
+func (s *ServerEntry) SamplingEnabled() bool {
+	return s.Sampling != nil && s.Sampling.Enabled
+}
+
+// validateSampling ensures the server's sampling limits are valid.
+func (s *ServerEntry) validateSampling() error {
+	if s.Sampling == nil {
+		return nil
+	}
+
+	if s.Sampling.MaxTokens != nil && *s.Sampling.MaxTokens <= 0 {
+		return fmt.Errorf("max tokens must be positive, got %d", *s.Sampling.MaxTokens)
+	}
+
+	return nil
+}
+
+// validateSampling ensures that sampling is configured when any server enables it.
+func (c *Config) validateSampling() error {
+	if c.Sampling != nil {
+		return c.Sampling.Validate()
+	}
+
+	var errs error
+	for _, entry := range c.Servers {
+		if entry.SamplingEnabled() {
+			err := fmt.Errorf("server '%s' enables sampling, but [sampling] is not configured", entry.Name)
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSamplingConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		cfg      SamplingConfig
+		expected []string
+	}{
+		{
+			name: "valid",
+			cfg: SamplingConfig{
+				Endpoint:  "http://localhost:8080/v1",
+				Model:     "qwen2.5-7b-instruct",
+				Models:    map[string]string{"claude-3-sonnet": "qwen2.5-7b-instruct"},
+				MaxTokens: testIntPtr(t, 1024),
+				Timeout:   testDurationPtr(t, time.Minute),
+			},
+		},
+		{
+			name:     "missing endpoint and model",
+			cfg:      SamplingConfig{},
+			expected: []string{"endpoint is required", "model is required"},
+		},
+		{
+			name:     "endpoint without scheme",
+			cfg:      SamplingConfig{Endpoint: "localhost:8080", Model: "m"},
+			expected: []string{"endpoint 'localhost:8080' must be an http(s) URL"},
+		},
+		{
+			name: "invalid model mapping",
+			cfg: SamplingConfig{
+				Endpoint: "https://api.example.com/v1",
+				Model:    "m",
+				Models:   map[string]string{"claude": " ", "": "m"},
+			},
+			expected: []string{"model mapping has empty hint", "model mapping for hint 'claude' has empty model"},
+		},
+		{
+			name: "invalid limits",
+			cfg: SamplingConfig{
+				Endpoint:  "https://api.example.com/v1",
+				Model:     "m",
+				MaxTokens: testIntPtr(t, 0),
+				Timeout:   testDurationPtr(t, -time.Second),
+			},
+			expected: []string{"max tokens must be positive, got 0", "timeout must be positive, got -1s"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.cfg.Validate()
+			if len(tc.expected) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			for _, msg := range tc.expected {
+				require.ErrorContains(t, err, msg)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateSampling(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Servers: []ServerEntry{
+			{Name: "summarizer", Sampling: &ServerSampling{Enabled: true}},
+			{Name: "github", Sampling: &ServerSampling{Enabled: false}},
+		},
+	}
+	require.EqualError(
+		t,
+		cfg.validateSampling(),
+		"server 'summarizer' enables sampling, but [sampling] is not configured",
+	)
+
+	cfg.Sampling = &SamplingConfig{Endpoint: "http://localhost:8080/v1", Model: "m"}
+	require.NoError(t, cfg.validateSampling())
+}
+
+func TestServerEntry_ValidateSampling(t *testing.T) {
+	t.Parallel()
+
+	entry := &ServerEntry{Name: "test"}
+	require.NoError(t, entry.validateSampling())
+	require.False(t, entry.SamplingEnabled())
+
+	entry.Sampling = &ServerSampling{Enabled: true, MaxTokens: testIntPtr(t, 512)}
+	require.NoError(t, entry.validateSampling())
+	require.True(t, entry.SamplingEnabled())
+
+	entry.Sampling.MaxTokens = testIntPtr(t, -1)
+	require.EqualError(t, entry.validateSampling(), "max tokens must be positive, got -1")
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"slices"
+
+	"github.com/mozilla-ai/mcpd/internal/filter"
+)
+
+// ToolPolicyProvider is implemented by configuration which defines a global tool policy, applied to all servers.
+type ToolPolicyProvider interface {
+	// GlobalToolPolicy returns the tool policy applied to all servers, or nil when none is configured.
+	GlobalToolPolicy() *ToolPolicy
+}
+
+// ToolPolicy restricts the tools of a server based on the hints (annotations) the server provides for them.
+// Unset fields are inherited from the global tool policy.
+//
+// NOTE: if you add/remove fields you must review Merge, Allows, and /docs/configuration.md.
+type ToolPolicy struct {
+	// ReadOnly only allows tools which are annotated as read-only (readOnlyHint).
+	ReadOnly *bool `json:"readOnly,omitempty" toml:"read_only,omitempty" yaml:"read_only,omitempty"`
+
+	// BlockDestructive blocks tools which may be destructive (destructiveHint),
+	// unless they are named explicitly in the server's tools allowlist.
+	BlockDestructive *bool `json:"blockDestructive,omitempty" toml:"block_destructive,omitempty" yaml:"block_destructive,omitempty"`
+
+	// BlockOpenWorld blocks tools which may interact with external entities (openWorldHint),
+	// unless they are named explicitly in the server's tools allowlist.
+	BlockOpenWorld *bool `json:"blockOpenWorld,omitempty" toml:"block_open_world,omitempty" yaml:"block_open_world,omitempty"`
+}
+
+// ToolHints are the hints a server provides about the behavior of a tool (i.e. its annotations).
+// Unset hints take the defaults defined by the MCP specification:
+// tools are not read-only, are destructive, and interact with an open world.
+type ToolHints struct {
+	ReadOnly    *bool
+	Destructive *bool
+	OpenWorld   *bool
+}
+
+// GlobalToolPolicy returns the tool policy applied to all servers, or nil when none is configured.
+func (c *Config) GlobalToolPolicy() *ToolPolicy {
+	return c.ToolPolicy
+}
+
+// Merge returns a new policy with the fields set in the override taking precedence over the fields of this policy.
+// Returns nil when neither policy is set.
+func (p *ToolPolicy) Merge(override *ToolPolicy) *ToolPolicy {
+	if p == nil && override == nil {
+		return nil
+	}
+
+	var merged ToolPolicy
+	if p != nil {
+		merged = *p
+	}
+
+	if override != nil {
+		if override.ReadOnly != nil {
+			merged.ReadOnly = override.ReadOnly
+		}
+		if override.BlockDestructive != nil {
+			merged.BlockDestructive = override.BlockDestructive
+		}
+		if override.BlockOpenWorld != nil {
+			merged.BlockOpenWorld = override.BlockOpenWorld
+		}
+	}
+
+	return &merged
+}
+
+// Equals compares two policies for equality, where an unset field is equal to false.
+func (p *ToolPolicy) Equals(other *ToolPolicy) bool {
+	return p.readOnly() == other.readOnly() &&
+		p.blockDestructive() == other.blockDestructive() &&
+		p.blockOpenWorld() == other.blockOpenWorld()
+}
+
+// Allows returns true when a tool with the given hints is allowed by the policy.
+// Tools which are explicitly allowlisted are exempt from the destructive and open world blocks, but not from ReadOnly.
+func (p *ToolPolicy) Allows(hints ToolHints, explicit bool) bool {
+	if p.readOnly() && !hints.isReadOnly() {
+		return false
+	}
+
+	if explicit {
+		return true
+	}
+
+	if p.blockDestructive() && hints.isDestructive() {
+		return false
+	}
+
+	if p.blockOpenWorld() && hints.isOpenWorld() {
+		return false
+	}
+
+	return true
+}
+
+// ToolPermitted returns true when the tool is permitted by the server's tool policy.
+// Tools are explicitly allowlisted when they are named exactly (not by pattern) in the server's tools allowlist.
+// When the tool's hints are unknown (nil), the defaults defined by the MCP specification are assumed.
+func (s *ServerEntry) ToolPermitted(tool string, hints *ToolHints) bool {
+	if s.ToolPolicy == nil {
+		return true
+	}
+
+	tool = filter.NormalizeString(tool)
+	explicit := slices.ContainsFunc(s.Tools, func(t string) bool {
+		return !IsToolPattern(t) && filter.NormalizeString(t) == tool
+	})
+
+	if hints == nil {
+		hints = &ToolHints{}
+	}
+
+	return s.ToolPolicy.Allows(*hints, explicit)
+}
+
+func (p *ToolPolicy) readOnly() bool {
+	return p != nil && p.ReadOnly != nil && *p.ReadOnly
+}
+
+func (p *ToolPolicy) blockDestructive() bool {
+	return p != nil && p.BlockDestructive != nil && *p.BlockDestructive
+}
+
+func (p *ToolPolicy) blockOpenWorld() bool {
+	return p != nil && p.BlockOpenWorld != nil && *p.BlockOpenWorld
+}
+
+// isReadOnly returns true when the tool is hinted to be read-only.
+func (h ToolHints) isReadOnly() bool {
+	return h.ReadOnly != nil && *h.ReadOnly
+}
+
+// isDestructive returns true unless the tool is hinted to be read-only or not destructive.
+func (h ToolHints) isDestructive() bool {
+	return !h.isReadOnly() && (h.Destructive == nil || *h.Destructive)
+}
+
+// isOpenWorld returns true unless the tool is hinted to interact with a closed world.
+func (h ToolHints) isOpenWorld() bool {
+	return h.OpenWorld == nil || *h.OpenWorld
+}
@@ -0,0 +1,139 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolPolicy_Merge(t *testing.T) {
+	t.Parallel()
+
+	var global *ToolPolicy
+	require.Nil(t, global.Merge(nil))
+
+	global = &ToolPolicy{ReadOnly: testBoolPtr(t, true), BlockOpenWorld: testBoolPtr(t, true)}
+	server := &ToolPolicy{ReadOnly: testBoolPtr(t, false), BlockDestructive: testBoolPtr(t, true)}
+
+	require.Equal(t, &ToolPolicy{
+		ReadOnly:         testBoolPtr(t, false),
+		BlockDestructive: testBoolPtr(t, true),
+		BlockOpenWorld:   testBoolPtr(t, true),
+	}, global.Merge(server))
+	require.Equal(t, server, (*ToolPolicy)(nil).Merge(server))
+	require.Equal(t, global, global.Merge(nil))
+
+	// Merging doesn't modify the global policy.
+	require.True(t, *global.ReadOnly)
+}
+
+func TestToolPolicy_Equals(t *testing.T) {
+	t.Parallel()
+
+	var unset *ToolPolicy
+	require.True(t, unset.Equals(&ToolPolicy{ReadOnly: testBoolPtr(t, false)}))
+	require.True(t, (&ToolPolicy{ReadOnly: testBoolPtr(t, true)}).Equals(&ToolPolicy{ReadOnly: testBoolPtr(t, true)}))
+	require.False(t, unset.Equals(&ToolPolicy{BlockOpenWorld: testBoolPtr(t, true)}))
+}
+
+func TestServerEntry_ToolPermitted(t *testing.T) {
+	t.Parallel()
+
+	readOnly := &ToolHints{ReadOnly: testBoolPtr(t, true)}
+	destructive := &ToolHints{Destructive: testBoolPtr(t, true), OpenWorld: testBoolPtr(t, false)}
+	additive := &ToolHints{Destructive: testBoolPtr(t, false), OpenWorld: testBoolPtr(t, false)}
+	openWorld := &ToolHints{Destructive: testBoolPtr(t, false), OpenWorld: testBoolPtr(t, true)}
+
+	onlyReadOnly := &ToolPolicy{ReadOnly: testBoolPtr(t, true)}
+	blockDestructive := &ToolPolicy{BlockDestructive: testBoolPtr(t, true)}
+	blockOpenWorld := &ToolPolicy{BlockOpenWorld: testBoolPtr(t, true)}
+
+	tests := []struct {
+		name     string
+		policy   *ToolPolicy
+		tool     string
+		hints    *ToolHints
+		expected bool
+	}{
+		{name: "no policy", tool: "delete_repo", hints: destructive, expected: true},
+		{name: "read only allows read only", policy: onlyReadOnly, tool: "list_repos", hints: readOnly, expected: true},
+		{name: "read only blocks additive", policy: onlyReadOnly, tool: "create_repo", hints: additive},
+		{name: "read only blocks explicit", policy: onlyReadOnly, tool: "create_issue", hints: additive},
+		{name: "read only blocks unknown", policy: onlyReadOnly, tool: "list_repos"},
+		{
+			name:     "read only disabled",
+			policy:   &ToolPolicy{ReadOnly: testBoolPtr(t, false)},
+			tool:     "delete_repo",
+			hints:    destructive,
+			expected: true,
+		},
+		{name: "block destructive", policy: blockDestructive, tool: "delete_repo", hints: destructive},
+		{
+			name:     "block destructive allows additive",
+			policy:   blockDestructive,
+			tool:     "create_repo",
+			hints:    additive,
+			expected: true,
+		},
+		{
+			name:     "block destructive allows read only",
+			policy:   blockDestructive,
+			tool:     "list_repos",
+			hints:    readOnly,
+			expected: true,
+		},
+		{name: "block destructive unannotated", policy: blockDestructive, tool: "list_repos", hints: &ToolHints{}},
+		{
+			name:     "block destructive explicit",
+			policy:   blockDestructive,
+			tool:     "Create_Issue",
+			hints:    destructive,
+			expected: true,
+		},
+		{name: "block destructive explicit unknown", policy: blockDestructive, tool: "create_issue", expected: true},
+		{name: "block open world", policy: blockOpenWorld, tool: "fetch", hints: openWorld},
+		{
+			name:     "block open world allows closed",
+			policy:   blockOpenWorld,
+			tool:     "create_repo",
+			hints:    additive,
+			expected: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			entry := &ServerEntry{Name: "github", Tools: []string{"*", "create_issue"}, ToolPolicy: tc.policy}
+			require.Equal(t, tc.expected, entry.ToolPermitted(tc.tool, tc.hints))
+		})
+	}
+}
+
+func TestToolPolicy_TOML(t *testing.T) {
+	t.Parallel()
+
+	data := `
+[tool_policy]
+block_destructive = true
+
+[[servers]]
+name = "github"
+package = "uvx::github-server@1.0.0"
+tools = ["*"]
+
+[servers.tool_policy]
+read_only = true
+`
+
+	var cfg Config
+	_, err := toml.Decode(data, &cfg)
+	require.NoError(t, err)
+	require.NoError(t, cfg.validate())
+
+	require.Equal(t, &ToolPolicy{BlockDestructive: testBoolPtr(t, true)}, cfg.GlobalToolPolicy())
+	require.Len(t, cfg.Servers, 1)
+	require.Equal(t, &ToolPolicy{ReadOnly: testBoolPtr(t, true)}, cfg.Servers[0].ToolPolicy)
+}
@@ -1,10 +1,15 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"maps"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/mozilla-ai/mcpd/internal/context"
+	"github.com/mozilla-ai/mcpd/internal/filter"
 )
 
 var (
@@ -81,10 +86,13 @@ type DefaultLoader struct{}
 
 // Config represents the .mcpd.toml file structure.
 type Config struct {
-	Servers        []ServerEntry `toml:"servers"`
-	Daemon         *DaemonConfig `toml:"daemon,omitempty"`
-	Plugins        *PluginConfig `toml:"plugins,omitempty"`
-	configFilePath string        `toml:"-"`
+	Servers        []ServerEntry   `toml:"servers"`
+	Daemon         *DaemonConfig   `toml:"daemon,omitempty"`
+	Plugins        *PluginConfig   `toml:"plugins,omitempty"`
+	Workflows      []WorkflowEntry `toml:"workflows,omitempty"`
+	ToolPolicy     *ToolPolicy     `toml:"tool_policy,omitempty"`
+	Sampling       *SamplingConfig `toml:"sampling,omitempty"`
+	configFilePath string          `toml:"-"`
 }
 
 // ServerEntry represents the configuration of a single versioned MCP Server and tools.
@@ -98,9 +106,19 @@ type ServerEntry struct {
 	Package string `json:"package" toml:"package" yaml:"package"`
 
 	// Tools lists the names of the tools which should be allowed on this server.
-	// e.g. 'create_repository'
+	// Names can be glob patterns, which are resolved against the tools offered by the server.
+	// e.g. 'create_repository', 'list_*', '*'
 	Tools []string `json:"tools" toml:"tools" yaml:"tools"`
 
+	// ToolsDeny lists the names (or glob patterns) of the tools which should never be allowed on this server.
+	// It takes precedence over Tools.
+	// e.g. 'delete_*'
+	ToolsDeny []string `json:"toolsDeny,omitempty" toml:"tools_deny,omitempty" yaml:"tools_deny,omitempty"`
+
+	// ToolPolicy restricts the allowed tools based on their annotations (e.g. only read-only tools).
+	// It takes precedence over the global tool policy.
+	ToolPolicy *ToolPolicy `json:"toolPolicy,omitempty" toml:"tool_policy,omitempty" yaml:"tool_policy,omitempty"`
+
 	// RequiredEnvVars captures any environment variables required to run the server.
 	RequiredEnvVars []string `json:"requiredEnv,omitempty" toml:"required_env,omitempty" yaml:"required_env,omitempty"`
 
@@ -116,6 +134,76 @@ type ServerEntry struct {
 
 	// Volumes maps volume names to their Docker volume configuration.
 	Volumes VolumesEntry `json:"volumes,omitempty" toml:"volumes,omitempty" yaml:"volumes,omitempty"`
+
+	// Timeout is the default timeout for tool calls on this server.
+	// It overrides the daemon's tool call timeout, and is overridden by ToolTimeouts.
+	// e.g. '30s'
+	Timeout *Duration `json:"timeout,omitempty" toml:"timeout,omitempty" yaml:"timeout,omitempty"`
+
+	// ToolTimeouts maps tool names to the timeout for calls to that tool.
+	// e.g. 'build' = '3m'
+	ToolTimeouts map[string]*Duration `json:"toolTimeouts,omitempty" toml:"tool_timeouts,omitempty" yaml:"tool_timeouts,omitempty"`
+
+	// Tags are keywords used to group servers, e.g. when filtering tools across servers.
+	// e.g. 'productivity'
+	Tags []string `json:"tags,omitempty" toml:"tags,omitempty" yaml:"tags,omitempty"`
+
+	// VirtualTools define additional tools which are exposed by calling an upstream tool on this server.
+	VirtualTools []VirtualToolEntry `json:"virtualTools,omitempty" toml:"virtual_tools,omitempty" yaml:"virtual_tools,omitempty"`
+
+	// Prompts lists the names of the prompts which should be allowed on this server.
+	// When empty, all prompts offered by the server are allowed.
+	// e.g. 'code_review'
+	Prompts []string `json:"prompts,omitempty" toml:"prompts,omitempty" yaml:"prompts,omitempty"`
+
+	// Resources lists the URI patterns of the resources which should be allowed on this server.
+	// Patterns are matched using path.Match, and a pattern ending in '**' matches any URI with that prefix.
+	// When empty, all resources offered by the server are allowed.
+	// e.g. 'file:///workspace/**'
+	Resources []string `json:"resources,omitempty" toml:"resources,omitempty" yaml:"resources,omitempty"`
+
+	// Approval marks the tools on this server which require approval before calls to them are made.
+	Approval *ApprovalConfig `json:"approval,omitempty" toml:"approval,omitempty" yaml:"approval,omitempty"`
+
+	// Sampling allows this server to request LLM completions (sampling/createMessage) through mcpd.
+	Sampling *ServerSampling `json:"sampling,omitempty" toml:"sampling,omitempty" yaml:"sampling,omitempty"`
+
+	// Roots lists the directories this server may operate on, as absolute paths or 'file://' URIs,
+	// which are offered to the server in response to roots/list requests.
+	// When empty, docker servers are offered the host paths of their volumes, other servers are offered no roots.
+	// e.g. '/Users/foo/repos/mcpd'
+	Roots []string `json:"roots,omitempty" toml:"roots,omitempty" yaml:"roots,omitempty"`
+
+	// LogLevel is the minimum level of the log messages this server sends, and mcpd logs for it.
+	// It is requested from the server (logging/setLevel) after it is initialized.
+	// e.g. 'debug', 'info', 'notice', 'warning', 'error', 'critical', 'alert', 'emergency'
+	LogLevel string `json:"logLevel,omitempty" toml:"log_level,omitempty" yaml:"log_level,omitempty"`
+}
+
+// VirtualToolEntry represents a tool that is exposed under its own name, and which calls an upstream tool.
+// It can be used to present a constrained version of the upstream tool, or a clearer description of it.
+type VirtualToolEntry struct {
+	// Name is the name the tool is exposed as.
+	// e.g. 'search_org_issues'
+	Name string `json:"name" toml:"name" yaml:"name"`
+
+	// Tool is the name of the upstream tool which is called.
+	// e.g. 'search_issues'
+	Tool string `json:"tool" toml:"tool" yaml:"tool"`
+
+	// Title overrides the title of the upstream tool.
+	Title string `json:"title,omitempty" toml:"title,omitempty" yaml:"title,omitempty"`
+
+	// Description overrides the description of the upstream tool.
+	Description string `json:"description,omitempty" toml:"description,omitempty" yaml:"description,omitempty"`
+
+	// PinnedArgs are arguments that are always sent to the upstream tool, and which cannot be supplied by callers.
+	// e.g. 'owner' = 'mozilla-ai'
+	PinnedArgs map[string]any `json:"pinnedArgs,omitempty" toml:"pinned_args,omitempty" yaml:"pinned_args,omitempty"`
+
+	// DefaultArgs are arguments that are sent to the upstream tool unless they are supplied by callers.
+	// e.g. 'per_page' = 10
+	DefaultArgs map[string]any `json:"defaultArgs,omitempty" toml:"default_args,omitempty" yaml:"default_args,omitempty"`
 }
 
 // VolumeEntry represents a single Docker volume configuration.
@@ -186,7 +274,9 @@ func (s *ServerEntry) RequiredArguments() []string {
 }
 
 // Equals compares two ServerEntry instances for equality.
-// Returns true if all fields are equal.
+// Returns true if all fields that require the server to be (re)started are equal.
+// Timeouts, tags, virtual tools, approvals, sampling, roots, log level, and the prompt and resource allowlists
+// are excluded, as they are applied without restarting the server.
 // RequiredPositionalArgs order matters (positional), all other slices are order-independent.
 func (s *ServerEntry) Equals(other *ServerEntry) bool {
 	if other == nil {
@@ -213,6 +303,14 @@ func (s *ServerEntry) Equals(other *ServerEntry) bool {
 		return false
 	}
 
+	if !equalStringSlicesUnordered(s.ToolsDeny, other.ToolsDeny) {
+		return false
+	}
+
+	if !s.ToolPolicy.Equals(other.ToolPolicy) {
+		return false
+	}
+
 	if !equalStringSlicesUnordered(s.RequiredEnvVars, other.RequiredEnvVars) {
 		return false
 	}
@@ -228,7 +326,101 @@ func (s *ServerEntry) Equals(other *ServerEntry) bool {
 	return true
 }
 
-// EqualExceptTools compares this server with another and returns true if only the Tools field differs.
+// ToolCallTimeout returns the most specific timeout configured for calls to the given tool on this server.
+// A tool specific timeout takes precedence over the server's default timeout.
+// Returns false when no timeout is configured for the tool or server.
+func (s *ServerEntry) ToolCallTimeout(tool string) (time.Duration, bool) {
+	tool = filter.NormalizeString(tool)
+	for name, timeout := range s.ToolTimeouts {
+		if timeout != nil && filter.NormalizeString(name) == tool {
+			return time.Duration(*timeout), true
+		}
+	}
+
+	if s.Timeout != nil {
+		return time.Duration(*s.Timeout), true
+	}
+
+	return 0, false
+}
+
+// VirtualTool returns the virtual tool exposed by this server with the given name.
+func (s *ServerEntry) VirtualTool(name string) (VirtualToolEntry, bool) {
+	name = filter.NormalizeString(name)
+	for _, vt := range s.VirtualTools {
+		if filter.NormalizeString(vt.Name) == name {
+			return vt, true
+		}
+	}
+
+	return VirtualToolEntry{}, false
+}
+
+// HiddenArgs returns the names of the arguments that are supplied by the virtual tool (pinned or default),
+// and which are therefore not published in its input schema.
+func (v VirtualToolEntry) HiddenArgs() []string {
+	args := slices.Collect(maps.Keys(v.PinnedArgs))
+	args = slices.AppendSeq(args, maps.Keys(v.DefaultArgs))
+	slices.Sort(args)
+
+	return slices.Compact(args)
+}
+
+// validateVirtualTools ensures virtual tools are named, reference an upstream tool,
+// and don't clash with each other or with the server's tools.
+func (s *ServerEntry) validateVirtualTools() error {
+	var errs error
+
+	seen := make(map[string]struct{}, len(s.VirtualTools))
+	for i, vt := range s.VirtualTools {
+		name := filter.NormalizeString(vt.Name)
+		if name == "" {
+			errs = errors.Join(errs, fmt.Errorf("virtual tool at index %d has empty name", i))
+			continue
+		}
+		if strings.TrimSpace(vt.Tool) == "" {
+			errs = errors.Join(errs, fmt.Errorf("virtual tool '%s' has empty upstream tool", vt.Name))
+		}
+		if _, ok := seen[name]; ok {
+			errs = errors.Join(errs, fmt.Errorf("duplicate virtual tool '%s'", vt.Name))
+		}
+		seen[name] = struct{}{}
+		if slices.Contains(filter.NormalizeSlice(s.Tools), name) {
+			errs = errors.Join(errs, fmt.Errorf("virtual tool '%s' has the same name as an allowed tool", vt.Name))
+		}
+		for _, arg := range slices.Sorted(maps.Keys(vt.PinnedArgs)) {
+			if _, ok := vt.DefaultArgs[arg]; ok {
+				errs = errors.Join(errs, fmt.Errorf("virtual tool '%s' argument '%s' is both pinned and defaulted", vt.Name, arg))
+			}
+		}
+	}
+
+	return errs
+}
+
+// validateTimeouts ensures any configured timeouts are positive.
+func (s *ServerEntry) validateTimeouts() error {
+	var errs error
+
+	if s.Timeout != nil && *s.Timeout <= 0 {
+		errs = errors.Join(errs, fmt.Errorf("timeout must be positive, got %s", s.Timeout.String()))
+	}
+
+	for _, tool := range slices.Sorted(maps.Keys(s.ToolTimeouts)) {
+		timeout := s.ToolTimeouts[tool]
+		if strings.TrimSpace(tool) == "" {
+			errs = errors.Join(errs, fmt.Errorf("tool timeout has empty tool name"))
+		}
+		if timeout == nil || *timeout <= 0 {
+			errs = errors.Join(errs, fmt.Errorf("timeout for tool '%s' must be positive", tool))
+		}
+	}
+
+	return errs
+}
+
+// EqualExceptTools compares this server with another and returns true if only the Tools (or ToolsDeny, or ToolPolicy)
+// fields differ.
 // All other configuration fields must be identical for this to return true.
 func (s *ServerEntry) EqualExceptTools(other *ServerEntry) bool {
 	if other == nil {
@@ -240,18 +432,21 @@ func (s *ServerEntry) EqualExceptTools(other *ServerEntry) bool {
 	b := other
 
 	// Temporarily set tools to be identical for comparison.
-	bTools := b.Tools
-	b.Tools = a.Tools
+	bTools, bToolsDeny, bToolPolicy := b.Tools, b.ToolsDeny, b.ToolPolicy
+	b.Tools, b.ToolsDeny, b.ToolPolicy = a.Tools, a.ToolsDeny, a.ToolPolicy
 
 	// If everything else is equal, then only tools differ.
 	equalIgnoringTools := a.Equals(b)
 
 	// Restore original tools.
-	b.Tools = bTools
+	b.Tools, b.ToolsDeny, b.ToolPolicy = bTools, bToolsDeny, bToolPolicy
 
 	// Return true only if everything else is equal AND tools actually differ.
 	// NOTE: We are assuming that tools are always already normalized, ready for comparison.
-	return equalIgnoringTools && !equalStringSlicesUnordered(s.Tools, other.Tools)
+	return equalIgnoringTools &&
+		(!equalStringSlicesUnordered(s.Tools, other.Tools) ||
+			!equalStringSlicesUnordered(s.ToolsDeny, other.ToolsDeny) ||
+			!s.ToolPolicy.Equals(other.ToolPolicy))
 }
 
 // equalStringSlicesUnordered compares two string slices for equality, ignoring order.
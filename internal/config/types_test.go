@@ -2,7 +2,9 @@ package config
 
 import (
 	"testing"
+	"time"
 
+	"github.com/BurntSushi/toml"
 	"github.com/stretchr/testify/require"
 )
 
@@ -127,6 +129,26 @@ func TestServerEntry_Equal(t *testing.T) {
 			}(),
 			expected: false,
 		},
+		{
+			name:   "different denied tools",
+			entry1: baseEntry(),
+			entry2: func() *ServerEntry {
+				srv := baseEntry()
+				srv.ToolsDeny = []string{"delete_*"}
+				return srv
+			}(),
+			expected: false,
+		},
+		{
+			name:   "different tool policy",
+			entry1: baseEntry(),
+			entry2: func() *ServerEntry {
+				srv := baseEntry()
+				srv.ToolPolicy = &ToolPolicy{ReadOnly: testBoolPtr(t, true)}
+				return srv
+			}(),
+			expected: false,
+		},
 		{
 			name:   "empty slices vs nil slices are equal",
 			entry1: baseEntry(),
@@ -285,3 +307,190 @@ func TestServerEntry_Runtime(t *testing.T) {
 		})
 	}
 }
+
+func TestServerEntry_ToolCallTimeout(t *testing.T) {
+	t.Parallel()
+
+	minute := Duration(time.Minute)
+	second := Duration(time.Second)
+
+	tests := []struct {
+		name       string
+		entry      ServerEntry
+		tool       string
+		expected   time.Duration
+		expectedOK bool
+	}{
+		{
+			name:  "no timeouts configured",
+			entry: ServerEntry{},
+			tool:  "tool1",
+		},
+		{
+			name:       "server timeout",
+			entry:      ServerEntry{Timeout: &minute},
+			tool:       "tool1",
+			expected:   time.Minute,
+			expectedOK: true,
+		},
+		{
+			name: "tool timeout takes precedence",
+			entry: ServerEntry{
+				Timeout:      &minute,
+				ToolTimeouts: map[string]*Duration{"tool1": &second},
+			},
+			tool:       "TOOL1",
+			expected:   time.Second,
+			expectedOK: true,
+		},
+		{
+			name: "other tool uses server timeout",
+			entry: ServerEntry{
+				Timeout:      &minute,
+				ToolTimeouts: map[string]*Duration{"tool1": &second},
+			},
+			tool:       "tool2",
+			expected:   time.Minute,
+			expectedOK: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			timeout, ok := tc.entry.ToolCallTimeout(tc.tool)
+			require.Equal(t, tc.expectedOK, ok)
+			require.Equal(t, tc.expected, timeout)
+		})
+	}
+}
+
+func TestServerEntry_ValidateTimeouts(t *testing.T) {
+	t.Parallel()
+
+	valid := Duration(time.Minute)
+	zero := Duration(0)
+
+	require.NoError(t, (&ServerEntry{}).validateTimeouts())
+	require.NoError(t, (&ServerEntry{
+		Timeout:      &valid,
+		ToolTimeouts: map[string]*Duration{"tool1": &valid},
+	}).validateTimeouts())
+
+	err := (&ServerEntry{
+		Timeout:      &zero,
+		ToolTimeouts: map[string]*Duration{"tool1": &zero, " ": &valid},
+	}).validateTimeouts()
+	require.Error(t, err)
+	require.ErrorContains(t, err, "timeout must be positive")
+	require.ErrorContains(t, err, "timeout for tool 'tool1' must be positive")
+	require.ErrorContains(t, err, "tool timeout has empty tool name")
+}
+
+func TestServerEntry_TimeoutsTOML(t *testing.T) {
+	t.Parallel()
+
+	data := `
+name = "time"
+package = "uvx::mcp-server-time@2025.8.4"
+tools = ["get_current_time"]
+timeout = "45s"
+
+[tool_timeouts]
+get_current_time = "5s"
+`
+
+	var entry ServerEntry
+	_, err := toml.Decode(data, &entry)
+	require.NoError(t, err)
+
+	timeout, ok := entry.ToolCallTimeout("get_current_time")
+	require.True(t, ok)
+	require.Equal(t, 5*time.Second, timeout)
+
+	timeout, ok = entry.ToolCallTimeout("convert_time")
+	require.True(t, ok)
+	require.Equal(t, 45*time.Second, timeout)
+
+	encoded, err := toml.Marshal(entry)
+	require.NoError(t, err)
+	require.Contains(t, string(encoded), `timeout = "45s"`)
+	require.Contains(t, string(encoded), `get_current_time = "5s"`)
+}
+
+func TestServerEntry_ValidateVirtualTools(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, (&ServerEntry{}).validateVirtualTools())
+	require.NoError(t, (&ServerEntry{
+		Tools: []string{"create_issue"},
+		VirtualTools: []VirtualToolEntry{
+			{
+				Name:        "search_org_issues",
+				Tool:        "search_issues",
+				PinnedArgs:  map[string]any{"owner": "mozilla-ai"},
+				DefaultArgs: map[string]any{"per_page": 10},
+			},
+		},
+	}).validateVirtualTools())
+
+	err := (&ServerEntry{
+		Tools: []string{"create_issue"},
+		VirtualTools: []VirtualToolEntry{
+			{Name: " ", Tool: "search_issues"},
+			{Name: "search", Tool: ""},
+			{Name: "Search", Tool: "search_issues"},
+			{Name: "create_issue", Tool: "create_issue"},
+			{
+				Name:        "search_org_issues",
+				Tool:        "search_issues",
+				PinnedArgs:  map[string]any{"owner": "mozilla-ai"},
+				DefaultArgs: map[string]any{"owner": "mozilla"},
+			},
+		},
+	}).validateVirtualTools()
+	require.Error(t, err)
+	require.ErrorContains(t, err, "virtual tool at index 0 has empty name")
+	require.ErrorContains(t, err, "virtual tool 'search' has empty upstream tool")
+	require.ErrorContains(t, err, "duplicate virtual tool 'Search'")
+	require.ErrorContains(t, err, "virtual tool 'create_issue' has the same name as an allowed tool")
+	require.ErrorContains(t, err, "virtual tool 'search_org_issues' argument 'owner' is both pinned and defaulted")
+}
+
+func TestServerEntry_VirtualToolsTOML(t *testing.T) {
+	t.Parallel()
+
+	data := `
+name = "github"
+package = "uvx::github-mcp@1.0.0"
+tools = ["create_issue"]
+
+[[virtual_tools]]
+name = "search_org_issues"
+tool = "search_issues"
+description = "Search issues in the organization's repositories"
+pinned_args = { owner = "mozilla-ai" }
+default_args = { per_page = 10 }
+`
+
+	var entry ServerEntry
+	_, err := toml.Decode(data, &entry)
+	require.NoError(t, err)
+	require.NoError(t, entry.validateVirtualTools())
+
+	vt, ok := entry.VirtualTool("Search_Org_Issues")
+	require.True(t, ok)
+	require.Equal(t, "search_issues", vt.Tool)
+	require.Equal(t, map[string]any{"owner": "mozilla-ai"}, vt.PinnedArgs)
+	require.Equal(t, map[string]any{"per_page": int64(10)}, vt.DefaultArgs)
+	require.Equal(t, []string{"owner", "per_page"}, vt.HiddenArgs())
+
+	_, ok = entry.VirtualTool("search_issues")
+	require.False(t, ok)
+
+	encoded, err := toml.Marshal(entry)
+	require.NoError(t, err)
+	require.Contains(t, string(encoded), "[[virtual_tools]]")
+	require.Contains(t, string(encoded), `tool = "search_issues"`)
+}
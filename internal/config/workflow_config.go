@@ -0,0 +1,172 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mozilla-ai/mcpd/internal/filter"
+)
+
+// workflowInputTypes defines the JSON Schema types which can be used for workflow inputs.
+// NOTE: This variable should not be modified in other parts of the codebase.
+var workflowInputTypes = []string{"array", "boolean", "integer", "number", "object", "string"}
+
+// WorkflowEntry represents a tool composed of sequential calls to the tools of configured servers.
+// Arguments of each step can be templated from the workflow's inputs and the results of previous steps.
+type WorkflowEntry struct {
+	// Name is the unique name the workflow is exposed as.
+	// e.g. 'triage_issue'
+	Name string `json:"name" toml:"name" yaml:"name"`
+
+	// Title is a human-readable title for the workflow.
+	Title string `json:"title,omitempty" toml:"title,omitempty" yaml:"title,omitempty"`
+
+	// Description describes the workflow for clients (e.g. the LLM).
+	Description string `json:"description,omitempty" toml:"description,omitempty" yaml:"description,omitempty"`
+
+	// Inputs are the arguments accepted by the workflow, published as its input schema.
+	Inputs []WorkflowInput `json:"inputs,omitempty" toml:"inputs,omitempty" yaml:"inputs,omitempty"`
+
+	// Steps are the tool calls made by the workflow, in order.
+	Steps []WorkflowStep `json:"steps" toml:"steps" yaml:"steps"`
+
+	// Output is an optional template for the result of the workflow.
+	// When empty, the result of the last step is returned.
+	// e.g. '{{ .steps.create.result }}'
+	Output string `json:"output,omitempty" toml:"output,omitempty" yaml:"output,omitempty"`
+
+	// Timeout bounds how long the whole workflow may run.
+	Timeout *Duration `json:"timeout,omitempty" toml:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
+// WorkflowInput describes an argument accepted by a workflow.
+type WorkflowInput struct {
+	// Name is the name of the argument.
+	Name string `json:"name" toml:"name" yaml:"name"`
+
+	// Type is the JSON Schema type of the argument, defaults to 'string'.
+	Type string `json:"type,omitempty" toml:"type,omitempty" yaml:"type,omitempty"`
+
+	// Description describes the argument for clients (e.g. the LLM).
+	Description string `json:"description,omitempty" toml:"description,omitempty" yaml:"description,omitempty"`
+
+	// Required indicates whether the argument must be supplied.
+	Required bool `json:"required,omitempty" toml:"required,omitempty" yaml:"required,omitempty"`
+
+	// Default is the value used when the argument isn't supplied.
+	Default any `json:"default,omitempty" toml:"default,omitempty" yaml:"default,omitempty"`
+}
+
+// WorkflowStep describes a single tool call made by a workflow.
+type WorkflowStep struct {
+	// ID identifies the step, so its result can be referenced by later steps.
+	// e.g. 'search'
+	ID string `json:"id" toml:"id" yaml:"id"`
+
+	// Server is the name of the server offering the tool.
+	Server string `json:"server" toml:"server" yaml:"server"`
+
+	// Tool is the name of the tool to call.
+	Tool string `json:"tool" toml:"tool" yaml:"tool"`
+
+	// Args are the arguments for the tool, string values (including those nested in tables and arrays)
+	// are rendered as templates.
+	// e.g. 'query' = '{{ .inputs.title }}'
+	Args map[string]any `json:"args,omitempty" toml:"args,omitempty" yaml:"args,omitempty"`
+
+	// Timeout is an optional tool call timeout, which is capped by the configured tool call timeout.
+	Timeout *Duration `json:"timeout,omitempty" toml:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
+// InputType returns the JSON Schema type of the input.
+func (i WorkflowInput) InputType() string {
+	if i.Type == "" {
+		return "string"
+	}
+	return i.Type
+}
+
+// validateWorkflows ensures workflows are named uniquely, and that their inputs and steps are valid.
+func (c *Config) validateWorkflows() error {
+	servers := make(map[string]struct{}, len(c.Servers))
+	for _, entry := range c.Servers {
+		servers[filter.NormalizeString(entry.Name)] = struct{}{}
+	}
+
+	seen := make(map[string]struct{}, len(c.Workflows))
+	for i, wf := range c.Workflows {
+		name := filter.NormalizeString(wf.Name)
+		if name == "" {
+			return fmt.Errorf("workflow at index %d has empty name", i)
+		}
+		if _, ok := seen[name]; ok {
+			return fmt.Errorf("duplicate workflow name '%s'", wf.Name)
+		}
+		seen[name] = struct{}{}
+
+		if err := wf.validate(servers); err != nil {
+			return fmt.Errorf("workflow '%s' is invalid: %w", wf.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// validate ensures the workflow's inputs and steps are valid, and that steps only call the given servers.
+func (w *WorkflowEntry) validate(servers map[string]struct{}) error {
+	var errs error
+
+	if w.Timeout != nil && *w.Timeout <= 0 {
+		errs = errors.Join(errs, fmt.Errorf("timeout must be positive, got %s", w.Timeout.String()))
+	}
+
+	inputs := make(map[string]struct{}, len(w.Inputs))
+	for i, input := range w.Inputs {
+		if strings.TrimSpace(input.Name) == "" {
+			errs = errors.Join(errs, fmt.Errorf("input at index %d has empty name", i))
+			continue
+		}
+		if _, ok := inputs[input.Name]; ok {
+			errs = errors.Join(errs, fmt.Errorf("duplicate input '%s'", input.Name))
+		}
+		inputs[input.Name] = struct{}{}
+		if !slices.Contains(workflowInputTypes, input.InputType()) {
+			errs = errors.Join(errs, fmt.Errorf(
+				"input '%s' has invalid type '%s', must be one of: %s",
+				input.Name,
+				input.Type,
+				strings.Join(workflowInputTypes, ", "),
+			))
+		}
+	}
+
+	if len(w.Steps) == 0 {
+		errs = errors.Join(errs, fmt.Errorf("workflow has no steps"))
+	}
+
+	steps := make(map[string]struct{}, len(w.Steps))
+	for i, step := range w.Steps {
+		if strings.TrimSpace(step.ID) == "" {
+			errs = errors.Join(errs, fmt.Errorf("step at index %d has empty id", i))
+			continue
+		}
+		if _, ok := steps[step.ID]; ok {
+			errs = errors.Join(errs, fmt.Errorf("duplicate step '%s'", step.ID))
+		}
+		steps[step.ID] = struct{}{}
+
+		if strings.TrimSpace(step.Tool) == "" {
+			errs = errors.Join(errs, fmt.Errorf("step '%s' has empty tool", step.ID))
+		}
+		if _, ok := servers[filter.NormalizeString(step.Server)]; !ok {
+			errs = errors.Join(errs, fmt.Errorf("step '%s' references unknown server '%s'", step.ID, step.Server))
+		}
+		if step.Timeout != nil && *step.Timeout <= 0 {
+			errs = errors.Join(errs, fmt.Errorf("step '%s' timeout must be positive", step.ID))
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,168 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_ValidateWorkflows(t *testing.T) {
+	t.Parallel()
+
+	servers := []ServerEntry{
+		{Name: "time", Package: "uvx::mcp-server-time@2025.8.4"},
+		{Name: "chat", Package: "npx::chat-server@1.0.0"},
+	}
+
+	tests := []struct {
+		name      string
+		workflows []WorkflowEntry
+		errs      []string
+	}{
+		{
+			name: "valid workflow",
+			workflows: []WorkflowEntry{
+				{
+					Name:    "report_time",
+					Inputs:  []WorkflowInput{{Name: "channel", Required: true}, {Name: "count", Type: "integer"}},
+					Timeout: testDurationPtr(t, time.Minute),
+					Steps: []WorkflowStep{
+						{ID: "now", Server: "time", Tool: "get_current_time"},
+						{ID: "post", Server: "Chat", Tool: "post_message", Timeout: testDurationPtr(t, time.Second)},
+					},
+				},
+			},
+		},
+		{
+			name: "empty name",
+			workflows: []WorkflowEntry{
+				{Name: " ", Steps: []WorkflowStep{{ID: "now", Server: "time", Tool: "get_current_time"}}},
+			},
+			errs: []string{"workflow at index 0 has empty name"},
+		},
+		{
+			name: "duplicate name",
+			workflows: []WorkflowEntry{
+				{Name: "report", Steps: []WorkflowStep{{ID: "now", Server: "time", Tool: "get_current_time"}}},
+				{Name: "Report", Steps: []WorkflowStep{{ID: "now", Server: "time", Tool: "get_current_time"}}},
+			},
+			errs: []string{"duplicate workflow name 'Report'"},
+		},
+		{
+			name:      "no steps",
+			workflows: []WorkflowEntry{{Name: "report"}},
+			errs:      []string{"workflow 'report' is invalid: workflow has no steps"},
+		},
+		{
+			name: "invalid inputs",
+			workflows: []WorkflowEntry{
+				{
+					Name:   "report",
+					Inputs: []WorkflowInput{{Name: ""}, {Name: "a"}, {Name: "a"}, {Name: "b", Type: "date"}},
+					Steps:  []WorkflowStep{{ID: "now", Server: "time", Tool: "get_current_time"}},
+				},
+			},
+			errs: []string{
+				"input at index 0 has empty name",
+				"duplicate input 'a'",
+				"input 'b' has invalid type 'date'",
+			},
+		},
+		{
+			name: "invalid steps",
+			workflows: []WorkflowEntry{
+				{
+					Name:    "report",
+					Timeout: testDurationPtr(t, 0),
+					Steps: []WorkflowStep{
+						{ID: "", Server: "time", Tool: "get_current_time"},
+						{ID: "now", Server: "time", Tool: ""},
+						{ID: "now", Server: "weather", Tool: "forecast", Timeout: testDurationPtr(t, -time.Second)},
+					},
+				},
+			},
+			errs: []string{
+				"timeout must be positive",
+				"step at index 0 has empty id",
+				"step 'now' has empty tool",
+				"duplicate step 'now'",
+				"step 'now' references unknown server 'weather'",
+				"step 'now' timeout must be positive",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &Config{Servers: servers, Workflows: tc.workflows}
+			err := cfg.validateWorkflows()
+			if len(tc.errs) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			for _, msg := range tc.errs {
+				require.ErrorContains(t, err, msg)
+			}
+		})
+	}
+}
+
+func TestWorkflowEntry_TOML(t *testing.T) {
+	t.Parallel()
+
+	data := `
+[[servers]]
+name = "time"
+package = "uvx::mcp-server-time@2025.8.4"
+tools = ["get_current_time"]
+
+[[workflows]]
+name = "report_time"
+description = "Reports the current time"
+output = "{{ .steps.now.result }}"
+timeout = "30s"
+
+[[workflows.inputs]]
+name = "timezone"
+default = "UTC"
+
+[[workflows.steps]]
+id = "now"
+server = "time"
+tool = "get_current_time"
+timeout = "5s"
+args = { timezone = "{{ .inputs.timezone }}" }
+`
+
+	var cfg Config
+	_, err := toml.Decode(data, &cfg)
+	require.NoError(t, err)
+	require.NoError(t, cfg.validate())
+
+	require.Len(t, cfg.Workflows, 1)
+	wf := cfg.Workflows[0]
+	require.Equal(t, "report_time", wf.Name)
+	require.Equal(t, testDurationPtr(t, 30*time.Second), wf.Timeout)
+	require.Equal(t, []WorkflowInput{{Name: "timezone", Default: "UTC"}}, wf.Inputs)
+	require.Equal(t, "string", wf.Inputs[0].InputType())
+	require.Equal(t, []WorkflowStep{
+		{
+			ID:      "now",
+			Server:  "time",
+			Tool:    "get_current_time",
+			Args:    map[string]any{"timezone": "{{ .inputs.timezone }}"},
+			Timeout: testDurationPtr(t, 5*time.Second),
+		},
+	}, wf.Steps)
+
+	encoded, err := toml.Marshal(cfg)
+	require.NoError(t, err)
+	require.Contains(t, string(encoded), "[[workflows]]")
+	require.Contains(t, string(encoded), "[[workflows.steps]]")
+}
@@ -21,8 +21,13 @@ type DefaultLoader struct{}
 
 // ExecutionContextConfig stores execution context data for all configured MCP servers.
 type ExecutionContextConfig struct {
-	Servers  map[string]ServerExecutionContext `toml:"servers"`
-	filePath string                            `toml:"-"`
+	Servers map[string]ServerExecutionContext `toml:"servers"`
+
+	// APIKeys maps the names of API keys to the keys, used to authenticate requests to the daemon API.
+	// Only the hashes of the keys are stored in the project configuration.
+	APIKeys map[string]string `toml:"api_keys,omitempty"`
+
+	filePath string `toml:"-"`
 }
 
 // ServerExecutionContext stores execution context data for an MCP server.
@@ -171,6 +176,62 @@ func (c *ExecutionContextConfig) Upsert(ec ServerExecutionContext) (UpsertResult
 	return op, nil
 }
 
+// APIKey retrieves the API key with the given name.
+func (c *ExecutionContextConfig) APIKey(name string) (string, bool) {
+	key, ok := c.APIKeys[strings.TrimSpace(name)]
+	return key, ok
+}
+
+// SetAPIKey stores the API key with the given name, and writes the change to disk.
+func (c *ExecutionContextConfig) SetAPIKey(name string, key string) (UpsertResult, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Noop, fmt.Errorf("API key name cannot be empty")
+	}
+	if strings.TrimSpace(key) == "" {
+		return Noop, fmt.Errorf("API key cannot be empty")
+	}
+
+	current, exists := c.APIKeys[name]
+	if exists && current == key {
+		return Noop, nil
+	}
+
+	if c.APIKeys == nil {
+		c.APIKeys = map[string]string{}
+	}
+	c.APIKeys[name] = key
+
+	if err := c.SaveConfig(); err != nil {
+		return Noop, fmt.Errorf("error saving execution context config: %w", err)
+	}
+
+	if exists {
+		return Updated, nil
+	}
+
+	return Created, nil
+}
+
+// RemoveAPIKey removes the API key with the given name, and writes the change to disk.
+func (c *ExecutionContextConfig) RemoveAPIKey(name string) (UpsertResult, error) {
+	name = strings.TrimSpace(name)
+	if _, exists := c.APIKeys[name]; !exists {
+		return Noop, nil
+	}
+
+	delete(c.APIKeys, name)
+	if len(c.APIKeys) == 0 {
+		c.APIKeys = nil
+	}
+
+	if err := c.SaveConfig(); err != nil {
+		return Noop, fmt.Errorf("error saving execution context config: %w", err)
+	}
+
+	return Deleted, nil
+}
+
 // Equals checks if this ServerExecutionContext is equal to another.
 func (s *ServerExecutionContext) Equals(b ServerExecutionContext) bool {
 	if s.Name != b.Name {
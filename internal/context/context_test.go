@@ -117,6 +117,53 @@ func TestSaveAndLoadExecutionContextConfig(t *testing.T) {
 	require.Equal(t, original, loaded)
 }
 
+func TestExecutionContextConfig_APIKeys(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "mcpd", "secrets.dev.toml")
+	cfg := NewExecutionContextConfig(path)
+
+	_, ok := cfg.APIKey("ci")
+	require.False(t, ok)
+
+	res, err := cfg.SetAPIKey("ci", "mcpd_abc")
+	require.NoError(t, err)
+	require.Equal(t, Created, res)
+
+	res, err = cfg.SetAPIKey("ci", "mcpd_abc")
+	require.NoError(t, err)
+	require.Equal(t, Noop, res)
+
+	res, err = cfg.SetAPIKey(" ci ", "mcpd_def")
+	require.NoError(t, err)
+	require.Equal(t, Updated, res)
+
+	_, err = cfg.SetAPIKey("", "mcpd_def")
+	require.EqualError(t, err, "API key name cannot be empty")
+
+	// Keys are persisted alongside server execution contexts.
+	loader := DefaultLoader{}
+	loaded, err := loader.Load(path)
+	require.NoError(t, err)
+	loadedCfg, ok := loaded.(*ExecutionContextConfig)
+	require.True(t, ok)
+	key, ok := loadedCfg.APIKey("ci")
+	require.True(t, ok)
+	require.Equal(t, "mcpd_def", key)
+
+	res, err = loadedCfg.RemoveAPIKey("ci")
+	require.NoError(t, err)
+	require.Equal(t, Deleted, res)
+
+	res, err = loadedCfg.RemoveAPIKey("ci")
+	require.NoError(t, err)
+	require.Equal(t, Noop, res)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "api_keys")
+}
+
 func TestUpsert(t *testing.T) {
 	t.Parallel()
 
@@ -1,10 +1,13 @@
 package contracts
 
 import (
+	"context"
 	"time"
 
 	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
 
+	"github.com/mozilla-ai/mcpd/internal/config"
 	"github.com/mozilla-ai/mcpd/internal/domain"
 )
 
@@ -49,3 +52,46 @@ type MCPClientAccessor interface {
 	// Remove deletes the client and its tools by server name.
 	Remove(name string)
 }
+
+// MCPClientUnwrapper is implemented by MCP clients which wrap another client (e.g. to record its requests).
+// Accessors may return a new wrapper for each lookup, so clients are compared once unwrapped.
+type MCPClientUnwrapper interface {
+	// Unwrap returns the wrapped client.
+	Unwrap() client.MCPClient
+}
+
+// MCPNotificationSubscriber provides a way to observe notifications sent by MCP servers.
+type MCPNotificationSubscriber interface {
+	// Subscribe registers a handler for notifications sent by the named server.
+	// It returns a function that removes the handler, which is safe to call more than once.
+	Subscribe(name string, handler func(notification mcp.JSONRPCNotification)) (unsubscribe func())
+}
+
+// MCPServerConfigAccessor provides a way to look up the configuration of running MCP servers.
+type MCPServerConfigAccessor interface {
+	// ServerConfig returns the configuration for the given server name.
+	// It returns a boolean to indicate whether the configuration was found.
+	ServerConfig(name string) (config.ServerEntry, bool)
+}
+
+// MCPCatalogAccessor provides cached listings of the tools, prompts, and resources offered by MCP servers.
+type MCPCatalogAccessor interface {
+	// Catalog returns the cached listings for the given server name.
+	// It returns a boolean to indicate whether the server's listings are tracked.
+	Catalog(name string) (domain.ServerCatalog, bool)
+}
+
+// MCPServerProcessAccessor provides details of the running MCP server processes.
+type MCPServerProcessAccessor interface {
+	// ServerProcess returns the details of the process for the given server name.
+	// It returns a boolean to indicate whether the server is running.
+	ServerProcess(name string) (domain.ServerProcess, bool)
+}
+
+// MCPLogLevelController provides a way to change the log level of running MCP servers.
+type MCPLogLevelController interface {
+	// SetLogLevel sets the minimum level of the log messages for the given server name,
+	// both those logged by mcpd for the server and those the server sends.
+	// It returns true when the server supports logging, and was asked to change its level.
+	SetLogLevel(ctx context.Context, name string, level mcp.LoggingLevel) (bool, error)
+}
@@ -2,13 +2,22 @@ package daemon
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"net/http"
+	"os"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/mozilla-ai/mcpd/internal/api"
+	"github.com/mozilla-ai/mcpd/internal/audit"
+	"github.com/mozilla-ai/mcpd/internal/auth"
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/contracts"
+	"github.com/mozilla-ai/mcpd/internal/workflow"
 )
 
 // APIOptions contains optional configuration for the API server.
@@ -23,9 +32,86 @@ type APIOptions struct {
 	// ToolCallTimeout specifies how long to wait for MCP tool calls.
 	ToolCallTimeout time.Duration
 
+	// BatchConcurrency specifies the maximum number of tool calls from a single batch that are made concurrently.
+	BatchConcurrency int
+
+	// Jobs configures the asynchronous tool calls made via the API.
+	Jobs JobsConfig
+
 	// MiddlewareProvider lazily provides HTTP middleware when called during API server startup.
 	// This allows plugin initialization to be deferred until the server actually starts.
 	MiddlewareProvider func(context.Context) (func(http.Handler) http.Handler, error)
+
+	// NotificationSubscriber provides access to notifications sent by MCP servers (e.g. progress).
+	// When nil, features relying on server notifications degrade gracefully.
+	NotificationSubscriber contracts.MCPNotificationSubscriber
+
+	// ServerConfigAccessor provides the configuration of MCP servers (e.g. timeouts).
+	// When nil, only daemon-wide settings are applied.
+	ServerConfigAccessor contracts.MCPServerConfigAccessor
+
+	// CatalogAccessor provides cached listings of the tools, prompts, and resources offered by MCP servers.
+	// When nil, listings are requested from the MCP server for each API request.
+	CatalogAccessor contracts.MCPCatalogAccessor
+
+	// ServerProcessAccessor provides details of the running MCP server processes (e.g. capabilities, PID).
+	// When nil, server details are limited to their configuration and tools.
+	ServerProcessAccessor contracts.MCPServerProcessAccessor
+
+	// LogLevelController changes the log levels of MCP servers.
+	// When nil, log levels can't be changed via the API.
+	LogLevelController contracts.MCPLogLevelController
+
+	// Authenticator identifies the callers of the API.
+	// When nil, requests to the API are not authenticated.
+	Authenticator auth.Authenticator
+
+	// Workflows are the configured workflows, exposed as tools composed of calls to the tools of MCP servers.
+	Workflows []config.WorkflowEntry
+
+	// Elicitations holds the requests for user input made by MCP servers, so they can be responded to via the API.
+	// When nil, the API uses a store which no MCP server makes requests to.
+	Elicitations *api.ElicitationStore
+
+	// TLS configures serving the API over TLS.
+	// When nil, the API is served over plain HTTP.
+	TLS *TLSConfig
+
+	// Socket configures the Unix domain sockets the API is served on, for "unix://" addresses.
+	Socket SocketConfig
+
+	// Routes are the groups of routes (see config.RouteGroups) served on the API address.
+	// When empty, all routes are served.
+	Routes []string
+
+	// Listeners are additional addresses the API is served on, each limited to some groups of routes.
+	Listeners []ListenerConfig
+
+	// Audit configures the audit log of the tool calls, prompt gets and resource reads made via the API.
+	// When nil, requests aren't audited.
+	Audit *audit.Options
+}
+
+// ListenerConfig defines an address the API is served on, and the groups of routes served on it.
+type ListenerConfig struct {
+	// Addr is the address to bind, "host:port" or "unix:///path/to/socket".
+	Addr string
+
+	// Routes are the groups of routes (see config.RouteGroups) served on the address.
+	// When empty, all routes are served.
+	Routes []string
+}
+
+// JobsConfig defines how long asynchronous tool calls may run, and how their results are retained.
+type JobsConfig struct {
+	// Timeout bounds how long a single asynchronous tool call may run.
+	Timeout time.Duration
+
+	// MaxFinished is the maximum number of finished asynchronous tool calls which are retained.
+	MaxFinished int
+
+	// Retention is how long finished asynchronous tool calls are retained.
+	Retention time.Duration
 }
 
 // CORSConfig defines Cross-Origin Resource Sharing settings for the API server.
@@ -75,7 +161,14 @@ func NewAPIOptions(opts ...APIOption) (APIOptions, error) {
 		},
 		ShutdownTimeout:    DefaultAPIShutdownTimeout(),
 		ToolCallTimeout:    DefaultToolCallTimeout(),
+		BatchConcurrency:   DefaultBatchConcurrency(),
 		MiddlewareProvider: DefaultMiddlewareProvider(),
+		Socket:             SocketConfig{Mode: defaultSocketMode},
+		Jobs: JobsConfig{
+			Timeout:     DefaultJobTimeout(),
+			MaxFinished: DefaultJobMaxFinished(),
+			Retention:   DefaultJobRetention(),
+		},
 	}
 
 	for _, opt := range opts {
@@ -172,6 +265,188 @@ func WithToolCallTimeout(timeout time.Duration) APIOption {
 	}
 }
 
+// WithBatchConcurrency configures the maximum number of tool calls from a single batch that are made concurrently.
+func WithBatchConcurrency(concurrency int) APIOption {
+	return func(o *APIOptions) error {
+		if concurrency <= 0 {
+			return fmt.Errorf("batch concurrency must be positive, got %d", concurrency)
+		}
+		o.BatchConcurrency = concurrency
+		return nil
+	}
+}
+
+// WithJobsConfig configures the asynchronous tool calls made via the API.
+// Settings which aren't configured keep their default values.
+func WithJobsConfig(cfg *config.APIJobsConfigSection) APIOption {
+	return func(o *APIOptions) error {
+		if cfg == nil {
+			return nil
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid jobs configuration: %w", err)
+		}
+
+		if cfg.Timeout != nil {
+			o.Jobs.Timeout = time.Duration(*cfg.Timeout)
+		}
+		if cfg.MaxFinished != nil {
+			o.Jobs.MaxFinished = *cfg.MaxFinished
+		}
+		if cfg.Retention != nil {
+			o.Jobs.Retention = time.Duration(*cfg.Retention)
+		}
+
+		return nil
+	}
+}
+
+// WithAuthConfig configures authentication of requests to the API.
+// Requests are not authenticated when no authentication methods are configured.
+func WithAuthConfig(cfg *config.APIAuthConfigSection) APIOption {
+	return func(o *APIOptions) error {
+		authenticator, err := auth.NewAuthenticator(cfg)
+		if err != nil {
+			return err
+		}
+		o.Authenticator = authenticator
+		return nil
+	}
+}
+
+// WithTLSConfig configures serving the API over TLS, and authenticating clients with certificates (mTLS) when a
+// client CA is configured.
+func WithTLSConfig(cfg *config.APITLSConfigSection) APIOption {
+	return func(o *APIOptions) error {
+		if cfg == nil {
+			o.TLS = nil
+			return nil
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid TLS configuration: %w", err)
+		}
+
+		tlsCfg := &TLSConfig{
+			CertFile:   *cfg.CertFile,
+			KeyFile:    *cfg.KeyFile,
+			MinVersion: tls.VersionTLS12,
+			ClientAuth: tls.RequireAndVerifyClientCert,
+		}
+		if cfg.MinVersion != nil && *cfg.MinVersion == config.TLSVersion13 {
+			tlsCfg.MinVersion = tls.VersionTLS13
+		}
+		if cfg.ClientCAFile != nil {
+			tlsCfg.ClientCAFile = *cfg.ClientCAFile
+		}
+		if cfg.ClientAuth != nil && *cfg.ClientAuth == config.ClientAuthOptional {
+			tlsCfg.ClientAuth = tls.VerifyClientCertIfGiven
+		}
+
+		o.TLS = tlsCfg
+		return nil
+	}
+}
+
+// WithAuditConfig configures the audit log of the tool calls, prompt gets and resource reads made via the API.
+// Requests aren't audited when no audit log path is configured.
+func WithAuditConfig(cfg *config.AuditConfigSection) APIOption {
+	return func(o *APIOptions) error {
+		if cfg == nil || cfg.Path == nil {
+			o.Audit = nil
+			return nil
+		}
+
+		auditOpts, err := audit.NewOptions(cfg)
+		if err != nil {
+			return fmt.Errorf("invalid audit configuration: %w", err)
+		}
+
+		o.Audit = &auditOpts
+		return nil
+	}
+}
+
+// WithRoutes limits the routes served on the API address to the groups (see config.RouteGroups).
+// When no groups are given, all routes are served.
+func WithRoutes(groups []string) APIOption {
+	return func(o *APIOptions) error {
+		if err := validateRouteGroups(groups); err != nil {
+			return err
+		}
+		o.Routes = slices.Clone(groups)
+		return nil
+	}
+}
+
+// WithListeners configures additional addresses the API is served on, each limited to some groups of routes.
+func WithListeners(listeners []config.APIListenerEntry) APIOption {
+	return func(o *APIOptions) error {
+		o.Listeners = make([]ListenerConfig, 0, len(listeners))
+		for _, entry := range listeners {
+			addr := strings.TrimSpace(entry.Addr)
+			if err := validateAddr(addr); err != nil {
+				return fmt.Errorf("invalid listener address '%s': %w", addr, err)
+			}
+			if len(entry.Routes) == 0 {
+				return fmt.Errorf("listener '%s' must serve at least one group of routes", addr)
+			}
+			if err := validateRouteGroups(entry.Routes); err != nil {
+				return fmt.Errorf("invalid listener '%s': %w", addr, err)
+			}
+
+			o.Listeners = append(o.Listeners, ListenerConfig{Addr: addr, Routes: slices.Clone(entry.Routes)})
+		}
+		return nil
+	}
+}
+
+// WithSocketConfig configures the file mode and ownership of the Unix domain socket the API is served on.
+// The configuration only applies when the API address is a "unix://" address.
+func WithSocketConfig(cfg *config.APISocketConfigSection) APIOption {
+	return func(o *APIOptions) error {
+		o.Socket = SocketConfig{Mode: defaultSocketMode}
+		if cfg == nil {
+			return nil
+		}
+
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid socket configuration: %w", err)
+		}
+
+		if cfg.Mode != nil {
+			mode, err := config.ParseSocketMode(*cfg.Mode)
+			if err != nil {
+				return err
+			}
+			o.Socket.Mode = os.FileMode(mode)
+		}
+		if cfg.Owner != nil {
+			o.Socket.Owner = strings.TrimSpace(*cfg.Owner)
+		}
+		if cfg.Group != nil {
+			o.Socket.Group = strings.TrimSpace(*cfg.Group)
+		}
+
+		return nil
+	}
+}
+
+// WithWorkflows configures the workflows exposed by the API.
+// Workflow templates are compiled to ensure they are valid.
+func WithWorkflows(workflows []config.WorkflowEntry) APIOption {
+	return func(o *APIOptions) error {
+		for _, entry := range workflows {
+			if _, err := workflow.New(entry); err != nil {
+				return fmt.Errorf("invalid workflow: %w", err)
+			}
+		}
+		o.Workflows = workflows
+		return nil
+	}
+}
+
 // WithMiddlewareProvider configures a provider function that returns HTTP middleware.
 // The provider is called during API server startup to lazily initialize middleware.
 func WithMiddlewareProvider(provider func(context.Context) (func(http.Handler) http.Handler, error)) APIOption {
@@ -181,6 +456,54 @@ func WithMiddlewareProvider(provider func(context.Context) (func(http.Handler) h
 	}
 }
 
+// WithNotificationSubscriber configures the source of notifications sent by MCP servers.
+func WithNotificationSubscriber(subscriber contracts.MCPNotificationSubscriber) APIOption {
+	return func(o *APIOptions) error {
+		o.NotificationSubscriber = subscriber
+		return nil
+	}
+}
+
+// WithServerConfigAccessor configures the source of MCP server configuration.
+func WithServerConfigAccessor(accessor contracts.MCPServerConfigAccessor) APIOption {
+	return func(o *APIOptions) error {
+		o.ServerConfigAccessor = accessor
+		return nil
+	}
+}
+
+// WithCatalogAccessor configures the source of cached MCP server listings.
+func WithCatalogAccessor(accessor contracts.MCPCatalogAccessor) APIOption {
+	return func(o *APIOptions) error {
+		o.CatalogAccessor = accessor
+		return nil
+	}
+}
+
+// WithServerProcessAccessor configures the source of details of the running MCP server processes.
+func WithServerProcessAccessor(accessor contracts.MCPServerProcessAccessor) APIOption {
+	return func(o *APIOptions) error {
+		o.ServerProcessAccessor = accessor
+		return nil
+	}
+}
+
+// WithLogLevelController configures the controller used to change the log levels of MCP servers.
+func WithLogLevelController(controller contracts.MCPLogLevelController) APIOption {
+	return func(o *APIOptions) error {
+		o.LogLevelController = controller
+		return nil
+	}
+}
+
+// WithElicitationStore configures the store of requests for user input made by MCP servers.
+func WithElicitationStore(store *api.ElicitationStore) APIOption {
+	return func(o *APIOptions) error {
+		o.Elicitations = store
+		return nil
+	}
+}
+
 // DefaultCORSAllowHeaders returns standard headers required for API interaction.
 func DefaultCORSAllowHeaders() []string {
 	// Headers that are safe-listed regardless of configuration.
@@ -226,6 +549,30 @@ func DefaultToolCallTimeout() time.Duration {
 	return api.DefaultToolCallTimeout()
 }
 
+// DefaultBatchConcurrency is the default number of tool calls from a single batch that are made concurrently.
+// It delegates to the API layer so the default has a single source of truth.
+func DefaultBatchConcurrency() int {
+	return api.DefaultBatchConcurrency()
+}
+
+// DefaultJobTimeout is the default timeout for asynchronous tool calls.
+// It delegates to the API layer so the default has a single source of truth.
+func DefaultJobTimeout() time.Duration {
+	return api.DefaultJobTimeout()
+}
+
+// DefaultJobMaxFinished is the default number of finished asynchronous tool calls which are retained.
+// It delegates to the API layer so the default has a single source of truth.
+func DefaultJobMaxFinished() int {
+	return api.DefaultJobMaxFinished()
+}
+
+// DefaultJobRetention is the default duration finished asynchronous tool calls are retained.
+// It delegates to the API layer so the default has a single source of truth.
+func DefaultJobRetention() time.Duration {
+	return api.DefaultJobRetention()
+}
+
 // DefaultMiddlewareProvider returns a provider that supplies no-op middleware.
 // The no-op middleware passes requests through unchanged.
 func DefaultMiddlewareProvider() func(context.Context) (func(http.Handler) http.Handler, error) {
@@ -237,8 +584,27 @@ func DefaultMiddlewareProvider() func(context.Context) (func(http.Handler) http.
 	}
 }
 
-// validateAddr checks if the address is a valid "host:port" string.
+// validateRouteGroups checks that each of the groups is a known group of routes.
+func validateRouteGroups(groups []string) error {
+	for _, group := range groups {
+		if !slices.Contains(config.RouteGroups(), group) {
+			return fmt.Errorf(
+				"unknown route group '%s' (expected one of: %s)",
+				group,
+				strings.Join(config.RouteGroups(), ", "),
+			)
+		}
+	}
+
+	return nil
+}
+
+// validateAddr checks if the address is a valid "host:port" string, or a Unix domain socket ("unix:///path").
 func validateAddr(addr string) error {
+	if path, ok := config.UnixSocketPath(addr); ok {
+		return validateSocketPath(path)
+	}
+
 	host, port, err := net.SplitHostPort(addr)
 	if err != nil {
 		return fmt.Errorf("invalid address format: %w", err)
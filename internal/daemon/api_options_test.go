@@ -7,6 +7,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/config"
 )
 
 func TestDaemon_NewAPIOptions(t *testing.T) {
@@ -99,6 +101,86 @@ func TestDaemon_APIOptions_WithShutdownTimeout(t *testing.T) {
 	})
 }
 
+func TestDaemon_APIOptions_WithJobsConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults when not configured", func(t *testing.T) {
+		t.Parallel()
+
+		opts, err := NewAPIOptions(WithJobsConfig(nil))
+
+		require.NoError(t, err)
+		assert.Equal(t, JobsConfig{
+			Timeout:     DefaultJobTimeout(),
+			MaxFinished: DefaultJobMaxFinished(),
+			Retention:   DefaultJobRetention(),
+		}, opts.Jobs)
+	})
+
+	t.Run("configured values override defaults", func(t *testing.T) {
+		t.Parallel()
+
+		timeout := config.Duration(10 * time.Minute)
+		maxFinished := 10
+		opts, err := NewAPIOptions(WithJobsConfig(&config.APIJobsConfigSection{
+			Timeout:     &timeout,
+			MaxFinished: &maxFinished,
+		}))
+
+		require.NoError(t, err)
+		assert.Equal(t, JobsConfig{
+			Timeout:     10 * time.Minute,
+			MaxFinished: 10,
+			Retention:   DefaultJobRetention(),
+		}, opts.Jobs)
+	})
+
+	t.Run("invalid values fail", func(t *testing.T) {
+		t.Parallel()
+
+		retention := config.Duration(0)
+		_, err := NewAPIOptions(WithJobsConfig(&config.APIJobsConfigSection{Retention: &retention}))
+
+		require.EqualError(t, err, "invalid jobs configuration: jobs retention must be positive")
+	})
+}
+
+func TestDaemon_APIOptions_WithWorkflows(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid workflows", func(t *testing.T) {
+		t.Parallel()
+
+		workflows := []config.WorkflowEntry{
+			{
+				Name: "lookup",
+				Steps: []config.WorkflowStep{
+					{ID: "time", Server: "time", Tool: "get_current_time", Args: map[string]any{"tz": "{{ .inputs.tz }}"}},
+				},
+			},
+		}
+		opts, err := NewAPIOptions(WithWorkflows(workflows))
+
+		require.NoError(t, err)
+		assert.Equal(t, workflows, opts.Workflows)
+	})
+
+	t.Run("invalid template fails", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewAPIOptions(WithWorkflows([]config.WorkflowEntry{
+			{
+				Name:   "lookup",
+				Steps:  []config.WorkflowStep{{ID: "time", Server: "time", Tool: "get_current_time"}},
+				Output: "{{ .steps.time.result",
+			},
+		}))
+
+		require.Error(t, err)
+		require.ErrorContains(t, err, "invalid workflow: workflow 'lookup'")
+	})
+}
+
 func TestDaemon_APIOptions_DefaultCORSHeaders(t *testing.T) {
 	t.Parallel()
 
@@ -179,6 +261,16 @@ func TestDaemon_ValidateAddr(t *testing.T) {
 			addr:    "localhost:",
 			wantErr: true,
 		},
+		{
+			name:    "unix socket",
+			addr:    "unix:///run/mcpd/mcpd.sock",
+			wantErr: false,
+		},
+		{
+			name:    "unix socket missing path",
+			addr:    "unix://",
+			wantErr: true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -4,6 +4,7 @@ import (
 	"context"
 	stdErrors "errors"
 	"fmt"
+	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -16,6 +17,9 @@ import (
 	"github.com/hashicorp/go-hclog"
 
 	"github.com/mozilla-ai/mcpd/internal/api"
+	"github.com/mozilla-ai/mcpd/internal/audit"
+	"github.com/mozilla-ai/mcpd/internal/auth"
+	"github.com/mozilla-ai/mcpd/internal/config"
 	"github.com/mozilla-ai/mcpd/internal/contracts"
 	"github.com/mozilla-ai/mcpd/internal/errors"
 )
@@ -44,8 +48,53 @@ type APIServer struct {
 	// toolCallTimeout specifies how long to wait for MCP tool calls.
 	toolCallTimeout time.Duration
 
+	// batchConcurrency specifies the maximum number of tool calls from a single batch that are made concurrently.
+	batchConcurrency int
+
+	// jobs configures the asynchronous tool calls made via the API.
+	jobs JobsConfig
+
 	// middlewareProvider lazily provides HTTP middleware during server startup.
 	middlewareProvider func(context.Context) (func(http.Handler) http.Handler, error)
+
+	// notificationSubscriber provides notifications sent by MCP servers.
+	notificationSubscriber contracts.MCPNotificationSubscriber
+
+	// serverConfigAccessor provides the configuration of MCP servers.
+	serverConfigAccessor contracts.MCPServerConfigAccessor
+
+	// catalogAccessor provides cached listings offered by MCP servers.
+	catalogAccessor contracts.MCPCatalogAccessor
+
+	// serverProcessAccessor provides details of the running MCP server processes.
+	serverProcessAccessor contracts.MCPServerProcessAccessor
+
+	// logLevelController changes the log levels of MCP servers.
+	logLevelController contracts.MCPLogLevelController
+
+	// authenticator identifies the callers of the API, nil when requests are not authenticated.
+	authenticator auth.Authenticator
+
+	// workflows are the configured workflows exposed by the API.
+	workflows []config.WorkflowEntry
+
+	// elicitations holds the requests for user input made by MCP servers.
+	elicitations *api.ElicitationStore
+
+	// tls provides the TLS configuration of the server, nil when the API is served over plain HTTP.
+	tls *serverTLS
+
+	// socket configures the Unix domain sockets the server listens on, for "unix://" addresses.
+	socket SocketConfig
+
+	// routes are the groups of routes served on addr, all groups when empty.
+	routes []string
+
+	// listeners are the additional addresses the API is served on, each limited to some groups of routes.
+	listeners []ListenerConfig
+
+	// audit configures the audit log of requests made to MCP servers, nil when requests aren't audited.
+	audit *audit.Options
 }
 
 // NewAPIServer creates a new API server with the provided dependencies and options.
@@ -61,23 +110,62 @@ func NewAPIServer(deps APIDependencies, opt ...APIOption) (*APIServer, error) {
 		return nil, fmt.Errorf("invalid API options: %w", err)
 	}
 
+	var serverTLS *serverTLS
+	if apiOpts.TLS != nil {
+		if serverTLS, err = newServerTLS(*apiOpts.TLS); err != nil {
+			return nil, fmt.Errorf("invalid TLS configuration for API server: %w", err)
+		}
+	}
+
 	return &APIServer{
-		logger:             deps.Logger.Named("api"),
-		clientManager:      deps.ClientManager,
-		healthTracker:      deps.HealthTracker,
-		addr:               deps.Addr,
-		cors:               apiOpts.CORS,
-		shutdownTimeout:    apiOpts.ShutdownTimeout,
-		toolCallTimeout:    apiOpts.ToolCallTimeout,
-		middlewareProvider: apiOpts.MiddlewareProvider,
+		logger:                 deps.Logger.Named("api"),
+		clientManager:          deps.ClientManager,
+		healthTracker:          deps.HealthTracker,
+		addr:                   deps.Addr,
+		cors:                   apiOpts.CORS,
+		shutdownTimeout:        apiOpts.ShutdownTimeout,
+		toolCallTimeout:        apiOpts.ToolCallTimeout,
+		batchConcurrency:       apiOpts.BatchConcurrency,
+		jobs:                   apiOpts.Jobs,
+		middlewareProvider:     apiOpts.MiddlewareProvider,
+		notificationSubscriber: apiOpts.NotificationSubscriber,
+		serverConfigAccessor:   apiOpts.ServerConfigAccessor,
+		catalogAccessor:        apiOpts.CatalogAccessor,
+		serverProcessAccessor:  apiOpts.ServerProcessAccessor,
+		logLevelController:     apiOpts.LogLevelController,
+		authenticator:          apiOpts.Authenticator,
+		workflows:              apiOpts.Workflows,
+		elicitations:           apiOpts.Elicitations,
+		tls:                    serverTLS,
+		socket:                 apiOpts.Socket,
+		routes:                 apiOpts.Routes,
+		listeners:              apiOpts.Listeners,
+		audit:                  apiOpts.Audit,
 	}, nil
 }
 
+// ReloadTLS reloads the TLS certificate presented by the server from its files, e.g. once it has been renewed.
+// Connections made after reloading are presented with the new certificate.
+// The current certificate is kept when reloading fails, and nothing is reloaded when TLS isn't configured.
+func (a *APIServer) ReloadTLS() error {
+	if a.tls == nil {
+		return nil
+	}
+
+	if err := a.tls.reload(); err != nil {
+		return err
+	}
+
+	a.logger.Info("Reloaded TLS certificate", "cert", a.tls.cfg.CertFile)
+	return nil
+}
+
 // Start starts the API server and blocks until the context is canceled or an error occurs.
 func (a *APIServer) Start(ctx context.Context) error {
 	// Create router.
 	mux := chi.NewMux()
 	mux.Use(middleware.StripSlashes)
+	mux.Use(clientCertMiddleware)
 
 	// Add CORS middleware if enabled.
 	if a.cors.Enabled {
@@ -91,16 +179,32 @@ func (a *APIServer) Start(ctx context.Context) error {
 	}
 	mux.Use(middlewareFunc)
 
+	// Record the requests made to MCP servers via the API in the audit log, when enabled.
+	clientManager := a.clientManager
+	if a.audit != nil {
+		auditLog, err := audit.Open(*a.audit)
+		if err != nil {
+			return fmt.Errorf("failed to open audit log: %w", err)
+		}
+		defer func() {
+			if err := auditLog.Close(); err != nil {
+				a.logger.Error("Failed to close audit log", "error", err)
+			}
+		}()
+		clientManager = audit.NewClientAccessor(clientManager, auditLog, a.logger.Named("audit"))
+		a.logger.Info("Audit log enabled", "path", a.audit.Path, "arguments", a.audit.Arguments)
+	}
+
 	// Set the version to match the API version (not the application version).
-	config := huma.DefaultConfig("mcpd docs", api.APIVersion)
+	humaConfig := huma.DefaultConfig("mcpd docs", api.APIVersion)
 
 	// Register API transformers.
 	// IMPORTANT: Prepend our transformers to run BEFORE Huma's defaults (including the link transformer).
 	// This ensures that when we modify response types (e.g., Tool → ToolMinimal), the link transformer
 	// adds the correct $schema for the filtered response type.
-	config.Transformers = append(api.Transformers(), config.Transformers...)
+	humaConfig.Transformers = append(api.Transformers(), humaConfig.Transformers...)
 
-	router := humachi.New(mux, config)
+	router := humachi.New(mux, humaConfig)
 
 	// Configure the error handling wrapping.
 	huma.NewErrorWithContext = errorHandler(a.logger)
@@ -109,45 +213,110 @@ func (a *APIServer) Start(ctx context.Context) error {
 	apiPathPrefix, err := api.RegisterRoutes(
 		router,
 		a.healthTracker,
-		a.clientManager,
+		clientManager,
 		api.WithToolCallTimeout(a.toolCallTimeout),
+		api.WithJobTimeout(a.jobs.Timeout),
+		api.WithJobRetention(a.jobs.MaxFinished, a.jobs.Retention),
+		api.WithNotificationSubscriber(a.notificationSubscriber),
+		api.WithServerConfigAccessor(a.serverConfigAccessor),
+		api.WithCatalogAccessor(a.catalogAccessor),
+		api.WithServerProcessAccessor(a.serverProcessAccessor),
+		api.WithLogLevelController(a.logLevelController),
+		api.WithBatchConcurrency(a.batchConcurrency),
+		api.WithBatchHandler(mux),
+		api.WithAuthenticator(a.authenticator),
+		api.WithWorkflows(a.workflows),
+		api.WithElicitationStore(a.elicitations),
+		api.WithLogger(a.logger),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to register API routes: %w", err)
 	}
 
-	srv := &http.Server{
-		Addr:    a.addr,
-		Handler: mux,
+	// The API is served on its address, and any additional listeners limited to some groups of routes.
+	listeners := append([]ListenerConfig{{Addr: a.addr, Routes: a.routes}}, a.listeners...)
+	servers := make([]*http.Server, 0, len(listeners))
+	shutdown := func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), a.shutdownTimeout)
+		defer cancel()
+		for _, srv := range servers {
+			_ = srv.Shutdown(shutdownCtx)
+		}
+	}
+	errCh := make(chan error, len(listeners))
+
+	if a.cors.Enabled {
+		a.logger.Info("CORS enabled", "origins", a.cors.AllowOrigins)
 	}
-	errCh := make(chan error, 1)
 
 	// Start the API.
-	go func() {
-		a.logger.Info("Starting API server", "address", a.addr, "prefix", apiPathPrefix)
-		if a.cors.Enabled {
-			a.logger.Info("CORS enabled", "origins", a.cors.AllowOrigins)
+	for _, l := range listeners {
+		listener, err := listen(l.Addr, a.socket)
+		if err != nil {
+			shutdown()
+			return fmt.Errorf("failed to listen on %s: %w", l.Addr, err)
 		}
-		if err := srv.ListenAndServe(); err != nil && !stdErrors.Is(err, http.ErrServerClosed) {
-			errCh <- err
+
+		srv := &http.Server{
+			Addr:        l.Addr,
+			Handler:     mux,
+			BaseContext: routeGroupsBaseContext(l.Routes),
+		}
+		if a.tls != nil {
+			srv.TLSConfig = a.tls.config()
+		}
+		servers = append(servers, srv)
+
+		routes := l.Routes
+		if len(routes) == 0 {
+			routes = config.RouteGroups()
 		}
-		close(errCh)
-	}()
+		a.logger.Info(
+			"Starting API server",
+			"address", l.Addr,
+			"prefix", apiPathPrefix,
+			"routes", routes,
+			"tls", a.tls != nil,
+		)
+
+		go func() {
+			var err error
+			if a.tls != nil {
+				// The certificate is provided by the TLS configuration, so it can be reloaded.
+				err = srv.ServeTLS(listener, "", "")
+			} else {
+				err = srv.Serve(listener)
+			}
+			if err != nil && !stdErrors.Is(err, http.ErrServerClosed) {
+				errCh <- fmt.Errorf("API server on %s failed: %w", srv.Addr, err)
+			}
+		}()
+	}
 
 	// Handle graceful shutdown.
 	select {
 	case <-ctx.Done():
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), a.shutdownTimeout)
-		defer cancel()
 		a.logger.Info("Shutting down API server...")
-		_ = srv.Shutdown(shutdownCtx)
+		shutdown()
 		a.logger.Info("Shutdown complete")
 		return ctx.Err()
 	case err := <-errCh:
+		shutdown()
 		return err
 	}
 }
 
+// routeGroupsBaseContext returns the base context for the requests received on a listener, limiting them to the
+// groups of routes it serves. When no groups are given, all routes are served.
+func routeGroupsBaseContext(groups []string) func(net.Listener) context.Context {
+	return func(net.Listener) context.Context {
+		if len(groups) == 0 {
+			return context.Background()
+		}
+		return api.ContextWithRouteGroups(context.Background(), groups)
+	}
+}
+
 // applyCORS applies CORS middleware to the router based on the configured options.
 func (a *APIServer) applyCORS(mux *chi.Mux) {
 	a.logger.Info("Enabling CORS", "origins", a.cors.AllowOrigins)
@@ -185,6 +354,7 @@ func (a *APIServer) applyCORS(mux *chi.Mux) {
 //
 // Mapping guidelines:
 //   - 400: Client errors (bad input, invalid requests)
+//   - 401: Authentication errors (missing or invalid credentials)
 //   - 403: Authorization/permission errors
 //   - 404: Resource not found errors
 //   - 502: External service/dependency failures
@@ -197,6 +367,10 @@ func mapError(logger hclog.Logger, err error) huma.StatusError {
 	switch {
 	case stdErrors.Is(err, errors.ErrBadRequest):
 		return huma.Error400BadRequest(err.Error())
+	case stdErrors.Is(err, errors.ErrUnauthorized):
+		return huma.Error401Unauthorized(err.Error())
+	case stdErrors.Is(err, errors.ErrForbidden):
+		return huma.Error403Forbidden(err.Error())
 	case stdErrors.Is(err, errors.ErrServerNotFound):
 		return huma.Error404NotFound(err.Error())
 	case stdErrors.Is(err, errors.ErrToolsNotFound):
@@ -241,6 +415,33 @@ func mapError(logger hclog.Logger, err error) huma.StatusError {
 		return huma.Error502BadGateway("MCP server error reading resource", err)
 	case stdErrors.Is(err, errors.ErrResourcesNotImplemented):
 		return huma.Error501NotImplemented(err.Error())
+	case stdErrors.Is(err, errors.ErrResourceSubscribeFailed):
+		logger.Error("Resource subscribe failed", "error", err)
+		return huma.Error502BadGateway("MCP server error subscribing to resource", err)
+	case stdErrors.Is(err, errors.ErrResourceSubscriptionsNotImplemented):
+		return huma.Error501NotImplemented(err.Error())
+	case stdErrors.Is(err, errors.ErrCompletionFailed):
+		logger.Error("Completion failed", "error", err)
+		return huma.Error502BadGateway("MCP server error completing argument", err)
+	case stdErrors.Is(err, errors.ErrCompletionsNotImplemented):
+		return huma.Error501NotImplemented(err.Error())
+	case stdErrors.Is(err, errors.ErrLogLevelFailed):
+		logger.Error("Setting log level failed", "error", err)
+		return huma.Error502BadGateway("MCP server error setting log level", err)
+	case stdErrors.Is(err, errors.ErrJobNotFound):
+		return huma.Error404NotFound(err.Error())
+	case stdErrors.Is(err, errors.ErrWorkflowNotFound):
+		return huma.Error404NotFound(err.Error())
+	case stdErrors.Is(err, errors.ErrWorkflowRenderFailed):
+		return huma.Error422UnprocessableEntity(err.Error())
+	case stdErrors.Is(err, errors.ErrToolApprovalRequired):
+		return huma.Error403Forbidden(err.Error())
+	case stdErrors.Is(err, errors.ErrApprovalNotFound):
+		return huma.Error404NotFound(err.Error())
+	case stdErrors.Is(err, errors.ErrApprovalNotPending):
+		return huma.Error409Conflict(err.Error())
+	case stdErrors.Is(err, errors.ErrElicitationNotFound):
+		return huma.Error404NotFound(err.Error())
 	default:
 		logger.Error("Unexpected error interacting with MCP server", "error", err)
 		return huma.Error500InternalServerError("Internal server error", err)
@@ -1,7 +1,14 @@
 package daemon
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -9,6 +16,8 @@ import (
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/require"
 
+	"github.com/mozilla-ai/mcpd/internal/audit"
+	"github.com/mozilla-ai/mcpd/internal/config"
 	"github.com/mozilla-ai/mcpd/internal/errors"
 )
 
@@ -303,6 +312,16 @@ func TestMapError(t *testing.T) {
 			err:            errors.ErrBadRequest,
 			expectedStatus: 400,
 		},
+		{
+			name:           "ErrUnauthorized maps to 401",
+			err:            errors.ErrUnauthorized,
+			expectedStatus: 401,
+		},
+		{
+			name:           "ErrForbidden maps to 403",
+			err:            errors.ErrForbidden,
+			expectedStatus: 403,
+		},
 		{
 			name:           "ErrServerNotFound maps to 404",
 			err:            errors.ErrServerNotFound,
@@ -393,6 +412,66 @@ func TestMapError(t *testing.T) {
 			err:            errors.ErrResourcesNotImplemented,
 			expectedStatus: 501,
 		},
+		{
+			name:           "ErrResourceSubscribeFailed maps to 502",
+			err:            errors.ErrResourceSubscribeFailed,
+			expectedStatus: 502,
+		},
+		{
+			name:           "ErrResourceSubscriptionsNotImplemented maps to 501",
+			err:            errors.ErrResourceSubscriptionsNotImplemented,
+			expectedStatus: 501,
+		},
+		{
+			name:           "ErrCompletionFailed maps to 502",
+			err:            errors.ErrCompletionFailed,
+			expectedStatus: 502,
+		},
+		{
+			name:           "ErrCompletionsNotImplemented maps to 501",
+			err:            errors.ErrCompletionsNotImplemented,
+			expectedStatus: 501,
+		},
+		{
+			name:           "ErrLogLevelFailed maps to 502",
+			err:            errors.ErrLogLevelFailed,
+			expectedStatus: 502,
+		},
+		{
+			name:           "ErrJobNotFound maps to 404",
+			err:            errors.ErrJobNotFound,
+			expectedStatus: 404,
+		},
+		{
+			name:           "ErrWorkflowNotFound maps to 404",
+			err:            errors.ErrWorkflowNotFound,
+			expectedStatus: 404,
+		},
+		{
+			name:           "ErrWorkflowRenderFailed maps to 422",
+			err:            errors.ErrWorkflowRenderFailed,
+			expectedStatus: 422,
+		},
+		{
+			name:           "ErrToolApprovalRequired maps to 403",
+			err:            errors.ErrToolApprovalRequired,
+			expectedStatus: 403,
+		},
+		{
+			name:           "ErrApprovalNotFound maps to 404",
+			err:            errors.ErrApprovalNotFound,
+			expectedStatus: 404,
+		},
+		{
+			name:           "ErrApprovalNotPending maps to 409",
+			err:            errors.ErrApprovalNotPending,
+			expectedStatus: 409,
+		},
+		{
+			name:           "ErrElicitationNotFound maps to 404",
+			err:            errors.ErrElicitationNotFound,
+			expectedStatus: 404,
+		},
 		{
 			name:           "Unknown error maps to 500",
 			err:            fmt.Errorf("unknown error"),
@@ -409,3 +488,162 @@ func TestMapError(t *testing.T) {
 		})
 	}
 }
+
+func TestAPIServer_Start_Listeners(t *testing.T) {
+	t.Parallel()
+
+	dir := socketDir(t)
+	dataAddr := "unix://" + filepath.Join(dir, "data.sock")
+	adminAddr := "unix://" + filepath.Join(dir, "admin.sock")
+
+	deps, err := NewAPIDependencies(
+		hclog.NewNullLogger(),
+		NewClientManager(),
+		NewHealthTracker([]string{"test-server"}),
+		dataAddr,
+	)
+	require.NoError(t, err)
+
+	server, err := NewAPIServer(
+		deps,
+		WithRoutes([]string{config.RouteGroupData}),
+		WithListeners([]config.APIListenerEntry{{Addr: adminAddr, Routes: []string{config.RouteGroupAdmin}}}),
+	)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- server.Start(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		require.ErrorIs(t, <-errCh, context.Canceled)
+	})
+
+	get := func(addr string, path string) int {
+		socket, _ := config.UnixSocketPath(addr)
+		client := &http.Client{Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _ string, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+			},
+		}}
+
+		resp, err := client.Get("http://mcpd/api/v1" + path)
+		if err != nil {
+			return 0
+		}
+		_ = resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	require.Eventually(t, func() bool {
+		return get(adminAddr, "/health/servers") == http.StatusOK
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.Equal(t, http.StatusNotFound, get(dataAddr, "/health/servers"))
+	require.Equal(t, http.StatusOK, get(dataAddr, "/servers"))
+	require.Equal(t, http.StatusNotFound, get(adminAddr, "/servers"))
+}
+
+func TestDaemon_APIOptions_WithListeners(t *testing.T) {
+	t.Parallel()
+
+	opts, err := NewAPIOptions(WithListeners([]config.APIListenerEntry{
+		{Addr: " 127.0.0.1:8091 ", Routes: []string{config.RouteGroupAdmin}},
+	}))
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		[]ListenerConfig{{Addr: "127.0.0.1:8091", Routes: []string{config.RouteGroupAdmin}}},
+		opts.Listeners,
+	)
+
+	_, err = NewAPIOptions(WithListeners([]config.APIListenerEntry{{Addr: "127.0.0.1:8091"}}))
+	require.ErrorContains(t, err, "must serve at least one group of routes")
+
+	_, err = NewAPIOptions(WithListeners([]config.APIListenerEntry{{Addr: "localhost", Routes: []string{"admin"}}}))
+	require.ErrorContains(t, err, "invalid listener address 'localhost'")
+
+	_, err = NewAPIOptions(WithRoutes([]string{"metrics"}))
+	require.ErrorContains(t, err, "unknown route group 'metrics'")
+}
+
+func TestAPIServer_Start_Audit(t *testing.T) {
+	t.Parallel()
+
+	dir := socketDir(t)
+	addr := "unix://" + filepath.Join(dir, "mcpd.sock")
+	auditPath := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	clientManager := NewClientManager()
+	clientManager.Add("test-server", &mockMCPClient{}, []string{"echo"})
+	deps, err := NewAPIDependencies(
+		hclog.NewNullLogger(),
+		clientManager,
+		NewHealthTracker([]string{"test-server"}),
+		addr,
+	)
+	require.NoError(t, err)
+
+	server, err := NewAPIServer(deps, WithAuditConfig(&config.AuditConfigSection{Path: &auditPath}))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- server.Start(ctx) }()
+
+	socket, _ := config.UnixSocketPath(addr)
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _ string, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+		},
+	}}
+
+	require.Eventually(t, func() bool {
+		resp, err := client.Post(
+			"http://mcpd/api/v1/servers/test-server/tools/echo",
+			"application/json",
+			strings.NewReader(`{"text":"hello"}`),
+		)
+		if err != nil {
+			return false
+		}
+		_ = resp.Body.Close()
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.ErrorIs(t, <-errCh, context.Canceled)
+
+	result, err := audit.Verify([]string{auditPath}, audit.VerifyOptions{})
+	require.NoError(t, err)
+	require.Equal(t, audit.VerifyResult{Files: 1, Records: 1}, result)
+
+	data, err := os.ReadFile(auditPath)
+	require.NoError(t, err)
+	var record audit.Record
+	require.NoError(t, json.Unmarshal(data, &record))
+	require.Equal(t, audit.OperationToolCall, record.Operation)
+	require.Equal(t, "test-server", record.Server)
+	require.Equal(t, "echo", record.Tool)
+	require.NotEmpty(t, record.ArgumentsHash)
+	require.Nil(t, record.Arguments)
+}
+
+func TestDaemon_APIOptions_WithAuditConfig(t *testing.T) {
+	t.Parallel()
+
+	opts, err := NewAPIOptions(WithAuditConfig(&config.AuditConfigSection{}))
+	require.NoError(t, err)
+	require.Nil(t, opts.Audit)
+
+	path := "audit.jsonl"
+	opts, err = NewAPIOptions(WithAuditConfig(&config.AuditConfigSection{Path: &path}))
+	require.NoError(t, err)
+	require.NotNil(t, opts.Audit)
+	require.Equal(t, path, opts.Audit.Path)
+	require.Equal(t, config.AuditArgumentsHash, opts.Audit.Arguments)
+
+	mode := "full"
+	_, err = NewAPIOptions(WithAuditConfig(&config.AuditConfigSection{Path: &path, Arguments: &mode}))
+	require.ErrorContains(t, err, "invalid audit configuration: invalid audit arguments 'full'")
+}
@@ -0,0 +1,173 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"os/user"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mozilla-ai/mcpd/internal/config"
+)
+
+const (
+	// defaultSocketMode is the file mode of the API socket when not configured,
+	// only allowing the user running the daemon to connect.
+	defaultSocketMode os.FileMode = 0o600
+
+	// staleSocketDialTimeout is how long to wait when checking whether an existing socket is still in use.
+	staleSocketDialTimeout = 500 * time.Millisecond
+)
+
+// SocketConfig defines the settings for serving the API on a Unix domain socket.
+type SocketConfig struct {
+	// Mode is the file mode of the socket.
+	Mode os.FileMode
+
+	// Owner is the name or numeric ID of the user which owns the socket.
+	// When empty, the owner isn't changed.
+	Owner string
+
+	// Group is the name or numeric ID of the group which owns the socket.
+	// When empty, the group isn't changed.
+	Group string
+}
+
+// listen creates the listener for the API address, which is either a "host:port" TCP address,
+// or a Unix domain socket ("unix:///path/to/mcpd.sock").
+func listen(addr string, socket SocketConfig) (net.Listener, error) {
+	path, ok := config.UnixSocketPath(addr)
+	if !ok {
+		return net.Listen("tcp", addr)
+	}
+
+	return listenUnix(path, socket)
+}
+
+// listenUnix creates a Unix domain socket listener at the path, with the configured file mode and owner.
+// A socket left behind by a daemon which didn't shut down cleanly is removed, while a socket which is still
+// being listened on is left in place.
+func listenUnix(path string, socket SocketConfig) (net.Listener, error) {
+	if err := removeStaleSocket(path); err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applySocketPermissions(path, socket); err != nil {
+		// Closing the listener also removes the socket file.
+		_ = listener.Close()
+		return nil, err
+	}
+
+	return listener, nil
+}
+
+// removeStaleSocket removes the socket at the path when nothing is listening on it.
+// Files which aren't sockets are never removed.
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check for existing socket: %w", err)
+	}
+
+	if info.Mode()&fs.ModeSocket == 0 {
+		return fmt.Errorf("refusing to replace '%s', it exists and isn't a socket", path)
+	}
+
+	conn, err := net.DialTimeout("unix", path, staleSocketDialTimeout)
+	if err == nil {
+		_ = conn.Close()
+		return fmt.Errorf("socket '%s' is already in use, is another daemon running?", path)
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove stale socket: %w", err)
+	}
+
+	return nil
+}
+
+// applySocketPermissions sets the file mode and ownership of the socket at the path.
+func applySocketPermissions(path string, socket SocketConfig) error {
+	mode := socket.Mode
+	if mode == 0 {
+		mode = defaultSocketMode
+	}
+
+	if err := os.Chmod(path, mode); err != nil {
+		return fmt.Errorf("failed to set socket mode: %w", err)
+	}
+
+	if socket.Owner == "" && socket.Group == "" {
+		return nil
+	}
+
+	uid, gid := -1, -1 // Unchanged.
+	if socket.Owner != "" {
+		id, err := lookupID(socket.Owner, func(name string) (string, error) {
+			u, err := user.Lookup(name)
+			if err != nil {
+				return "", err
+			}
+			return u.Uid, nil
+		})
+		if err != nil {
+			return fmt.Errorf("invalid socket owner '%s': %w", socket.Owner, err)
+		}
+		uid = id
+	}
+	if socket.Group != "" {
+		id, err := lookupID(socket.Group, func(name string) (string, error) {
+			g, err := user.LookupGroup(name)
+			if err != nil {
+				return "", err
+			}
+			return g.Gid, nil
+		})
+		if err != nil {
+			return fmt.Errorf("invalid socket group '%s': %w", socket.Group, err)
+		}
+		gid = id
+	}
+
+	if err := os.Chown(path, uid, gid); err != nil {
+		return fmt.Errorf("failed to set socket owner: %w", err)
+	}
+
+	return nil
+}
+
+// lookupID returns the numeric ID for a user or group, given either its ID or its name.
+func lookupID(nameOrID string, lookup func(name string) (string, error)) (int, error) {
+	nameOrID = strings.TrimSpace(nameOrID)
+	if id, err := strconv.Atoi(nameOrID); err == nil {
+		return id, nil
+	}
+
+	id, err := lookup(nameOrID)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(id)
+}
+
+// validateSocketPath checks the path of a Unix domain socket address.
+func validateSocketPath(path string) error {
+	if strings.TrimSpace(path) == "" {
+		return fmt.Errorf("address missing socket path")
+	}
+
+	return nil
+}
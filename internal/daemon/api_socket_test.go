@@ -0,0 +1,112 @@
+package daemon
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/config"
+)
+
+// socketDir returns a short temporary directory for sockets, as socket paths are limited to around 100 characters.
+func socketDir(t *testing.T) string {
+	t.Helper()
+
+	dir, err := os.MkdirTemp("", "mcpd")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	return dir
+}
+
+func TestListen_UnixSocket(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(socketDir(t), "mcpd.sock")
+
+	listener, err := listen("unix://"+path, SocketConfig{Mode: 0o660, Group: strconv.Itoa(os.Getgid())})
+	require.NoError(t, err)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o660), info.Mode().Perm())
+
+	// A socket which is being listened on is left in place.
+	_, err = listen("unix://"+path, SocketConfig{Mode: 0o600})
+	require.ErrorContains(t, err, "is already in use")
+
+	// The socket is removed once the listener is closed.
+	require.NoError(t, listener.Close())
+	_, err = os.Stat(path)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestListen_RemovesStaleSocket(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(socketDir(t), "mcpd.sock")
+
+	// Leave a socket behind, as a daemon which didn't shut down cleanly would.
+	stale, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	require.NoError(t, err)
+	stale.SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+
+	listener, err := listen("unix://"+path, SocketConfig{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, defaultSocketMode, info.Mode().Perm())
+}
+
+func TestListen_RefusesToReplaceFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(socketDir(t), "mcpd.sock")
+	require.NoError(t, os.WriteFile(path, []byte("data"), 0o600))
+
+	_, err := listen("unix://"+path, SocketConfig{})
+	require.ErrorContains(t, err, "exists and isn't a socket")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "data", string(data))
+}
+
+func TestListen_InvalidOwner(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(socketDir(t), "mcpd.sock")
+
+	_, err := listen("unix://"+path, SocketConfig{Owner: "mcpd-no-such-user"})
+	require.ErrorContains(t, err, "invalid socket owner 'mcpd-no-such-user'")
+
+	// The socket isn't left behind.
+	_, err = os.Stat(path)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestDaemon_APIOptions_WithSocketConfig(t *testing.T) {
+	t.Parallel()
+
+	opts, err := NewAPIOptions()
+	require.NoError(t, err)
+	require.Equal(t, SocketConfig{Mode: defaultSocketMode}, opts.Socket)
+
+	opts, err = NewAPIOptions(WithSocketConfig(&config.APISocketConfigSection{
+		Mode:  testStringPtr("0660"),
+		Owner: testStringPtr("mcpd"),
+		Group: testStringPtr("1000"),
+	}))
+	require.NoError(t, err)
+	require.Equal(t, SocketConfig{Mode: 0o660, Owner: "mcpd", Group: "1000"}, opts.Socket)
+
+	_, err = NewAPIOptions(WithSocketConfig(&config.APISocketConfigSection{Mode: testStringPtr("rwx")}))
+	require.ErrorContains(t, err, "invalid socket configuration")
+}
@@ -0,0 +1,114 @@
+package daemon
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/mozilla-ai/mcpd/internal/api"
+)
+
+// TLSConfig defines the settings for serving the API over TLS.
+type TLSConfig struct {
+	// CertFile is the path of the PEM encoded certificate (chain) presented by the API server.
+	CertFile string
+
+	// KeyFile is the path of the PEM encoded private key of the certificate.
+	KeyFile string
+
+	// MinVersion is the minimum TLS version accepted (e.g. tls.VersionTLS12).
+	MinVersion uint16
+
+	// ClientCAFile is the path of the PEM encoded CA certificates which client certificates are verified against.
+	// When empty, client certificates aren't requested.
+	ClientCAFile string
+
+	// ClientAuth determines whether clients must present a certificate when ClientCAFile is set.
+	ClientAuth tls.ClientAuthType
+}
+
+// serverTLS provides the TLS configuration of the API server, with a certificate which can be reloaded while the
+// server is running (e.g. once it has been renewed).
+// newServerTLS should be used to create instances of serverTLS.
+type serverTLS struct {
+	cfg       TLSConfig
+	clientCAs *x509.CertPool
+
+	mu   sync.RWMutex
+	cert *tls.Certificate
+}
+
+// newServerTLS loads the certificate and client CAs, ensuring they are valid before the server is started.
+func newServerTLS(cfg TLSConfig) (*serverTLS, error) {
+	s := &serverTLS{cfg: cfg}
+
+	if err := s.reload(); err != nil {
+		return nil, err
+	}
+
+	if cfg.ClientCAFile != "" {
+		data, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read TLS client CA file: %w", err)
+		}
+
+		s.clientCAs = x509.NewCertPool()
+		if !s.clientCAs.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("TLS client CA file contains no PEM encoded certificates: %s", cfg.ClientCAFile)
+		}
+	}
+
+	return s, nil
+}
+
+// reload replaces the certificate with the one loaded from the certificate and key files.
+// The current certificate is kept when loading fails.
+func (s *serverTLS) reload() error {
+	cert, err := tls.LoadX509KeyPair(s.cfg.CertFile, s.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cert = &cert
+
+	return nil
+}
+
+// config returns the TLS configuration for the API server.
+func (s *serverTLS) config() *tls.Config {
+	cfg := &tls.Config{
+		MinVersion: s.cfg.MinVersion,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			s.mu.RLock()
+			defer s.mu.RUnlock()
+			return s.cert, nil
+		},
+	}
+
+	if s.clientCAs != nil {
+		cfg.ClientCAs = s.clientCAs
+		cfg.ClientAuth = s.cfg.ClientAuth
+	}
+
+	return cfg
+}
+
+// clientCertMiddleware sets the api.HeaderClientCertSubject header of requests to the subject of their verified
+// client certificate, so plugins can make decisions based on the caller.
+// Any value sent by the client is removed, so the header can't be spoofed.
+func clientCertMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Header.Del(api.HeaderClientCertSubject)
+
+		if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 && len(r.TLS.VerifiedChains[0]) > 0 {
+			r.Header.Set(api.HeaderClientCertSubject, r.TLS.VerifiedChains[0][0].Subject.String())
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
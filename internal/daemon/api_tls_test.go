@@ -0,0 +1,256 @@
+package daemon
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/api"
+	"github.com/mozilla-ai/mcpd/internal/config"
+)
+
+// testCA issues certificates for tests.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "mcpd test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns a PEM encoded certificate and key for the subject, signed by the CA.
+func (ca *testCA) issue(t *testing.T, subject pkix.Name, usage x509.ExtKeyUsage) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      subject,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// writeServerCert issues a server certificate with the common name, writing it and its key to the files.
+func (ca *testCA) writeServerCert(t *testing.T, commonName string, certFile string, keyFile string) {
+	t.Helper()
+
+	certPEM, keyPEM := ca.issue(t, pkix.Name{CommonName: commonName}, x509.ExtKeyUsageServerAuth)
+	require.NoError(t, os.WriteFile(certFile, certPEM, 0o600))
+	require.NoError(t, os.WriteFile(keyFile, keyPEM, 0o600))
+}
+
+// serveTLS serves the handler over TLS on a local port, returning its URL.
+func serveTLS(t *testing.T, s *serverTLS, handler http.Handler) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := &http.Server{
+		Handler:           handler,
+		TLSConfig:         s.config(),
+		ReadHeaderTimeout: time.Second,
+		ErrorLog:          log.New(io.Discard, "", 0), // Handshakes are expected to fail.
+	}
+	go func() { _ = srv.ServeTLS(listener, "", "") }()
+	t.Cleanup(func() { _ = srv.Close() })
+
+	return "https://" + listener.Addr().String()
+}
+
+// tlsClient returns a client trusting the CA, presenting the client certificate when given.
+func tlsClient(t *testing.T, ca *testCA, certPEM []byte, keyPEM []byte) *http.Client {
+	t.Helper()
+
+	roots := x509.NewCertPool()
+	require.True(t, roots.AppendCertsFromPEM(ca.pem))
+
+	cfg := &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+	if certPEM != nil {
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		require.NoError(t, err)
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	// Connections aren't reused, so each request sees the server's current certificate.
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: cfg, DisableKeepAlives: true}}
+}
+
+func TestServerTLS_MutualTLS(t *testing.T) {
+	t.Parallel()
+
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	caFile := filepath.Join(dir, "ca.crt")
+	ca.writeServerCert(t, "mcpd-1", certFile, keyFile)
+	require.NoError(t, os.WriteFile(caFile, ca.pem, 0o600))
+
+	s, err := newServerTLS(TLSConfig{
+		CertFile:     certFile,
+		KeyFile:      keyFile,
+		MinVersion:   tls.VersionTLS12,
+		ClientCAFile: caFile,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	})
+	require.NoError(t, err)
+
+	url := serveTLS(t, s, clientCertMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, r.Header.Get(api.HeaderClientCertSubject))
+	})))
+
+	clientCert, clientKey := ca.issue(
+		t,
+		pkix.Name{CommonName: "agent", Organization: []string{"Example"}},
+		x509.ExtKeyUsageClientAuth,
+	)
+	client := tlsClient(t, ca, clientCert, clientKey)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	req.Header.Set(api.HeaderClientCertSubject, "CN=spoofed")
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, "CN=agent,O=Example", string(body))
+	require.Equal(t, "mcpd-1", resp.TLS.PeerCertificates[0].Subject.CommonName)
+
+	// Clients without a certificate are rejected during the handshake.
+	_, err = tlsClient(t, ca, nil, nil).Get(url)
+	require.Error(t, err)
+
+	// The renewed certificate is presented once reloaded.
+	ca.writeServerCert(t, "mcpd-2", certFile, keyFile)
+	require.NoError(t, s.reload())
+
+	resp, err = client.Get(url)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, "mcpd-2", resp.TLS.PeerCertificates[0].Subject.CommonName)
+
+	// The current certificate is kept when reloading fails.
+	require.NoError(t, os.WriteFile(keyFile, []byte("invalid"), 0o600))
+	require.ErrorContains(t, s.reload(), "failed to load TLS certificate")
+
+	resp, err = client.Get(url)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, "mcpd-2", resp.TLS.PeerCertificates[0].Subject.CommonName)
+}
+
+func TestNewServerTLS_InvalidFiles(t *testing.T) {
+	t.Parallel()
+
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	ca.writeServerCert(t, "mcpd", certFile, keyFile)
+
+	_, err := newServerTLS(TLSConfig{CertFile: certFile, KeyFile: filepath.Join(dir, "missing.key")})
+	require.ErrorContains(t, err, "failed to load TLS certificate")
+
+	caFile := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, []byte("not a certificate"), 0o600))
+
+	_, err = newServerTLS(TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: caFile})
+	require.ErrorContains(t, err, "TLS client CA file contains no PEM encoded certificates")
+}
+
+func TestClientCertMiddleware_RemovesSpoofedHeader(t *testing.T) {
+	t.Parallel()
+
+	var got []string
+	handler := clientCertMiddleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		got = r.Header.Values(api.HeaderClientCertSubject)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/servers", nil)
+	req.Header.Set(api.HeaderClientCertSubject, "CN=spoofed")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	require.Empty(t, got)
+}
+
+func TestDaemon_APIOptions_WithTLSConfig(t *testing.T) {
+	t.Parallel()
+
+	opts, err := NewAPIOptions(WithTLSConfig(&config.APITLSConfigSection{
+		CertFile:     testStringPtr("/etc/mcpd/tls.crt"),
+		KeyFile:      testStringPtr("/etc/mcpd/tls.key"),
+		MinVersion:   testStringPtr(config.TLSVersion13),
+		ClientCAFile: testStringPtr("/etc/mcpd/ca.crt"),
+		ClientAuth:   testStringPtr(config.ClientAuthOptional),
+	}))
+	require.NoError(t, err)
+	require.Equal(t, &TLSConfig{
+		CertFile:     "/etc/mcpd/tls.crt",
+		KeyFile:      "/etc/mcpd/tls.key",
+		MinVersion:   tls.VersionTLS13,
+		ClientCAFile: "/etc/mcpd/ca.crt",
+		ClientAuth:   tls.VerifyClientCertIfGiven,
+	}, opts.TLS)
+
+	_, err = NewAPIOptions(WithTLSConfig(&config.APITLSConfigSection{}))
+	require.ErrorContains(t, err, "invalid TLS configuration")
+}
+
+func testStringPtr(s string) *string {
+	return &s
+}
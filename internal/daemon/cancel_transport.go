@@ -0,0 +1,74 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// cancelNotificationTimeout bounds how long sending a cancellation notification may take.
+const cancelNotificationTimeout = 5 * time.Second
+
+// stderrProvider is implemented by transports which expose the stderr of the MCP server process.
+type stderrProvider interface {
+	Stderr() io.Reader
+}
+
+// cancellingTransport wraps an MCP transport so that requests abandoned by the caller
+// (context canceled or deadline exceeded) are reported to the server via notifications/cancelled.
+// The underlying mcp-go client stops waiting for the response, but does not tell the server.
+type cancellingTransport struct {
+	transport.BidirectionalInterface
+}
+
+// newCancellingTransport wraps the supplied transport.
+func newCancellingTransport(t transport.BidirectionalInterface) *cancellingTransport {
+	return &cancellingTransport{BidirectionalInterface: t}
+}
+
+// SendRequest sends the request and notifies the server if the caller gives up before a response arrives.
+func (c *cancellingTransport) SendRequest(
+	ctx context.Context,
+	request transport.JSONRPCRequest,
+) (*transport.JSONRPCResponse, error) {
+	resp, err := c.BidirectionalInterface.SendRequest(ctx, request)
+	if err == nil || ctx.Err() == nil || request.Method == string(mcp.MethodInitialize) {
+		return resp, err
+	}
+
+	// Use a detached context, the request context is already done.
+	notifyCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cancelNotificationTimeout)
+	defer cancel()
+
+	reason := "request cancelled"
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		reason = "request timed out"
+	}
+
+	_ = c.SendNotification(notifyCtx, mcp.JSONRPCNotification{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		Notification: mcp.Notification{
+			Method: string(mcp.MethodNotificationCancelled),
+			Params: mcp.NotificationParams{
+				AdditionalFields: map[string]any{
+					"requestId": request.ID.Value(),
+					"reason":    reason,
+				},
+			},
+		},
+	})
+
+	return resp, err
+}
+
+// Stderr exposes the stderr of the wrapped transport, when available, so client.GetStderr keeps working.
+func (c *cancellingTransport) Stderr() io.Reader {
+	if s, ok := c.BidirectionalInterface.(stderrProvider); ok {
+		return s.Stderr()
+	}
+	return nil
+}
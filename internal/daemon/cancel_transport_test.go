@@ -0,0 +1,135 @@
+package daemon
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockTransport is a transport whose requests block until the context is done, or respond immediately.
+type mockTransport struct {
+	block bool
+
+	mu            sync.Mutex
+	notifications []mcp.JSONRPCNotification
+}
+
+func (m *mockTransport) Start(context.Context) error { return nil }
+
+func (m *mockTransport) SendRequest(
+	ctx context.Context,
+	_ transport.JSONRPCRequest,
+) (*transport.JSONRPCResponse, error) {
+	if !m.block {
+		return &transport.JSONRPCResponse{}, nil
+	}
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (m *mockTransport) SendNotification(_ context.Context, n mcp.JSONRPCNotification) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.notifications = append(m.notifications, n)
+	return nil
+}
+
+func (m *mockTransport) SetNotificationHandler(func(mcp.JSONRPCNotification)) {}
+
+func (m *mockTransport) SetRequestHandler(transport.RequestHandler) {}
+
+func (m *mockTransport) Close() error { return nil }
+
+func (m *mockTransport) GetSessionId() string { return "" }
+
+func (m *mockTransport) sent() []mcp.JSONRPCNotification {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.notifications
+}
+
+func TestCancellingTransport_SendRequest(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		method         string
+		block          bool
+		ctx            func() (context.Context, context.CancelFunc)
+		expectCancel   bool
+		expectedReason string
+	}{
+		{
+			name:   "completed request",
+			method: string(mcp.MethodToolsCall),
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithCancel(context.Background())
+			},
+		},
+		{
+			name:   "cancelled request",
+			method: string(mcp.MethodToolsCall),
+			block:  true,
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				time.AfterFunc(10*time.Millisecond, cancel)
+				return ctx, cancel
+			},
+			expectCancel:   true,
+			expectedReason: "request cancelled",
+		},
+		{
+			name:   "timed out request",
+			method: string(mcp.MethodToolsCall),
+			block:  true,
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 10*time.Millisecond)
+			},
+			expectCancel:   true,
+			expectedReason: "request timed out",
+		},
+		{
+			name:   "initialize is never cancelled",
+			method: string(mcp.MethodInitialize),
+			block:  true,
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 10*time.Millisecond)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			inner := &mockTransport{block: tc.block}
+			ct := newCancellingTransport(inner)
+
+			ctx, cancel := tc.ctx()
+			defer cancel()
+
+			_, _ = ct.SendRequest(ctx, transport.JSONRPCRequest{
+				JSONRPC: mcp.JSONRPC_VERSION,
+				ID:      mcp.NewRequestId(int64(42)),
+				Method:  tc.method,
+			})
+
+			sent := inner.sent()
+			if !tc.expectCancel {
+				assert.Empty(t, sent)
+				return
+			}
+
+			require.Len(t, sent, 1)
+			assert.Equal(t, string(mcp.MethodNotificationCancelled), sent[0].Method)
+			assert.Equal(t, int64(42), sent[0].Params.AdditionalFields["requestId"])
+			assert.Equal(t, tc.expectedReason, sent[0].Params.AdditionalFields["reason"])
+		})
+	}
+}
@@ -0,0 +1,331 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/mozilla-ai/mcpd/internal/contracts"
+	"github.com/mozilla-ai/mcpd/internal/domain"
+	"github.com/mozilla-ai/mcpd/internal/filter"
+)
+
+const (
+	// catalogTools is the tools listing.
+	catalogTools catalogKind = iota
+
+	// catalogPrompts is the prompts listing.
+	catalogPrompts
+
+	// catalogResources covers both the resources and resource templates listings.
+	catalogResources
+)
+
+// catalogKind identifies a listing that is cached for an MCP server.
+type catalogKind int
+
+// CatalogCache caches the tools, prompts, and resources listings of MCP servers.
+// Listings are fetched (following pagination) when a server is tracked, and refreshed when
+// the server sends a list_changed notification or Refresh is called (e.g. on reload).
+// It is safe for concurrent use by multiple goroutines.
+// NewCatalogCache should be used to create instances of CatalogCache.
+type CatalogCache struct {
+	logger        hclog.Logger
+	notifications contracts.MCPNotificationSubscriber
+
+	// timeout bounds how long refreshing the listings of a server may take.
+	timeout time.Duration
+
+	// now returns the current time, replaceable for tests.
+	now func() time.Time
+
+	mu      sync.RWMutex
+	servers map[string]*catalogEntry
+
+	// onToolsRefreshed is called with the server name after its tools listing is refreshed successfully.
+	onToolsRefreshed func(name string)
+}
+
+// catalogEntry holds the cached listings for a single server.
+type catalogEntry struct {
+	name        string
+	client      client.MCPClient
+	unsubscribe func()
+
+	// refreshMu serializes refreshes so that results are applied in the order they were requested.
+	refreshMu sync.Mutex
+
+	// catalog is guarded by the CatalogCache mutex.
+	catalog domain.ServerCatalog
+}
+
+// NewCatalogCache creates an empty CatalogCache.
+// When notifications is nil, listings are only refreshed when Refresh is called.
+func NewCatalogCache(
+	logger hclog.Logger,
+	notifications contracts.MCPNotificationSubscriber,
+	timeout time.Duration,
+) *CatalogCache {
+	return &CatalogCache{
+		logger:        logger,
+		notifications: notifications,
+		timeout:       timeout,
+		now:           func() time.Time { return time.Now().UTC() },
+		servers:       make(map[string]*catalogEntry),
+	}
+}
+
+// Track starts caching the listings of the named server, replacing any existing entry for the server.
+// Listings are fetched before returning, failures are recorded against the listing and returned.
+func (c *CatalogCache) Track(ctx context.Context, name string, mcpClient client.MCPClient) error {
+	name = filter.NormalizeString(name)
+
+	entry := &catalogEntry{
+		name:        name,
+		client:      mcpClient,
+		unsubscribe: func() {},
+	}
+	if c.notifications != nil {
+		entry.unsubscribe = c.notifications.Subscribe(name, func(n mcp.JSONRPCNotification) {
+			c.handleNotification(name, entry, n)
+		})
+	}
+
+	c.mu.Lock()
+	previous := c.servers[name]
+	c.servers[name] = entry
+	c.mu.Unlock()
+
+	if previous != nil {
+		previous.unsubscribe()
+	}
+
+	return c.refresh(ctx, entry, catalogTools, catalogPrompts, catalogResources)
+}
+
+// Refresh fetches all listings for the named server.
+// Failures are recorded against the listing (retaining the previously cached items) and returned.
+func (c *CatalogCache) Refresh(ctx context.Context, name string) error {
+	c.mu.RLock()
+	entry, ok := c.servers[filter.NormalizeString(name)]
+	c.mu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("server '%s' not found", name)
+	}
+
+	return c.refresh(ctx, entry, catalogTools, catalogPrompts, catalogResources)
+}
+
+// Remove stops caching the listings of the named server.
+func (c *CatalogCache) Remove(name string) {
+	name = filter.NormalizeString(name)
+
+	c.mu.Lock()
+	entry, ok := c.servers[name]
+	delete(c.servers, name)
+	c.mu.Unlock()
+
+	if ok {
+		entry.unsubscribe()
+	}
+}
+
+// OnToolsRefreshed registers a function which is called with the server name
+// after the server's tools listing is refreshed successfully (e.g. when tracked, or on a list_changed notification).
+// The function is called synchronously from the refresh, so it can read the refreshed listing.
+func (c *CatalogCache) OnToolsRefreshed(fn func(name string)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.onToolsRefreshed = fn
+}
+
+// Catalog returns the cached listings for the given server name.
+// The server name is normalized for case-insensitive lookup.
+// It returns a boolean to indicate whether the server is tracked.
+// Items are replaced rather than modified on refresh, so the returned listings can be read safely.
+func (c *CatalogCache) Catalog(name string) (domain.ServerCatalog, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.servers[filter.NormalizeString(name)]
+	if !ok {
+		return domain.ServerCatalog{}, false
+	}
+
+	return entry.catalog, true
+}
+
+// handleNotification refreshes the listing a list_changed notification refers to.
+// Notification handlers must not block, so the refresh happens in the background.
+func (c *CatalogCache) handleNotification(name string, entry *catalogEntry, n mcp.JSONRPCNotification) {
+	var kind catalogKind
+	switch n.Method {
+	case mcp.MethodNotificationToolsListChanged:
+		kind = catalogTools
+	case mcp.MethodNotificationPromptsListChanged:
+		kind = catalogPrompts
+	case mcp.MethodNotificationResourcesListChanged:
+		kind = catalogResources
+	default:
+		return
+	}
+
+	go func() {
+		if err := c.refresh(context.Background(), entry, kind); err != nil {
+			c.logger.Warn("Failed to refresh listing after change notification", "server", name, "error", err)
+			return
+		}
+		c.logger.Debug("Refreshed listing after change notification", "server", name, "method", n.Method)
+	}()
+}
+
+// refresh fetches the requested listings and applies the results to the entry.
+func (c *CatalogCache) refresh(ctx context.Context, entry *catalogEntry, kinds ...catalogKind) error {
+	entry.refreshMu.Lock()
+	defer entry.refreshMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var errs error
+	for _, kind := range kinds {
+		switch kind {
+		case catalogTools:
+			tools, err := listAll(ctx, func(ctx context.Context, cursor mcp.Cursor) ([]mcp.Tool, mcp.Cursor, error) {
+				req := mcp.ListToolsRequest{}
+				req.Params.Cursor = cursor
+				result, err := entry.client.ListTools(ctx, req)
+				if err != nil || result == nil {
+					return nil, "", resultErr(err)
+				}
+				return result.Tools, result.NextCursor, nil
+			})
+			if err := applyListing(c, &entry.catalog.Tools, tools, err, "tools"); err != nil {
+				errs = errors.Join(errs, err)
+				continue
+			}
+			c.toolsRefreshed(entry.name)
+		case catalogPrompts:
+			prompts, err := listAll(ctx, func(ctx context.Context, cursor mcp.Cursor) ([]mcp.Prompt, mcp.Cursor, error) {
+				req := mcp.ListPromptsRequest{}
+				req.Params.Cursor = cursor
+				result, err := entry.client.ListPrompts(ctx, req)
+				if err != nil || result == nil {
+					return nil, "", resultErr(err)
+				}
+				return result.Prompts, result.NextCursor, nil
+			})
+			errs = errors.Join(errs, applyListing(c, &entry.catalog.Prompts, prompts, err, "prompts"))
+		case catalogResources:
+			resources, err := listAll(ctx, func(ctx context.Context, cursor mcp.Cursor) ([]mcp.Resource, mcp.Cursor, error) {
+				req := mcp.ListResourcesRequest{}
+				req.Params.Cursor = cursor
+				result, err := entry.client.ListResources(ctx, req)
+				if err != nil || result == nil {
+					return nil, "", resultErr(err)
+				}
+				return result.Resources, result.NextCursor, nil
+			})
+			errs = errors.Join(errs, applyListing(c, &entry.catalog.Resources, resources, err, "resources"))
+
+			templates, err := listAll(
+				ctx,
+				func(ctx context.Context, cursor mcp.Cursor) ([]mcp.ResourceTemplate, mcp.Cursor, error) {
+					req := mcp.ListResourceTemplatesRequest{}
+					req.Params.Cursor = cursor
+					result, err := entry.client.ListResourceTemplates(ctx, req)
+					if err != nil || result == nil {
+						return nil, "", resultErr(err)
+					}
+					return result.ResourceTemplates, result.NextCursor, nil
+				},
+			)
+			errs = errors.Join(errs, applyListing(c, &entry.catalog.ResourceTemplates, templates, err, "resource templates"))
+		}
+	}
+
+	return errs
+}
+
+// toolsRefreshed notifies the registered function (if any) that the tools listing of the server was refreshed.
+func (c *CatalogCache) toolsRefreshed(name string) {
+	c.mu.RLock()
+	fn := c.onToolsRefreshed
+	c.mu.RUnlock()
+
+	if fn != nil {
+		fn(name)
+	}
+}
+
+// applyListing records the outcome of fetching a listing.
+func applyListing[T any](c *CatalogCache, listing *domain.CatalogListing[T], items []T, err error, label string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := updateListing(listing, items, err, c.now()); err != nil {
+		return fmt.Errorf("failed to list %s: %w", label, err)
+	}
+
+	return nil
+}
+
+// updateListing updates a listing with the outcome of a refresh.
+// Servers that don't implement the listing are recorded as unsupported rather than failed.
+// When the refresh failed, the previously cached items are retained.
+func updateListing[T any](listing *domain.CatalogListing[T], items []T, err error, now time.Time) error {
+	switch {
+	case errors.Is(err, mcp.ErrMethodNotFound):
+		*listing = domain.CatalogListing[T]{Unsupported: true, RefreshedAt: &now}
+		return nil
+	case err != nil:
+		listing.Err = err
+		return err
+	default:
+		*listing = domain.CatalogListing[T]{Items: items, RefreshedAt: &now}
+		return nil
+	}
+}
+
+// listAll fetches every page of a listing by following the pagination cursor.
+func listAll[T any](
+	ctx context.Context,
+	fetch func(ctx context.Context, cursor mcp.Cursor) ([]T, mcp.Cursor, error),
+) ([]T, error) {
+	var all []T
+	var cursor mcp.Cursor
+	seen := make(map[mcp.Cursor]struct{})
+
+	for {
+		items, next, err := fetch(ctx, cursor)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+
+		if next == "" {
+			return all, nil
+		}
+		if _, ok := seen[next]; ok {
+			return nil, fmt.Errorf("server returned a repeated pagination cursor: %s", next)
+		}
+		seen[next] = struct{}{}
+		cursor = next
+	}
+}
+
+// resultErr returns the error from a list request, or an error describing a missing result.
+func resultErr(err error) error {
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("no result")
+}
@@ -0,0 +1,266 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// catalogMCPClient is a mock client that serves paginated tools, and configurable prompts and resources.
+type catalogMCPClient struct {
+	mockMCPClient
+
+	mu sync.Mutex
+
+	// toolPages maps a cursor to the page of tool names returned for it.
+	toolPages map[mcp.Cursor][]string
+
+	// nextCursors maps a cursor to the cursor of the page that follows it.
+	nextCursors map[mcp.Cursor]mcp.Cursor
+
+	// annotations maps a tool name to the annotations returned for it.
+	annotations map[string]mcp.ToolAnnotation
+
+	prompts    []mcp.Prompt
+	promptsErr error
+}
+
+func newCatalogMCPClient() *catalogMCPClient {
+	return &catalogMCPClient{
+		toolPages: map[mcp.Cursor][]string{
+			"":      {"tool1", "tool2"},
+			"page2": {"tool3"},
+		},
+		nextCursors: map[mcp.Cursor]mcp.Cursor{"": "page2"},
+		prompts:     []mcp.Prompt{{Name: "prompt1"}},
+	}
+}
+
+func (m *catalogMCPClient) ListTools(_ context.Context, req mcp.ListToolsRequest) (*mcp.ListToolsResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	page, ok := m.toolPages[req.Params.Cursor]
+	if !ok {
+		return nil, fmt.Errorf("unknown cursor: %s", req.Params.Cursor)
+	}
+
+	result := &mcp.ListToolsResult{}
+	result.NextCursor = m.nextCursors[req.Params.Cursor]
+	for _, name := range page {
+		result.Tools = append(result.Tools, mcp.Tool{Name: name, Annotations: m.annotations[name]})
+	}
+
+	return result, nil
+}
+
+func (m *catalogMCPClient) ListPrompts(context.Context, mcp.ListPromptsRequest) (*mcp.ListPromptsResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.promptsErr != nil {
+		return nil, m.promptsErr
+	}
+	return &mcp.ListPromptsResult{Prompts: m.prompts}, nil
+}
+
+func (m *catalogMCPClient) ListResources(context.Context, mcp.ListResourcesRequest) (*mcp.ListResourcesResult, error) {
+	return nil, fmt.Errorf("%w: resources/list", mcp.ErrMethodNotFound)
+}
+
+func (m *catalogMCPClient) ListResourceTemplates(
+	context.Context,
+	mcp.ListResourceTemplatesRequest,
+) (*mcp.ListResourceTemplatesResult, error) {
+	return nil, fmt.Errorf("%w: resources/templates/list", mcp.ErrMethodNotFound)
+}
+
+func (m *catalogMCPClient) setTools(names ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.toolPages = map[mcp.Cursor][]string{"": names}
+	m.nextCursors = nil
+}
+
+func (m *catalogMCPClient) setAnnotations(annotations map[string]mcp.ToolAnnotation) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.annotations = annotations
+}
+
+func toolNames(tools []mcp.Tool) []string {
+	names := make([]string, 0, len(tools))
+	for _, tool := range tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+func TestCatalogCache_TrackFollowsPagination(t *testing.T) {
+	t.Parallel()
+
+	cache := NewCatalogCache(hclog.NewNullLogger(), nil, time.Second)
+	mockClient := newCatalogMCPClient()
+
+	require.NoError(t, cache.Track(context.Background(), "Time", mockClient))
+
+	catalog, ok := cache.Catalog("time")
+	require.True(t, ok)
+
+	require.True(t, catalog.Tools.Cached())
+	assert.Equal(t, []string{"tool1", "tool2", "tool3"}, toolNames(catalog.Tools.Items))
+	assert.NoError(t, catalog.Tools.Err)
+
+	require.True(t, catalog.Prompts.Cached())
+	assert.Equal(t, "prompt1", catalog.Prompts.Items[0].Name)
+
+	// Servers which don't implement a listing are recorded as unsupported, not failed.
+	assert.True(t, catalog.Resources.Unsupported)
+	assert.NoError(t, catalog.Resources.Err)
+	assert.True(t, catalog.ResourceTemplates.Unsupported)
+
+	_, ok = cache.Catalog("other")
+	require.False(t, ok)
+}
+
+func TestCatalogCache_RepeatedCursor(t *testing.T) {
+	t.Parallel()
+
+	cache := NewCatalogCache(hclog.NewNullLogger(), nil, time.Second)
+	mockClient := newCatalogMCPClient()
+	mockClient.nextCursors["page2"] = "page2"
+
+	err := cache.Track(context.Background(), "time", mockClient)
+	require.ErrorContains(t, err, "repeated pagination cursor")
+
+	catalog, ok := cache.Catalog("time")
+	require.True(t, ok)
+	assert.False(t, catalog.Tools.Cached())
+	assert.Error(t, catalog.Tools.Err)
+}
+
+func TestCatalogCache_RefreshFailureRetainsItems(t *testing.T) {
+	t.Parallel()
+
+	cache := NewCatalogCache(hclog.NewNullLogger(), nil, time.Second)
+	mockClient := newCatalogMCPClient()
+	require.NoError(t, cache.Track(context.Background(), "time", mockClient))
+
+	before, _ := cache.Catalog("time")
+
+	mockClient.mu.Lock()
+	mockClient.promptsErr = errors.New("server unavailable")
+	mockClient.mu.Unlock()
+	mockClient.setTools("tool4")
+
+	err := cache.Refresh(context.Background(), "time")
+	require.ErrorContains(t, err, "failed to list prompts: server unavailable")
+
+	catalog, _ := cache.Catalog("time")
+	assert.Equal(t, []string{"tool4"}, toolNames(catalog.Tools.Items))
+	assert.NoError(t, catalog.Tools.Err)
+
+	// The failed listing keeps the previous items and refresh time, and records the error.
+	assert.Equal(t, before.Prompts.Items, catalog.Prompts.Items)
+	assert.Equal(t, before.Prompts.RefreshedAt, catalog.Prompts.RefreshedAt)
+	assert.EqualError(t, catalog.Prompts.Err, "server unavailable")
+
+	require.Error(t, cache.Refresh(context.Background(), "unknown"))
+}
+
+func TestCatalogCache_ListChangedNotificationRefreshes(t *testing.T) {
+	t.Parallel()
+
+	broker := NewNotificationBroker()
+	cache := NewCatalogCache(hclog.NewNullLogger(), broker, time.Second)
+	mockClient := newCatalogMCPClient()
+	require.NoError(t, cache.Track(context.Background(), "time", mockClient))
+
+	mockClient.setTools("tool4", "tool5")
+
+	// Unrelated notifications don't trigger a refresh.
+	broker.Publish("time", mcp.JSONRPCNotification{
+		Notification: mcp.Notification{Method: string(mcp.MethodNotificationProgress)},
+	})
+	broker.Publish("time", mcp.JSONRPCNotification{
+		Notification: mcp.Notification{Method: mcp.MethodNotificationToolsListChanged},
+	})
+
+	require.Eventually(t, func() bool {
+		catalog, _ := cache.Catalog("time")
+		return len(catalog.Tools.Items) == 2 && catalog.Tools.Items[0].Name == "tool4"
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestCatalogCache_OnToolsRefreshed(t *testing.T) {
+	t.Parallel()
+
+	broker := NewNotificationBroker()
+	cache := NewCatalogCache(hclog.NewNullLogger(), broker, time.Second)
+
+	var mu sync.Mutex
+	var refreshed []string
+	cache.OnToolsRefreshed(func(name string) {
+		mu.Lock()
+		defer mu.Unlock()
+		refreshed = append(refreshed, name)
+	})
+
+	mockClient := newCatalogMCPClient()
+	require.NoError(t, cache.Track(context.Background(), "Time", mockClient))
+
+	mu.Lock()
+	require.Equal(t, []string{"time"}, refreshed)
+	mu.Unlock()
+
+	// Refreshing other listings doesn't notify.
+	broker.Publish("time", mcp.JSONRPCNotification{
+		Notification: mcp.Notification{Method: mcp.MethodNotificationPromptsListChanged},
+	})
+	broker.Publish("time", mcp.JSONRPCNotification{
+		Notification: mcp.Notification{Method: mcp.MethodNotificationToolsListChanged},
+	})
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(refreshed) == 2
+	}, 2*time.Second, 10*time.Millisecond)
+
+	// Failed refreshes don't notify.
+	mockClient.mu.Lock()
+	mockClient.toolPages = nil
+	mockClient.mu.Unlock()
+	require.Error(t, cache.Refresh(context.Background(), "time"))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, refreshed, 2)
+}
+
+func TestCatalogCache_Remove(t *testing.T) {
+	t.Parallel()
+
+	broker := NewNotificationBroker()
+	cache := NewCatalogCache(hclog.NewNullLogger(), broker, time.Second)
+	require.NoError(t, cache.Track(context.Background(), "time", newCatalogMCPClient()))
+
+	cache.Remove("TIME")
+
+	_, ok := cache.Catalog("time")
+	require.False(t, ok)
+	assert.Empty(t, broker.handlers)
+
+	// Removing an unknown server is a no-op.
+	cache.Remove("time")
+}
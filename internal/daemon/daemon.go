@@ -9,6 +9,8 @@ import (
 	"log/slog"
 	"net"
 	"net/http"
+	"os"
+	"os/exec"
 	"slices"
 	"strconv"
 	"strings"
@@ -21,12 +23,15 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/mozilla-ai/mcpd/internal/api"
 	"github.com/mozilla-ai/mcpd/internal/cmd"
+	"github.com/mozilla-ai/mcpd/internal/config"
 	"github.com/mozilla-ai/mcpd/internal/contracts"
 	"github.com/mozilla-ai/mcpd/internal/domain"
 	"github.com/mozilla-ai/mcpd/internal/filter"
 	"github.com/mozilla-ai/mcpd/internal/plugin"
 	"github.com/mozilla-ai/mcpd/internal/runtime"
+	"github.com/mozilla-ai/mcpd/internal/sampling"
 )
 
 // Daemon manages MCP server lifecycles, client connections, and health monitoring.
@@ -36,9 +41,16 @@ type Daemon struct {
 	logger            hclog.Logger
 	clientManager     contracts.MCPClientAccessor
 	healthTracker     contracts.MCPHealthMonitor
+	notifications     *NotificationBroker
+	serverConfigs     *ServerConfigStore
+	catalog           *CatalogCache
+	processes         *ProcessTracker
+	logLevels         *ServerLogLevels
 	supportedRuntimes map[runtime.Runtime]struct{}
 	runtimeServers    []runtime.Server
 	pluginManager     *plugin.Manager
+	sampling          *sampling.Forwarder
+	elicitations      *api.ElicitationStore
 
 	// clientInitTimeout is the time allowed for MCP servers to initialize.
 	clientInitTimeout time.Duration
@@ -160,6 +172,11 @@ func NewDaemon(deps Dependencies, opt ...Option) (*Daemon, error) {
 
 	healthTracker := NewHealthTracker(serverNames)
 	clientManager := NewClientManager()
+	notifications := NewNotificationBroker()
+	serverConfigs := NewServerConfigStore(deps.RuntimeServers)
+	catalog := NewCatalogCache(deps.Logger.Named("catalog"), notifications, opts.ClientInitTimeout)
+	processes := NewProcessTracker()
+	logLevels := NewServerLogLevels(clientManager, processes)
 	apiDeps, err := NewAPIDependencies(
 		deps.Logger,
 		clientManager,
@@ -172,7 +189,16 @@ func NewDaemon(deps Dependencies, opt ...Option) (*Daemon, error) {
 
 	// Initialize plugin manager if config and directory are provided.
 	var pluginManager *plugin.Manager
-	apiOptions := opts.APIOptions
+	elicitations := api.NewElicitationStore(deps.Logger.Named("api"), api.DefaultElicitationTimeout())
+	apiOptions := append(
+		slices.Clone(opts.APIOptions),
+		WithNotificationSubscriber(notifications),
+		WithServerConfigAccessor(serverConfigs),
+		WithCatalogAccessor(catalog),
+		WithServerProcessAccessor(processes),
+		WithLogLevelController(logLevels),
+		WithElicitationStore(elicitations),
+	)
 	if opts.PluginConfig != nil && opts.PluginConfig.Dir != "" {
 		pluginManager, err = plugin.NewManager(deps.Logger, opts.PluginConfig)
 		if err != nil {
@@ -192,19 +218,41 @@ func NewDaemon(deps Dependencies, opt ...Option) (*Daemon, error) {
 		return nil, fmt.Errorf("failed to create daemon API server: %w", err)
 	}
 
-	return &Daemon{
+	// Forward sampling requests from MCP servers when sampling is configured.
+	var samplingForwarder *sampling.Forwarder
+	if opts.SamplingConfig != nil {
+		samplingLogger := deps.Logger.Named("sampling")
+		samplingForwarder, err = sampling.NewForwarder(samplingLogger, opts.SamplingConfig, serverConfigs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create sampling forwarder: %w", err)
+		}
+	}
+
+	d := &Daemon{
 		logger:                    deps.Logger.Named("daemon"),
 		clientManager:             clientManager,
 		healthTracker:             healthTracker,
+		notifications:             notifications,
+		serverConfigs:             serverConfigs,
+		catalog:                   catalog,
+		processes:                 processes,
+		logLevels:                 logLevels,
 		apiServer:                 apiServer,
 		supportedRuntimes:         runtime.DefaultSupportedRuntimes(),
 		runtimeServers:            deps.RuntimeServers,
 		pluginManager:             pluginManager,
+		sampling:                  samplingForwarder,
+		elicitations:              elicitations,
 		clientInitTimeout:         opts.ClientInitTimeout,
 		clientShutdownTimeout:     opts.ClientShutdownTimeout,
 		clientHealthCheckTimeout:  opts.ClientHealthCheckTimeout,
 		clientHealthCheckInterval: opts.ClientHealthCheckInterval,
-	}, nil
+	}
+
+	// Resolve tool allowlists whenever the tools offered by a server are (re)discovered.
+	catalog.OnToolsRefreshed(d.updateAllowedTools)
+
+	return d, nil
 }
 
 // StartAndManage is a long-running method that starts configured MCP servers, and the API.
@@ -279,6 +327,12 @@ func (d *Daemon) startMCPServer(ctx context.Context, server runtime.Server) erro
 	}
 
 	logger := d.logger.Named("mcp").Named(server.Name())
+	if server.LogLevel != "" {
+		logger.SetLevel(mcpToHclogLevel(mcp.LoggingLevel(server.LogLevel)))
+	}
+	if d.logLevels != nil {
+		d.logLevels.Add(server.Name(), logger)
+	}
 
 	pkg := server.PackageName()
 	ver := server.PackageVersion()
@@ -323,15 +377,54 @@ func (d *Daemon) startMCPServer(ctx context.Context, server runtime.Server) erro
 	logger.Debug("attempting to start server", "binary", runtimeBinary)
 
 	mcpLogger := slog.New(newHclogSlogHandler(logger.Named("transport")))
-	stdioClient, err := client.NewStdioMCPClientWithOptions(
+
+	// Keep hold of the command (built as the transport would by default), so the server's process can be reported.
+	var serverCmd *exec.Cmd
+	stdioTransport := transport.NewStdioWithOptions(
 		runtimeBinary,
 		environ,
 		args,
 		transport.WithCommandLogger(mcpLogger),
+		transport.WithCommandFunc(func(ctx context.Context, bin string, env, args []string) (*exec.Cmd, error) {
+			serverCmd = exec.CommandContext(ctx, bin, args...)
+			serverCmd.Env = append(os.Environ(), env...)
+			return serverCmd, nil
+		}),
 	)
-	if err != nil {
+	if err := stdioTransport.Start(context.Background()); err != nil {
 		return fmt.Errorf("error starting MCP server: '%s': %w", server.Name(), err)
 	}
+	startedAt := time.Now()
+
+	var clientOpts []client.ClientOption
+	if d.serverConfigs != nil {
+		// Roots are looked up per request, servers are notified when they change on reload.
+		clientOpts = append(clientOpts, client.WithRootsHandler(newRootsHandler(server.Name(), d.serverConfigs)))
+	}
+	if d.elicitations != nil {
+		// Requests for user input are held until they are responded to via the API.
+		clientOpts = append(clientOpts, client.WithElicitationHandler(d.elicitations.Handler(server.Name())))
+	}
+	if d.sampling != nil {
+		// Servers which aren't allowed to sample are still offered the capability, so that enabling sampling
+		// for them doesn't require a restart. Their requests are rejected by the handler.
+		clientOpts = append(clientOpts, client.WithSamplingHandler(d.sampling.Handler(server.Name())))
+	}
+
+	stdioClient := client.NewClient(newCancellingTransport(stdioTransport), clientOpts...)
+
+	// Starting the client (the transport is already running) installs the notification dispatch.
+	if err := stdioClient.Start(context.Background()); err != nil {
+		return fmt.Errorf("error starting MCP client: '%s': %w", server.Name(), err)
+	}
+
+	serverName := server.Name()
+	stdioClient.OnNotification(func(notification mcp.JSONRPCNotification) {
+		if notification.Method == string(mcp.MethodNotificationMessage) {
+			logServerMessage(logger, notification)
+		}
+		d.notifications.Publish(serverName, notification)
+	})
 
 	logger.Info("Started")
 
@@ -389,8 +482,35 @@ func (d *Daemon) startMCPServer(ctx context.Context, server runtime.Server) erro
 	)
 
 	// Store and track the client.
-	d.clientManager.Add(server.Name(), stdioClient, server.Tools)
+	// Until the server's tools are listed, only the tools named exactly in the allowlist are allowed.
+	d.clientManager.Add(server.Name(), stdioClient, d.allowedTools(server.ServerEntry))
 	d.healthTracker.Add(server.Name())
+	if d.processes != nil {
+		process := domain.ServerProcess{
+			Info:            initResult.ServerInfo,
+			ProtocolVersion: initResult.ProtocolVersion,
+			Capabilities:    initResult.Capabilities,
+			Instructions:    initResult.Instructions,
+			StartedAt:       startedAt,
+		}
+		if serverCmd != nil && serverCmd.Process != nil {
+			process.PID = serverCmd.Process.Pid
+		}
+		d.processes.Add(server.Name(), process)
+	}
+
+	// Request the configured log level, now the server's capabilities are known.
+	if server.LogLevel != "" && d.logLevels != nil {
+		d.applyLogLevel(ctx, server.Name(), server.LogLevel)
+	}
+
+	// Cache the listings (tools, prompts, resources) offered by the server.
+	// Failures are not fatal, they are visible via the API and retried when the server notifies of changes.
+	if d.catalog != nil {
+		if err := d.catalog.Track(ctx, server.Name(), stdioClient); err != nil {
+			logger.Warn("Failed to cache server listings", "error", err)
+		}
+	}
 
 	logger.Info("Ready!")
 
@@ -525,8 +645,13 @@ func (d *Daemon) pingAllServers(ctx context.Context, maxTimeout time.Duration) e
 	}
 }
 
-// IsValidAddr returns an error if the address is not a valid "host:port" string.
+// IsValidAddr returns an error if the address is not a valid "host:port" string,
+// or Unix domain socket ("unix:///path").
 func IsValidAddr(addr string) error {
+	if path, ok := config.UnixSocketPath(addr); ok {
+		return validateSocketPath(path)
+	}
+
 	host, port, err := net.SplitHostPort(addr)
 	if err != nil {
 		return fmt.Errorf("invalid address format: %w", err)
@@ -654,6 +779,12 @@ func (d *Daemon) closeClientWithTimeout(name string, c client.MCPClient, timeout
 	}
 }
 
+// ReloadTLS reloads the TLS certificate presented by the API server from its files.
+// The current certificate is kept when reloading fails.
+func (d *Daemon) ReloadTLS() error {
+	return d.apiServer.ReloadTLS()
+}
+
 // ReloadServers reloads the daemon's MCP servers based on a new configuration.
 // It compares the current servers with the new configuration and:
 // - Stops servers that have been removed
@@ -697,7 +828,7 @@ func (d *Daemon) ReloadServers(ctx context.Context, newServers []runtime.Server)
 	var toAdd []*runtime.Server
 	var toUpdateTools []*runtime.Server
 	var toRestart []*runtime.Server
-	var unchangedCount int
+	var unchanged []*runtime.Server
 
 	// Find servers to remove (in current but not in new).
 	for name := range existing {
@@ -715,7 +846,7 @@ func (d *Daemon) ReloadServers(ctx context.Context, newServers []runtime.Server)
 			toAdd = append(toAdd, srv)
 		case existingSrv.Equals(srv):
 			// No changes
-			unchangedCount++
+			unchanged = append(unchanged, srv)
 		case existingSrv.EqualsExceptTools(srv):
 			// Only tools changed
 			toUpdateTools = append(toUpdateTools, srv)
@@ -725,12 +856,18 @@ func (d *Daemon) ReloadServers(ctx context.Context, newServers []runtime.Server)
 		}
 	}
 
+	// Update the stored configuration before applying the changes,
+	// so that tool listings refreshed during the reload are resolved against the new allowlists.
+	if d.serverConfigs != nil {
+		d.serverConfigs.Replace(newServers)
+	}
+
 	d.logger.Info("Server configuration changes",
 		"removed", len(toRemove),
 		"added", len(toAdd),
 		"tools_updated", len(toUpdateTools),
 		"restarted", len(toRestart),
-		"unchanged", unchangedCount)
+		"unchanged", len(unchanged))
 
 	var errs []error
 
@@ -740,15 +877,19 @@ func (d *Daemon) ReloadServers(ctx context.Context, newServers []runtime.Server)
 			d.logger.Error("Failed to stop server", "server", name, "error", err)
 			errs = append(errs, fmt.Errorf("stop %s: %w", name, err))
 		}
+		if d.processes != nil {
+			d.processes.Forget(name)
+		}
 	}
 
 	// Update tools for servers with tools-only changes.
 	for _, srv := range toUpdateTools {
-		if err := d.clientManager.UpdateTools(srv.Name(), srv.Tools); err != nil {
+		tools := d.allowedTools(srv.ServerEntry)
+		if err := d.clientManager.UpdateTools(srv.Name(), tools); err != nil {
 			d.logger.Error("Failed to update tools", "server", srv.Name(), "error", err)
 			errs = append(errs, fmt.Errorf("update-tools %s: %w", srv.Name(), err))
 		} else {
-			d.logger.Info("Updated tools", "server", srv.Name(), "tools", srv.Tools)
+			d.logger.Info("Updated tools", "server", srv.Name(), "tools", tools)
 		}
 	}
 
@@ -764,6 +905,9 @@ func (d *Daemon) ReloadServers(ctx context.Context, newServers []runtime.Server)
 		}
 
 		// Start the server with new configuration.
+		if d.processes != nil {
+			d.processes.Restarted(srv.Name())
+		}
 		if err := d.startMCPServer(ctx, *srv); err != nil {
 			d.logger.Error("Failed to start server after restart", "server", srv.Name(), "error", err)
 			errs = append(errs, fmt.Errorf("restart-start %s: %w", srv.Name(), err))
@@ -778,6 +922,23 @@ func (d *Daemon) ReloadServers(ctx context.Context, newServers []runtime.Server)
 		}
 	}
 
+	// Notify servers that kept running when their roots changed, (re)started servers list them on start.
+	// Log levels are applied to them in the same way.
+	for _, srv := range slices.Concat(unchanged, toUpdateTools) {
+		previous := existing[filter.NormalizeString(srv.Name())]
+		d.notifyRootsChanged(ctx, previous, srv)
+		d.updateLogLevel(ctx, previous, srv)
+	}
+
+	// Refresh cached listings for servers that kept running, (re)started servers were refreshed on start.
+	if d.catalog != nil {
+		for _, srv := range slices.Concat(unchanged, toUpdateTools) {
+			if err := d.catalog.Refresh(ctx, srv.Name()); err != nil {
+				d.logger.Warn("Failed to refresh server listings", "server", srv.Name(), "error", err)
+			}
+		}
+	}
+
 	// Update stored runtime servers after reload (even if some operations failed).
 	d.runtimeServers = newServers
 
@@ -790,6 +951,54 @@ func (d *Daemon) ReloadServers(ctx context.Context, newServers []runtime.Server)
 	return nil
 }
 
+// allowedTools resolves the server's tools allowlist against the tools it offers, as cached in the catalog.
+// When the server's tools are unknown, only the tools named exactly in the allowlist are allowed.
+func (d *Daemon) allowedTools(entry config.ServerEntry) []string {
+	var available []string
+	var hints map[string]*config.ToolHints
+	if d.catalog != nil {
+		if c, ok := d.catalog.Catalog(entry.Name); ok && c.Tools.Cached() {
+			available = make([]string, 0, len(c.Tools.Items))
+			hints = make(map[string]*config.ToolHints, len(c.Tools.Items))
+			for _, tool := range c.Tools.Items {
+				available = append(available, tool.Name)
+				hints[filter.NormalizeString(tool.Name)] = &config.ToolHints{
+					ReadOnly:    tool.Annotations.ReadOnlyHint,
+					Destructive: tool.Annotations.DestructiveHint,
+					OpenWorld:   tool.Annotations.OpenWorldHint,
+				}
+			}
+		}
+	}
+
+	// Apply the tool policy, tools with unknown hints (i.e. before the server's tools are listed) are
+	// held to the defaults defined by the MCP specification.
+	return slices.DeleteFunc(entry.AllowedTools(available), func(tool string) bool {
+		return !entry.ToolPermitted(tool, hints[tool])
+	})
+}
+
+// updateAllowedTools resolves the tools allowlist of the named server against the tools it currently offers.
+// It is called whenever the server's tools listing is refreshed (e.g. on start, reload, or list_changed).
+func (d *Daemon) updateAllowedTools(name string) {
+	if d.serverConfigs == nil {
+		return
+	}
+
+	entry, ok := d.serverConfigs.ServerConfig(name)
+	if !ok {
+		return
+	}
+
+	tools := d.allowedTools(entry)
+	if err := d.clientManager.UpdateTools(name, tools); err != nil {
+		d.logger.Warn("Failed to update allowed tools", "server", name, "error", err)
+		return
+	}
+
+	d.logger.Debug("Resolved allowed tools", "server", name, "tools", tools)
+}
+
 // stopMCPServer gracefully stops a single MCP server and removes it from tracking.
 func (d *Daemon) stopMCPServer(name string) error {
 	d.logger.Info("Stopping MCP server", "server", name)
@@ -802,6 +1011,15 @@ func (d *Daemon) stopMCPServer(name string) error {
 	// Always remove from managers to maintain consistency.
 	d.clientManager.Remove(name)
 	d.healthTracker.Remove(name)
+	if d.catalog != nil {
+		d.catalog.Remove(name)
+	}
+	if d.processes != nil {
+		d.processes.Remove(name)
+	}
+	if d.logLevels != nil {
+		d.logLevels.Remove(name)
+	}
 
 	// Close the client with timeout.
 	if closed := d.closeClientWithTimeout(name, c, d.clientShutdownTimeout); !closed {
@@ -27,6 +27,9 @@ type Options struct {
 
 	// PluginConfig specifies the configuration for plugins.
 	PluginConfig *config.PluginConfig
+
+	// SamplingConfig specifies how sampling requests from MCP servers are fulfilled.
+	SamplingConfig *config.SamplingConfig
 }
 
 // Option defines a functional option for configuring Options.
@@ -111,6 +114,14 @@ func WithPluginConfig(cfg *config.PluginConfig) Option {
 	}
 }
 
+// WithSamplingConfig configures how sampling requests from MCP servers are fulfilled.
+func WithSamplingConfig(cfg *config.SamplingConfig) Option {
+	return func(o *Options) error {
+		o.SamplingConfig = cfg
+		return nil
+	}
+}
+
 // DefaultClientInitTimeout is the default time to wait for MCP server initialization.
 func DefaultClientInitTimeout() time.Duration {
 	return 30 * time.Second
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"slices"
 	"strings"
 	"sync"
 	"testing"
@@ -341,6 +342,8 @@ func TestIsValidAddr(t *testing.T) {
 		{"missing host and port", "", true},
 		{"missing host with invalid port", ":!@#", true},
 		{"host only colon", "host:", true},
+		{"valid unix socket", "unix:///run/mcpd/mcpd.sock", false},
+		{"unix socket missing path", "unix://", true},
 	}
 
 	for _, tc := range tests {
@@ -975,6 +978,90 @@ func TestDaemon_CloseAllClients_Direct(t *testing.T) {
 	assert.GreaterOrEqual(t, found, 5, "Should have logged all expected messages")
 }
 
+func TestDaemon_UpdateAllowedTools(t *testing.T) {
+	t.Parallel()
+
+	servers := []runtime.Server{
+		{
+			ServerEntry: config.ServerEntry{
+				Name:      "github",
+				Package:   "uvx::github-server@1.0.0",
+				Tools:     []string{"list_*", "create_issue", "missing_tool"},
+				ToolsDeny: []string{"list_secrets"},
+			},
+		},
+	}
+	deps, err := NewDependencies(hclog.NewNullLogger(), ":8082", servers)
+	require.NoError(t, err)
+	daemon, err := NewDaemon(deps)
+	require.NoError(t, err)
+
+	// Before the server's tools are known, only exact names are allowed.
+	daemon.clientManager.Add("github", &mockMCPClient{}, daemon.allowedTools(servers[0].ServerEntry))
+	tools, ok := daemon.clientManager.Tools("github")
+	require.True(t, ok)
+	require.Equal(t, []string{"create_issue", "missing_tool"}, tools)
+
+	// Tracking the server lists its tools, which resolves the patterns.
+	mockClient := newCatalogMCPClient()
+	mockClient.setTools("list_issues", "list_repos", "list_secrets", "create_issue", "delete_repo")
+	require.NoError(t, daemon.catalog.Track(context.Background(), "github", mockClient))
+
+	tools, ok = daemon.clientManager.Tools("github")
+	require.True(t, ok)
+	require.Equal(t, []string{"create_issue", "list_issues", "list_repos"}, tools)
+
+	// Tools added by the server are resolved when it notifies of the change.
+	mockClient.setTools("list_issues", "list_pulls", "create_issue")
+	daemon.notifications.Publish("github", mcp.JSONRPCNotification{
+		Notification: mcp.Notification{Method: mcp.MethodNotificationToolsListChanged},
+	})
+
+	require.Eventually(t, func() bool {
+		tools, _ := daemon.clientManager.Tools("github")
+		return slices.Equal([]string{"create_issue", "list_issues", "list_pulls"}, tools)
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestDaemon_UpdateAllowedTools_ToolPolicy(t *testing.T) {
+	t.Parallel()
+
+	servers := []runtime.Server{
+		{
+			ServerEntry: config.ServerEntry{
+				Name:       "github",
+				Package:    "uvx::github-server@1.0.0",
+				Tools:      []string{"*", "create_issue"},
+				ToolPolicy: &config.ToolPolicy{BlockDestructive: mcp.ToBoolPtr(true)},
+			},
+		},
+	}
+	deps, err := NewDependencies(hclog.NewNullLogger(), ":8082", servers)
+	require.NoError(t, err)
+	daemon, err := NewDaemon(deps)
+	require.NoError(t, err)
+
+	// Before the server's tools are known, they're assumed to be destructive unless explicitly allowlisted.
+	daemon.clientManager.Add("github", &mockMCPClient{}, daemon.allowedTools(servers[0].ServerEntry))
+	tools, ok := daemon.clientManager.Tools("github")
+	require.True(t, ok)
+	require.Equal(t, []string{"create_issue"}, tools)
+
+	mockClient := newCatalogMCPClient()
+	mockClient.setTools("list_issues", "create_issue", "delete_repo", "update_issue")
+	mockClient.setAnnotations(map[string]mcp.ToolAnnotation{
+		"list_issues":  {ReadOnlyHint: mcp.ToBoolPtr(true)},
+		"create_issue": {DestructiveHint: mcp.ToBoolPtr(true)},
+		"delete_repo":  {DestructiveHint: mcp.ToBoolPtr(true)},
+		"update_issue": {DestructiveHint: mcp.ToBoolPtr(false)},
+	})
+	require.NoError(t, daemon.catalog.Track(context.Background(), "github", mockClient))
+
+	tools, ok = daemon.clientManager.Tools("github")
+	require.True(t, ok)
+	require.Equal(t, []string{"create_issue", "list_issues", "update_issue"}, tools)
+}
+
 // TestDaemon_CloseAllClients_EmptyManager tests behavior with no clients
 func TestDaemon_CloseAllClients_EmptyManager(t *testing.T) {
 	t.Parallel()
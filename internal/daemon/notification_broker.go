@@ -0,0 +1,68 @@
+package daemon
+
+import (
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/mozilla-ai/mcpd/internal/filter"
+)
+
+// NotificationBroker fans out notifications received from MCP servers to subscribers.
+// NewNotificationBroker should be used to initialize this type.
+type NotificationBroker struct {
+	mu       sync.RWMutex
+	nextID   uint64
+	handlers map[string]map[uint64]func(mcp.JSONRPCNotification)
+}
+
+// NewNotificationBroker creates a NotificationBroker with no subscribers.
+func NewNotificationBroker() *NotificationBroker {
+	return &NotificationBroker{
+		handlers: make(map[string]map[uint64]func(mcp.JSONRPCNotification)),
+	}
+}
+
+// Subscribe registers a handler for notifications sent by the named server.
+// It returns a function that removes the handler, which is safe to call more than once.
+func (b *NotificationBroker) Subscribe(name string, handler func(mcp.JSONRPCNotification)) func() {
+	name = filter.NormalizeString(name)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	id := b.nextID
+
+	if _, ok := b.handlers[name]; !ok {
+		b.handlers[name] = make(map[uint64]func(mcp.JSONRPCNotification))
+	}
+	b.handlers[name][id] = handler
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		delete(b.handlers[name], id)
+		if len(b.handlers[name]) == 0 {
+			delete(b.handlers, name)
+		}
+	}
+}
+
+// Publish delivers a notification from the named server to all of its subscribers.
+// Handlers are called synchronously and should not block.
+func (b *NotificationBroker) Publish(name string, notification mcp.JSONRPCNotification) {
+	name = filter.NormalizeString(name)
+
+	b.mu.RLock()
+	handlers := make([]func(mcp.JSONRPCNotification), 0, len(b.handlers[name]))
+	for _, h := range b.handlers[name] {
+		handlers = append(handlers, h)
+	}
+	b.mu.RUnlock()
+
+	for _, h := range handlers {
+		h(notification)
+	}
+}
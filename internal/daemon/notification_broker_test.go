@@ -0,0 +1,49 @@
+package daemon
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationBroker_PublishToServerSubscribers(t *testing.T) {
+	t.Parallel()
+
+	broker := NewNotificationBroker()
+
+	var timeReceived, otherReceived []string
+	broker.Subscribe("Time", func(n mcp.JSONRPCNotification) {
+		timeReceived = append(timeReceived, n.Method)
+	})
+	broker.Subscribe("other", func(n mcp.JSONRPCNotification) {
+		otherReceived = append(otherReceived, n.Method)
+	})
+
+	broker.Publish(" time ", mcp.JSONRPCNotification{
+		Notification: mcp.Notification{Method: string(mcp.MethodNotificationProgress)},
+	})
+
+	require.Equal(t, []string{string(mcp.MethodNotificationProgress)}, timeReceived)
+	assert.Empty(t, otherReceived)
+}
+
+func TestNotificationBroker_Unsubscribe(t *testing.T) {
+	t.Parallel()
+
+	broker := NewNotificationBroker()
+
+	calls := 0
+	unsubscribe := broker.Subscribe("time", func(mcp.JSONRPCNotification) {
+		calls++
+	})
+
+	broker.Publish("time", mcp.JSONRPCNotification{})
+	unsubscribe()
+	unsubscribe() // Safe to call more than once.
+	broker.Publish("time", mcp.JSONRPCNotification{})
+
+	assert.Equal(t, 1, calls)
+	assert.Empty(t, broker.handlers)
+}
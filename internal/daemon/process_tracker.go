@@ -0,0 +1,73 @@
+package daemon
+
+import (
+	"sync"
+
+	"github.com/mozilla-ai/mcpd/internal/domain"
+	"github.com/mozilla-ai/mcpd/internal/filter"
+)
+
+// ProcessTracker records the details of running MCP server processes, and how often each server is restarted.
+// It is safe for concurrent use by multiple goroutines.
+// NewProcessTracker should be used to create instances of ProcessTracker.
+type ProcessTracker struct {
+	mu        sync.RWMutex
+	processes map[string]domain.ServerProcess
+	restarts  map[string]int
+}
+
+// NewProcessTracker creates an empty ProcessTracker.
+func NewProcessTracker() *ProcessTracker {
+	return &ProcessTracker{
+		processes: make(map[string]domain.ServerProcess),
+		restarts:  make(map[string]int),
+	}
+}
+
+// Add records the process of a started server.
+// The server name is normalized for case-insensitive lookup.
+func (t *ProcessTracker) Add(name string, process domain.ServerProcess) {
+	name = filter.NormalizeString(name)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.processes[name] = process
+}
+
+// Remove forgets the process of a stopped server, its restart count is retained.
+func (t *ProcessTracker) Remove(name string) {
+	name = filter.NormalizeString(name)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.processes, name)
+}
+
+// Restarted counts a restart of the server.
+func (t *ProcessTracker) Restarted(name string) {
+	name = filter.NormalizeString(name)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.restarts[name]++
+}
+
+// Forget removes all records of a server which is no longer configured, including its restart count.
+func (t *ProcessTracker) Forget(name string) {
+	name = filter.NormalizeString(name)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.processes, name)
+	delete(t.restarts, name)
+}
+
+// ServerProcess returns the details of the process for the given server name, including its restart count.
+// It returns a boolean to indicate whether the server is running.
+func (t *ProcessTracker) ServerProcess(name string) (domain.ServerProcess, bool) {
+	name = filter.NormalizeString(name)
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	process, ok := t.processes[name]
+	if !ok {
+		return domain.ServerProcess{}, false
+	}
+	process.Restarts = t.restarts[name]
+	return process, true
+}
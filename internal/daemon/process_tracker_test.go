@@ -0,0 +1,44 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/domain"
+)
+
+func TestProcessTracker(t *testing.T) {
+	t.Parallel()
+
+	tracker := NewProcessTracker()
+	_, ok := tracker.ServerProcess("time")
+	require.False(t, ok)
+
+	startedAt := time.Now()
+	tracker.Add("Time", domain.ServerProcess{PID: 100, StartedAt: startedAt})
+
+	process, ok := tracker.ServerProcess(" time ")
+	require.True(t, ok)
+	require.Equal(t, domain.ServerProcess{PID: 100, StartedAt: startedAt}, process)
+
+	// Restarts are counted across the server's processes.
+	tracker.Remove("time")
+	tracker.Restarted("time")
+	_, ok = tracker.ServerProcess("time")
+	require.False(t, ok)
+
+	tracker.Add("time", domain.ServerProcess{PID: 200})
+	process, ok = tracker.ServerProcess("time")
+	require.True(t, ok)
+	require.Equal(t, 200, process.PID)
+	require.Equal(t, 1, process.Restarts)
+
+	// Servers which are no longer configured start counting again.
+	tracker.Forget("time")
+	tracker.Add("time", domain.ServerProcess{PID: 300})
+	process, ok = tracker.ServerProcess("time")
+	require.True(t, ok)
+	require.Equal(t, 0, process.Restarts)
+}
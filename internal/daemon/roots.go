@@ -0,0 +1,71 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/mozilla-ai/mcpd/internal/runtime"
+)
+
+// rootsHandler answers roots/list requests from an MCP server with the roots currently configured for it.
+type rootsHandler struct {
+	server string
+	store  *ServerConfigStore
+}
+
+// rootsListChangeNotifier is implemented by MCP clients which can notify servers that their roots changed.
+type rootsListChangeNotifier interface {
+	RootListChanges(ctx context.Context) error
+}
+
+// newRootsHandler returns a handler for roots/list requests from the named server.
+// Roots are looked up per request, so changes applied on reload are reflected without restarting the server.
+func newRootsHandler(server string, store *ServerConfigStore) client.RootsHandler {
+	return &rootsHandler{server: server, store: store}
+}
+
+// ListRoots implements client.RootsHandler.
+func (h *rootsHandler) ListRoots(_ context.Context, _ mcp.ListRootsRequest) (*mcp.ListRootsResult, error) {
+	roots, ok := h.store.Roots(h.server)
+	if !ok {
+		return nil, fmt.Errorf("server '%s' is not configured", h.server)
+	}
+
+	result := &mcp.ListRootsResult{Roots: make([]mcp.Root, 0, len(roots))}
+	for _, r := range roots {
+		result.Roots = append(result.Roots, mcp.Root{URI: r.URI(), Name: r.Name})
+	}
+
+	return result, nil
+}
+
+// notifyRootsChanged sends notifications/roots/list_changed to the server when its roots differ between the
+// previous and current configuration.
+// Returns true when a notification was sent.
+func (d *Daemon) notifyRootsChanged(ctx context.Context, previous *runtime.Server, current *runtime.Server) bool {
+	if slices.Equal(previous.Roots(), current.Roots()) {
+		return false
+	}
+
+	c, ok := d.clientManager.Client(current.Name())
+	if !ok {
+		return false
+	}
+
+	notifier, ok := c.(rootsListChangeNotifier)
+	if !ok {
+		return false
+	}
+
+	if err := notifier.RootListChanges(ctx); err != nil {
+		d.logger.Warn("Failed to notify server of roots change", "server", current.Name(), "error", err)
+		return false
+	}
+
+	d.logger.Info("Notified server of roots change", "server", current.Name())
+	return true
+}
@@ -0,0 +1,82 @@
+package daemon
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/runtime"
+)
+
+// mockMCPClientWithRoots records the roots list-changed notifications it sends.
+type mockMCPClientWithRoots struct {
+	mockMCPClient
+	notified atomic.Int32
+}
+
+func (m *mockMCPClientWithRoots) RootListChanges(_ context.Context) error {
+	m.notified.Add(1)
+	return nil
+}
+
+func TestRootsHandler_ListRoots(t *testing.T) {
+	t.Parallel()
+
+	store := NewServerConfigStore([]runtime.Server{
+		{ServerEntry: config.ServerEntry{Name: "filesystem", Roots: []string{"/Users/foo/repos"}}},
+	})
+
+	result, err := newRootsHandler("Filesystem", store).ListRoots(context.Background(), mcp.ListRootsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []mcp.Root{{URI: "file:///Users/foo/repos", Name: "repos"}}, result.Roots)
+
+	// Roots changed on reload are reflected in subsequent requests.
+	store.Replace([]runtime.Server{
+		{ServerEntry: config.ServerEntry{Name: "filesystem", Package: "uvx::mcp-server-filesystem@1.0.0"}},
+	})
+	result, err = newRootsHandler("filesystem", store).ListRoots(context.Background(), mcp.ListRootsRequest{})
+	require.NoError(t, err)
+	require.Empty(t, result.Roots)
+
+	_, err = newRootsHandler("github", store).ListRoots(context.Background(), mcp.ListRootsRequest{})
+	require.EqualError(t, err, "server 'github' is not configured")
+}
+
+func TestDaemon_NotifyRootsChanged(t *testing.T) {
+	t.Parallel()
+
+	previous := runtime.Server{
+		ServerEntry: config.ServerEntry{
+			Name:    "filesystem",
+			Package: "uvx::mcp-server-filesystem@1.0.0",
+			Tools:   []string{"read_file"},
+			Roots:   []string{"/Users/foo/repos"},
+		},
+	}
+	deps, err := NewDependencies(hclog.NewNullLogger(), ":8083", []runtime.Server{previous})
+	require.NoError(t, err)
+	daemon, err := NewDaemon(deps)
+	require.NoError(t, err)
+
+	mockClient := &mockMCPClientWithRoots{}
+	daemon.clientManager.Add("filesystem", mockClient, []string{"read_file"})
+
+	// Unchanged roots aren't notified.
+	unchanged := previous
+	require.False(t, daemon.notifyRootsChanged(context.Background(), &previous, &unchanged))
+	require.Equal(t, int32(0), mockClient.notified.Load())
+
+	current := previous
+	current.ServerEntry.Roots = []string{"/Users/foo/repos", "/Users/foo/notes"}
+	require.True(t, daemon.notifyRootsChanged(context.Background(), &previous, &current))
+	require.Equal(t, int32(1), mockClient.notified.Load())
+
+	// Servers which aren't running can't be notified.
+	current.ServerEntry.Name = "github"
+	require.False(t, daemon.notifyRootsChanged(context.Background(), &previous, &current))
+}
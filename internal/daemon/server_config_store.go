@@ -0,0 +1,64 @@
+package daemon
+
+import (
+	"sync"
+
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/filter"
+	"github.com/mozilla-ai/mcpd/internal/runtime"
+)
+
+// ServerConfigStore holds the current configuration of MCP servers, so that settings which don't require
+// a server restart (e.g. timeouts, roots) can be looked up at request time, and are refreshed on reload.
+// It is safe for concurrent use by multiple goroutines.
+// NewServerConfigStore should be used to create instances of ServerConfigStore.
+type ServerConfigStore struct {
+	mu      sync.RWMutex
+	configs map[string]config.ServerEntry
+	roots   map[string][]runtime.Root
+}
+
+// NewServerConfigStore creates a ServerConfigStore holding the configuration of the supplied servers.
+func NewServerConfigStore(servers []runtime.Server) *ServerConfigStore {
+	s := &ServerConfigStore{}
+	s.Replace(servers)
+	return s
+}
+
+// ServerConfig returns the configuration for the given server name.
+// The server name is normalized for case-insensitive lookup.
+// It returns a boolean to indicate whether the configuration was found.
+func (s *ServerConfigStore) ServerConfig(name string) (config.ServerEntry, bool) {
+	name = filter.NormalizeString(name)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.configs[name]
+	return entry, ok
+}
+
+// Roots returns the roots offered to the given server, see runtime.Server.Roots.
+// The server name is normalized for case-insensitive lookup.
+// It returns a boolean to indicate whether the server was found.
+func (s *ServerConfigStore) Roots(name string) ([]runtime.Root, bool) {
+	name = filter.NormalizeString(name)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	roots, ok := s.roots[name]
+	return roots, ok
+}
+
+// Replace swaps the stored configuration for that of the supplied servers.
+func (s *ServerConfigStore) Replace(servers []runtime.Server) {
+	configs := make(map[string]config.ServerEntry, len(servers))
+	roots := make(map[string][]runtime.Root, len(servers))
+	for _, srv := range servers {
+		name := filter.NormalizeString(srv.Name())
+		configs[name] = srv.ServerEntry
+		roots[name] = srv.Roots()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.configs = configs
+	s.roots = roots
+}
@@ -0,0 +1,63 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/runtime"
+)
+
+func TestServerConfigStore_ServerConfig(t *testing.T) {
+	t.Parallel()
+
+	timeout := config.Duration(time.Minute)
+	store := NewServerConfigStore([]runtime.Server{
+		{ServerEntry: config.ServerEntry{Name: "Time", Timeout: &timeout}},
+	})
+
+	entry, ok := store.ServerConfig(" time ")
+	require.True(t, ok)
+	require.Equal(t, "Time", entry.Name)
+	require.Equal(t, &timeout, entry.Timeout)
+
+	_, ok = store.ServerConfig("github")
+	require.False(t, ok)
+}
+
+func TestServerConfigStore_Replace(t *testing.T) {
+	t.Parallel()
+
+	store := NewServerConfigStore([]runtime.Server{
+		{ServerEntry: config.ServerEntry{Name: "time"}},
+	})
+
+	timeout := config.Duration(time.Second)
+	store.Replace([]runtime.Server{
+		{ServerEntry: config.ServerEntry{Name: "github", Timeout: &timeout}},
+	})
+
+	_, ok := store.ServerConfig("time")
+	require.False(t, ok)
+
+	entry, ok := store.ServerConfig("github")
+	require.True(t, ok)
+	require.Equal(t, &timeout, entry.Timeout)
+}
+
+func TestServerConfigStore_Roots(t *testing.T) {
+	t.Parallel()
+
+	store := NewServerConfigStore([]runtime.Server{
+		{ServerEntry: config.ServerEntry{Name: "Filesystem", Roots: []string{"file:///Users/foo/repos"}}},
+	})
+
+	roots, ok := store.Roots("filesystem")
+	require.True(t, ok)
+	require.Equal(t, []runtime.Root{{Name: "repos", Path: "/Users/foo/repos"}}, roots)
+
+	_, ok = store.Roots("github")
+	require.False(t, ok)
+}
@@ -0,0 +1,173 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/mozilla-ai/mcpd/internal/contracts"
+	"github.com/mozilla-ai/mcpd/internal/errors"
+	"github.com/mozilla-ai/mcpd/internal/filter"
+	"github.com/mozilla-ai/mcpd/internal/runtime"
+)
+
+// ServerLogLevels controls the log levels of running MCP servers.
+// A server's level is applied to its logger in mcpd, and requested from the server when it supports logging.
+// It is safe for concurrent use by multiple goroutines.
+// NewServerLogLevels should be used to create instances of ServerLogLevels.
+type ServerLogLevels struct {
+	mu        sync.RWMutex
+	loggers   map[string]hclog.Logger
+	clients   contracts.MCPClientAccessor
+	processes contracts.MCPServerProcessAccessor
+}
+
+// NewServerLogLevels creates a ServerLogLevels which requests level changes from servers using the given clients.
+// The capabilities of servers are looked up from their processes, to determine whether they support logging.
+func NewServerLogLevels(
+	clients contracts.MCPClientAccessor,
+	processes contracts.MCPServerProcessAccessor,
+) *ServerLogLevels {
+	return &ServerLogLevels{
+		loggers:   make(map[string]hclog.Logger),
+		clients:   clients,
+		processes: processes,
+	}
+}
+
+// Add registers the logger used for a server.
+// The server name is normalized for case-insensitive lookup.
+func (s *ServerLogLevels) Add(name string, logger hclog.Logger) {
+	name = filter.NormalizeString(name)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loggers[name] = logger
+}
+
+// Remove forgets the logger of a stopped server.
+func (s *ServerLogLevels) Remove(name string) {
+	name = filter.NormalizeString(name)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.loggers, name)
+}
+
+// SetLogLevel implements contracts.MCPLogLevelController.
+func (s *ServerLogLevels) SetLogLevel(ctx context.Context, name string, level mcp.LoggingLevel) (bool, error) {
+	s.mu.RLock()
+	logger, ok := s.loggers[filter.NormalizeString(name)]
+	s.mu.RUnlock()
+	if !ok {
+		return false, fmt.Errorf("%w: %s", errors.ErrServerNotFound, name)
+	}
+
+	logger.SetLevel(mcpToHclogLevel(level))
+
+	// Only servers which declared the logging capability accept logging/setLevel.
+	if s.processes == nil {
+		return false, nil
+	}
+	process, ok := s.processes.ServerProcess(name)
+	if !ok || process.Capabilities.Logging == nil {
+		return false, nil
+	}
+
+	c, ok := s.clients.Client(name)
+	if !ok {
+		return false, fmt.Errorf("%w: %s", errors.ErrServerNotFound, name)
+	}
+
+	if err := c.SetLevel(ctx, mcp.SetLevelRequest{Params: mcp.SetLevelParams{Level: level}}); err != nil {
+		return false, fmt.Errorf("%w: %s: %w", errors.ErrLogLevelFailed, name, err)
+	}
+
+	return true, nil
+}
+
+// ResetLogLevel sets the level of a server's logger in mcpd, without requesting a change from the server.
+// It is used to restore the daemon's level when a server no longer has a log level configured.
+func (s *ServerLogLevels) ResetLogLevel(name string, level hclog.Level) {
+	s.mu.RLock()
+	logger, ok := s.loggers[filter.NormalizeString(name)]
+	s.mu.RUnlock()
+	if ok {
+		logger.SetLevel(level)
+	}
+}
+
+// applyLogLevel sets the configured log level of a server, failures are logged as they aren't fatal.
+func (d *Daemon) applyLogLevel(ctx context.Context, name string, level string) {
+	ctx, cancel := context.WithTimeout(ctx, d.clientInitTimeout)
+	defer cancel()
+
+	forwarded, err := d.logLevels.SetLogLevel(ctx, name, mcp.LoggingLevel(level))
+	if err != nil {
+		d.logger.Warn("Failed to set server log level", "server", name, "level", level, "error", err)
+		return
+	}
+
+	d.logger.Info("Set server log level", "server", name, "level", level, "forwarded", forwarded)
+}
+
+// updateLogLevel applies a change to a server's configured log level on reload.
+// When the log level is removed, the server's logger is restored to the daemon's level,
+// the server keeps sending messages at the level it was last asked for.
+func (d *Daemon) updateLogLevel(ctx context.Context, previous *runtime.Server, current *runtime.Server) {
+	if d.logLevels == nil || previous.LogLevel == current.LogLevel {
+		return
+	}
+
+	if current.LogLevel == "" {
+		d.logLevels.ResetLogLevel(current.Name(), d.logger.GetLevel())
+		return
+	}
+
+	d.applyLogLevel(ctx, current.Name(), current.LogLevel)
+}
+
+// mcpToHclogLevel maps an MCP logging level (RFC-5424 severities) to the nearest hclog level.
+func mcpToHclogLevel(level mcp.LoggingLevel) hclog.Level {
+	switch level {
+	case mcp.LoggingLevelDebug:
+		return hclog.Debug
+	case mcp.LoggingLevelInfo, mcp.LoggingLevelNotice:
+		return hclog.Info
+	case mcp.LoggingLevelWarning:
+		return hclog.Warn
+	case mcp.LoggingLevelError, mcp.LoggingLevelCritical, mcp.LoggingLevelAlert, mcp.LoggingLevelEmergency:
+		return hclog.Error
+	default:
+		return hclog.Info
+	}
+}
+
+// logServerMessage logs a log message sent by an MCP server (notifications/message) using the server's logger,
+// at the level of the message.
+func logServerMessage(logger hclog.Logger, notification mcp.JSONRPCNotification) {
+	fields := notification.Params.AdditionalFields
+	level, _ := fields["level"].(string)
+
+	var message string
+	switch data := fields["data"].(type) {
+	case string:
+		message = data
+	default:
+		b, err := json.Marshal(data)
+		if err != nil {
+			message = fmt.Sprintf("%v", data)
+		} else {
+			message = string(b)
+		}
+	}
+
+	var args []any
+	if name, ok := fields["logger"].(string); ok && name != "" {
+		args = append(args, "logger", name)
+	}
+
+	logger.Log(mcpToHclogLevel(mcp.LoggingLevel(level)), message, args...)
+}
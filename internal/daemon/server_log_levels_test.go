@@ -0,0 +1,154 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/domain"
+	internalerrors "github.com/mozilla-ai/mcpd/internal/errors"
+)
+
+// setLevelClient records the log levels requested from an MCP server.
+type setLevelClient struct {
+	mockMCPClient
+	levels []mcp.LoggingLevel
+	err    error
+}
+
+func (c *setLevelClient) SetLevel(_ context.Context, request mcp.SetLevelRequest) error {
+	c.levels = append(c.levels, request.Params.Level)
+	return c.err
+}
+
+func TestMCPToHclogLevel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		level    mcp.LoggingLevel
+		expected hclog.Level
+	}{
+		{level: mcp.LoggingLevelDebug, expected: hclog.Debug},
+		{level: mcp.LoggingLevelInfo, expected: hclog.Info},
+		{level: mcp.LoggingLevelNotice, expected: hclog.Info},
+		{level: mcp.LoggingLevelWarning, expected: hclog.Warn},
+		{level: mcp.LoggingLevelError, expected: hclog.Error},
+		{level: mcp.LoggingLevelEmergency, expected: hclog.Error},
+		{level: "unknown", expected: hclog.Info},
+	}
+
+	for _, tc := range tests {
+		t.Run(string(tc.level), func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tc.expected, mcpToHclogLevel(tc.level))
+		})
+	}
+}
+
+func TestServerLogLevels_SetLogLevel(t *testing.T) {
+	t.Parallel()
+
+	withLogging := &setLevelClient{}
+	withoutLogging := &setLevelClient{}
+	failing := &setLevelClient{err: errors.New("boom")}
+
+	clients := NewClientManager()
+	clients.Add("time", withLogging, nil)
+	clients.Add("fetch", withoutLogging, nil)
+	clients.Add("github", failing, nil)
+
+	processes := NewProcessTracker()
+	processes.Add("time", domain.ServerProcess{Capabilities: mcp.ServerCapabilities{Logging: &struct{}{}}})
+	processes.Add("fetch", domain.ServerProcess{})
+	processes.Add("github", domain.ServerProcess{Capabilities: mcp.ServerCapabilities{Logging: &struct{}{}}})
+
+	newLogger := func() hclog.Logger {
+		return hclog.New(&hclog.LoggerOptions{Output: io.Discard, Level: hclog.Info})
+	}
+	loggers := map[string]hclog.Logger{"time": newLogger(), "fetch": newLogger(), "github": newLogger()}
+	levels := NewServerLogLevels(clients, processes)
+	for name, logger := range loggers {
+		levels.Add(name, logger)
+	}
+
+	forwarded, err := levels.SetLogLevel(context.Background(), "Time", mcp.LoggingLevelWarning)
+	require.NoError(t, err)
+	require.True(t, forwarded)
+	require.Equal(t, []mcp.LoggingLevel{mcp.LoggingLevelWarning}, withLogging.levels)
+	require.Equal(t, hclog.Warn, loggers["time"].GetLevel())
+
+	// Servers without the logging capability are not asked to change their level.
+	forwarded, err = levels.SetLogLevel(context.Background(), "fetch", mcp.LoggingLevelDebug)
+	require.NoError(t, err)
+	require.False(t, forwarded)
+	require.Empty(t, withoutLogging.levels)
+	require.Equal(t, hclog.Debug, loggers["fetch"].GetLevel())
+
+	_, err = levels.SetLogLevel(context.Background(), "github", mcp.LoggingLevelError)
+	require.ErrorIs(t, err, internalerrors.ErrLogLevelFailed)
+
+	_, err = levels.SetLogLevel(context.Background(), "missing", mcp.LoggingLevelError)
+	require.ErrorIs(t, err, internalerrors.ErrServerNotFound)
+
+	// Removed servers are no longer controlled.
+	levels.Remove("time")
+	_, err = levels.SetLogLevel(context.Background(), "time", mcp.LoggingLevelError)
+	require.ErrorIs(t, err, internalerrors.ErrServerNotFound)
+
+	levels.ResetLogLevel("fetch", hclog.Info)
+	require.Equal(t, hclog.Info, loggers["fetch"].GetLevel())
+}
+
+func TestLogServerMessage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		fields   map[string]any
+		expected []string
+		absent   []string
+	}{
+		{
+			name:     "string data",
+			fields:   map[string]any{"level": "warning", "logger": "db", "data": "connection lost"},
+			expected: []string{"[WARN]", "connection lost", "logger=db"},
+		},
+		{
+			name:     "structured data",
+			fields:   map[string]any{"level": "error", "data": map[string]any{"code": 42}},
+			expected: []string{"[ERROR]", `{"code":42}`},
+		},
+		{
+			name:   "below logger level",
+			fields: map[string]any{"level": "debug", "data": "details"},
+			absent: []string{"details"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			logger := hclog.New(&hclog.LoggerOptions{Output: &buf, Level: hclog.Info})
+
+			notification := mcp.JSONRPCNotification{}
+			notification.Method = string(mcp.MethodNotificationMessage)
+			notification.Params.AdditionalFields = tc.fields
+			logServerMessage(logger, notification)
+
+			for _, s := range tc.expected {
+				require.Contains(t, buf.String(), s)
+			}
+			for _, s := range tc.absent {
+				require.NotContains(t, buf.String(), s)
+			}
+		})
+	}
+}
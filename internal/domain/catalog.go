@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ServerCatalog holds the cached listings offered by an MCP server.
+type ServerCatalog struct {
+	Tools             CatalogListing[mcp.Tool]
+	Prompts           CatalogListing[mcp.Prompt]
+	Resources         CatalogListing[mcp.Resource]
+	ResourceTemplates CatalogListing[mcp.ResourceTemplate]
+}
+
+// CatalogListing is a single cached listing (e.g. tools) along with the state of its most recent refresh.
+type CatalogListing[T any] struct {
+	// Items holds every item in the listing, across all pages.
+	Items []T
+
+	// Unsupported indicates the server does not implement the listing.
+	Unsupported bool
+
+	// RefreshedAt is when the listing was last refreshed successfully, nil if it never has been.
+	RefreshedAt *time.Time
+
+	// Err is the error from the most recent refresh, nil if it succeeded.
+	// Items from the last successful refresh are retained when a refresh fails.
+	Err error
+}
+
+// Cached reports whether the listing holds the result of a successful refresh.
+func (l CatalogListing[T]) Cached() bool {
+	return l.RefreshedAt != nil
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ServerProcess describes a running MCP server, as negotiated when it was initialized.
+type ServerProcess struct {
+	// Info is the name and version the server reported when it was initialized.
+	Info mcp.Implementation
+
+	// ProtocolVersion is the MCP protocol version negotiated with the server.
+	ProtocolVersion string
+
+	// Capabilities are the capabilities the server declared when it was initialized.
+	Capabilities mcp.ServerCapabilities
+
+	// Instructions describe how to use the server and its features, as provided by the server.
+	Instructions string
+
+	// PID is the process ID of the server, zero when it is unknown.
+	PID int
+
+	// StartedAt is when the server was started.
+	StartedAt time.Time
+
+	// Restarts is the number of times the server has been restarted by the daemon (e.g. on reload).
+	Restarts int
+}
@@ -27,6 +27,17 @@ var (
 	// Recommended to map to HTTP 404 Not Found.
 	ErrServerNotFound = errors.New("server not found")
 
+	// ErrUnauthorized indicates that the request did not present valid credentials for the daemon API.
+	// This occurs when authentication is configured and the request has a missing, unknown or expired token.
+	// Recommended to map to HTTP 401 Unauthorized.
+	ErrUnauthorized = errors.New("unauthorized")
+
+	// ErrForbidden indicates that the authenticated caller is not allowed to make the request.
+	// This occurs when the request is outside the caller's scope (e.g. a server or tool it may not access),
+	// or would make changes when the caller is read-only.
+	// Recommended to map to HTTP 403 Forbidden.
+	ErrForbidden = errors.New("forbidden")
+
 	// ErrToolsNotFound indicates that no tools are configured or available for the specified server.
 	// This can happen when a server exists but has no tools defined.
 	// Recommended to map to HTTP 404 Not Found.
@@ -112,4 +123,62 @@ var (
 	// This occurs when calling resource methods on servers that only implement tools.
 	// Recommended to map to HTTP 501 Not Implemented.
 	ErrResourcesNotImplemented = errors.New("resources not implemented by server")
+
+	// ErrResourceSubscribeFailed indicates that subscribing to a resource on an MCP server failed.
+	// This represents a communication or protocol error with the external MCP server.
+	// Recommended to map to HTTP 502 Bad Gateway.
+	ErrResourceSubscribeFailed = errors.New("resource subscribe failed")
+
+	// ErrResourceSubscriptionsNotImplemented indicates that the MCP server does not support resource subscriptions.
+	// This occurs when subscribing to resources on servers that don't offer the resources subscribe capability.
+	// Recommended to map to HTTP 501 Not Implemented.
+	ErrResourceSubscriptionsNotImplemented = errors.New("resource subscriptions not implemented by server")
+
+	// ErrCompletionFailed indicates that requesting argument completions from an MCP server failed.
+	// This represents a communication or protocol error with the external MCP server.
+	// Recommended to map to HTTP 502 Bad Gateway.
+	ErrCompletionFailed = errors.New("completion failed")
+
+	// ErrCompletionsNotImplemented indicates that the MCP server does not support argument completion.
+	// This occurs when completing arguments on servers that don't declare the completions capability.
+	// Recommended to map to HTTP 501 Not Implemented.
+	ErrCompletionsNotImplemented = errors.New("completions not implemented by server")
+
+	// ErrLogLevelFailed indicates that asking an MCP server to change its log level failed.
+	// This represents a communication or protocol error with the external MCP server.
+	// Recommended to map to HTTP 502 Bad Gateway.
+	ErrLogLevelFailed = errors.New("setting log level failed")
+
+	// ErrJobNotFound indicates that the requested asynchronous tool call job does not exist.
+	// This occurs when the job ID is unknown, or the finished job is no longer retained.
+	// Recommended to map to HTTP 404 Not Found.
+	ErrJobNotFound = errors.New("job not found")
+
+	// ErrWorkflowNotFound indicates that the requested workflow does not exist or is not configured.
+	// Recommended to map to HTTP 404 Not Found.
+	ErrWorkflowNotFound = errors.New("workflow not found")
+
+	// ErrWorkflowRenderFailed indicates that a workflow template could not be rendered.
+	// This occurs when a template references an input or step result (field) which is not present.
+	// Recommended to map to HTTP 422 Unprocessable Entity.
+	ErrWorkflowRenderFailed = errors.New("workflow template rendering failed")
+
+	// ErrToolApprovalRequired indicates that the tool requires approval before it can be called,
+	// and the request cannot be held pending approval (e.g. a streamed tool call or workflow step).
+	// Recommended to map to HTTP 403 Forbidden.
+	ErrToolApprovalRequired = errors.New("tool call requires approval")
+
+	// ErrApprovalNotFound indicates that the requested tool call approval does not exist.
+	// This occurs when the approval ID is unknown, or the decided approval is no longer retained.
+	// Recommended to map to HTTP 404 Not Found.
+	ErrApprovalNotFound = errors.New("approval not found")
+
+	// ErrApprovalNotPending indicates that the tool call approval has already been decided or has expired.
+	// Recommended to map to HTTP 409 Conflict.
+	ErrApprovalNotPending = errors.New("approval is not pending")
+
+	// ErrElicitationNotFound indicates that the requested elicitation does not exist or is no longer pending.
+	// This occurs when the elicitation ID is unknown, or the elicitation was already resolved or timed out.
+	// Recommended to map to HTTP 404 Not Found.
+	ErrElicitationNotFound = errors.New("elicitation not found")
 )
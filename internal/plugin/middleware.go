@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/mozilla-ai/mcpd/internal/api"
 )
@@ -44,6 +45,12 @@ func (p *pipeline) Middleware() func(http.Handler) http.Handler {
 			recorder := newResponseRecorder(w)
 			next.ServeHTTP(recorder, r)
 
+			// Streamed responses (e.g. Server-Sent Events) have already been written to the client,
+			// so they cannot be processed by the response flow.
+			if recorder.streaming {
+				return
+			}
+
 			// Convert handler response.
 			handlerResp := &HTTPResponse{
 				StatusCode: int32(recorder.statusCode),
@@ -119,10 +126,12 @@ func convertHeadersToMap(h http.Header) map[string]string {
 }
 
 // responseRecorder captures the response from the next handler.
+// Streamed responses (see isStreamingResponse) are passed through to the client instead of being captured.
 type responseRecorder struct {
 	http.ResponseWriter
 	statusCode int
 	body       bytes.Buffer
+	streaming  bool
 }
 
 // newResponseRecorder creates a new responseRecorder.
@@ -133,12 +142,40 @@ func newResponseRecorder(w http.ResponseWriter) *responseRecorder {
 	}
 }
 
-// WriteHeader captures the status code.
+// WriteHeader captures the status code, or writes it to the client when the response is streamed.
 func (r *responseRecorder) WriteHeader(code int) {
 	r.statusCode = code
+
+	if isStreamingResponse(r.Header()) {
+		r.streaming = true
+		r.ResponseWriter.WriteHeader(code)
+	}
 }
 
-// Write captures the response body.
+// Write captures the response body, or writes it to the client when the response is streamed.
 func (r *responseRecorder) Write(b []byte) (int, error) {
+	if r.streaming {
+		return r.ResponseWriter.Write(b)
+	}
 	return r.body.Write(b)
 }
+
+// Flush sends any buffered data to the client when the response is streamed.
+// Captured responses are not flushed as they are written once the response flow completes.
+func (r *responseRecorder) Flush() {
+	if !r.streaming {
+		return
+	}
+	_ = http.NewResponseController(r.ResponseWriter).Flush()
+}
+
+// Unwrap returns the underlying http.ResponseWriter, for use by http.ResponseController.
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// isStreamingResponse reports whether the headers describe a streamed response.
+func isStreamingResponse(h http.Header) bool {
+	mediaType, _, _ := strings.Cut(h.Get("Content-Type"), ";")
+	return strings.EqualFold(strings.TrimSpace(mediaType), "text/event-stream")
+}
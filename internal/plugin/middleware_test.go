@@ -98,3 +98,44 @@ func TestMiddleware_ResponsePipelineFailure(t *testing.T) {
 	require.Equal(t, string(api.PipelineResponseFailure), recorder.Header().Get(api.HeaderErrorType))
 	require.Contains(t, recorder.Body.String(), "Response processing failed")
 }
+
+func TestMiddleware_StreamingResponseBypassesResponsePipeline(t *testing.T) {
+	t.Parallel()
+
+	// Create pipeline with a required plugin that would fail during response.
+	logger := hclog.NewNullLogger()
+	p := newPipeline(logger)
+
+	inst := &Instance{
+		Plugin: &mockPlugin{
+			capabilities: []config.Flow{config.FlowResponse},
+			responseErr:  errors.New("plugin failed"),
+		},
+		name:     "failing-plugin",
+		required: true,
+	}
+	inst.SetFlows(map[config.Flow]struct{}{config.FlowResponse: {}})
+	p.plugins[config.CategoryAuthentication] = []*Instance{inst}
+
+	// Create test handler that streams events.
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("event: progress\ndata: {}\n\n"))
+
+		flusher, ok := w.(http.Flusher)
+		require.True(t, ok)
+		flusher.Flush()
+	})
+
+	handler := p.Middleware()(nextHandler)
+
+	req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader("test body"))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.True(t, recorder.Flushed)
+	require.Equal(t, "event: progress\ndata: {}\n\n", recorder.Body.String())
+	require.Empty(t, recorder.Header().Get(api.HeaderErrorType))
+}
@@ -0,0 +1,75 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/mozilla-ai/mcpd/internal/api"
+	"github.com/mozilla-ai/mcpd/internal/cmd/output"
+)
+
+var _ output.Printer[api.Approval] = (*ApprovalPrinter)(nil)
+
+// ApprovalPrinter prints tool call approvals.
+type ApprovalPrinter struct {
+	headerFunc output.WriteFunc[api.Approval]
+	footerFunc output.WriteFunc[api.Approval]
+}
+
+func (p *ApprovalPrinter) Header(w io.Writer, count int) {
+	if p.headerFunc != nil {
+		p.headerFunc(w, count)
+	}
+}
+
+func (p *ApprovalPrinter) SetHeader(fn output.WriteFunc[api.Approval]) {
+	p.headerFunc = fn
+}
+
+func (p *ApprovalPrinter) Item(w io.Writer, approval api.Approval) error {
+	_, _ = fmt.Fprintf(w, "%s (%s)\n", approval.ID, approval.Status)
+	_, _ = fmt.Fprintf(w, "  Server: %s\n", approval.Server)
+	_, _ = fmt.Fprintf(w, "  Tool: %s\n", approval.Tool)
+
+	if len(approval.Arguments) > 0 {
+		_, _ = fmt.Fprintln(w, "  Arguments:")
+		for _, k := range slices.Sorted(maps.Keys(approval.Arguments)) {
+			_, _ = fmt.Fprintf(w, "    %s: %v\n", k, approval.Arguments[k])
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "  Requested: %s\n", approval.CreatedAt.Format(time.RFC3339))
+
+	if approval.DecidedAt == nil {
+		_, _ = fmt.Fprintf(w, "  Expires: %s\n", approval.ExpiresAt.Format(time.RFC3339))
+	} else {
+		_, _ = fmt.Fprintf(w, "  Decided: %s\n", approval.DecidedAt.Format(time.RFC3339))
+	}
+
+	if approval.Reason != "" {
+		_, _ = fmt.Fprintf(w, "  Reason: %s\n", approval.Reason)
+	}
+
+	if approval.JobID != "" {
+		_, _ = fmt.Fprintf(w, "  Job: %s\n", approval.JobID)
+	}
+
+	if approval.Error != "" {
+		_, _ = fmt.Fprintf(w, "  Error: %s\n", approval.Error)
+	}
+
+	return nil
+}
+
+func (p *ApprovalPrinter) Footer(w io.Writer, count int) {
+	if p.footerFunc != nil {
+		p.footerFunc(w, count)
+	}
+}
+
+func (p *ApprovalPrinter) SetFooter(fn output.WriteFunc[api.Approval]) {
+	p.footerFunc = fn
+}
@@ -0,0 +1,96 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/api"
+)
+
+func TestApprovalPrinter_Item(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	decidedAt := createdAt.Add(time.Minute)
+
+	tests := []struct {
+		name     string
+		approval api.Approval
+		expected string
+	}{
+		{
+			name: "pending",
+			approval: api.Approval{
+				ID:        "abc",
+				Server:    "github",
+				Tool:      "delete_repo",
+				Arguments: map[string]any{"repo": "mcpd", "owner": "mozilla-ai"},
+				Status:    api.ApprovalStatusPending,
+				CreatedAt: createdAt,
+				ExpiresAt: createdAt.Add(15 * time.Minute),
+			},
+			expected: "abc (pending)\n" +
+				"  Server: github\n" +
+				"  Tool: delete_repo\n" +
+				"  Arguments:\n" +
+				"    owner: mozilla-ai\n" +
+				"    repo: mcpd\n" +
+				"  Requested: 2025-01-02T03:04:05Z\n" +
+				"  Expires: 2025-01-02T03:19:05Z\n",
+		},
+		{
+			name: "approved",
+			approval: api.Approval{
+				ID:        "abc",
+				Server:    "github",
+				Tool:      "delete_repo",
+				Status:    api.ApprovalStatusApproved,
+				Reason:    "confirmed with owner",
+				JobID:     "job1",
+				CreatedAt: createdAt,
+				ExpiresAt: createdAt.Add(15 * time.Minute),
+				DecidedAt: &decidedAt,
+			},
+			expected: "abc (approved)\n" +
+				"  Server: github\n" +
+				"  Tool: delete_repo\n" +
+				"  Requested: 2025-01-02T03:04:05Z\n" +
+				"  Decided: 2025-01-02T03:05:05Z\n" +
+				"  Reason: confirmed with owner\n" +
+				"  Job: job1\n",
+		},
+		{
+			name: "approved but failed to start",
+			approval: api.Approval{
+				ID:        "abc",
+				Server:    "github",
+				Tool:      "delete_repo",
+				Status:    api.ApprovalStatusApproved,
+				Error:     "tool not allowed",
+				CreatedAt: createdAt,
+				ExpiresAt: createdAt.Add(15 * time.Minute),
+				DecidedAt: &decidedAt,
+			},
+			expected: "abc (approved)\n" +
+				"  Server: github\n" +
+				"  Tool: delete_repo\n" +
+				"  Requested: 2025-01-02T03:04:05Z\n" +
+				"  Decided: 2025-01-02T03:05:05Z\n" +
+				"  Error: tool not allowed\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			p := &ApprovalPrinter{}
+			require.NoError(t, p.Item(&buf, tc.approval))
+			require.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
@@ -3,6 +3,7 @@ package printer
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/mozilla-ai/mcpd/internal/cmd/output"
 )
@@ -11,9 +12,14 @@ var _ output.Printer[ToolsListResult] = (*ToolsListPrinter)(nil)
 
 // ToolsListResult represents the tools list output structure.
 type ToolsListResult struct {
-	Server string   `json:"server" yaml:"server"`
-	Tools  []string `json:"tools"  yaml:"tools"`
-	Count  int      `json:"count"  yaml:"count"`
+	Server string   `json:"server"           yaml:"server"`
+	Tools  []string `json:"tools"            yaml:"tools"`
+	Count  int      `json:"count"            yaml:"count"`
+	Denied []string `json:"denied,omitempty" yaml:"denied,omitempty"`
+
+	// Matches maps each tool pattern (in Tools or Denied) to the tools available in the registry which it matches.
+	// Nil when matches weren't requested, or the available tools could not be resolved.
+	Matches map[string][]string `json:"matches,omitempty" yaml:"matches,omitempty"`
 }
 
 type ToolsListPrinter struct {
@@ -39,13 +45,36 @@ func (p *ToolsListPrinter) Item(w io.Writer, result ToolsListResult) error {
 	} else {
 		// Tools should already be sorted.
 		for _, tool := range result.Tools {
-			_, _ = fmt.Fprintf(w, "  %s\n", tool)
+			_, _ = fmt.Fprintf(w, "  %s%s\n", tool, formatToolMatches(result.Matches, tool))
+		}
+	}
+
+	if len(result.Denied) > 0 {
+		_, _ = fmt.Fprintf(w, "Denied tools (%d total):\n", len(result.Denied))
+		for _, tool := range result.Denied {
+			_, _ = fmt.Fprintf(w, "  %s%s\n", tool, formatToolMatches(result.Matches, tool))
 		}
 	}
 
 	return nil
 }
 
+// formatToolMatches returns the tools available in the registry which the tool pattern matches,
+// for display after the pattern.
+// An empty string is returned when the tool isn't a pattern, or its matches are unknown.
+func formatToolMatches(matches map[string][]string, tool string) string {
+	matched, ok := matches[tool]
+	if !ok {
+		return ""
+	}
+
+	if len(matched) == 0 {
+		return " (registry matches: none)"
+	}
+
+	return fmt.Sprintf(" (registry matches: %s)", strings.Join(matched, ", "))
+}
+
 func (p *ToolsListPrinter) Footer(w io.Writer, count int) {
 	if p.footerFunc != nil {
 		p.footerFunc(w, count)
@@ -52,6 +52,19 @@ func TestToolsListPrinter_Item(t *testing.T) {
 			},
 			expected: "Tools for 'nil-tools-server' (0 total):\n  (No tools configured)\n",
 		},
+		{
+			name: "server with patterns and denied tools",
+			result: ToolsListResult{
+				Server:  "pattern-server",
+				Tools:   []string{"create_file", "read_*"},
+				Count:   2,
+				Denied:  []string{"delete_*"},
+				Matches: map[string][]string{"read_*": {"read_dir", "read_file"}, "delete_*": {}},
+			},
+			expected: "Tools for 'pattern-server' (2 total):\n" +
+				"  create_file\n  read_* (registry matches: read_dir, read_file)\n" +
+				"Denied tools (1 total):\n  delete_* (registry matches: none)\n",
+		},
 	}
 
 	for _, tc := range tests {
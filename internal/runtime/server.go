@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"net/url"
 	"os"
+	"path/filepath"
 	"regexp"
 	"slices"
 	"strings"
@@ -38,6 +40,22 @@ type Volume struct {
 	From string
 }
 
+// Root is a directory the server may operate on, which is offered to it in response to roots/list requests.
+type Root struct {
+	// Name is the human-readable name of the root.
+	// e.g., "workspace" or "mcpd".
+	Name string
+
+	// Path is the absolute path of the directory.
+	// e.g., "/Users/foo/repos/mcpd".
+	Path string
+}
+
+// URI formats the root as a file URI (e.g., "file:///Users/foo/repos/mcpd").
+func (r Root) URI() string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(r.Path)}).String()
+}
+
 // String formats the volume as a Docker volume mount string.
 // Returns format: "from:path" (e.g., "/Users/foo/repos:/workspace" or "mcp-gdrive:/data").
 func (v Volume) String() string {
@@ -110,6 +128,38 @@ func (s *Server) SafeVolumes() []Volume {
 	return s.filterVolumes(s.volumes)
 }
 
+// Roots returns the directories the server may operate on.
+// Explicitly configured roots take precedence, otherwise docker servers are offered the host paths of their
+// volumes (named volumes are skipped), sorted by volume name. Other servers have no roots by default.
+func (s *Server) Roots() []Root {
+	if len(s.ServerEntry.Roots) > 0 {
+		roots := make([]Root, 0, len(s.ServerEntry.Roots))
+		for _, r := range s.ServerEntry.Roots {
+			path, ok := config.RootPath(r)
+			if !ok {
+				continue // Ignored - validated when the configuration is loaded.
+			}
+			roots = append(roots, Root{Name: filepath.Base(path), Path: path})
+		}
+		return roots
+	}
+
+	if Runtime(s.Runtime()) != Docker {
+		return []Root{}
+	}
+
+	roots := make([]Root, 0, len(s.volumes))
+	for _, vol := range s.SafeVolumes() {
+		if !filepath.IsAbs(vol.From) {
+			continue // Named volumes have no host path.
+		}
+		roots = append(roots, Root{Name: vol.Name, Path: filepath.Clean(vol.From)})
+	}
+	slices.SortFunc(roots, func(a, b Root) int { return strings.Compare(a.Name, b.Name) })
+
+	return roots
+}
+
 // computeVolumes populates the volumes field by combining static config with runtime mappings.
 // Only includes volumes that either have runtime config or are required.
 func (s *Server) computeVolumes() {
@@ -533,17 +583,35 @@ func AggregateConfigs(
 ) (Servers, error) {
 	var runtimeCfg []Server
 
+	// Servers inherit any global tool policy, with their own tool policy taking precedence.
+	var globalToolPolicy *config.ToolPolicy
+	if p, ok := cfg.(config.ToolPolicyProvider); ok {
+		globalToolPolicy = p.GlobalToolPolicy()
+	}
+
 	for _, s := range cfg.ListServers() {
 		runtimeServer := Server{
 			ServerEntry: config.ServerEntry{
 				Name:                   s.Name,
 				Package:                s.Package,
 				Tools:                  s.Tools,
+				ToolsDeny:              s.ToolsDeny,
+				ToolPolicy:             globalToolPolicy.Merge(s.ToolPolicy),
 				RequiredEnvVars:        s.RequiredEnvVars,
 				RequiredPositionalArgs: s.RequiredPositionalArgs,
 				RequiredValueArgs:      s.RequiredValueArgs,
 				RequiredBoolArgs:       s.RequiredBoolArgs,
 				Volumes:                s.Volumes,
+				Timeout:                s.Timeout,
+				ToolTimeouts:           s.ToolTimeouts,
+				Tags:                   s.Tags,
+				VirtualTools:           s.VirtualTools,
+				Prompts:                s.Prompts,
+				Resources:              s.Resources,
+				Approval:               s.Approval,
+				Sampling:               s.Sampling,
+				Roots:                  s.Roots,
+				LogLevel:               s.LogLevel,
 			},
 		}
 
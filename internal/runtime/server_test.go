@@ -1620,6 +1620,27 @@ func TestServer_EqualExceptTools(t *testing.T) {
 			}(),
 			expected: false, // Same tools, different order = no real change
 		},
+		{
+			name:    "only denied tools changed",
+			server1: baseServer(),
+			server2: func() *Server {
+				srv := baseServer()
+				srv.ToolsDeny = []string{"delete_*"}
+				return srv
+			}(),
+			expected: true,
+		},
+		{
+			name:    "only tool policy changed",
+			server1: baseServer(),
+			server2: func() *Server {
+				srv := baseServer()
+				readOnly := true
+				srv.ToolPolicy = &config.ToolPolicy{ReadOnly: &readOnly}
+				return srv
+			}(),
+			expected: true,
+		},
 		{
 			name:    "package changed",
 			server1: baseServer(),
@@ -2319,3 +2340,117 @@ func TestServer_Volumes(t *testing.T) {
 		})
 	}
 }
+
+func TestAggregateConfigs_ToolPolicy(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, ".mcpd.toml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+[tool_policy]
+block_destructive = true
+
+[[servers]]
+name = "github"
+package = "uvx::github-server@1.0.0"
+tools = ["*"]
+
+[servers.tool_policy]
+read_only = true
+block_destructive = false
+
+[[servers]]
+name = "time"
+package = "uvx::mcp-server-time@2025.8.4"
+tools = ["get_current_time"]
+`), 0o644))
+
+	configModifier, err := (&config.DefaultLoader{}).Load(configPath)
+	require.NoError(t, err)
+
+	contextModifier, err := (&context.DefaultLoader{}).Load(filepath.Join(dir, "runtime.toml"))
+	require.NoError(t, err)
+
+	servers, err := AggregateConfigs(configModifier, contextModifier)
+	require.NoError(t, err)
+	require.Len(t, servers, 2)
+
+	readOnly, blockDestructive := true, false
+	require.Equal(
+		t,
+		&config.ToolPolicy{ReadOnly: &readOnly, BlockDestructive: &blockDestructive},
+		servers[0].ToolPolicy,
+	)
+
+	blockDestructive = true
+	require.Equal(t, &config.ToolPolicy{BlockDestructive: &blockDestructive}, servers[1].ToolPolicy)
+}
+
+func TestServer_Roots(t *testing.T) {
+	t.Parallel()
+
+	volumes := config.VolumesEntry{
+		"workspace": config.VolumeEntry{Path: "/workspace", Required: true},
+		"data":      config.VolumeEntry{Path: "/data", Required: true},
+		"cache":     config.VolumeEntry{Path: "/cache", Required: true},
+	}
+	contextVolumes := context.VolumeExecutionContext{
+		"workspace": "/Users/foo/repos/mcpd/",
+		"data":      "/Users/foo/data",
+		"cache":     "mcp-cache",
+	}
+
+	tests := []struct {
+		name     string
+		entry    config.ServerEntry
+		expected []Root
+	}{
+		{
+			name:  "docker defaults to volume host paths",
+			entry: config.ServerEntry{Name: "filesystem", Package: "docker::mcp/filesystem@latest", Volumes: volumes},
+			expected: []Root{
+				{Name: "data", Path: "/Users/foo/data"},
+				{Name: "workspace", Path: "/Users/foo/repos/mcpd"},
+			},
+		},
+		{
+			name: "explicit roots take precedence",
+			entry: config.ServerEntry{
+				Name:    "filesystem",
+				Package: "docker::mcp/filesystem@latest",
+				Volumes: volumes,
+				Roots:   []string{"/Users/foo/notes", "file:///Users/foo/repos/mcpd"},
+			},
+			expected: []Root{
+				{Name: "notes", Path: "/Users/foo/notes"},
+				{Name: "mcpd", Path: "/Users/foo/repos/mcpd"},
+			},
+		},
+		{
+			name:     "no roots by default for other runtimes",
+			entry:    config.ServerEntry{Name: "filesystem", Package: "npx::@modelcontextprotocol/server-filesystem@1.0.0"},
+			expected: []Root{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := &Server{
+				ServerEntry:            tc.entry,
+				ServerExecutionContext: context.ServerExecutionContext{Volumes: contextVolumes},
+			}
+			server.computeVolumes()
+
+			require.Equal(t, tc.expected, server.Roots())
+		})
+	}
+}
+
+func TestRoot_URI(t *testing.T) {
+	t.Parallel()
+
+	root := Root{Name: "my repos", Path: "/Users/foo/my repos"}
+	require.Equal(t, "file:///Users/foo/my%20repos", root.URI())
+}
@@ -0,0 +1,183 @@
+package sampling
+
+import (
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// chatCompletionRequest is the request body for an OpenAI-compatible chat completions endpoint.
+type chatCompletionRequest struct {
+	Model       string        `json:"model"`
+	Messages    []chatMessage `json:"messages"`
+	MaxTokens   int           `json:"max_tokens,omitempty"`
+	Temperature *float64      `json:"temperature,omitempty"`
+	Stop        []string      `json:"stop,omitempty"`
+}
+
+// chatMessage is a message sent to a chat completions endpoint.
+// Content is either a string, or a slice of chatContentPart when the message includes images.
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content any    `json:"content"`
+}
+
+// chatContentPart is a part of the content of a chat message.
+type chatContentPart struct {
+	Type     string        `json:"type"`
+	Text     string        `json:"text,omitempty"`
+	ImageURL *chatImageURL `json:"image_url,omitempty"`
+}
+
+// chatImageURL is an image included in the content of a chat message, provided as a data URL.
+type chatImageURL struct {
+	URL string `json:"url"`
+}
+
+// chatCompletionResponse is the response body from an OpenAI-compatible chat completions endpoint.
+type chatCompletionResponse struct {
+	Model   string       `json:"model"`
+	Choices []chatChoice `json:"choices"`
+	Usage   *chatUsage   `json:"usage,omitempty"`
+}
+
+// chatChoice is a completion generated by the model.
+type chatChoice struct {
+	Message      chatResponseMessage `json:"message"`
+	FinishReason string              `json:"finish_reason"`
+}
+
+// chatResponseMessage is the message generated by the model.
+type chatResponseMessage struct {
+	Role    string  `json:"role"`
+	Content *string `json:"content"`
+}
+
+// chatUsage reports the tokens used to fulfil a request.
+type chatUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+}
+
+// chatErrorResponse is the body of an error response from an OpenAI-compatible endpoint.
+type chatErrorResponse struct {
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// toChatMessages converts the system prompt and messages of a sampling request into chat messages.
+func toChatMessages(systemPrompt string, messages []mcp.SamplingMessage) ([]chatMessage, error) {
+	out := make([]chatMessage, 0, len(messages)+1)
+	if systemPrompt != "" {
+		out = append(out, chatMessage{Role: "system", Content: systemPrompt})
+	}
+
+	for i, msg := range messages {
+		if msg.Role != mcp.RoleUser && msg.Role != mcp.RoleAssistant {
+			return nil, fmt.Errorf("message %d has unsupported role '%s'", i, msg.Role)
+		}
+
+		content, err := toChatContent(msg.Content)
+		if err != nil {
+			return nil, fmt.Errorf("message %d: %w", i, err)
+		}
+
+		out = append(out, chatMessage{Role: string(msg.Role), Content: content})
+	}
+
+	return out, nil
+}
+
+// toChatContent converts the content of a sampling message into the content of a chat message.
+// Text-only content is sent as a string, as not all OpenAI-compatible endpoints support content parts.
+func toChatContent(content any) (any, error) {
+	items, err := contentItems(content)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := make([]chatContentPart, 0, len(items))
+	textOnly := true
+	for _, item := range items {
+		switch c := item.(type) {
+		case mcp.TextContent:
+			parts = append(parts, chatContentPart{Type: "text", Text: c.Text})
+		case mcp.ImageContent:
+			textOnly = false
+			url := fmt.Sprintf("data:%s;base64,%s", c.MIMEType, c.Data)
+			parts = append(parts, chatContentPart{Type: "image_url", ImageURL: &chatImageURL{URL: url}})
+		case mcp.AudioContent:
+			return nil, fmt.Errorf("audio content is not supported")
+		default:
+			return nil, fmt.Errorf("unsupported content type %T", item)
+		}
+	}
+
+	if !textOnly {
+		return parts, nil
+	}
+
+	var text string
+	for i, part := range parts {
+		if i > 0 {
+			text += "\n"
+		}
+		text += part.Text
+	}
+
+	return text, nil
+}
+
+// contentItems returns the content blocks of a sampling message, which can hold a single block or a list of blocks.
+// Blocks which haven't been parsed into their content types (i.e. are still maps) are parsed.
+func contentItems(content any) ([]mcp.Content, error) {
+	var raw []any
+	switch c := content.(type) {
+	case nil:
+		return nil, fmt.Errorf("content is missing")
+	case []any:
+		raw = c
+	case []mcp.Content:
+		raw = make([]any, 0, len(c))
+		for _, item := range c {
+			raw = append(raw, item)
+		}
+	default:
+		raw = []any{c}
+	}
+
+	items := make([]mcp.Content, 0, len(raw))
+	for _, item := range raw {
+		switch c := item.(type) {
+		case map[string]any:
+			parsed, err := mcp.ParseContent(c)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, parsed)
+		case *mcp.TextContent:
+			items = append(items, *c)
+		case *mcp.ImageContent:
+			items = append(items, *c)
+		case mcp.Content:
+			items = append(items, c)
+		default:
+			return nil, fmt.Errorf("unsupported content type %T", item)
+		}
+	}
+
+	return items, nil
+}
+
+// toStopReason converts the finish reason reported by a chat completions endpoint into a sampling stop reason.
+func toStopReason(finishReason string) string {
+	switch finishReason {
+	case "stop":
+		return "endTurn"
+	case "length":
+		return "maxTokens"
+	default:
+		return finishReason
+	}
+}
@@ -0,0 +1,287 @@
+package sampling
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/contracts"
+)
+
+// ErrSamplingNotEnabled is returned to servers which make sampling requests without being allowed to.
+var ErrSamplingNotEnabled = errors.New("sampling is not enabled")
+
+// maxErrorBodySize is the maximum number of bytes read from an error response of the endpoint.
+const maxErrorBodySize = 4 << 10
+
+// Forwarder fulfils sampling requests made by MCP servers, by forwarding them to an OpenAI-compatible
+// chat completions endpoint.
+// Whether a server may make sampling requests, and the limits applied to them, are looked up per request,
+// so that changes to the server's configuration are applied without restarting it.
+// NewForwarder should be used to create instances of Forwarder.
+type Forwarder struct {
+	cfg        config.SamplingConfig
+	endpoint   string
+	apiKey     string
+	httpClient *http.Client
+	configs    contracts.MCPServerConfigAccessor
+	logger     hclog.Logger
+}
+
+// serverHandler is the sampling handler for a single MCP server.
+type serverHandler struct {
+	forwarder *Forwarder
+	server    string
+}
+
+// request is a sampling request resolved against the configuration.
+type request struct {
+	model     string
+	maxTokens int
+	body      chatCompletionRequest
+}
+
+// NewForwarder creates a Forwarder for the sampling configuration.
+// The server configuration is used to check which servers are allowed to make sampling requests.
+// Each request is recorded in the audit log, using the supplied logger.
+func NewForwarder(
+	logger hclog.Logger,
+	cfg *config.SamplingConfig,
+	configs contracts.MCPServerConfigAccessor,
+) (*Forwarder, error) {
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+	if cfg == nil {
+		return nil, fmt.Errorf("sampling configuration cannot be nil")
+	}
+	if configs == nil {
+		return nil, fmt.Errorf("server configuration accessor cannot be nil")
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid sampling configuration: %w", err)
+	}
+
+	var apiKey string
+	if env := strings.TrimSpace(cfg.APIKeyEnv); env != "" {
+		apiKey = os.Getenv(env)
+		if apiKey == "" {
+			return nil, fmt.Errorf("environment variable '%s' for the sampling API key is not set", env)
+		}
+	}
+
+	timeout := DefaultTimeout()
+	if cfg.Timeout != nil {
+		timeout = time.Duration(*cfg.Timeout)
+	}
+
+	return &Forwarder{
+		cfg:        *cfg,
+		endpoint:   strings.TrimSuffix(strings.TrimSpace(cfg.Endpoint), "/") + "/chat/completions",
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: timeout},
+		configs:    configs,
+		logger:     logger,
+	}, nil
+}
+
+// DefaultTimeout is the default time allowed for the endpoint to respond to a sampling request.
+func DefaultTimeout() time.Duration {
+	return 2 * time.Minute
+}
+
+// Handler returns the sampling handler for the named server.
+func (f *Forwarder) Handler(server string) client.SamplingHandler {
+	return &serverHandler{forwarder: f, server: server}
+}
+
+// CreateMessage implements client.SamplingHandler.
+func (h *serverHandler) CreateMessage(
+	ctx context.Context,
+	req mcp.CreateMessageRequest,
+) (*mcp.CreateMessageResult, error) {
+	return h.forwarder.createMessage(ctx, h.server, req.CreateMessageParams)
+}
+
+// createMessage fulfils a sampling request made by the server, recording the outcome in the audit log.
+func (f *Forwarder) createMessage(
+	ctx context.Context,
+	server string,
+	params mcp.CreateMessageParams,
+) (*mcp.CreateMessageResult, error) {
+	start := time.Now()
+	logger := f.logger.With("server", server, "messages", len(params.Messages))
+
+	entry, ok := f.configs.ServerConfig(server)
+	if !ok || !entry.SamplingEnabled() {
+		logger.Warn("Sampling request denied", "reason", "sampling not enabled")
+		return nil, fmt.Errorf("%w for server '%s'", ErrSamplingNotEnabled, server)
+	}
+
+	r, err := f.resolve(entry.Sampling, params)
+	if err != nil {
+		logger.Warn("Sampling request rejected", "error", err)
+		return nil, fmt.Errorf("invalid sampling request: %w", err)
+	}
+
+	logger = logger.With("model", r.model, "max_tokens", r.maxTokens)
+
+	resp, err := f.complete(ctx, r.body)
+	if err != nil {
+		logger.Error("Sampling request failed", "duration", time.Since(start), "error", err)
+		return nil, fmt.Errorf("sampling request failed: %w", err)
+	}
+
+	if len(resp.Choices) == 0 {
+		logger.Error("Sampling request failed", "duration", time.Since(start), "error", "no choices returned")
+		return nil, fmt.Errorf("sampling request failed: endpoint returned no choices")
+	}
+
+	choice := resp.Choices[0]
+	var text string
+	if choice.Message.Content != nil {
+		text = *choice.Message.Content
+	}
+
+	model := resp.Model
+	if model == "" {
+		model = r.model
+	}
+	stopReason := toStopReason(choice.FinishReason)
+
+	args := []any{"duration", time.Since(start), "stop_reason", stopReason}
+	if resp.Usage != nil {
+		args = append(args, "prompt_tokens", resp.Usage.PromptTokens, "completion_tokens", resp.Usage.CompletionTokens)
+	}
+	logger.Info("Sampling request completed", args...)
+
+	return &mcp.CreateMessageResult{
+		SamplingMessage: mcp.SamplingMessage{
+			Role:    mcp.RoleAssistant,
+			Content: mcp.NewTextContent(text),
+		},
+		Model:      model,
+		StopReason: stopReason,
+	}, nil
+}
+
+// resolve builds the chat completion request for the sampling request, applying the model mapping and token limits.
+func (f *Forwarder) resolve(server *config.ServerSampling, params mcp.CreateMessageParams) (request, error) {
+	if len(params.Tools) > 0 {
+		return request{}, fmt.Errorf("tool use is not supported")
+	}
+	if len(params.Messages) == 0 {
+		return request{}, fmt.Errorf("messages are required")
+	}
+
+	messages, err := toChatMessages(params.SystemPrompt, params.Messages)
+	if err != nil {
+		return request{}, err
+	}
+
+	model := f.model(server, params.ModelPreferences)
+	maxTokens := f.maxTokens(server, params.MaxTokens)
+
+	body := chatCompletionRequest{
+		Model:     model,
+		Messages:  messages,
+		MaxTokens: maxTokens,
+		Stop:      params.StopSequences,
+	}
+	if params.Temperature != 0 {
+		body.Temperature = &params.Temperature
+	}
+
+	return request{model: model, maxTokens: maxTokens, body: body}, nil
+}
+
+// model returns the model used for a sampling request.
+// The server's configured model takes precedence, followed by the first of the server's hints that is mapped to a
+// model, and finally the default model.
+func (f *Forwarder) model(server *config.ServerSampling, prefs *mcp.ModelPreferences) string {
+	if server != nil && strings.TrimSpace(server.Model) != "" {
+		return strings.TrimSpace(server.Model)
+	}
+
+	if prefs != nil {
+		for _, hint := range prefs.Hints {
+			if model, ok := f.cfg.Models[hint.Name]; ok {
+				return model
+			}
+		}
+	}
+
+	return f.cfg.Model
+}
+
+// maxTokens returns the maximum number of tokens to generate for a sampling request,
+// capping the requested number at the server's limit (or the global limit when the server has none).
+// Returns 0 when neither the request nor the configuration specify a limit.
+func (f *Forwarder) maxTokens(server *config.ServerSampling, requested int) int {
+	limit := f.cfg.MaxTokens
+	if server != nil && server.MaxTokens != nil {
+		limit = server.MaxTokens
+	}
+
+	if limit != nil && (requested <= 0 || requested > *limit) {
+		return *limit
+	}
+
+	return max(requested, 0)
+}
+
+// complete makes the request to the chat completions endpoint.
+func (f *Forwarder) complete(ctx context.Context, body chatCompletionRequest) (*chatCompletionResponse, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.endpoint, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if f.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+f.apiKey)
+	}
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		var errResp chatErrorResponse
+		if json.Unmarshal(msg, &errResp) == nil && errResp.Error.Message != "" {
+			msg = []byte(errResp.Error.Message)
+		}
+		return nil, fmt.Errorf(
+			"endpoint responded with status %d: %s",
+			resp.StatusCode,
+			strings.TrimSpace(string(msg)),
+		)
+	}
+
+	var out chatCompletionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &out, nil
+}
@@ -0,0 +1,341 @@
+package sampling
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/config"
+)
+
+// mockServerConfigAccessor returns the configured server entries.
+type mockServerConfigAccessor struct {
+	configs map[string]config.ServerEntry
+}
+
+func (m *mockServerConfigAccessor) ServerConfig(name string) (config.ServerEntry, bool) {
+	entry, ok := m.configs[name]
+	return entry, ok
+}
+
+// chatCompletionsStub is an OpenAI-compatible chat completions endpoint which records the requests made to it.
+type chatCompletionsStub struct {
+	mu       sync.Mutex
+	requests []chatCompletionRequest
+	headers  []http.Header
+}
+
+func (s *chatCompletionsStub) lastRequest(t *testing.T) (chatCompletionRequest, http.Header) {
+	t.Helper()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	require.NotEmpty(t, s.requests)
+	return s.requests[len(s.requests)-1], s.headers[len(s.headers)-1]
+}
+
+func (s *chatCompletionsStub) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.requests)
+}
+
+func newChatCompletionsStub(t *testing.T, status int, response string) (*chatCompletionsStub, string) {
+	t.Helper()
+
+	stub := &chatCompletionsStub{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			http.NotFound(w, r)
+			return
+		}
+
+		var req chatCompletionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		stub.mu.Lock()
+		stub.requests = append(stub.requests, req)
+		stub.headers = append(stub.headers, r.Header.Clone())
+		stub.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(srv.Close)
+
+	return stub, srv.URL + "/v1/"
+}
+
+func testIntPtr(t *testing.T, i int) *int {
+	t.Helper()
+	return &i
+}
+
+func newTestForwarder(t *testing.T, cfg *config.SamplingConfig, servers ...config.ServerEntry) *Forwarder {
+	t.Helper()
+
+	configs := &mockServerConfigAccessor{configs: map[string]config.ServerEntry{}}
+	for _, s := range servers {
+		configs.configs[s.Name] = s
+	}
+
+	f, err := NewForwarder(hclog.NewNullLogger(), cfg, configs)
+	require.NoError(t, err)
+	return f
+}
+
+func createMessageRequest(params mcp.CreateMessageParams) mcp.CreateMessageRequest {
+	return mcp.CreateMessageRequest{
+		Request:             mcp.Request{Method: string(mcp.MethodSamplingCreateMessage)},
+		CreateMessageParams: params,
+	}
+}
+
+const completionResponse = `{
+	"model": "qwen2.5-7b-instruct",
+	"choices": [{"message": {"role": "assistant", "content": "A short summary."}, "finish_reason": "stop"}],
+	"usage": {"prompt_tokens": 12, "completion_tokens": 4}
+}`
+
+func TestNewForwarder(t *testing.T) {
+	t.Parallel()
+
+	configs := &mockServerConfigAccessor{}
+	valid := &config.SamplingConfig{Endpoint: "http://localhost:8080/v1", Model: "m"}
+
+	_, err := NewForwarder(nil, nil, configs)
+	require.EqualError(t, err, "sampling configuration cannot be nil")
+
+	_, err = NewForwarder(nil, valid, nil)
+	require.EqualError(t, err, "server configuration accessor cannot be nil")
+
+	_, err = NewForwarder(nil, &config.SamplingConfig{Model: "m"}, configs)
+	require.ErrorContains(t, err, "invalid sampling configuration: endpoint is required")
+
+	missingKey := *valid
+	missingKey.APIKeyEnv = "MCPD_TEST_SAMPLING_API_KEY_UNSET"
+	_, err = NewForwarder(nil, &missingKey, configs)
+	require.EqualError(
+		t,
+		err,
+		"environment variable 'MCPD_TEST_SAMPLING_API_KEY_UNSET' for the sampling API key is not set",
+	)
+
+	f, err := NewForwarder(nil, valid, configs)
+	require.NoError(t, err)
+	require.Equal(t, "http://localhost:8080/v1/chat/completions", f.endpoint)
+	require.Equal(t, DefaultTimeout(), f.httpClient.Timeout)
+}
+
+func TestForwarder_CreateMessage(t *testing.T) {
+	stub, endpoint := newChatCompletionsStub(t, http.StatusOK, completionResponse)
+	t.Setenv("MCPD_TEST_SAMPLING_API_KEY", "secret")
+
+	f := newTestForwarder(
+		t,
+		&config.SamplingConfig{
+			Endpoint:  endpoint,
+			APIKeyEnv: "MCPD_TEST_SAMPLING_API_KEY",
+			Model:     "default-model",
+			MaxTokens: testIntPtr(t, 256),
+		},
+		config.ServerEntry{Name: "summarizer", Sampling: &config.ServerSampling{Enabled: true}},
+	)
+
+	result, err := f.Handler("summarizer").CreateMessage(context.Background(), createMessageRequest(
+		mcp.CreateMessageParams{
+			SystemPrompt: "You summarize text.",
+			Messages: []mcp.SamplingMessage{
+				{Role: mcp.RoleUser, Content: mcp.NewTextContent("Summarize this.")},
+			},
+			MaxTokens:     100,
+			Temperature:   0.2,
+			StopSequences: []string{"END"},
+		},
+	))
+	require.NoError(t, err)
+
+	assert.Equal(t, mcp.RoleAssistant, result.Role)
+	assert.Equal(t, mcp.NewTextContent("A short summary."), result.Content)
+	assert.Equal(t, "qwen2.5-7b-instruct", result.Model)
+	assert.Equal(t, "endTurn", result.StopReason)
+
+	req, headers := stub.lastRequest(t)
+	assert.Equal(t, "Bearer secret", headers.Get("Authorization"))
+	assert.Equal(t, "default-model", req.Model)
+	assert.Equal(t, 100, req.MaxTokens)
+	require.NotNil(t, req.Temperature)
+	assert.InDelta(t, 0.2, *req.Temperature, 0.0001)
+	assert.Equal(t, []string{"END"}, req.Stop)
+	assert.Equal(t, []chatMessage{
+		{Role: "system", Content: "You summarize text."},
+		{Role: "user", Content: "Summarize this."},
+	}, req.Messages)
+}
+
+func TestForwarder_CreateMessage_NotEnabled(t *testing.T) {
+	t.Parallel()
+
+	stub, endpoint := newChatCompletionsStub(t, http.StatusOK, completionResponse)
+	f := newTestForwarder(
+		t,
+		&config.SamplingConfig{Endpoint: endpoint, Model: "m"},
+		config.ServerEntry{Name: "github"},
+		config.ServerEntry{Name: "time", Sampling: &config.ServerSampling{Enabled: false}},
+	)
+
+	request := createMessageRequest(mcp.CreateMessageParams{
+		Messages: []mcp.SamplingMessage{{Role: mcp.RoleUser, Content: mcp.NewTextContent("hi")}},
+	})
+
+	for _, server := range []string{"github", "time", "unknown"} {
+		_, err := f.Handler(server).CreateMessage(context.Background(), request)
+		require.ErrorIs(t, err, ErrSamplingNotEnabled)
+		require.EqualError(t, err, "sampling is not enabled for server '"+server+"'")
+	}
+
+	assert.Zero(t, stub.count())
+}
+
+func TestForwarder_CreateMessage_EndpointError(t *testing.T) {
+	t.Parallel()
+
+	_, endpoint := newChatCompletionsStub(
+		t,
+		http.StatusBadRequest,
+		`{"error": {"message": "model 'm' not found"}}`,
+	)
+	f := newTestForwarder(
+		t,
+		&config.SamplingConfig{Endpoint: endpoint, Model: "m"},
+		config.ServerEntry{Name: "summarizer", Sampling: &config.ServerSampling{Enabled: true}},
+	)
+
+	_, err := f.Handler("summarizer").CreateMessage(context.Background(), createMessageRequest(
+		mcp.CreateMessageParams{
+			Messages: []mcp.SamplingMessage{{Role: mcp.RoleUser, Content: mcp.NewTextContent("hi")}},
+		},
+	))
+	require.EqualError(t, err, "sampling request failed: endpoint responded with status 400: model 'm' not found")
+}
+
+func TestForwarder_CreateMessage_InvalidRequest(t *testing.T) {
+	t.Parallel()
+
+	stub, endpoint := newChatCompletionsStub(t, http.StatusOK, completionResponse)
+	f := newTestForwarder(
+		t,
+		&config.SamplingConfig{Endpoint: endpoint, Model: "m"},
+		config.ServerEntry{Name: "summarizer", Sampling: &config.ServerSampling{Enabled: true}},
+	)
+
+	tests := []struct {
+		name     string
+		params   mcp.CreateMessageParams
+		expected string
+	}{
+		{
+			name:     "no messages",
+			params:   mcp.CreateMessageParams{},
+			expected: "invalid sampling request: messages are required",
+		},
+		{
+			name: "tools",
+			params: mcp.CreateMessageParams{
+				Messages: []mcp.SamplingMessage{{Role: mcp.RoleUser, Content: mcp.NewTextContent("hi")}},
+				Tools:    []mcp.Tool{{Name: "search"}},
+			},
+			expected: "invalid sampling request: tool use is not supported",
+		},
+		{
+			name: "audio",
+			params: mcp.CreateMessageParams{
+				Messages: []mcp.SamplingMessage{
+					{Role: mcp.RoleUser, Content: mcp.NewAudioContent("data", "audio/wav")},
+				},
+			},
+			expected: "invalid sampling request: message 0: audio content is not supported",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := f.Handler("summarizer").CreateMessage(context.Background(), createMessageRequest(tc.params))
+			require.EqualError(t, err, tc.expected)
+		})
+	}
+
+	assert.Zero(t, stub.count())
+}
+
+func TestForwarder_Model(t *testing.T) {
+	t.Parallel()
+
+	f := newTestForwarder(t, &config.SamplingConfig{
+		Endpoint: "http://localhost:8080/v1",
+		Model:    "default-model",
+		Models:   map[string]string{"claude-3-sonnet": "qwen2.5-7b-instruct"},
+	})
+
+	hints := &mcp.ModelPreferences{Hints: []mcp.ModelHint{{Name: "gpt-4o"}, {Name: "claude-3-sonnet"}}}
+
+	assert.Equal(t, "default-model", f.model(nil, nil))
+	assert.Equal(t, "default-model", f.model(nil, &mcp.ModelPreferences{Hints: []mcp.ModelHint{{Name: "gpt-4o"}}}))
+	assert.Equal(t, "qwen2.5-7b-instruct", f.model(nil, hints))
+	assert.Equal(t, "pinned", f.model(&config.ServerSampling{Enabled: true, Model: "pinned"}, hints))
+}
+
+func TestForwarder_MaxTokens(t *testing.T) {
+	t.Parallel()
+
+	unlimited := newTestForwarder(t, &config.SamplingConfig{Endpoint: "http://localhost:8080/v1", Model: "m"})
+	limited := newTestForwarder(t, &config.SamplingConfig{
+		Endpoint:  "http://localhost:8080/v1",
+		Model:     "m",
+		MaxTokens: testIntPtr(t, 500),
+	})
+	server := &config.ServerSampling{Enabled: true, MaxTokens: testIntPtr(t, 50)}
+
+	assert.Equal(t, 0, unlimited.maxTokens(nil, 0))
+	assert.Equal(t, 1000, unlimited.maxTokens(nil, 1000))
+	assert.Equal(t, 500, limited.maxTokens(nil, 1000))
+	assert.Equal(t, 500, limited.maxTokens(nil, 0))
+	assert.Equal(t, 100, limited.maxTokens(nil, 100))
+	assert.Equal(t, 50, limited.maxTokens(server, 100))
+	assert.Equal(t, 50, unlimited.maxTokens(server, 0))
+}
+
+func TestToChatContent(t *testing.T) {
+	t.Parallel()
+
+	content, err := toChatContent([]any{
+		map[string]any{"type": "text", "text": "What is in this image?"},
+		map[string]any{"type": "image", "data": "aGVsbG8=", "mimeType": "image/png"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []chatContentPart{
+		{Type: "text", Text: "What is in this image?"},
+		{Type: "image_url", ImageURL: &chatImageURL{URL: "data:image/png;base64,aGVsbG8="}},
+	}, content)
+
+	content, err = toChatContent([]mcp.Content{mcp.NewTextContent("one"), mcp.NewTextContent("two")})
+	require.NoError(t, err)
+	assert.Equal(t, "one\ntwo", content)
+
+	_, err = toChatContent(nil)
+	require.EqualError(t, err, "content is missing")
+}
@@ -0,0 +1,151 @@
+package search
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+const (
+	// k1 controls how quickly repeated occurrences of a term stop increasing the score.
+	k1 = 1.2
+
+	// b controls how much scores are normalized by document length.
+	b = 0.75
+)
+
+// Document is a piece of text to be indexed, made up of weighted fields.
+type Document struct {
+	// Fields holds the text to index, each with a weight applied to the terms it contains.
+	// For example, a weight of 3 counts each term in the field as if it occurred three times.
+	Fields []Field
+}
+
+// Field is a weighted piece of text within a Document.
+type Field struct {
+	Text   string
+	Weight int
+}
+
+// Result is a document that matched a query.
+type Result struct {
+	// Index is the position of the document in the slice supplied to NewIndex.
+	Index int
+
+	// Score is the relevance of the document to the query, higher is more relevant.
+	Score float64
+}
+
+// Index ranks documents against keyword queries using the Okapi BM25 scoring function.
+// NewIndex should be used to create instances of Index.
+type Index struct {
+	docs         []map[string]float64
+	lengths      []float64
+	avgLength    float64
+	docFrequency map[string]int
+}
+
+// NewIndex creates an Index over the supplied documents.
+func NewIndex(docs []Document) *Index {
+	idx := &Index{
+		docs:         make([]map[string]float64, len(docs)),
+		lengths:      make([]float64, len(docs)),
+		docFrequency: make(map[string]int),
+	}
+
+	var total float64
+	for i, doc := range docs {
+		terms := make(map[string]float64)
+		for _, field := range doc.Fields {
+			weight := float64(max(field.Weight, 1))
+			for _, token := range Tokenize(field.Text) {
+				terms[token] += weight
+				idx.lengths[i] += weight
+			}
+		}
+		for term := range terms {
+			idx.docFrequency[term]++
+		}
+		idx.docs[i] = terms
+		total += idx.lengths[i]
+	}
+
+	if len(docs) > 0 {
+		idx.avgLength = total / float64(len(docs))
+	}
+
+	return idx
+}
+
+// Search scores every document against the query, returning those that match at least one term.
+// Results are ordered by descending score, ties keep the order the documents were indexed in.
+func (idx *Index) Search(query string) []Result {
+	terms := slices.Compact(slices.Sorted(slices.Values(Tokenize(query))))
+	if len(terms) == 0 || idx.avgLength == 0 {
+		return nil
+	}
+
+	n := float64(len(idx.docs))
+	var results []Result
+	for i, doc := range idx.docs {
+		var score float64
+		for _, term := range terms {
+			tf, ok := doc[term]
+			if !ok {
+				continue
+			}
+			df := float64(idx.docFrequency[term])
+			idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+			score += idf * (tf * (k1 + 1)) / (tf + k1*(1-b+b*idx.lengths[i]/idx.avgLength))
+		}
+		if score > 0 {
+			results = append(results, Result{Index: i, Score: score})
+		}
+	}
+
+	slices.SortStableFunc(results, func(x, y Result) int {
+		switch {
+		case x.Score > y.Score:
+			return -1
+		case x.Score < y.Score:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	return results
+}
+
+// Tokenize splits text into lowercase terms.
+// Terms are separated by anything other than letters and digits, and at lower-to-upper case transitions,
+// so identifiers such as "get_current_time" and "getCurrentTime" produce the same terms.
+func Tokenize(text string) []string {
+	var tokens []string
+	var current strings.Builder
+	var prev rune
+
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, strings.ToLower(current.String()))
+			current.Reset()
+		}
+	}
+
+	for _, r := range text {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && unicode.IsLower(prev):
+			flush()
+			current.WriteRune(r)
+		default:
+			current.WriteRune(r)
+		}
+		prev = r
+	}
+	flush()
+
+	return tokens
+}
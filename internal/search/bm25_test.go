@@ -0,0 +1,66 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{name: "snake case", text: "get_current_time", expected: []string{"get", "current", "time"}},
+		{name: "camel case", text: "getCurrentTime", expected: []string{"get", "current", "time"}},
+		{
+			name:     "sentence",
+			text:     "Fetches a URL, returns Markdown.",
+			expected: []string{"fetches", "a", "url", "returns", "markdown"},
+		},
+		{name: "digits", text: "list_v2 items", expected: []string{"list", "v2", "items"}},
+		{name: "empty", text: "  --  ", expected: nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, Tokenize(tc.text))
+		})
+	}
+}
+
+func TestIndex_Search(t *testing.T) {
+	t.Parallel()
+
+	idx := NewIndex([]Document{
+		{Fields: []Field{{Text: "get_current_time", Weight: 3}, {Text: "Get the current time in a timezone", Weight: 1}}},
+		{Fields: []Field{{Text: "convert_time", Weight: 3}, {Text: "Convert a time between timezones", Weight: 1}}},
+		{Fields: []Field{{Text: "fetch", Weight: 3}, {Text: "Fetches a URL from the internet", Weight: 1}}},
+		{Fields: []Field{{Text: "create_issue", Weight: 3}, {Text: "Create an issue to track the time", Weight: 1}}},
+	})
+
+	results := idx.Search("current time")
+	require.Len(t, results, 3)
+	assert.Equal(t, 0, results[0].Index, "matches both terms in the name")
+	for i := 1; i < len(results); i++ {
+		assert.GreaterOrEqual(t, results[i-1].Score, results[i].Score)
+	}
+
+	// Name matches outrank description-only matches.
+	results = idx.Search("Time")
+	require.Len(t, results, 3)
+	assert.Equal(t, 3, results[2].Index)
+
+	results = idx.Search("fetch")
+	require.Len(t, results, 1)
+	assert.Equal(t, 2, results[0].Index)
+
+	assert.Empty(t, idx.Search("weather"))
+	assert.Empty(t, idx.Search("   "))
+	assert.Empty(t, NewIndex(nil).Search("time"))
+}
@@ -0,0 +1,186 @@
+package workflow
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/mozilla-ai/mcpd/internal/errors"
+)
+
+// templateFuncs are the functions available to workflow templates, in addition to the built-in functions.
+// NOTE: This variable should not be modified in other parts of the codebase.
+var templateFuncs = template.FuncMap{
+	"toJSON": toJSON,
+}
+
+// value is a compiled argument value, which is rendered against the template data.
+type value interface {
+	render(data map[string]any) (any, error)
+}
+
+// literalValue is a value which doesn't contain templates.
+type literalValue struct {
+	v any
+}
+
+// textValue is a string value rendered as a template.
+type textValue struct {
+	tmpl *template.Template
+}
+
+// actionValue is a string value made up of a single template action (e.g. '{{ .inputs.limit }}').
+// It renders to the value of the action, preserving its type (e.g. numbers, objects).
+type actionValue struct {
+	tmpl *template.Template
+}
+
+// mapValue is a map whose values are compiled.
+type mapValue map[string]value
+
+// sliceValue is a slice whose items are compiled.
+type sliceValue []value
+
+// compileValue compiles the templates within the value.
+// Strings are compiled as templates, maps and slices are compiled recursively, anything else is a literal.
+func compileValue(name string, v any) (value, error) {
+	switch v := v.(type) {
+	case string:
+		return compileString(name, v)
+	case map[string]any:
+		m := make(mapValue, len(v))
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			compiled, err := compileValue(name+"."+key, v[key])
+			if err != nil {
+				return nil, err
+			}
+			m[key] = compiled
+		}
+		return m, nil
+	case []any:
+		s := make(sliceValue, len(v))
+		for i, item := range v {
+			compiled, err := compileValue(fmt.Sprintf("%s[%d]", name, i), item)
+			if err != nil {
+				return nil, err
+			}
+			s[i] = compiled
+		}
+		return s, nil
+	default:
+		return literalValue{v: v}, nil
+	}
+}
+
+// compileString compiles the string as a template.
+func compileString(name string, text string) (value, error) {
+	tmpl, err := newTemplate(name, text)
+	if err != nil {
+		return nil, err
+	}
+
+	if tmpl.Tree == nil || tmpl.Root == nil || len(tmpl.Root.Nodes) == 0 {
+		return literalValue{v: text}, nil
+	}
+
+	if len(tmpl.Root.Nodes) == 1 {
+		switch node := tmpl.Root.Nodes[0].(type) {
+		case *parse.TextNode:
+			return literalValue{v: text}, nil
+		case *parse.ActionNode:
+			if len(node.Pipe.Decl) == 0 {
+				// Render the result of the action as JSON, so it can be decoded with its type intact.
+				action, err := newTemplate(name, "{{ toJSON ("+node.Pipe.String()+") }}")
+				if err != nil {
+					return nil, err
+				}
+				return actionValue{tmpl: action}, nil
+			}
+		}
+	}
+
+	return textValue{tmpl: tmpl}, nil
+}
+
+// newTemplate parses the text as a template which fails when referencing missing keys.
+func newTemplate(name string, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template '%s': %w", name, err)
+	}
+
+	return tmpl, nil
+}
+
+func (v literalValue) render(map[string]any) (any, error) {
+	return v.v, nil
+}
+
+func (v textValue) render(data map[string]any) (any, error) {
+	return execute(v.tmpl, data)
+}
+
+func (v actionValue) render(data map[string]any) (any, error) {
+	out, err := execute(v.tmpl, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var result any
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", errors.ErrWorkflowRenderFailed, v.tmpl.Name(), err)
+	}
+
+	return result, nil
+}
+
+func (v mapValue) render(data map[string]any) (any, error) {
+	m := make(map[string]any, len(v))
+	for _, key := range slices.Sorted(maps.Keys(v)) {
+		rendered, err := v[key].render(data)
+		if err != nil {
+			return nil, err
+		}
+		m[key] = rendered
+	}
+
+	return m, nil
+}
+
+func (v sliceValue) render(data map[string]any) (any, error) {
+	s := make([]any, len(v))
+	for i, item := range v {
+		rendered, err := item.render(data)
+		if err != nil {
+			return nil, err
+		}
+		s[i] = rendered
+	}
+
+	return s, nil
+}
+
+// execute renders the template against the data.
+func execute(tmpl *template.Template, data map[string]any) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("%w: %w", errors.ErrWorkflowRenderFailed, err)
+	}
+
+	return buf.String(), nil
+}
+
+// toJSON returns the JSON encoding of the value.
+func toJSON(v any) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(b)), nil
+}
@@ -0,0 +1,272 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/errors"
+)
+
+const (
+	// StepSucceeded indicates the step's tool call succeeded.
+	StepSucceeded StepStatus = "succeeded"
+
+	// StepFailed indicates the step's arguments could not be rendered, or its tool call failed.
+	StepFailed StepStatus = "failed"
+
+	// StepSkipped indicates the step was not run because an earlier step failed.
+	StepSkipped StepStatus = "skipped"
+)
+
+// StepStatus describes the outcome of a workflow step.
+type StepStatus string
+
+// Caller calls a tool on a server, returning the message extracted from its result.
+// The timeout is optional (zero when not configured for the step).
+type Caller func(
+	ctx context.Context,
+	server string,
+	tool string,
+	args map[string]any,
+	timeout time.Duration,
+) (string, error)
+
+// Workflow is a compiled workflow, which can be run concurrently.
+// New should be used to create instances of Workflow.
+type Workflow struct {
+	entry  config.WorkflowEntry
+	steps  []step
+	output value
+}
+
+// step is a compiled workflow step.
+type step struct {
+	config.WorkflowStep
+	args value
+}
+
+// Result is the outcome of running a workflow.
+type Result struct {
+	// Output is the result of the workflow, present when all steps succeeded.
+	Output string
+
+	// Steps contains the outcome of each step, in order, including those which were skipped.
+	Steps []StepResult
+}
+
+// StepResult is the outcome of a single workflow step.
+type StepResult struct {
+	ID       string
+	Server   string
+	Tool     string
+	Status   StepStatus
+	Result   string
+	Error    error
+	Duration time.Duration
+}
+
+// New compiles the templates of the workflow.
+func New(entry config.WorkflowEntry) (*Workflow, error) {
+	w := &Workflow{
+		entry: entry,
+		steps: make([]step, 0, len(entry.Steps)),
+	}
+
+	for _, s := range entry.Steps {
+		args, err := compileValue(s.ID, s.Args)
+		if err != nil {
+			return nil, fmt.Errorf("workflow '%s' step '%s': %w", entry.Name, s.ID, err)
+		}
+		w.steps = append(w.steps, step{WorkflowStep: s, args: args})
+	}
+
+	if entry.Output != "" {
+		output, err := newTemplate("output", entry.Output)
+		if err != nil {
+			return nil, fmt.Errorf("workflow '%s': %w", entry.Name, err)
+		}
+		w.output = textValue{tmpl: output}
+	}
+
+	return w, nil
+}
+
+// Name returns the name the workflow is exposed as.
+func (w *Workflow) Name() string {
+	return w.entry.Name
+}
+
+// Steps returns the configured steps of the workflow, in order.
+func (w *Workflow) Steps() []config.WorkflowStep {
+	return slices.Clone(w.entry.Steps)
+}
+
+// Tool describes the workflow as a tool, with an input schema derived from the workflow's inputs.
+func (w *Workflow) Tool() mcp.Tool {
+	properties := make(map[string]any, len(w.entry.Inputs))
+	var required []string
+	for _, input := range w.entry.Inputs {
+		property := map[string]any{"type": input.InputType()}
+		if input.Description != "" {
+			property["description"] = input.Description
+		}
+		if input.Default != nil {
+			property["default"] = input.Default
+		}
+		properties[input.Name] = property
+		if input.Required {
+			required = append(required, input.Name)
+		}
+	}
+
+	return mcp.Tool{
+		Name:        w.entry.Name,
+		Title:       w.entry.Title,
+		Description: w.entry.Description,
+		InputSchema: mcp.ToolInputSchema{
+			Type:       "object",
+			Properties: properties,
+			Required:   required,
+		},
+		Annotations: mcp.ToolAnnotation{Title: w.entry.Title},
+	}
+}
+
+// Run runs the steps of the workflow in order, stopping at the first step that fails.
+// The result includes the outcome of every step, so partial results are available when an error is returned.
+// Errors from steps are wrapped, so the underlying cause (e.g. a failed tool call) can be inspected.
+func (w *Workflow) Run(ctx context.Context, args map[string]any, call Caller) (Result, error) {
+	inputs, err := w.inputs(args)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if w.entry.Timeout != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(*w.entry.Timeout))
+		defer cancel()
+	}
+
+	stepData := make(map[string]any, len(w.steps))
+	data := map[string]any{
+		"inputs": inputs,
+		"steps":  stepData,
+	}
+
+	result := Result{Steps: make([]StepResult, 0, len(w.steps))}
+	var runErr error
+	for _, s := range w.steps {
+		stepResult := StepResult{ID: s.ID, Server: s.Server, Tool: s.Tool}
+		if runErr != nil {
+			stepResult.Status = StepSkipped
+			result.Steps = append(result.Steps, stepResult)
+			continue
+		}
+
+		stepResult = runStep(ctx, s, data, call)
+		result.Steps = append(result.Steps, stepResult)
+		if stepResult.Error != nil {
+			runErr = fmt.Errorf("workflow '%s' step '%s' failed: %w", w.entry.Name, s.ID, stepResult.Error)
+			continue
+		}
+
+		stepData[s.ID] = map[string]any{
+			"result": stepResult.Result,
+			"json":   decodeJSON(stepResult.Result),
+		}
+	}
+	if runErr != nil {
+		return result, runErr
+	}
+
+	if w.output == nil {
+		result.Output = result.Steps[len(result.Steps)-1].Result
+		return result, nil
+	}
+
+	output, err := w.output.render(data)
+	if err != nil {
+		return result, fmt.Errorf("workflow '%s' output: %w", w.entry.Name, err)
+	}
+	result.Output = output.(string)
+
+	return result, nil
+}
+
+// runStep renders the arguments for the step and calls its tool.
+func runStep(ctx context.Context, s step, data map[string]any, call Caller) StepResult {
+	result := StepResult{ID: s.ID, Server: s.Server, Tool: s.Tool, Status: StepFailed}
+	start := time.Now()
+
+	message, err := func() (string, error) {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+
+		rendered, err := s.args.render(data)
+		if err != nil {
+			return "", err
+		}
+		args, _ := rendered.(map[string]any)
+
+		var timeout time.Duration
+		if s.Timeout != nil {
+			timeout = time.Duration(*s.Timeout)
+		}
+
+		return call(ctx, s.Server, s.Tool, args, timeout)
+	}()
+	result.Duration = time.Since(start)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	result.Status = StepSucceeded
+	result.Result = message
+
+	return result
+}
+
+// inputs validates the supplied arguments against the workflow's inputs, applying defaults.
+func (w *Workflow) inputs(args map[string]any) (map[string]any, error) {
+	inputs := make(map[string]any, len(w.entry.Inputs))
+	known := make(map[string]struct{}, len(w.entry.Inputs))
+	for _, input := range w.entry.Inputs {
+		known[input.Name] = struct{}{}
+		if v, ok := args[input.Name]; ok {
+			inputs[input.Name] = v
+			continue
+		}
+		if input.Required {
+			return nil, fmt.Errorf("%w: workflow '%s': missing required input '%s'",
+				errors.ErrBadRequest, w.entry.Name, input.Name)
+		}
+		inputs[input.Name] = input.Default
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(args)) {
+		if _, ok := known[name]; !ok {
+			return nil, fmt.Errorf("%w: workflow '%s': unknown input '%s'", errors.ErrBadRequest, w.entry.Name, name)
+		}
+	}
+
+	return inputs, nil
+}
+
+// decodeJSON returns the decoded JSON value of the text, or nil when the text isn't JSON.
+func decodeJSON(text string) any {
+	var v any
+	if err := json.Unmarshal([]byte(text), &v); err != nil {
+		return nil
+	}
+
+	return v
+}
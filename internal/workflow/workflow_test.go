@@ -0,0 +1,293 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mozilla-ai/mcpd/internal/config"
+	"github.com/mozilla-ai/mcpd/internal/errors"
+)
+
+// fakeCall is a tool call received by fakeServers.
+type fakeCall struct {
+	server  string
+	tool    string
+	args    map[string]any
+	timeout time.Duration
+}
+
+// fakeServers stands in for MCP servers, responding to tool calls with canned results.
+type fakeServers struct {
+	mu      sync.Mutex
+	results map[string]string
+	errs    map[string]error
+	calls   []fakeCall
+}
+
+func (f *fakeServers) call(
+	ctx context.Context,
+	server string,
+	tool string,
+	args map[string]any,
+	timeout time.Duration,
+) (string, error) {
+	f.mu.Lock()
+	f.calls = append(f.calls, fakeCall{server: server, tool: tool, args: args, timeout: timeout})
+	f.mu.Unlock()
+
+	key := server + "/" + tool
+	if key == "slow/wait" {
+		<-ctx.Done()
+		return "", ctx.Err()
+	}
+	if err, ok := f.errs[key]; ok {
+		return "", err
+	}
+
+	return f.results[key], nil
+}
+
+func testDurationPtr(d time.Duration) *config.Duration {
+	v := config.Duration(d)
+	return &v
+}
+
+func newTriageWorkflow(t *testing.T) *Workflow {
+	t.Helper()
+
+	w, err := New(config.WorkflowEntry{
+		Name:        "triage_issue",
+		Title:       "Triage Issue",
+		Description: "Finds the owner of a component and assigns the issue",
+		Inputs: []config.WorkflowInput{
+			{Name: "issue", Type: "integer", Description: "Issue number", Required: true},
+			{Name: "component", Description: "Component name", Default: "core"},
+		},
+		Steps: []config.WorkflowStep{
+			{
+				ID:     "owner",
+				Server: "catalog",
+				Tool:   "find_owner",
+				Args:   map[string]any{"component": "{{ .inputs.component }}", "limit": 1},
+			},
+			{
+				ID:     "assign",
+				Server: "github",
+				Tool:   "assign_issue",
+				Args: map[string]any{
+					"issue":    "{{ .inputs.issue }}",
+					"assignee": "{{ .steps.owner.json.login }}",
+					"comment":  "Assigned to {{ .steps.owner.json.name }} (#{{ .inputs.issue }})",
+					"labels":   []any{"triaged", "{{ .inputs.component }}"},
+				},
+				Timeout: testDurationPtr(5 * time.Second),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	return w
+}
+
+func TestWorkflow_Tool(t *testing.T) {
+	t.Parallel()
+
+	tool := newTriageWorkflow(t).Tool()
+
+	assert.Equal(t, "triage_issue", tool.Name)
+	assert.Equal(t, "Triage Issue", tool.Annotations.Title)
+	assert.Equal(t, "Finds the owner of a component and assigns the issue", tool.Description)
+	assert.Equal(t, "object", tool.InputSchema.Type)
+	assert.Equal(t, map[string]any{
+		"issue":     map[string]any{"type": "integer", "description": "Issue number"},
+		"component": map[string]any{"type": "string", "description": "Component name", "default": "core"},
+	}, tool.InputSchema.Properties)
+	assert.Equal(t, []string{"issue"}, tool.InputSchema.Required)
+}
+
+func TestWorkflow_Run(t *testing.T) {
+	t.Parallel()
+
+	servers := &fakeServers{
+		results: map[string]string{
+			"catalog/find_owner":  `{"login": "octocat", "name": "Octo Cat"}`,
+			"github/assign_issue": "assigned",
+		},
+	}
+
+	result, err := newTriageWorkflow(t).Run(context.Background(), map[string]any{"issue": 42}, servers.call)
+	require.NoError(t, err)
+
+	assert.Equal(t, "assigned", result.Output)
+	require.Len(t, result.Steps, 2)
+	for _, step := range result.Steps {
+		assert.Equal(t, StepSucceeded, step.Status)
+		assert.NoError(t, step.Error)
+	}
+
+	require.Len(t, servers.calls, 2)
+	assert.Equal(t, fakeCall{
+		server: "catalog",
+		tool:   "find_owner",
+		args:   map[string]any{"component": "core", "limit": 1},
+	}, servers.calls[0])
+	assert.Equal(t, fakeCall{
+		server: "github",
+		tool:   "assign_issue",
+		args: map[string]any{
+			"issue":    float64(42), // Single actions keep the type of the value.
+			"assignee": "octocat",
+			"comment":  "Assigned to Octo Cat (#42)",
+			"labels":   []any{"triaged", "core"},
+		},
+		timeout: 5 * time.Second,
+	}, servers.calls[1])
+}
+
+func TestWorkflow_RunOutputTemplate(t *testing.T) {
+	t.Parallel()
+
+	w, err := New(config.WorkflowEntry{
+		Name: "lookup",
+		Steps: []config.WorkflowStep{
+			{ID: "first", Server: "a", Tool: "one"},
+			{ID: "second", Server: "b", Tool: "two"},
+		},
+		Output: "{{ .steps.first.result }} then {{ .steps.second.result }}",
+	})
+	require.NoError(t, err)
+
+	servers := &fakeServers{results: map[string]string{"a/one": "1", "b/two": "2"}}
+	result, err := w.Run(context.Background(), nil, servers.call)
+	require.NoError(t, err)
+	assert.Equal(t, "1 then 2", result.Output)
+}
+
+func TestWorkflow_RunStepFailure(t *testing.T) {
+	t.Parallel()
+
+	w, err := New(config.WorkflowEntry{
+		Name: "pipeline",
+		Steps: []config.WorkflowStep{
+			{ID: "first", Server: "a", Tool: "one"},
+			{ID: "second", Server: "b", Tool: "two"},
+			{ID: "third", Server: "c", Tool: "three"},
+		},
+	})
+	require.NoError(t, err)
+
+	servers := &fakeServers{
+		results: map[string]string{"a/one": "1"},
+		errs:    map[string]error{"b/two": fmt.Errorf("%w: b/two: boom", errors.ErrToolCallFailed)},
+	}
+	result, err := w.Run(context.Background(), nil, servers.call)
+	require.ErrorIs(t, err, errors.ErrToolCallFailed)
+	assert.ErrorContains(t, err, "step 'second' failed")
+
+	// Partial results are returned.
+	assert.Empty(t, result.Output)
+	require.Len(t, result.Steps, 3)
+	assert.Equal(t, StepSucceeded, result.Steps[0].Status)
+	assert.Equal(t, "1", result.Steps[0].Result)
+	assert.Equal(t, StepFailed, result.Steps[1].Status)
+	assert.ErrorIs(t, result.Steps[1].Error, errors.ErrToolCallFailed)
+	assert.Equal(t, StepSkipped, result.Steps[2].Status)
+	assert.Len(t, servers.calls, 2)
+}
+
+func TestWorkflow_RunRenderFailure(t *testing.T) {
+	t.Parallel()
+
+	servers := &fakeServers{
+		results: map[string]string{"catalog/find_owner": "not json"},
+	}
+
+	result, err := newTriageWorkflow(t).Run(context.Background(), map[string]any{"issue": 42}, servers.call)
+	require.ErrorIs(t, err, errors.ErrWorkflowRenderFailed)
+	require.Len(t, result.Steps, 2)
+	assert.Equal(t, StepSucceeded, result.Steps[0].Status)
+	assert.Equal(t, StepFailed, result.Steps[1].Status)
+	assert.Len(t, servers.calls, 1, "the step should not be called when its arguments cannot be rendered")
+}
+
+func TestWorkflow_RunTimeout(t *testing.T) {
+	t.Parallel()
+
+	w, err := New(config.WorkflowEntry{
+		Name:    "slow",
+		Timeout: testDurationPtr(20 * time.Millisecond),
+		Steps: []config.WorkflowStep{
+			{ID: "wait", Server: "slow", Tool: "wait"},
+			{ID: "after", Server: "a", Tool: "one"},
+		},
+	})
+	require.NoError(t, err)
+
+	servers := &fakeServers{}
+	result, err := w.Run(context.Background(), nil, servers.call)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Len(t, result.Steps, 2)
+	assert.Equal(t, StepFailed, result.Steps[0].Status)
+	assert.Positive(t, result.Steps[0].Duration)
+	assert.Equal(t, StepSkipped, result.Steps[1].Status)
+}
+
+func TestWorkflow_RunInvalidInputs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		args     map[string]any
+		expected string
+	}{
+		{
+			name:     "missing required input",
+			args:     map[string]any{"component": "api"},
+			expected: "missing required input 'issue'",
+		},
+		{
+			name:     "unknown input",
+			args:     map[string]any{"issue": 1, "priority": "high"},
+			expected: "unknown input 'priority'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			servers := &fakeServers{}
+			_, err := newTriageWorkflow(t).Run(context.Background(), tc.args, servers.call)
+			require.ErrorIs(t, err, errors.ErrBadRequest)
+			require.ErrorContains(t, err, tc.expected)
+			require.Empty(t, servers.calls)
+		})
+	}
+}
+
+func TestNew_InvalidTemplate(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(config.WorkflowEntry{
+		Name: "broken",
+		Steps: []config.WorkflowStep{
+			{ID: "first", Server: "a", Tool: "one", Args: map[string]any{"query": "{{ .inputs.query "}},
+		},
+	})
+	require.Error(t, err)
+	require.ErrorContains(t, err, "workflow 'broken' step 'first'")
+	require.ErrorContains(t, err, "invalid template 'first.query'")
+
+	_, err = New(config.WorkflowEntry{
+		Name:   "broken",
+		Steps:  []config.WorkflowStep{{ID: "first", Server: "a", Tool: "one"}},
+		Output: "{{ end }}",
+	})
+	require.ErrorContains(t, err, "invalid template 'output'")
+}